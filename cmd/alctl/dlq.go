@@ -0,0 +1,123 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"activity-log-service/internal/infrastructure/config"
+	"activity-log-service/internal/infrastructure/messaging"
+)
+
+const dlqUsage = `Usage: alctl dlq <list|show|replay> [flags]
+
+  list     Print the oldest dead letters with their error
+  show     Print one dead letter with its payload (-seq)
+  replay   Publish dead letters back to their subject and remove them
+           (-seq for one, -all for every listed one)
+`
+
+// runDLQ works on the dead-letter stream configured under nats
+func runDLQ(args []string) int {
+	if len(args) < 1 {
+		fmt.Fprint(os.Stderr, dlqUsage)
+		return 2
+	}
+
+	flags := flag.NewFlagSet("dlq "+args[0], flag.ExitOnError)
+	var (
+		configPath = flags.String("config", "configs/config.yaml", "Path to configuration file")
+		profile    = flags.String("profile", os.Getenv("CONFIG_PROFILE"), "Config profile overlay to merge")
+		limit      = flags.Int("limit", 100, "Most dead letters to list or replay")
+		seq        = flags.Uint64("seq", 0, "Sequence of the dead letter in the dead-letter stream")
+		all        = flags.Bool("all", false, "Replay every listed dead letter")
+	)
+	flags.Parse(args[1:])
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+
+	cfg, err := config.LoadProfile(*configPath, *profile)
+	if err != nil {
+		logger.WithError(err).Error("Failed to load config")
+		return 1
+	}
+	if cfg.NATS.DeadLetterStream == "" {
+		fmt.Fprintln(os.Stderr, "nats.dead_letter_stream is not set")
+		return 1
+	}
+
+	queue, err := messaging.NewDeadLetterQueue(cfg.NATS, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to open dead-letter queue")
+		return 1
+	}
+	defer queue.Close()
+
+	switch args[0] {
+	case "list":
+		letters, err := queue.List(*limit)
+		if err != nil {
+			logger.WithError(err).Error("Failed to list dead letters")
+			return 1
+		}
+		for _, letter := range letters {
+			fmt.Printf("%-8d %-25s %-3d %s  %s\n", letter.Sequence, letter.Subject, letter.Deliveries,
+				letter.FailedAt.Format(time.RFC3339), letter.Error)
+		}
+		fmt.Printf("\n%d dead letters\n", len(letters))
+	case "show":
+		if *seq == 0 {
+			fmt.Fprintln(os.Stderr, "-seq is required")
+			return 2
+		}
+		letter, err := queue.Get(*seq)
+		if err != nil {
+			logger.WithError(err).Error("Failed to get dead letter")
+			return 1
+		}
+		fmt.Printf("sequence:          %d\n", letter.Sequence)
+		fmt.Printf("subject:           %s\n", letter.Subject)
+		fmt.Printf("original sequence: %d\n", letter.OriginalSequence)
+		fmt.Printf("deliveries:        %d\n", letter.Deliveries)
+		fmt.Printf("failed at:         %s\n", letter.FailedAt.Format(time.RFC3339))
+		fmt.Printf("error:             %s\n\n%s\n", letter.Error, letter.Payload())
+	case "replay":
+		var seqs []uint64
+		switch {
+		case *seq != 0:
+			seqs = []uint64{*seq}
+		case *all:
+			letters, err := queue.List(*limit)
+			if err != nil {
+				logger.WithError(err).Error("Failed to list dead letters")
+				return 1
+			}
+			for _, letter := range letters {
+				seqs = append(seqs, letter.Sequence)
+			}
+		default:
+			fmt.Fprintln(os.Stderr, "either -seq or -all is required")
+			return 2
+		}
+
+		failed := 0
+		for _, s := range seqs {
+			if err := queue.Replay(s); err != nil {
+				logger.WithError(err).Error("Failed to replay dead letter")
+				failed++
+			}
+		}
+		fmt.Printf("%d replayed, %d failed\n", len(seqs)-failed, failed)
+		if failed > 0 {
+			return 1
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown dlq command: %s\n\n%s", args[0], dlqUsage)
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"activity-log-service/internal/infrastructure/config"
+	"activity-log-service/internal/infrastructure/doctor"
+)
+
+const usage = `Usage: alctl <command> [flags]
+
+Commands:
+  doctor   Compare the live deployment with what this version expects
+  dlq      List dead-lettered NATS messages or replay them
+  replay   Publish created events from the stream again to reprocess them
+  unwrap-key
+           Unwrap an encrypted archive's data key with the private export key
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "doctor":
+		os.Exit(runDoctor(os.Args[2:]))
+	case "dlq":
+		os.Exit(runDLQ(os.Args[2:]))
+	case "replay":
+		os.Exit(runReplay(os.Args[2:]))
+	case "unwrap-key":
+		os.Exit(runUnwrapKey(os.Args[2:]))
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+}
+
+// runDoctor prints every finding and returns 1 if any check failed, so it can
+// gate deploys
+func runDoctor(args []string) int {
+	flags := flag.NewFlagSet("doctor", flag.ExitOnError)
+	var (
+		configPath     = flags.String("config", "configs/config.yaml", "Path to configuration file")
+		profile        = flags.String("profile", os.Getenv("CONFIG_PROFILE"), "Config profile overlay to merge")
+		migrationsPath = flags.String("migrations", "migrations", "Path to migrations directory")
+		timeout        = flags.Duration("timeout", 30*time.Second, "Timeout for all checks")
+		verbose        = flags.Bool("v", false, "Also print passing checks")
+	)
+	flags.Parse(args)
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+
+	cfg, err := config.LoadProfile(*configPath, *profile)
+	if err != nil {
+		logger.WithError(err).Error("Failed to load config")
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	findings := doctor.New(cfg, *migrationsPath, logger).Run(ctx)
+
+	counts := make(map[doctor.Status]int)
+	for _, finding := range findings {
+		counts[finding.Status]++
+		if finding.Status == doctor.StatusOK && !*verbose {
+			continue
+		}
+		fmt.Printf("%-5s %-7s %-40s %s\n", finding.Status, finding.Component, finding.Check, finding.Detail)
+		if finding.Fix != "" {
+			fmt.Printf("%-5s %-7s %-40s fix: %s\n", "", "", "", finding.Fix)
+		}
+	}
+
+	fmt.Printf("\n%d ok, %d warnings, %d failures\n", counts[doctor.StatusOK], counts[doctor.StatusWarn], counts[doctor.StatusFail])
+	if doctor.Failed(findings) {
+		return 1
+	}
+	return 0
+}
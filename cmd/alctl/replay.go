@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"activity-log-service/internal/infrastructure/config"
+	"activity-log-service/internal/infrastructure/messaging"
+)
+
+// runReplay publishes created events from the stream configured under nats
+// again, for the consumer to process a second time
+func runReplay(args []string) int {
+	flags := flag.NewFlagSet("replay", flag.ExitOnError)
+	var (
+		configPath = flags.String("config", "configs/config.yaml", "Path to configuration file")
+		profile    = flags.String("profile", os.Getenv("CONFIG_PROFILE"), "Config profile overlay to merge")
+		fromSeq    = flags.Uint64("from-seq", 0, "First stream sequence to replay")
+		toSeq      = flags.Uint64("to-seq", 0, "Last stream sequence to replay (default: the end of the stream)")
+		since      = flags.String("since", "", "Replay messages stored at or after this RFC 3339 time")
+		until      = flags.String("until", "", "Replay messages stored before this RFC 3339 time")
+		dryRun     = flags.Bool("dry-run", false, "Only count the messages that would be replayed")
+	)
+	flags.Parse(args)
+
+	rng := messaging.ReplayRange{FromSequence: *fromSeq, ToSequence: *toSeq}
+	var err error
+	if rng.Since, err = parseTimeFlag(*since); err != nil {
+		fmt.Fprintf(os.Stderr, "-since: %v\n", err)
+		return 2
+	}
+	if rng.Until, err = parseTimeFlag(*until); err != nil {
+		fmt.Fprintf(os.Stderr, "-until: %v\n", err)
+		return 2
+	}
+	if rng.FromSequence == 0 && rng.Since.IsZero() {
+		fmt.Fprintln(os.Stderr, "either -from-seq or -since is required")
+		return 2
+	}
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+
+	cfg, err := config.LoadProfile(*configPath, *profile)
+	if err != nil {
+		logger.WithError(err).Error("Failed to load config")
+		return 1
+	}
+
+	replayer, err := messaging.NewStreamReplayer(cfg.NATS, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to open stream")
+		return 1
+	}
+	defer replayer.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	replayed, err := replayer.Replay(ctx, rng, *dryRun)
+	if *dryRun {
+		fmt.Printf("%d messages would be replayed\n", replayed)
+	} else {
+		fmt.Printf("%d messages replayed\n", replayed)
+	}
+	if err != nil {
+		logger.WithError(err).Error("Replay stopped")
+		return 1
+	}
+	return 0
+}
+
+// parseTimeFlag parses an RFC 3339 time; empty is the zero time
+func parseTimeFlag(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
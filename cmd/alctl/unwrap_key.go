@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"os"
+
+	"activity-log-service/internal/infrastructure/envelope"
+)
+
+// runUnwrapKey recovers an archive's data key from the envelope header
+// GetArchiveKey returned, with the company's private key. It runs on the
+// company's side so the private key never reaches the service.
+func runUnwrapKey(args []string) int {
+	flags := flag.NewFlagSet("unwrap-key", flag.ExitOnError)
+	var (
+		keyPath = flags.String("key", "", "Path to the PEM private export key")
+		header  = flags.String("header", "", "Base64 envelope_header from GetArchiveKey")
+	)
+	flags.Parse(args)
+
+	if *keyPath == "" || *header == "" {
+		fmt.Fprintln(os.Stderr, "-key and -header are required")
+		return 2
+	}
+
+	pemData, err := os.ReadFile(*keyPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "-key: %v\n", err)
+		return 1
+	}
+	priv, err := envelope.ParsePrivateKey(pemData)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "-key: %v\n", err)
+		return 1
+	}
+	raw, err := base64.StdEncoding.DecodeString(*header)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "-header: %v\n", err)
+		return 2
+	}
+
+	dataKey, err := envelope.UnwrapKey(priv, raw)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to unwrap data key: %v\n", err)
+		return 1
+	}
+	fmt.Println(base64.StdEncoding.EncodeToString(dataKey))
+	return 0
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"os"
 	"os/signal"
 	"syscall"
@@ -14,22 +15,20 @@ import (
 )
 
 func main() {
-	// Get configuration path
+	// Get configuration path and environment profile
 	configPath := os.Getenv("CONFIG_PATH")
 	if configPath == "" {
 		configPath = "configs/config.yaml"
 	}
+	profile := flag.String("profile", os.Getenv("CONFIG_PROFILE"), "Config profile overlay to merge, e.g. docker or staging")
+	flag.Parse()
 
 	// Initialize all dependencies
-	deps, err := initialization.GetConsumerDependencies(configPath)
+	deps, err := initialization.GetConsumerDependencies(configPath, *profile)
 	if err != nil {
 		logrus.WithError(err).Fatal("Failed to initialize dependencies")
 	}
-	defer func() {
-		if err := deps.Cleanup(); err != nil {
-			deps.Logger.WithError(err).Error("Failed to cleanup dependencies")
-		}
-	}()
+	defer deps.Cleanup()
 
 	deps.Logger.Info("Starting NATS consumer...")
 
@@ -38,7 +37,7 @@ func main() {
 	metrics.StartMetricsServer(metricsPort, deps.Logger)
 
 	// Create NATS consumer server
-	consumerServer, err := server.NewConsumerServer(deps.Repository, deps.Config, deps.Logger, deps.Tracer)
+	consumerServer, err := server.NewConsumerServer(deps.Repository, deps.Search, deps.Config, deps.Logger, deps.Tracer)
 	if err != nil {
 		deps.Logger.WithError(err).Fatal("Failed to create consumer server")
 	}
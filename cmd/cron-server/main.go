@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"os"
 	"os/signal"
 	"syscall"
@@ -14,22 +15,20 @@ import (
 )
 
 func main() {
-	// Get configuration path
+	// Get configuration path and environment profile
 	configPath := os.Getenv("CONFIG_PATH")
 	if configPath == "" {
 		configPath = "configs/config.yaml"
 	}
+	profile := flag.String("profile", os.Getenv("CONFIG_PROFILE"), "Config profile overlay to merge, e.g. docker or staging")
+	flag.Parse()
 
 	// Initialize all dependencies
-	deps, err := initialization.GetCronDependencies(configPath)
+	deps, err := initialization.GetCronDependencies(configPath, *profile)
 	if err != nil {
 		logrus.WithError(err).Fatal("Failed to initialize dependencies")
 	}
-	defer func() {
-		if err := deps.Cleanup(); err != nil {
-			deps.Logger.WithError(err).Error("Failed to cleanup dependencies")
-		}
-	}()
+	defer deps.Cleanup()
 
 	deps.Logger.Info("Starting cron server...")
 
@@ -44,7 +43,7 @@ func main() {
 	metrics.StartMetricsServer(metricsPort, deps.Logger)
 
 	// Create cron server
-	cronServer := server.NewCronServer(deps.Repository, deps.Cache, deps.Mailer, deps.Config, deps.Logger, deps.Tracer)
+	cronServer := server.NewCronServer(deps.Repository, deps.Cache, deps.Mailer, deps.Canary, deps.Rollups, deps.Partitions, deps.Indexes, deps.Storage, deps.Archive, deps.DailyExport, deps.Anomalies, deps.Alerter, deps.JobRuns, deps.ExportKeys, deps.Config, deps.Logger, deps.Tracer)
 
 	// Setup graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
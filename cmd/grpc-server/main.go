@@ -2,34 +2,34 @@ package main
 
 import (
 	"context"
+	"flag"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/sirupsen/logrus"
 
+	deliveryGRPC "activity-log-service/internal/delivery/grpc"
 	"activity-log-service/internal/infrastructure/metrics"
 	"activity-log-service/internal/initialization"
 	"activity-log-service/internal/server"
 )
 
 func main() {
-	// Get configuration path
+	// Get configuration path and environment profile
 	configPath := os.Getenv("CONFIG_PATH")
 	if configPath == "" {
 		configPath = "configs/config.yaml"
 	}
+	profile := flag.String("profile", os.Getenv("CONFIG_PROFILE"), "Config profile overlay to merge, e.g. docker or staging")
+	flag.Parse()
 
 	// Initialize all dependencies
-	deps, err := initialization.GetGRPCDependencies(configPath)
+	deps, err := initialization.GetGRPCDependencies(configPath, *profile)
 	if err != nil {
 		logrus.WithError(err).Fatal("Failed to initialize dependencies")
 	}
-	defer func() {
-		if err := deps.Cleanup(); err != nil {
-			deps.Logger.WithError(err).Error("Failed to cleanup dependencies")
-		}
-	}()
+	defer deps.Cleanup()
 
 	deps.Logger.Info("Starting gRPC server...")
 
@@ -37,7 +37,7 @@ func main() {
 	metrics.StartMetricsServer(deps.Config.Metrics.Port, deps.Logger)
 
 	// Create gRPC server
-	grpcServer, err := server.NewGRPCServer(deps.UseCase, deps.Config, deps.Logger, deps.Tracer)
+	grpcServer, err := server.NewGRPCServer(deps.UseCase, adminService(deps), deps.Health, deps.Shedder, deps.Config, deps.Logger, deps.Tracer)
 	if err != nil {
 		deps.Logger.WithError(err).Fatal("Failed to create gRPC server")
 	}
@@ -64,3 +64,16 @@ func main() {
 
 	deps.Logger.Info("gRPC server shutdown complete")
 }
+
+// adminService builds the AdminService when it is enabled. Cron jobs run in
+// this process on demand through an unscheduled cron server; the cache can
+// only be flushed when reads go through it.
+func adminService(deps *initialization.Dependencies) *deliveryGRPC.AdminServiceServer {
+	if !deps.Config.Admin.Enabled {
+		return nil
+	}
+
+	jobs := server.NewCronServer(deps.Repository, deps.Cache, deps.Mailer, deps.Canary, deps.Rollups, deps.Partitions, deps.Indexes, deps.Storage, deps.Archive, deps.DailyExport, deps.Anomalies, deps.Alerter, deps.JobRuns, deps.ExportKeys, deps.Config, deps.Logger, deps.Tracer)
+	cache, _ := deps.Repository.(deliveryGRPC.CacheFlusher)
+	return deliveryGRPC.NewAdminServiceServer(cache, jobs, deps.Indexes, deps.Purger, deps.Archive, deps.JobRuns, deps.UseCase, deps.Tracer)
+}
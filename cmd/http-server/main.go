@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"os"
 	"os/signal"
 	"syscall"
@@ -14,22 +15,20 @@ import (
 )
 
 func main() {
-	// Get configuration path
+	// Get configuration path and environment profile
 	configPath := os.Getenv("CONFIG_PATH")
 	if configPath == "" {
 		configPath = "configs/config.yaml"
 	}
+	profile := flag.String("profile", os.Getenv("CONFIG_PROFILE"), "Config profile overlay to merge, e.g. docker or staging")
+	flag.Parse()
 
 	// Initialize all dependencies
-	deps, err := initialization.GetHTTPDependencies(configPath)
+	deps, err := initialization.GetHTTPDependencies(configPath, *profile)
 	if err != nil {
 		logrus.WithError(err).Fatal("Failed to initialize dependencies")
 	}
-	defer func() {
-		if err := deps.Cleanup(); err != nil {
-			deps.Logger.WithError(err).Error("Failed to cleanup dependencies")
-		}
-	}()
+	defer deps.Cleanup()
 
 	deps.Logger.Info("Starting HTTP server...")
 
@@ -38,7 +37,10 @@ func main() {
 	metrics.StartMetricsServer(metricsPort, deps.Logger)
 
 	// Create HTTP server
-	httpServer := server.NewHTTPServer(deps.UseCase, deps.Config, deps.Logger, deps.Tracer)
+	httpServer, err := server.NewHTTPServer(deps.UseCase, deps.Health, deps.Shedder, deps.Config, deps.Logger, deps.Tracer)
+	if err != nil {
+		deps.Logger.WithError(err).Fatal("Failed to create HTTP server")
+	}
 
 	// Setup graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
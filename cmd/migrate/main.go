@@ -4,21 +4,25 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"os"
 
 	"github.com/arangodb/go-driver"
-	"github.com/arangodb/go-driver/http"
 	"github.com/sirupsen/logrus"
 
+	"activity-log-service/internal/domain/entity"
 	"activity-log-service/internal/infrastructure/config"
+	"activity-log-service/internal/infrastructure/database"
 	"activity-log-service/internal/infrastructure/migration"
 )
 
 func main() {
 	var (
 		configPath     = flag.String("config", "configs/config.yaml", "Path to configuration file")
+		profile        = flag.String("profile", os.Getenv("CONFIG_PROFILE"), "Config profile overlay to merge")
 		migrationsPath = flag.String("migrations", "migrations", "Path to migrations directory")
-		command        = flag.String("command", "up", "Migration command: up, down, status")
+		command        = flag.String("command", "up", "Migration command: up, down, status, schema-check")
 		targetVersion  = flag.Int("version", 0, "Target version for down migration")
+		readerVersion  = flag.Int("reader-version", 0, "Oldest activity log schema version still deployed, for schema-check")
 	)
 	flag.Parse()
 
@@ -30,13 +34,25 @@ func main() {
 	})
 
 	// Load configuration
-	cfg, err := config.LoadConfig(*configPath)
+	cfg, err := config.LoadProfile(*configPath, *profile)
 	if err != nil {
 		logger.WithError(err).Fatal("Failed to load config")
 	}
 
+	// The compatibility check only needs the configuration
+	if *command == "schema-check" {
+		ok, err := checkSchemaCompatibility(cfg, *readerVersion)
+		if err != nil {
+			logger.WithError(err).Fatal("Failed to check schema compatibility")
+		}
+		if !ok {
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Get database connection
-	db, err := getDatabase(cfg)
+	db, err := getDatabase(cfg, logger)
 	if err != nil {
 		logger.WithError(err).Fatal("Failed to get database connection")
 	}
@@ -70,30 +86,32 @@ func main() {
 		}
 
 	default:
-		logger.Fatalf("Unknown command: %s. Available commands: up, down, status", *command)
+		logger.Fatalf("Unknown command: %s. Available commands: up, down, status, schema-check", *command)
 	}
 }
 
-func getDatabase(cfg *config.Config) (driver.Database, error) {
-	conn, err := http.NewConnection(http.ConnectionConfig{
-		Endpoints: []string{cfg.Arango.URL},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create connection: %w", err)
-	}
+func getDatabase(cfg *config.Config, logger *logrus.Logger) (driver.Database, error) {
+	ctx := context.Background()
 
-	client, err := driver.NewClient(driver.ClientConfig{
-		Connection:     conn,
-		Authentication: driver.BasicAuthentication(cfg.Arango.Username, cfg.Arango.Password),
-	})
+	// Migrations often run as soon as the database is started, so wait for
+	// it like the servers do
+	client, err := database.NewClient(ctx, database.ConnectOptions{
+		Endpoints:      cfg.Arango.Endpoints(),
+		Username:       cfg.Arango.Username,
+		Password:       cfg.Arango.Password,
+		MaxAttempts:    cfg.Arango.Connect.MaxAttempts,
+		InitialBackoff: cfg.Arango.Connect.InitialBackoff,
+		MaxBackoff:     cfg.Arango.Connect.MaxBackoff,
+	}, logger)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create client: %w", err)
+		return nil, err
 	}
-
-	ctx := context.Background()
 	db, err := client.Database(ctx, cfg.Arango.Database)
 	if driver.IsNotFound(err) {
-		db, err = client.CreateDatabase(ctx, cfg.Arango.Database, nil)
+		db, err = client.CreateDatabase(ctx, cfg.Arango.Database, database.ClusterOptions{
+			ReplicationFactor: cfg.Arango.Cluster.ReplicationFactor,
+			WriteConcern:      cfg.Arango.Cluster.WriteConcern,
+		}.DatabaseOptions())
 		if err != nil {
 			return nil, fmt.Errorf("failed to create database: %w", err)
 		}
@@ -139,3 +157,42 @@ func showMigrationStatus(ctx context.Context, migrator *migration.Migrator, migr
 
 	return nil
 }
+
+// checkSchemaCompatibility reports whether writers using cfg only write fields
+// readers at readerVersion know
+func checkSchemaCompatibility(cfg *config.Config, readerVersion int) (bool, error) {
+	if readerVersion < 1 || readerVersion > entity.SchemaVersion {
+		return false, fmt.Errorf("reader-version must be between 1 and %d", entity.SchemaVersion)
+	}
+	withheld, err := entity.ParseWithheldFields(cfg.Schema.WithheldFields)
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Println("Schema Compatibility:")
+	fmt.Println("=====================")
+	fmt.Printf("Writer schema version: %d\n", entity.SchemaVersion)
+	fmt.Printf("Reader schema version: %d\n\n", readerVersion)
+	fmt.Printf("%-15s %-10s %-10s\n", "Field", "Since", "Status")
+	fmt.Printf("%-15s %-10s %-10s\n", "-----", "-----", "------")
+
+	for _, field := range entity.Fields {
+		status := "Written"
+		switch {
+		case withheld[field.Name]:
+			status = "Withheld"
+		case field.Since > readerVersion:
+			status = "UNKNOWN"
+		}
+		fmt.Printf("%-15s %-10d %-10s\n", field.Name, field.Since, status)
+	}
+
+	unknown := entity.FieldsUnknownTo(readerVersion, withheld)
+	if len(unknown) > 0 {
+		fmt.Printf("\nIncompatible: %d written field(s) are unknown to readers at version %d; add them to schema.withheld_fields\n", len(unknown), readerVersion)
+		return false, nil
+	}
+
+	fmt.Println("\nCompatible")
+	return true, nil
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+)
+
+// ErrAccessLogDisabled is returned when reading the access log of a
+// deployment that does not record one
+var ErrAccessLogDisabled = errors.New("access log is not enabled")
+
+// ErrAccessLogForbidden is returned when the caller is not one of the
+// company's access log readers
+var ErrAccessLogForbidden = errors.New("caller may not read this company's access log")
+
+// AccessLogOptions scopes the access log to tenant admins: Readers maps a
+// principal onto the companies whose access log it may list, "*" standing
+// for every company
+type AccessLogOptions struct {
+	Readers map[string][]string
+}
+
+// allows reports whether principal may list companyID's access log
+func (o AccessLogOptions) allows(principal, companyID string) bool {
+	for _, company := range o.Readers[principal] {
+		if company == companyID || company == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// AnonymousPrincipal is recorded when a read carries no caller identity
+const AnonymousPrincipal = "anonymous"
+
+type principalKey struct{}
+
+// WithPrincipal attaches the identity of the caller to ctx so reads made with
+// it are attributed in the access log
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the caller set by WithPrincipal, or
+// AnonymousPrincipal when there is none
+func PrincipalFromContext(ctx context.Context) string {
+	if principal, ok := ctx.Value(principalKey{}).(string); ok && principal != "" {
+		return principal
+	}
+	return AnonymousPrincipal
+}
+
+// ListAccessLog lists who read a company's activity data, newest first, for
+// the company's readers only. It is itself a read of audit data and is
+// recorded as one.
+func (uc *ActivityLogUseCase) ListAccessLog(ctx context.Context, companyID string, page, limit int) ([]*entity.AccessLogEntry, int, error) {
+	if companyID == "" {
+		return nil, 0, fmt.Errorf("company ID is required")
+	}
+	if uc.accessLog == nil {
+		return nil, 0, ErrAccessLogDisabled
+	}
+	if principal := PrincipalFromContext(ctx); !uc.accessLogOptions.allows(principal, companyID) {
+		return nil, 0, fmt.Errorf("%w: %s", ErrAccessLogForbidden, principal)
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	entries, total, err := uc.accessLog.GetByCompanyID(ctx, companyID, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list access log: %w", err)
+	}
+
+	uc.recordAccess(ctx, companyID, "list_access_log", nil, len(entries))
+	return entries, total, nil
+}
+
+// recordAccess writes an access log entry for a read that succeeded. Failing
+// to record never fails the read itself.
+func (uc *ActivityLogUseCase) recordAccess(ctx context.Context, companyID, operation string, filter map[string]string, resultCount int) {
+	if uc.accessLog == nil {
+		return
+	}
+
+	entry := &entity.AccessLogEntry{
+		Principal:   PrincipalFromContext(ctx),
+		CompanyID:   companyID,
+		Operation:   operation,
+		Filter:      filter,
+		ResultCount: resultCount,
+		AccessedAt:  time.Now(),
+	}
+	if err := uc.accessLog.Record(context.WithoutCancel(ctx), entry); err != nil {
+		fmt.Printf("Failed to record access log entry: %v\n", err)
+	}
+}
+
+// accessFilter flattens the set fields of a log filter for the access log
+func accessFilter(filter repository.ActivityLogFilter) map[string]string {
+	fields := map[string]string{
+		"object_id":     filter.ObjectID,
+		"activity_name": filter.ActivityName,
+		"actor_id":      filter.ActorID,
+		"device_id":     filter.DeviceID,
+		"ip_address":    filter.IPAddress,
+		"user_agent":    filter.UserAgent,
+		"country_code":  filter.CountryCode,
+		"text":          filter.Text,
+		"tags":          strings.Join(filter.Tags, ","),
+	}
+	if !filter.StartDate.IsZero() {
+		fields["start_date"] = filter.StartDate.Format(time.RFC3339)
+	}
+	if !filter.EndDate.IsZero() {
+		fields["end_date"] = filter.EndDate.Format(time.RFC3339)
+	}
+
+	for name, value := range fields {
+		if value == "" {
+			delete(fields, name)
+		}
+	}
+	return fields
+}
@@ -3,35 +3,268 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"strings"
+	"time"
 
 	"activity-log-service/internal/domain/entity"
 	"activity-log-service/internal/domain/event"
 	"activity-log-service/internal/domain/repository"
 	"activity-log-service/internal/domain/valueobject"
 	"activity-log-service/internal/infrastructure/email"
-	"activity-log-service/internal/infrastructure/messaging"
+	"activity-log-service/internal/infrastructure/geoip"
+	"activity-log-service/internal/infrastructure/sampling"
 )
 
 type ActivityLogUseCase struct {
-	arangoRepo repository.ActivityLogRepository
-	publisher  *messaging.NATSPublisher
-	mailer     *email.Mailer
+	arangoRepo       repository.ActivityLogRepository
+	publisher        event.Publisher
+	mailer           *email.Mailer
+	privacy          PrivacyOptions
+	geoIP            geoip.Resolver
+	consistency      ConsistencyOptions
+	rollups          repository.ActivityStatsRepository
+	stats            StatsOptions
+	accessLog        repository.AccessLogRepository
+	accessLogOptions AccessLogOptions
+	schema           SchemaOptions
+	notify           sampling.Sampler
+	exportKeys       repository.ExportKeyRepository
 }
 
 func NewActivityLogUseCase(
 	arangoRepo repository.ActivityLogRepository,
-	publisher *messaging.NATSPublisher,
+	publisher event.Publisher,
 	mailer *email.Mailer,
+	privacy PrivacyOptions,
+	geoIP geoip.Resolver,
+	consistency ConsistencyOptions,
+	rollups repository.ActivityStatsRepository,
+	stats StatsOptions,
+	accessLog repository.AccessLogRepository,
+	accessLogOptions AccessLogOptions,
+	schema SchemaOptions,
+	notify sampling.Sampler,
+	exportKeys repository.ExportKeyRepository,
 ) *ActivityLogUseCase {
 	return &ActivityLogUseCase{
-		arangoRepo: arangoRepo,
-		publisher:  publisher,
-		mailer:     mailer,
+		arangoRepo:       arangoRepo,
+		publisher:        publisher,
+		mailer:           mailer,
+		privacy:          privacy,
+		geoIP:            geoIP,
+		consistency:      consistency,
+		rollups:          rollups,
+		stats:            stats,
+		accessLog:        accessLog,
+		accessLogOptions: accessLogOptions,
+		schema:           schema,
+		notify:           notify,
+		exportKeys:       exportKeys,
 	}
 }
 
-func (uc *ActivityLogUseCase) CreateActivityLog(ctx context.Context, req *CreateActivityLogRequest) (*entity.ActivityLog, error) {
+// CreateMode selects what CreateActivityLog does when the company already has
+// a log with the request's external ID, so replayed producers can re-send
+// safely
+type CreateMode string
+
+const (
+	// CreateModeAlways stores every request; a duplicate external ID is
+	// rejected by the database
+	CreateModeAlways       CreateMode = ""
+	CreateModeSkipIfExists CreateMode = "skip_if_exists"
+	CreateModeUpsert       CreateMode = "upsert"
+)
+
+var ErrInvalidCreateMode = errors.New("invalid create mode")
+
+// CreateActivityLogResult is returned by CreateActivityLog. Outcome tells
+// whether the log was created, or for conditional creates skipped or updated;
+// ActivityLog is then the stored log. Queued is set when the database was
+// unavailable and the log was only accepted onto the event stream;
+// SessionToken then lets the caller read its own write once the consumer has
+// stored it.
+type CreateActivityLogResult struct {
+	ActivityLog  *entity.ActivityLog
+	Outcome      repository.WriteOutcome
+	Queued       bool
+	SessionToken string
+}
+
+func (uc *ActivityLogUseCase) CreateActivityLog(ctx context.Context, req *CreateActivityLogRequest) (*CreateActivityLogResult, error) {
+	switch req.Mode {
+	case CreateModeAlways:
+	case CreateModeSkipIfExists, CreateModeUpsert:
+		if req.ExternalID == "" {
+			return nil, fmt.Errorf("invalid activity log: %w: required by create mode %s", entity.ErrInvalidExternalID, req.Mode)
+		}
+		if uc.schema.Withheld["external_id"] {
+			return nil, fmt.Errorf("%w: external_id is required by create mode %s", ErrFieldWithheld, req.Mode)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCreateMode, req.Mode)
+	}
+
+	activityLog, err := uc.buildActivityLog(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Mode != CreateModeAlways {
+		return uc.createConditional(ctx, activityLog, req.Mode == CreateModeUpsert)
+	}
+
+	result := &CreateActivityLogResult{ActivityLog: activityLog, Outcome: repository.WriteCreated}
+	if err := uc.arangoRepo.Create(ctx, activityLog); err != nil {
+		if !uc.consistency.QueueOnDBFailure || uc.publisher == nil || !errors.Is(err, entity.ErrDatabaseUnavailable) {
+			return nil, fmt.Errorf("failed to create activity log: %w", err)
+		}
+		fmt.Printf("Database unavailable, queueing activity log %s: %v\n", activityLog.ID, err)
+		result.Queued = true
+	}
+
+	sequence, err := uc.afterCreate(ctx, activityLog, result.Queued)
+	if err != nil {
+		return nil, err
+	}
+	if result.Queued {
+		result.SessionToken = EncodeSessionToken(sequence)
+	}
+
+	return result, nil
+}
+
+// createConditional stores a log keyed by its external ID. A newly created
+// log is announced and notified like any other; a replaced one only gets an
+// updated event, and a skipped one nothing, since a replay must not notify
+// twice. Existence cannot be decided without the database, so these writes
+// are never queued.
+func (uc *ActivityLogUseCase) createConditional(ctx context.Context, activityLog *entity.ActivityLog, update bool) (*CreateActivityLogResult, error) {
+	stored, outcome, err := uc.arangoRepo.CreateByExternalID(ctx, activityLog, update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create activity log: %w", err)
+	}
+
+	switch outcome {
+	case repository.WriteCreated:
+		if _, err := uc.afterCreate(ctx, stored, false); err != nil {
+			return nil, err
+		}
+	case repository.WriteUpdated:
+		if uc.publisher != nil {
+			if _, err := uc.publisher.PublishActivityLogUpdated(ctx, event.NewActivityLogUpdated(stored)); err != nil {
+				return nil, fmt.Errorf("failed to publish event: %w", err)
+			}
+		}
+	}
+
+	return &CreateActivityLogResult{ActivityLog: stored, Outcome: outcome}, nil
+}
+
+// CreateActivityLogs validates and bulk-inserts a batch of logs. The returned
+// slice has one entry per request, nil for the ones that were stored; the
+// error is only set when the batch as a whole could not be written.
+func (uc *ActivityLogUseCase) CreateActivityLogs(ctx context.Context, reqs []*CreateActivityLogRequest) ([]error, error) {
+	results, err := uc.BatchCreateActivityLogs(ctx, reqs, false)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make([]error, len(results))
+	for i, result := range results {
+		errs[i] = result.Err
+	}
+	return errs, nil
+}
+
+// BatchCreateResult is the outcome of one request of a batch. Exactly one of
+// ActivityLog and Err is set. PublishErr is set alongside ActivityLog when the
+// log was stored but its created event could not be published.
+type BatchCreateResult struct {
+	ActivityLog *entity.ActivityLog
+	Err         error
+	PublishErr  error
+}
+
+// BatchCreateActivityLogs validates and bulk-inserts a batch of logs,
+// returning one result per request in order. When atomic is set a single
+// invalid or rejected request fails the whole batch: nothing is stored and
+// the remaining requests report entity.ErrBatchAborted. A log that was stored
+// is reported as stored even if publishing its event fails, since retrying it
+// would store it twice. The error is only set when the batch as a whole could
+// not be written.
+func (uc *ActivityLogUseCase) BatchCreateActivityLogs(ctx context.Context, reqs []*CreateActivityLogRequest, atomic bool) ([]BatchCreateResult, error) {
+	results := make([]BatchCreateResult, len(reqs))
+	activityLogs := make([]*entity.ActivityLog, 0, len(reqs))
+	indexes := make([]int, 0, len(reqs))
+
+	for i, req := range reqs {
+		activityLog, err := uc.buildActivityLog(ctx, req)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		activityLogs = append(activityLogs, activityLog)
+		indexes = append(indexes, i)
+	}
+
+	if atomic && len(activityLogs) < len(reqs) {
+		return abortBatch(results), nil
+	}
+	if len(activityLogs) == 0 {
+		return results, nil
+	}
+
+	createBatch := uc.arangoRepo.CreateBatch
+	if atomic {
+		createBatch = uc.arangoRepo.CreateBatchAtomic
+	}
+	createErrs, err := createBatch(ctx, activityLogs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create activity logs: %w", err)
+	}
+
+	failed := false
+	for j := range activityLogs {
+		if j < len(createErrs) && createErrs[j] != nil {
+			results[indexes[j]].Err = fmt.Errorf("failed to create activity log: %w", createErrs[j])
+			failed = true
+		}
+	}
+	if atomic && failed {
+		return abortBatch(results), nil
+	}
+
+	for j, activityLog := range activityLogs {
+		i := indexes[j]
+		if results[i].Err != nil {
+			continue
+		}
+		if _, err := uc.afterCreate(ctx, activityLog, false); err != nil {
+			results[i].PublishErr = err
+		}
+		results[i].ActivityLog = activityLog
+	}
+
+	return results, nil
+}
+
+// abortBatch marks every request that did not fail itself as aborted
+func abortBatch(results []BatchCreateResult) []BatchCreateResult {
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Err = entity.ErrBatchAborted
+		}
+	}
+	return results
+}
+
+// buildActivityLog validates a create request and turns it into an enriched
+// entity ready to be stored.
+func (uc *ActivityLogUseCase) buildActivityLog(ctx context.Context, req *CreateActivityLogRequest) (*entity.ActivityLog, error) {
 	var changes json.RawMessage
 	if req.Changes != "" {
 		if !json.Valid([]byte(req.Changes)) {
@@ -40,6 +273,10 @@ func (uc *ActivityLogUseCase) CreateActivityLog(ctx context.Context, req *Create
 		changes = json.RawMessage(req.Changes)
 	}
 
+	if req.IPAddress != "" && net.ParseIP(req.IPAddress) == nil {
+		return nil, fmt.Errorf("invalid activity log: %w", entity.ErrInvalidIPAddress)
+	}
+
 	activityLog := entity.NewActivityLog(
 		req.ActivityName,
 		req.CompanyID,
@@ -51,24 +288,49 @@ func (uc *ActivityLogUseCase) CreateActivityLog(ctx context.Context, req *Create
 		req.ActorName,
 		req.ActorEmail,
 	)
+	// Enrich before the address is (optionally) hashed below
+	if uc.geoIP != nil && req.IPAddress != "" {
+		if location, err := uc.geoIP.Lookup(ctx, req.IPAddress); err == nil {
+			activityLog.CountryCode = location.CountryCode
+			activityLog.City = location.City
+		} else {
+			fmt.Printf("Failed to resolve geo location: %v\n", err)
+		}
+	}
+
+	activityLog.Tags = req.Tags
+	activityLog.ExternalID = req.ExternalID
+
+	activityLog.UserAgent = uc.privacy.userAgent(req.UserAgent)
+	activityLog.IPAddress = uc.privacy.ipAddress(req.IPAddress)
+	activityLog.DeviceID = uc.privacy.deviceID(req.DeviceID)
+	activityLog.WithholdFields(uc.schema.Withheld)
 
 	if err := activityLog.IsValid(); err != nil {
 		return nil, fmt.Errorf("invalid activity log: %w", err)
 	}
 
-	if err := uc.arangoRepo.Create(ctx, activityLog); err != nil {
-		return nil, fmt.Errorf("failed to create activity log: %w", err)
-	}
+	return activityLog, nil
+}
 
+// afterCreate publishes the created event and sends the notification email
+// for a log that has been stored, or queued for the consumer to store. It
+// returns the event's stream sequence, or zero when no publisher is
+// configured.
+func (uc *ActivityLogUseCase) afterCreate(ctx context.Context, activityLog *entity.ActivityLog, queued bool) (uint64, error) {
+	var sequence uint64
 	if uc.publisher != nil {
 		event := event.NewActivityLogCreated(activityLog)
-		if err := uc.publisher.PublishActivityLogCreated(ctx, event); err != nil {
-			return nil, fmt.Errorf("failed to publish event: %w", err)
+		event.Queued = queued
+		var err error
+		sequence, err = uc.publisher.PublishActivityLogCreated(ctx, event)
+		if err != nil {
+			return 0, fmt.Errorf("failed to publish event: %w", err)
 		}
 	}
 
-	// Send email notification if configured
-	if uc.mailer != nil {
+	// Send email notification if configured and the log is sampled for one
+	if uc.mailer != nil && uc.notify.Sample(notificationKey(activityLog)) {
 		go func() {
 			emailData := email.ActivityLogEmailData{
 				ActivityLog: activityLog,
@@ -83,7 +345,15 @@ func (uc *ActivityLogUseCase) CreateActivityLog(ctx context.Context, req *Create
 		}()
 	}
 
-	return activityLog, nil
+	return sequence, nil
+}
+
+func notificationKey(activityLog *entity.ActivityLog) sampling.Key {
+	return sampling.Key{
+		CompanyID:    activityLog.CompanyID,
+		ActivityName: activityLog.ActivityName,
+		ObjectID:     activityLog.ObjectID,
+	}
 }
 
 func (uc *ActivityLogUseCase) GetActivityLog(ctx context.Context, id string) (*entity.ActivityLog, error) {
@@ -97,9 +367,59 @@ func (uc *ActivityLogUseCase) GetActivityLog(ctx context.Context, id string) (*e
 		return nil, fmt.Errorf("failed to get activity log: %w", err)
 	}
 
+	uc.recordAccess(ctx, activityLog.CompanyID, "get", map[string]string{"id": id}, 1)
 	return activityLog, nil
 }
 
+// MaxBatchGetIDs bounds how many logs GetActivityLogs reads at once
+const MaxBatchGetIDs = 100
+
+var ErrInvalidBatchSize = fmt.Errorf("between 1 and %d IDs are required", MaxBatchGetIDs)
+
+// GetActivityLogs reads the company's logs with the given IDs, in the order
+// asked for. IDs that are unknown or belong to another company are returned
+// as missing rather than failing the call.
+func (uc *ActivityLogUseCase) GetActivityLogs(ctx context.Context, companyID string, ids []string) ([]*entity.ActivityLog, []string, error) {
+	if companyID == "" {
+		return nil, nil, fmt.Errorf("company ID is required")
+	}
+	if len(ids) == 0 || len(ids) > MaxBatchGetIDs {
+		return nil, nil, ErrInvalidBatchSize
+	}
+
+	activityLogIDs := make([]valueobject.ActivityLogID, len(ids))
+	for i, id := range ids {
+		activityLogIDs[i] = valueobject.ActivityLogID(id)
+		if !activityLogIDs[i].IsValid() {
+			return nil, nil, fmt.Errorf("invalid activity log ID %q", id)
+		}
+	}
+
+	stored, err := uc.arangoRepo.GetByIDs(ctx, activityLogIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get activity logs: %w", err)
+	}
+
+	activityLogs := make([]*entity.ActivityLog, 0, len(stored))
+	returned := make(map[string]bool, len(stored))
+	for _, activityLog := range stored {
+		if activityLog.CompanyID == companyID {
+			activityLogs = append(activityLogs, activityLog)
+			returned[activityLog.ID.String()] = true
+		}
+	}
+	var missing []string
+	for _, id := range ids {
+		if !returned[id] {
+			missing = append(missing, id)
+			returned[id] = true
+		}
+	}
+
+	uc.recordAccess(ctx, companyID, "batch_get", map[string]string{"ids": strings.Join(ids, ",")}, len(activityLogs))
+	return activityLogs, missing, nil
+}
+
 func (uc *ActivityLogUseCase) ListActivityLogs(ctx context.Context, companyID string, page, limit int) ([]*entity.ActivityLog, int, error) {
 	if companyID == "" {
 		return nil, 0, fmt.Errorf("company ID is required")
@@ -117,17 +437,278 @@ func (uc *ActivityLogUseCase) ListActivityLogs(ctx context.Context, companyID st
 		return nil, 0, fmt.Errorf("failed to list activity logs: %w", err)
 	}
 
+	uc.recordAccess(ctx, companyID, "list", nil, len(activityLogs))
 	return activityLogs, total, nil
 }
 
+func (uc *ActivityLogUseCase) ListActivityLogsByDevice(ctx context.Context, companyID, deviceID string, page, limit int) ([]*entity.ActivityLog, int, error) {
+	if companyID == "" {
+		return nil, 0, fmt.Errorf("company ID is required")
+	}
+	if deviceID == "" {
+		return nil, 0, fmt.Errorf("device ID is required")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	deviceID = uc.privacy.deviceID(deviceID)
+	filter := repository.ActivityLogFilter{CompanyID: companyID, DeviceID: deviceID}
+	activityLogs, total, err := uc.listPage(ctx, filter, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list activity logs by device: %w", err)
+	}
+
+	uc.recordAccess(ctx, companyID, "list_by_device", map[string]string{"device_id": deviceID}, len(activityLogs))
+	return activityLogs, total, nil
+}
+
+func (uc *ActivityLogUseCase) ListActivityLogsByCountry(ctx context.Context, companyID, countryCode string, page, limit int) ([]*entity.ActivityLog, int, error) {
+	if companyID == "" {
+		return nil, 0, fmt.Errorf("company ID is required")
+	}
+	if countryCode == "" {
+		return nil, 0, fmt.Errorf("country code is required")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	countryCode = strings.ToUpper(countryCode)
+	filter := repository.ActivityLogFilter{CompanyID: companyID, CountryCode: countryCode}
+	activityLogs, total, err := uc.listPage(ctx, filter, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list activity logs by country: %w", err)
+	}
+
+	uc.recordAccess(ctx, companyID, "list_by_country", map[string]string{"country_code": countryCode}, len(activityLogs))
+	return activityLogs, total, nil
+}
+
+// ListActivityLogsByDeviceAndCountry lists the logs matching both filters
+func (uc *ActivityLogUseCase) ListActivityLogsByDeviceAndCountry(ctx context.Context, companyID, deviceID, countryCode string, page, limit int) ([]*entity.ActivityLog, int, error) {
+	if companyID == "" {
+		return nil, 0, fmt.Errorf("company ID is required")
+	}
+	if deviceID == "" {
+		return nil, 0, fmt.Errorf("device ID is required")
+	}
+	if countryCode == "" {
+		return nil, 0, fmt.Errorf("country code is required")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	deviceID = uc.privacy.deviceID(deviceID)
+	countryCode = strings.ToUpper(countryCode)
+	filter := repository.ActivityLogFilter{CompanyID: companyID, DeviceID: deviceID, CountryCode: countryCode}
+	activityLogs, total, err := uc.listPage(ctx, filter, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list activity logs by device and country: %w", err)
+	}
+
+	uc.recordAccess(ctx, companyID, "list_by_device_and_country", map[string]string{"device_id": deviceID, "country_code": countryCode}, len(activityLogs))
+	return activityLogs, total, nil
+}
+
+// listPage returns a numbered page of the logs matching filter together with
+// their total, for the offset-paged listings
+func (uc *ActivityLogUseCase) listPage(ctx context.Context, filter repository.ActivityLogFilter, page, limit int) ([]*entity.ActivityLog, int, error) {
+	result, err := uc.arangoRepo.Search(ctx, filter, repository.SearchPage{Limit: limit, Number: page})
+	if err != nil {
+		return nil, 0, err
+	}
+	return result.ActivityLogs, result.Total, nil
+}
+
+func (uc *ActivityLogUseCase) GetCountryFacets(ctx context.Context, companyID string) (map[string]int, error) {
+	if companyID == "" {
+		return nil, fmt.Errorf("company ID is required")
+	}
+
+	counts, err := uc.arangoRepo.CountByCountryCode(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get country facets: %w", err)
+	}
+
+	uc.recordAccess(ctx, companyID, "country_facets", nil, len(counts))
+	return counts, nil
+}
+
+// ListActivityNames lists the activity names a company has logged, for
+// filter dropdowns
+func (uc *ActivityLogUseCase) ListActivityNames(ctx context.Context, companyID string) ([]string, error) {
+	if companyID == "" {
+		return nil, fmt.Errorf("company ID is required")
+	}
+
+	names, err := uc.arangoRepo.DistinctActivityNames(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list activity names: %w", err)
+	}
+
+	uc.recordAccess(ctx, companyID, "activity_names", nil, len(names))
+	return names, nil
+}
+
+// ListActors lists everyone a company has logs of, for filter dropdowns
+func (uc *ActivityLogUseCase) ListActors(ctx context.Context, companyID string) ([]repository.Actor, error) {
+	if companyID == "" {
+		return nil, fmt.Errorf("company ID is required")
+	}
+
+	actors, err := uc.arangoRepo.DistinctActors(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list actors: %w", err)
+	}
+
+	uc.recordAccess(ctx, companyID, "actors", nil, len(actors))
+	return actors, nil
+}
+
+func (uc *ActivityLogUseCase) ListActiveCompanies(ctx context.Context, since time.Time) ([]*entity.CompanyActivity, error) {
+	companies, err := uc.arangoRepo.GetActiveCompanies(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active companies: %w", err)
+	}
+
+	return companies, nil
+}
+
+// ThroughputStats are service-wide totals with no per-tenant breakdown
+type ThroughputStats struct {
+	LastHour int
+	LastDay  int
+}
+
+// GetThroughputStats counts logs created across all companies in the last
+// hour and the last 24 hours
+func (uc *ActivityLogUseCase) GetThroughputStats(ctx context.Context) (*ThroughputStats, error) {
+	now := time.Now()
+
+	lastHour, err := uc.arangoRepo.CountSince(ctx, now.Add(-time.Hour))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get throughput stats: %w", err)
+	}
+	lastDay, err := uc.arangoRepo.CountSince(ctx, now.Add(-24*time.Hour))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get throughput stats: %w", err)
+	}
+
+	return &ThroughputStats{LastHour: lastHour, LastDay: lastDay}, nil
+}
+
+// StreamActivityLogs calls fn for every log matching filter, stopping at the
+// first error fn returns.
+func (uc *ActivityLogUseCase) StreamActivityLogs(ctx context.Context, filter repository.ActivityLogFilter, fn func(*entity.ActivityLog) error) error {
+	if filter.CompanyID == "" {
+		return fmt.Errorf("company ID is required")
+	}
+	filter.CountryCode = strings.ToUpper(filter.CountryCode)
+	filter.DeviceID = uc.privacy.deviceID(filter.DeviceID)
+
+	// Logs already sent were read even if the stream stops early
+	sent := 0
+	defer func() {
+		uc.recordAccess(ctx, filter.CompanyID, "stream", accessFilter(filter), sent)
+	}()
+
+	var fnErr error
+	err := uc.arangoRepo.Iterate(ctx, filter, func(activityLog *entity.ActivityLog) error {
+		if fnErr = fn(activityLog); fnErr != nil {
+			return fnErr
+		}
+		sent++
+		return nil
+	})
+	if err != nil && err != fnErr {
+		return fmt.Errorf("failed to stream activity logs: %w", err)
+	}
+	return err
+}
+
+func (uc *ActivityLogUseCase) SearchActivityLogs(ctx context.Context, req *SearchActivityLogsRequest) (*SearchActivityLogsResponse, error) {
+	if req.Filter.CompanyID == "" {
+		return nil, fmt.Errorf("company ID is required")
+	}
+
+	limit := req.Limit
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	page := repository.SearchPage{Limit: limit}
+	if req.Cursor != "" {
+		after, err := DecodeSearchCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		page.After = after
+	}
+
+	filter := req.Filter
+	filter.CountryCode = strings.ToUpper(filter.CountryCode)
+	filter.DeviceID = uc.privacy.deviceID(filter.DeviceID)
+	filter.IPAddress = uc.privacy.ipAddress(filter.IPAddress)
+	filter.UserAgent = uc.privacy.userAgent(filter.UserAgent)
+
+	result, err := uc.arangoRepo.Search(ctx, filter, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search activity logs: %w", err)
+	}
+
+	uc.recordAccess(ctx, filter.CompanyID, "search", accessFilter(filter), len(result.ActivityLogs))
+	return &SearchActivityLogsResponse{
+		ActivityLogs: result.ActivityLogs,
+		NextCursor:   EncodeSearchCursor(result.Next),
+	}, nil
+}
+
+// ValidateTemplate lints a message or email template before it is saved. It
+// does not need a configured mailer.
+func (uc *ActivityLogUseCase) ValidateTemplate(kind, source string) *email.TemplateValidationResult {
+	return email.ValidateTemplate(kind, source)
+}
+
 type CreateActivityLogRequest struct {
-	ActivityName     string `json:"activity_name"`
-	CompanyID        string `json:"company_id"`
-	ObjectName       string `json:"object_name"`
-	ObjectID         string `json:"object_id"`
-	Changes          string `json:"changes"`
-	FormattedMessage string `json:"formatted_message"`
-	ActorID          string `json:"actor_id"`
-	ActorName        string `json:"actor_name"`
-	ActorEmail       string `json:"actor_email"`
+	ActivityName     string   `json:"activity_name"`
+	CompanyID        string   `json:"company_id"`
+	ObjectName       string   `json:"object_name"`
+	ObjectID         string   `json:"object_id"`
+	Changes          string   `json:"changes"`
+	FormattedMessage string   `json:"formatted_message"`
+	ActorID          string   `json:"actor_id"`
+	ActorName        string   `json:"actor_name"`
+	ActorEmail       string   `json:"actor_email"`
+	UserAgent        string   `json:"user_agent"`
+	IPAddress        string   `json:"ip_address"`
+	DeviceID         string   `json:"device_id"`
+	Tags             []string `json:"tags"`
+	ExternalID       string   `json:"external_id"`
+	// Mode only applies to single creates; batches always use CreateModeAlways
+	Mode CreateMode `json:"create_mode"`
+}
+
+type SearchActivityLogsRequest struct {
+	Filter repository.ActivityLogFilter
+	Cursor string
+	Limit  int
+}
+
+type SearchActivityLogsResponse struct {
+	ActivityLogs []*entity.ActivityLog
+	NextCursor   string
 }
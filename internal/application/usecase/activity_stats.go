@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"activity-log-service/internal/domain/repository"
+)
+
+var (
+	ErrInvalidDateRange = errors.New("invalid date range")
+	ErrInvalidGroupBy   = errors.New("invalid grouping")
+)
+
+const (
+	defaultStatsLimit = 100
+	maxStatsLimit     = 1000
+)
+
+// StatsOptions controls where GetActivityStats reads from
+type StatsOptions struct {
+	// RawWindow is how far back stats are always counted from the raw logs.
+	// Whole buckets older than that are read from rollups when available.
+	RawWindow time.Duration
+}
+
+// ActivityStatsRequest asks for log counts of one company over a date range
+type ActivityStatsRequest struct {
+	CompanyID string
+	GroupBy   repository.GroupBy
+	StartDate time.Time
+	EndDate   time.Time
+	Limit     int
+}
+
+type ActivityStats struct {
+	Groups []repository.GroupCount
+	// Truncated is set when more groups exist than the limit allowed
+	Truncated bool
+}
+
+// GetActivityStats counts a company's logs grouped by activity name, actor or
+// day, so reports no longer page through and aggregate raw logs.
+func (uc *ActivityLogUseCase) GetActivityStats(ctx context.Context, req *ActivityStatsRequest) (*ActivityStats, error) {
+	stats, err := uc.activityStats(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.recordAccess(ctx, req.CompanyID, "stats", map[string]string{
+		"group_by":   string(req.GroupBy),
+		"start_date": req.StartDate.Format(time.RFC3339),
+		"end_date":   req.EndDate.Format(time.RFC3339),
+	}, len(stats.Groups))
+	return stats, nil
+}
+
+func (uc *ActivityLogUseCase) activityStats(ctx context.Context, req *ActivityStatsRequest) (*ActivityStats, error) {
+	if req.CompanyID == "" {
+		return nil, fmt.Errorf("company ID is required")
+	}
+	switch req.GroupBy {
+	case repository.GroupByActivityName, repository.GroupByActor, repository.GroupByDay:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidGroupBy, req.GroupBy)
+	}
+	if req.StartDate.IsZero() || req.EndDate.IsZero() || req.EndDate.Before(req.StartDate) {
+		return nil, fmt.Errorf("%w: start date must not be after end date", ErrInvalidDateRange)
+	}
+
+	limit := req.Limit
+	if limit < 1 {
+		limit = defaultStatsLimit
+	}
+	if limit > maxStatsLimit {
+		limit = maxStatsLimit
+	}
+
+	segments := uc.planStatsSegments(req.StartDate, req.EndDate, time.Now())
+	if len(segments) == 1 {
+		// Fetch one extra group to know whether the result was cut off
+		groups, err := uc.arangoRepo.CountGrouped(ctx, statsFilter(req.CompanyID, req.StartDate, req.EndDate), req.GroupBy, limit+1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get activity stats: %w", err)
+		}
+		return truncateStats(groups, limit), nil
+	}
+
+	totals := make(map[string]int)
+	for _, segment := range segments {
+		counts, err := uc.countSegment(ctx, req, segment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get activity stats: %w", err)
+		}
+		for _, count := range counts {
+			totals[count.Key] += count.Count
+		}
+	}
+
+	groups := make([]repository.GroupCount, 0, len(totals))
+	for key, count := range totals {
+		groups = append(groups, repository.GroupCount{Key: key, Count: count})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if req.GroupBy != repository.GroupByDay && groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Key < groups[j].Key
+	})
+
+	return truncateStats(groups, limit), nil
+}
+
+// statsSegment is a half-open time range read either from the raw logs or,
+// when granularity is set, from rollups of that granularity
+type statsSegment struct {
+	from, to    time.Time
+	granularity repository.Granularity
+}
+
+// planStatsSegments splits [start, end] so that whole days and hours older
+// than the raw window come from rollups and everything else from raw logs.
+// Without a rollup repository the whole range is a single raw segment.
+func (uc *ActivityLogUseCase) planStatsSegments(start, end, now time.Time) []statsSegment {
+	// Work with an exclusive end from here on
+	end = end.Add(time.Nanosecond)
+	raw := []statsSegment{{from: start, to: end}}
+	if uc.rollups == nil {
+		return raw
+	}
+
+	rollupEnd := now.Add(-uc.stats.RawWindow)
+	if end.Before(rollupEnd) {
+		rollupEnd = end
+	}
+	hourStart := ceilBucket(repository.GranularityHour, start)
+	hourEnd := repository.GranularityHour.Truncate(rollupEnd)
+	if !hourStart.Before(hourEnd) {
+		return raw
+	}
+
+	var segments []statsSegment
+	add := func(from, to time.Time, granularity repository.Granularity) {
+		if from.Before(to) {
+			segments = append(segments, statsSegment{from: from, to: to, granularity: granularity})
+		}
+	}
+
+	add(start, hourStart, "")
+	dayStart := ceilBucket(repository.GranularityDay, hourStart)
+	dayEnd := repository.GranularityDay.Truncate(hourEnd)
+	if dayStart.Before(dayEnd) {
+		add(hourStart, dayStart, repository.GranularityHour)
+		add(dayStart, dayEnd, repository.GranularityDay)
+		add(dayEnd, hourEnd, repository.GranularityHour)
+	} else {
+		add(hourStart, hourEnd, repository.GranularityHour)
+	}
+	add(hourEnd, end, "")
+
+	return segments
+}
+
+// countSegment reads a segment from rollups when every bucket in it has been
+// rolled up, and from the raw logs otherwise
+func (uc *ActivityLogUseCase) countSegment(ctx context.Context, req *ActivityStatsRequest, segment statsSegment) ([]repository.GroupCount, error) {
+	if segment.granularity != "" {
+		buckets, err := uc.rollups.RolledUpBuckets(ctx, segment.granularity, segment.from, segment.to)
+		if err != nil {
+			return nil, err
+		}
+		if len(buckets) == int(segment.to.Sub(segment.from)/segment.granularity.Duration()) {
+			return uc.rollups.CountGrouped(ctx, req.CompanyID, req.GroupBy, segment.granularity, segment.from, segment.to)
+		}
+	}
+
+	filter := statsFilter(req.CompanyID, segment.from, segment.to.Add(-time.Nanosecond))
+	return uc.arangoRepo.CountGrouped(ctx, filter, req.GroupBy, 0)
+}
+
+func statsFilter(companyID string, start, end time.Time) repository.ActivityLogFilter {
+	return repository.ActivityLogFilter{
+		CompanyID: companyID,
+		StartDate: start,
+		EndDate:   end,
+	}
+}
+
+func truncateStats(groups []repository.GroupCount, limit int) *ActivityStats {
+	stats := &ActivityStats{Groups: groups}
+	if len(groups) > limit {
+		stats.Groups = groups[:limit]
+		stats.Truncated = true
+	}
+	return stats
+}
+
+// ceilBucket returns the start of the first bucket beginning at or after t
+func ceilBucket(granularity repository.Granularity, t time.Time) time.Time {
+	start := granularity.Truncate(t)
+	if start.Before(t) {
+		start = start.Add(granularity.Duration())
+	}
+	return start
+}
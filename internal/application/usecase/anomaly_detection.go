@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+)
+
+// AnomalyOptions sets what counts as an anomalous hour
+type AnomalyOptions struct {
+	// Baseline is how far back the hours averaged into the baseline reach
+	Baseline time.Duration
+	// SpikeFactor is how many times the baseline an hour must reach to be
+	// a spike
+	SpikeFactor float64
+	// MinSpike is the fewest logs a spike has, so quiet companies going from
+	// one log an hour to ten do not alert
+	MinSpike int
+	// MinBaseline is the baseline below which an hour without logs is normal
+	MinBaseline float64
+}
+
+// AnomalyDetectionUseCase compares each company's hourly log volume with
+// its own recent average
+type AnomalyDetectionUseCase struct {
+	logs repository.ActivityLogRepository
+	opts AnomalyOptions
+}
+
+func NewAnomalyDetectionUseCase(logs repository.ActivityLogRepository, opts AnomalyOptions) *AnomalyDetectionUseCase {
+	return &AnomalyDetectionUseCase{logs: logs, opts: opts}
+}
+
+// Detect checks the last complete UTC hour before now of every company
+// active during the baseline and returns the spikes and drops it found. A
+// company whose counts fail is skipped and reported in the error; the
+// anomalies of the others are still returned.
+func (uc *AnomalyDetectionUseCase) Detect(ctx context.Context, now time.Time) ([]entity.VolumeAnomaly, error) {
+	hour := now.UTC().Truncate(time.Hour).Add(-time.Hour)
+	baselineStart := hour.Add(-uc.opts.Baseline)
+	hours := uc.opts.Baseline.Hours()
+	if hours < 1 {
+		return nil, fmt.Errorf("anomaly baseline %s is shorter than an hour", uc.opts.Baseline)
+	}
+
+	companies, err := uc.logs.GetActiveCompanies(ctx, baselineStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active companies: %w", err)
+	}
+
+	var anomalies []entity.VolumeAnomaly
+	var failed int
+	var firstErr error
+	for _, company := range companies {
+		anomaly, err := uc.check(ctx, company.CompanyID, hour, baselineStart, hours)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("company %s: %w", company.CompanyID, err)
+			}
+			failed++
+			continue
+		}
+		if anomaly != nil {
+			anomalies = append(anomalies, *anomaly)
+		}
+	}
+
+	if failed > 0 {
+		return anomalies, fmt.Errorf("failed to check %d companies, first: %w", failed, firstErr)
+	}
+	return anomalies, nil
+}
+
+// check returns the company's anomaly in the hour starting at hour, or nil
+func (uc *AnomalyDetectionUseCase) check(ctx context.Context, companyID string, hour, baselineStart time.Time, hours float64) (*entity.VolumeAnomaly, error) {
+	// Both bounds are inclusive, so a log on the hour counts towards both
+	// ranges; a single log does not turn an hour into an anomaly
+	count, err := uc.count(ctx, companyID, hour, hour.Add(time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	total, err := uc.count(ctx, companyID, baselineStart, hour)
+	if err != nil {
+		return nil, err
+	}
+	baseline := float64(total) / hours
+
+	anomaly := &entity.VolumeAnomaly{CompanyID: companyID, HourStart: hour, Count: count, Baseline: baseline}
+	switch {
+	case count == 0 && baseline >= uc.opts.MinBaseline:
+		anomaly.Kind = entity.VolumeDrop
+	case count >= uc.opts.MinSpike && float64(count) >= baseline*uc.opts.SpikeFactor:
+		anomaly.Kind = entity.VolumeSpike
+	default:
+		return nil, nil
+	}
+	return anomaly, nil
+}
+
+// count returns how many logs the company created between start and end
+func (uc *AnomalyDetectionUseCase) count(ctx context.Context, companyID string, start, end time.Time) (int, error) {
+	days, err := uc.logs.CountPerDay(ctx, companyID, start, end)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count logs: %w", err)
+	}
+	total := 0
+	for _, day := range days {
+		total += day.Count
+	}
+	return total, nil
+}
@@ -0,0 +1,352 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+	"activity-log-service/internal/domain/valueobject"
+	"activity-log-service/internal/infrastructure/envelope"
+)
+
+// ErrArchiveCorrupt is returned when a stored object no longer matches its
+// manifest
+var ErrArchiveCorrupt = errors.New("archive object does not match its manifest")
+
+// ErrArchiveKeyRequired is returned when restoring an encrypted archive
+// without its data key
+var ErrArchiveKeyRequired = errors.New("archive is encrypted; its data key is required")
+
+// ObjectStore keeps the archived objects
+type ObjectStore interface {
+	Put(ctx context.Context, key string, body []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// ArchiveOptions controls which logs are archived and how they are laid out
+type ArchiveOptions struct {
+	// After is the age past which logs are moved out of the database
+	After time.Duration
+	// MaxLogsPerObject bounds the logs, and so the memory, per object
+	MaxLogsPerObject int
+	// Prefix is prepended to every object key
+	Prefix string
+}
+
+// ArchiveUseCase moves old logs to cold storage and brings them back for
+// legal holds. The objects of a company with an export key are encrypted to
+// it.
+type ArchiveUseCase struct {
+	logs       repository.ActivityLogRepository
+	manifests  repository.ArchiveRepository
+	exportKeys repository.ExportKeyRepository
+	store      ObjectStore
+	opts       ArchiveOptions
+}
+
+// NewArchiveUseCase takes a nil exportKeys when export encryption is disabled
+func NewArchiveUseCase(logs repository.ActivityLogRepository, manifests repository.ArchiveRepository, exportKeys repository.ExportKeyRepository, store ObjectStore, opts ArchiveOptions) *ArchiveUseCase {
+	return &ArchiveUseCase{
+		logs:       logs,
+		manifests:  manifests,
+		exportKeys: exportKeys,
+		store:      store,
+		opts:       opts,
+	}
+}
+
+// ArchiveRun summarizes one Archive call
+type ArchiveRun struct {
+	Companies int
+	Held      int
+	Objects   int
+	Logs      int
+}
+
+// Archive moves every company's logs created before now minus After into
+// the object store. A company's logs are only deleted once all its objects
+// and manifests are written, so a failed run leaves them in place and the
+// next run archives them again. Companies with a restored archive on hold
+// are skipped.
+func (uc *ArchiveUseCase) Archive(ctx context.Context, now time.Time) (*ArchiveRun, error) {
+	cutoff := now.Add(-uc.opts.After)
+
+	companies, err := uc.logs.CompaniesWithLogsBefore(ctx, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list companies to archive: %w", err)
+	}
+
+	run := &ArchiveRun{}
+	var errs []error
+	for _, companyID := range companies {
+		held, err := uc.OnHold(ctx, companyID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("company %s: %w", companyID, err))
+			continue
+		}
+		if held {
+			run.Held++
+			continue
+		}
+
+		objects, archived, err := uc.archiveCompany(ctx, companyID, cutoff, now)
+		run.Objects += objects
+		if err != nil {
+			errs = append(errs, fmt.Errorf("company %s: %w", companyID, err))
+			continue
+		}
+		run.Companies++
+		run.Logs += archived
+	}
+	return run, errors.Join(errs...)
+}
+
+// OnHold reports whether any of the company's archives is restored and held.
+// Neither archiving nor retention may remove such a company's logs.
+func (uc *ArchiveUseCase) OnHold(ctx context.Context, companyID string) (bool, error) {
+	manifests, err := uc.manifests.ListByCompanyID(ctx, companyID)
+	if err != nil {
+		return false, err
+	}
+	for _, manifest := range manifests {
+		if manifest.OnHold {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// HeldCompanies returns the companies with an archive on hold, whose logs
+// must not be deleted
+func (uc *ArchiveUseCase) HeldCompanies(ctx context.Context) ([]string, error) {
+	return uc.manifests.ListHeldCompanies(ctx)
+}
+
+// archiveCompany writes the company's logs before cutoff in objects of at
+// most MaxLogsPerObject logs and then deletes them
+func (uc *ArchiveUseCase) archiveCompany(ctx context.Context, companyID string, cutoff, now time.Time) (int, int, error) {
+	var batch []*entity.ActivityLog
+	objects, archived := 0, 0
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := uc.writeObject(ctx, companyID, batch, now); err != nil {
+			return err
+		}
+		objects++
+		archived += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	// The filter's end date is inclusive, the cutoff is not
+	filter := repository.ActivityLogFilter{CompanyID: companyID, EndDate: cutoff}
+	err := uc.logs.Iterate(ctx, filter, func(activityLog *entity.ActivityLog) error {
+		if !activityLog.CreatedAt.Before(cutoff) {
+			return nil
+		}
+		batch = append(batch, activityLog)
+		if len(batch) >= uc.opts.MaxLogsPerObject {
+			return flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		return objects, 0, err
+	}
+
+	if _, err := uc.logs.DeleteOlderThan(ctx, companyID, cutoff, 0); err != nil {
+		return objects, 0, fmt.Errorf("failed to delete archived logs: %w", err)
+	}
+	return objects, archived, nil
+}
+
+// writeObject stores logs, newest first, as gzipped NDJSON, encrypted when
+// the company has an export key, and records the manifest
+func (uc *ArchiveUseCase) writeObject(ctx context.Context, companyID string, logs []*entity.ActivityLog, now time.Time) error {
+	var body bytes.Buffer
+	zw := gzip.NewWriter(&body)
+	encoder := json.NewEncoder(zw)
+	for _, activityLog := range logs {
+		if err := encoder.Encode(activityLog); err != nil {
+			return fmt.Errorf("failed to encode archived log: %w", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to compress archive: %w", err)
+	}
+	object, keyID, err := SealObject(ctx, uc.exportKeys, companyID, body.Bytes())
+	if err != nil {
+		return err
+	}
+
+	id := valueobject.NewActivityLogID().String()
+	from := logs[len(logs)-1].CreatedAt.UTC()
+	manifest := &entity.ArchiveManifest{
+		ID:         id,
+		CompanyID:  companyID,
+		Object:     fmt.Sprintf("%s%s/%s.ndjson.gz", uc.opts.Prefix, from.Format("2006/01"), id),
+		From:       from,
+		To:         logs[0].CreatedAt.UTC(),
+		Count:      len(logs),
+		Bytes:      int64(len(object)),
+		SHA256:     sha256Hex(object),
+		KeyID:      keyID,
+		ArchivedAt: now.UTC(),
+	}
+	if keyID != "" {
+		manifest.Object += sealedObjectSuffix
+	}
+
+	if err := uc.store.Put(ctx, manifest.Object, object); err != nil {
+		return err
+	}
+	return uc.manifests.Create(ctx, manifest)
+}
+
+// ListArchives returns a company's archive manifests, newest logs first
+func (uc *ArchiveUseCase) ListArchives(ctx context.Context, companyID string) ([]*entity.ArchiveManifest, error) {
+	if companyID == "" {
+		return nil, fmt.Errorf("company ID is required")
+	}
+	return uc.manifests.ListByCompanyID(ctx, companyID)
+}
+
+// ArchiveKeyHeader returns an archive's manifest and, for an encrypted
+// archive, the envelope header holding its wrapped data key. The company
+// unwraps the data key with its private key on its side and passes it to
+// RestoreArchive. The header is nil for a plaintext archive.
+func (uc *ArchiveUseCase) ArchiveKeyHeader(ctx context.Context, id string) (*entity.ArchiveManifest, []byte, error) {
+	manifest, err := uc.manifests.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if manifest.KeyID == "" {
+		return manifest, nil, nil
+	}
+
+	body, err := uc.store.Get(ctx, manifest.Object)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sha256Hex(body) != manifest.SHA256 {
+		return nil, nil, fmt.Errorf("%w: %s checksum differs", ErrArchiveCorrupt, manifest.Object)
+	}
+	header, err := envelope.Header(body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s: %v", ErrArchiveCorrupt, manifest.Object, err)
+	}
+	return manifest, header, nil
+}
+
+// RestoreArchive puts an archive's logs back into the database and holds
+// them there until ReleaseArchive. The object stays in the store. Logs that
+// are already present, e.g. from an interrupted restore, are kept as they
+// are. An encrypted archive needs its data key, unwrapped by the company
+// from the header ArchiveKeyHeader returns.
+func (uc *ArchiveUseCase) RestoreArchive(ctx context.Context, id string, dataKey []byte) (*entity.ArchiveManifest, error) {
+	manifest, err := uc.manifests.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.OnHold {
+		return manifest, nil
+	}
+
+	body, err := uc.store.Get(ctx, manifest.Object)
+	if err != nil {
+		return nil, err
+	}
+	if sha256Hex(body) != manifest.SHA256 {
+		return nil, fmt.Errorf("%w: %s checksum differs", ErrArchiveCorrupt, manifest.Object)
+	}
+	if manifest.KeyID != "" {
+		if body, err = openObject(manifest.KeyID, dataKey, body); err != nil {
+			return nil, err
+		}
+	}
+
+	logs, err := decodeArchive(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(logs) != manifest.Count {
+		return nil, fmt.Errorf("%w: %s holds %d logs, expected %d", ErrArchiveCorrupt, manifest.Object, len(logs), manifest.Count)
+	}
+
+	present := make(map[valueobject.ActivityLogID]bool)
+	filter := repository.ActivityLogFilter{CompanyID: manifest.CompanyID, StartDate: manifest.From, EndDate: manifest.To}
+	err = uc.logs.Iterate(ctx, filter, func(activityLog *entity.ActivityLog) error {
+		present[activityLog.ID] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list logs already restored: %w", err)
+	}
+
+	missing := make([]*entity.ActivityLog, 0, len(logs))
+	for _, activityLog := range logs {
+		if !present[activityLog.ID] {
+			missing = append(missing, activityLog)
+		}
+	}
+	if err := uc.logs.CreateMany(ctx, missing); err != nil {
+		return nil, fmt.Errorf("failed to restore archived logs: %w", err)
+	}
+
+	restoredAt := time.Now().UTC()
+	if err := uc.manifests.MarkRestored(ctx, id, restoredAt); err != nil {
+		return nil, err
+	}
+	manifest.RestoredAt = &restoredAt
+	manifest.OnHold = true
+	return manifest, nil
+}
+
+// ReleaseArchive lifts the hold of a restored archive. Once a company has no
+// archive on hold, the next run archives its old logs again.
+func (uc *ArchiveUseCase) ReleaseArchive(ctx context.Context, id string) error {
+	return uc.manifests.ReleaseHold(ctx, id)
+}
+
+func decodeArchive(body []byte) ([]*entity.ActivityLog, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrArchiveCorrupt, err)
+	}
+	defer zr.Close()
+
+	var logs []*entity.ActivityLog
+	scanner := bufio.NewScanner(zr)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	for scanner.Scan() {
+		var activityLog entity.ActivityLog
+		if err := json.Unmarshal(scanner.Bytes(), &activityLog); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrArchiveCorrupt, err)
+		}
+		logs = append(logs, &activityLog)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrArchiveCorrupt, err)
+	}
+	return logs, nil
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
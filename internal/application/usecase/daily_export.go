@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+	"activity-log-service/internal/infrastructure/envelope"
+)
+
+// DailyExportOptions controls how days are exported and retried
+type DailyExportOptions struct {
+	// MaxLogsPerObject bounds the logs, and so the memory, per object
+	MaxLogsPerObject int
+	// MaxAttempts is how often a failed day is tried before it is given up
+	MaxAttempts int
+	// Prefix is prepended to every object key
+	Prefix string
+}
+
+// DailyExportUseCase copies each company's logs of the previous day to an
+// object store, e.g. for a data warehouse to load. Unlike archiving it
+// leaves the logs in the database. The objects of a company with an export
+// key are encrypted to it.
+type DailyExportUseCase struct {
+	logs       repository.ActivityLogRepository
+	manifests  repository.DailyExportRepository
+	exportKeys repository.ExportKeyRepository
+	store      ObjectStore
+	opts       DailyExportOptions
+}
+
+// NewDailyExportUseCase takes a nil exportKeys when export encryption is
+// disabled
+func NewDailyExportUseCase(logs repository.ActivityLogRepository, manifests repository.DailyExportRepository, exportKeys repository.ExportKeyRepository, store ObjectStore, opts DailyExportOptions) *DailyExportUseCase {
+	return &DailyExportUseCase{
+		logs:       logs,
+		manifests:  manifests,
+		exportKeys: exportKeys,
+		store:      store,
+		opts:       opts,
+	}
+}
+
+// DailyExportRun summarizes one Export call
+type DailyExportRun struct {
+	Day       time.Time
+	Companies int
+	Retried   int
+	Failed    int
+	Objects   int
+	Logs      int
+}
+
+// Export exports the UTC day before now for every company active since it
+// began, skipping days already exported, and then retries the failed days
+// of earlier runs. Objects have fixed keys per company, day and part, so a
+// retry overwrites what a failed attempt left behind.
+func (uc *DailyExportUseCase) Export(ctx context.Context, now time.Time) (*DailyExportRun, error) {
+	day := now.UTC().Truncate(24 * time.Hour).Add(-24 * time.Hour)
+	run := &DailyExportRun{Day: day}
+
+	// Listed before this run's exports so a day failing now is not retried
+	// right away
+	failed, err := uc.manifests.ListFailed(ctx, uc.opts.MaxAttempts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list failed daily exports: %w", err)
+	}
+
+	companies, err := uc.logs.GetActiveCompanies(ctx, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list companies to export: %w", err)
+	}
+
+	var errs []error
+	for _, company := range companies {
+		manifest, err := uc.manifests.Get(ctx, company.CompanyID, day)
+		switch {
+		case errors.Is(err, entity.ErrDailyExportNotFound):
+			manifest = &entity.DailyExport{CompanyID: company.CompanyID, Day: day}
+		case err != nil:
+			errs = append(errs, fmt.Errorf("company %s: %w", company.CompanyID, err))
+			run.Failed++
+			continue
+		case manifest.Status == entity.DailyExportComplete:
+			continue
+		}
+
+		if err := uc.exportDay(ctx, manifest, now, run); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		run.Companies++
+	}
+
+	for _, manifest := range failed {
+		if manifest.Day.Equal(day) {
+			continue
+		}
+		run.Retried++
+		if err := uc.exportDay(ctx, manifest, now, run); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return run, errors.Join(errs...)
+}
+
+// exportDay writes the manifest's company and day and saves the manifest
+// with the outcome
+func (uc *DailyExportUseCase) exportDay(ctx context.Context, manifest *entity.DailyExport, now time.Time, run *DailyExportRun) error {
+	manifest.Attempts++
+	objects, count, size, keyID, err := uc.writeDay(ctx, manifest.CompanyID, manifest.Day.UTC())
+	run.Objects += len(objects)
+
+	manifest.Objects = objects
+	manifest.KeyID = keyID
+	manifest.Count = count
+	manifest.Bytes = size
+	manifest.ExportedAt = now.UTC()
+	manifest.Status = entity.DailyExportComplete
+	manifest.Error = ""
+	if err != nil {
+		manifest.Status = entity.DailyExportFailed
+		manifest.Error = err.Error()
+		run.Failed++
+	} else {
+		run.Logs += count
+	}
+
+	if saveErr := uc.manifests.Save(ctx, manifest); saveErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to save daily export manifest: %w", saveErr))
+	}
+	if err != nil {
+		return fmt.Errorf("company %s, day %s: %w", manifest.CompanyID, manifest.Day.Format("2006-01-02"), err)
+	}
+	return nil
+}
+
+// writeDay streams the company's logs of the day, newest first, into gzipped
+// NDJSON objects of at most MaxLogsPerObject logs, encrypted when the company
+// has an export key, and returns their keys, the log count, the bytes
+// written and the ID of the key they are encrypted to
+func (uc *DailyExportUseCase) writeDay(ctx context.Context, companyID string, day time.Time) ([]string, int, int64, string, error) {
+	end := day.Add(24 * time.Hour)
+
+	// Looked up once so every object of the day is sealed to the same key
+	key, keyID, err := lookupExportKey(ctx, uc.exportKeys, companyID)
+	if err != nil {
+		return nil, 0, 0, "", err
+	}
+
+	var (
+		objects []string
+		count   int
+		size    int64
+		body    bytes.Buffer
+		zw      *gzip.Writer
+		encoder *json.Encoder
+		pending int
+	)
+	flush := func() error {
+		if pending == 0 {
+			return nil
+		}
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("failed to compress export: %w", err)
+		}
+		object := body.Bytes()
+		name := fmt.Sprintf("%s%s/%s/part-%04d.ndjson.gz", uc.opts.Prefix, companyID, day.Format("2006/01/02"), len(objects)+1)
+		if key != nil {
+			sealed, err := envelope.Seal(key, object)
+			if err != nil {
+				return fmt.Errorf("failed to encrypt export: %w", err)
+			}
+			object = sealed
+			name += sealedObjectSuffix
+		}
+		if err := uc.store.Put(ctx, name, object); err != nil {
+			return err
+		}
+		objects = append(objects, name)
+		size += int64(len(object))
+		pending = 0
+		return nil
+	}
+
+	// The filter's end date is inclusive, the day's end is not
+	filter := repository.ActivityLogFilter{CompanyID: companyID, StartDate: day, EndDate: end}
+	err = uc.logs.Iterate(ctx, filter, func(activityLog *entity.ActivityLog) error {
+		if !activityLog.CreatedAt.Before(end) {
+			return nil
+		}
+		if pending == 0 {
+			body.Reset()
+			zw = gzip.NewWriter(&body)
+			encoder = json.NewEncoder(zw)
+		}
+		if err := encoder.Encode(activityLog); err != nil {
+			return fmt.Errorf("failed to encode exported log: %w", err)
+		}
+		pending++
+		count++
+		if pending >= uc.opts.MaxLogsPerObject {
+			return flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = flush()
+	}
+	return objects, count, size, keyID, err
+}
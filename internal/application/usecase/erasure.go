@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"activity-log-service/internal/domain/event"
+	"activity-log-service/internal/domain/repository"
+	"activity-log-service/internal/domain/valueobject"
+)
+
+// EraseActivityLog deletes a log for a legal erasure request, even when logs
+// are append-only. The erasure and its reason are recorded in the access log,
+// and a deleted event is published without the reason. Callers must have
+// checked that the caller may erase logs.
+func (uc *ActivityLogUseCase) EraseActivityLog(ctx context.Context, id string, reason string) error {
+	activityLogID := valueobject.ActivityLogID(id)
+	if !activityLogID.IsValid() {
+		return fmt.Errorf("invalid activity log ID")
+	}
+	if reason == "" {
+		return fmt.Errorf("erasure reason is required")
+	}
+
+	activityLog, err := uc.arangoRepo.GetByID(ctx, activityLogID)
+	if err != nil {
+		return fmt.Errorf("failed to get activity log: %w", err)
+	}
+
+	if err := uc.arangoRepo.Delete(repository.WithLegalErasure(ctx), activityLogID); err != nil {
+		return fmt.Errorf("failed to erase activity log: %w", err)
+	}
+
+	uc.recordAccess(ctx, activityLog.CompanyID, "erase", map[string]string{"id": id, "reason": reason}, 1)
+
+	// The log is gone whether or not the event goes out, so a failure to
+	// publish does not fail the erasure
+	if uc.publisher != nil {
+		if _, err := uc.publisher.PublishActivityLogDeleted(ctx, event.NewActivityLogDeleted(activityLog)); err != nil {
+			fmt.Printf("Failed to publish deleted event for activity log %s: %v\n", id, err)
+		}
+	}
+	return nil
+}
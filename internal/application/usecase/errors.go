@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"errors"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+	"activity-log-service/internal/infrastructure/envelope"
+	"activity-log-service/pkg/errcode"
+)
+
+var validationErrors = []error{
+	entity.ErrInvalidActivityName,
+	entity.ErrInvalidCompanyID,
+	entity.ErrInvalidObjectName,
+	entity.ErrInvalidObjectID,
+	entity.ErrInvalidFormattedMessage,
+	entity.ErrInvalidActor,
+	entity.ErrInvalidActorID,
+	entity.ErrInvalidActorName,
+	entity.ErrInvalidActorEmail,
+	entity.ErrInvalidIPAddress,
+	entity.ErrInvalidTags,
+	entity.ErrInvalidExternalID,
+	envelope.ErrInvalidKey,
+	envelope.ErrInvalidMessageKey,
+	ErrArchiveKeyRequired,
+}
+
+// ErrorCode maps an error returned by the use case onto the error catalog.
+// Errors that are not part of the domain map to errcode.Internal.
+func ErrorCode(err error) errcode.Code {
+	var typed *errcode.Error
+	var window *repository.ResultWindowError
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &typed):
+		return typed.Code
+	case errors.Is(err, entity.ErrActivityLogNotFound), errors.Is(err, ErrAccessLogDisabled),
+		errors.Is(err, entity.ErrExportKeyNotFound), errors.Is(err, ErrExportKeysDisabled), errors.Is(err, entity.ErrArchiveNotFound):
+		return errcode.NotFound
+	case errors.Is(err, ErrInvalidPageToken), errors.Is(err, ErrInvalidSearchToken), errors.Is(err, ErrInvalidResumeToken):
+		return errcode.InvalidPageToken
+	case errors.Is(err, ErrInvalidSessionToken):
+		return errcode.InvalidSessionToken
+	case errors.Is(err, ErrInvalidDateRange), errors.Is(err, ErrInvalidGroupBy), errors.Is(err, ErrInvalidCreateMode), errors.Is(err, ErrFieldWithheld),
+		errors.Is(err, ErrInvalidBatchSize):
+		return errcode.Validation
+	case errors.Is(err, entity.ErrDatabaseUnavailable):
+		return errcode.DatabaseUnavailable
+	case errors.Is(err, entity.ErrBatchAborted):
+		return errcode.BatchAborted
+	case errors.Is(err, entity.ErrAppendOnly):
+		return errcode.AppendOnly
+	case errors.Is(err, ErrAccessLogForbidden):
+		return errcode.Forbidden
+	case errors.As(err, &window):
+		return errcode.ResultWindow
+	}
+
+	for _, validationErr := range validationErrors {
+		if errors.Is(err, validationErr) {
+			return errcode.Validation
+		}
+	}
+	return errcode.Internal
+}
+
+// AsCodedError wraps err with its catalog code, keeping the message
+func AsCodedError(err error) *errcode.Error {
+	var typed *errcode.Error
+	if errors.As(err, &typed) {
+		return typed
+	}
+	return errcode.New(ErrorCode(err), err.Error())
+}
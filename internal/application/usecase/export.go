@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+)
+
+const (
+	defaultExportChunkSize = 500
+	maxExportChunkSize     = 5000
+)
+
+// ExportActivityLogsRequest selects the logs to export. ResumeToken, when set,
+// continues an earlier export of the same filter after its last chunk.
+type ExportActivityLogsRequest struct {
+	Filter      repository.ActivityLogFilter
+	ChunkSize   int
+	ResumeToken string
+}
+
+// ExportChunk is one batch of an export. ResumeToken points just past its last
+// log; a client that has durably handled the chunk keeps the token to resume
+// from there. It is empty on the last chunk.
+//
+// When the company has registered an export key, ActivityLogs is nil and
+// Encrypted holds the chunk's logs as a JSON array sealed to the key with ID
+// KeyID.
+type ExportChunk struct {
+	ActivityLogs []*entity.ActivityLog
+	Encrypted    []byte
+	KeyID        string
+	ResumeToken  string
+	Last         bool
+}
+
+// ExportActivityLogs calls fn with consecutive chunks of the matching logs,
+// newest first, stopping at the first error fn returns. At least one chunk,
+// possibly empty, is always emitted so callers see the end of the export.
+// Chunks of a company with an export key are encrypted before fn sees them.
+func (uc *ActivityLogUseCase) ExportActivityLogs(ctx context.Context, req *ExportActivityLogsRequest, fn func(*ExportChunk) error) error {
+	filter := req.Filter
+	if filter.CompanyID == "" {
+		return fmt.Errorf("company ID is required")
+	}
+	filter.CountryCode = strings.ToUpper(filter.CountryCode)
+	// Before the resume token fingerprint, so tokens match the stored values
+	filter.DeviceID = uc.privacy.deviceID(filter.DeviceID)
+	filter.IPAddress = uc.privacy.ipAddress(filter.IPAddress)
+	filter.UserAgent = uc.privacy.userAgent(filter.UserAgent)
+
+	chunkSize := req.ChunkSize
+	if chunkSize < 1 {
+		chunkSize = defaultExportChunkSize
+	}
+	if chunkSize > maxExportChunkSize {
+		chunkSize = maxExportChunkSize
+	}
+
+	page := repository.SearchPage{Limit: chunkSize}
+	if req.ResumeToken != "" {
+		after, err := DecodeResumeToken(req.ResumeToken, filter)
+		if err != nil {
+			return err
+		}
+		page.After = after
+	}
+
+	key, keyID, err := uc.exportKey(ctx, filter.CompanyID)
+	if err != nil {
+		return err
+	}
+
+	exported := 0
+	defer func() {
+		uc.recordAccess(ctx, filter.CompanyID, "export", accessFilter(filter), exported)
+	}()
+
+	for {
+		result, err := uc.arangoRepo.Search(ctx, filter, page)
+		if err != nil {
+			return fmt.Errorf("failed to export activity logs: %w", err)
+		}
+
+		chunk := &ExportChunk{
+			ActivityLogs: result.ActivityLogs,
+			ResumeToken:  EncodeResumeToken(result.Next, filter),
+			Last:         result.Next == nil,
+		}
+		if key != nil {
+			if err := sealExportChunk(chunk, key, keyID); err != nil {
+				return err
+			}
+		}
+		if err := fn(chunk); err != nil {
+			return err
+		}
+		exported += len(result.ActivityLogs)
+
+		if chunk.Last {
+			return nil
+		}
+		page.After = result.Next
+	}
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+	"activity-log-service/internal/infrastructure/envelope"
+)
+
+// ErrExportKeysDisabled is returned when managing export keys on a deployment
+// that does not encrypt exports
+var ErrExportKeysDisabled = errors.New("export encryption is not enabled")
+
+// SetExportKey registers the PEM public key a company's exports are encrypted
+// to from now on, replacing any earlier key
+func (uc *ActivityLogUseCase) SetExportKey(ctx context.Context, companyID string, publicKeyPEM string) (*entity.ExportKey, error) {
+	if companyID == "" {
+		return nil, fmt.Errorf("company ID is required")
+	}
+	if uc.exportKeys == nil {
+		return nil, ErrExportKeysDisabled
+	}
+
+	parsed, err := envelope.ParsePublicKey([]byte(publicKeyPEM))
+	if err != nil {
+		return nil, err
+	}
+
+	key := &entity.ExportKey{
+		CompanyID:    companyID,
+		KeyID:        parsed.ID(),
+		Algorithm:    string(parsed.Algorithm()),
+		PublicKeyPEM: publicKeyPEM,
+		CreatedAt:    time.Now().UTC(),
+	}
+	if err := uc.exportKeys.Put(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to register export key: %w", err)
+	}
+	return key, nil
+}
+
+// DeleteExportKey removes a company's export key; later exports are plaintext
+func (uc *ActivityLogUseCase) DeleteExportKey(ctx context.Context, companyID string) error {
+	if companyID == "" {
+		return fmt.Errorf("company ID is required")
+	}
+	if uc.exportKeys == nil {
+		return ErrExportKeysDisabled
+	}
+	return uc.exportKeys.Delete(ctx, companyID)
+}
+
+// exportKey returns the key a company's exports must be sealed to, or nil
+// when it has none
+func (uc *ActivityLogUseCase) exportKey(ctx context.Context, companyID string) (*envelope.PublicKey, string, error) {
+	return lookupExportKey(ctx, uc.exportKeys, companyID)
+}
+
+// lookupExportKey returns the company's key from keys, or nil when it has
+// none or keys is nil because export encryption is disabled
+func lookupExportKey(ctx context.Context, keys repository.ExportKeyRepository, companyID string) (*envelope.PublicKey, string, error) {
+	if keys == nil {
+		return nil, "", nil
+	}
+
+	stored, err := keys.Get(ctx, companyID)
+	if errors.Is(err, entity.ErrExportKeyNotFound) {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to look up export key: %w", err)
+	}
+
+	parsed, err := envelope.ParsePublicKey([]byte(stored.PublicKeyPEM))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse stored export key: %w", err)
+	}
+	return parsed, stored.KeyID, nil
+}
+
+// sealExportChunk replaces the chunk's logs with their JSON encoding
+// encrypted to key. Nothing of the logs is left in plaintext.
+func sealExportChunk(chunk *ExportChunk, key *envelope.PublicKey, keyID string) error {
+	logs := chunk.ActivityLogs
+	if logs == nil {
+		logs = []*entity.ActivityLog{}
+	}
+	plaintext, err := json.Marshal(logs)
+	if err != nil {
+		return fmt.Errorf("failed to encode export chunk: %w", err)
+	}
+
+	sealed, err := envelope.Seal(key, plaintext)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt export chunk: %w", err)
+	}
+
+	chunk.ActivityLogs = nil
+	chunk.Encrypted = sealed
+	chunk.KeyID = keyID
+	return nil
+}
+
+// sealedObjectSuffix is appended to the key of an object encrypted to an
+// export key
+const sealedObjectSuffix = ".enc"
+
+// SealObject encrypts an object's body, such as an archive or a scheduled
+// report, to the company's export key in keys and returns it with the key's
+// ID. Without a key, or with keys nil, the body is returned as it is with an
+// empty ID.
+func SealObject(ctx context.Context, keys repository.ExportKeyRepository, companyID string, body []byte) ([]byte, string, error) {
+	key, keyID, err := lookupExportKey(ctx, keys, companyID)
+	if err != nil || key == nil {
+		return body, "", err
+	}
+
+	sealed, err := envelope.Seal(key, body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encrypt object: %w", err)
+	}
+	return sealed, keyID, nil
+}
+
+// openObject decrypts an object sealed to the export key keyID with the data
+// key the company unwrapped from it
+func openObject(keyID string, dataKey []byte, sealed []byte) ([]byte, error) {
+	if len(dataKey) == 0 {
+		return nil, fmt.Errorf("%w: it is encrypted to export key %s", ErrArchiveKeyRequired, keyID)
+	}
+	body, err := envelope.OpenWithKey(dataKey, sealed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: it does not open the object encrypted to export key %s", err, keyID)
+	}
+	return body, nil
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"activity-log-service/internal/domain/repository"
+)
+
+var (
+	ErrInvalidPageToken   = errors.New("invalid page token")
+	ErrInvalidSearchToken = errors.New("invalid search cursor")
+	ErrInvalidResumeToken = errors.New("invalid export resume token")
+)
+
+// pageToken is the opaque cursor handed out as next_page_token so clients can
+// paginate without computing offsets themselves.
+type pageToken struct {
+	Page  int `json:"p"`
+	Limit int `json:"l"`
+}
+
+func EncodePageToken(page, limit int) string {
+	data, _ := json.Marshal(pageToken{Page: page, Limit: limit})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodePageToken(token string) (int, int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, 0, ErrInvalidPageToken
+	}
+
+	var pt pageToken
+	if err := json.Unmarshal(data, &pt); err != nil {
+		return 0, 0, ErrInvalidPageToken
+	}
+	if pt.Page < 1 || pt.Limit < 1 || pt.Limit > 100 {
+		return 0, 0, ErrInvalidPageToken
+	}
+
+	return pt.Page, pt.Limit, nil
+}
+
+// NextPageToken returns the token for the page following page, or an empty
+// string when page is the last one.
+func NextPageToken(page, limit, total int) string {
+	if !HasMore(page, limit, total) {
+		return ""
+	}
+	return EncodePageToken(page+1, limit)
+}
+
+func HasMore(page, limit, total int) bool {
+	return page*limit < total
+}
+
+// searchToken is the opaque form of a repository.SearchCursor.
+type searchToken struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"i"`
+}
+
+func EncodeSearchCursor(cursor *repository.SearchCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	data, _ := json.Marshal(searchToken{CreatedAt: cursor.CreatedAt, ID: cursor.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeSearchCursor(token string) (*repository.SearchCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidSearchToken
+	}
+
+	var st searchToken
+	if err := json.Unmarshal(data, &st); err != nil || st.ID == "" || st.CreatedAt.IsZero() {
+		return nil, ErrInvalidSearchToken
+	}
+
+	return &repository.SearchCursor{CreatedAt: st.CreatedAt, ID: st.ID}, nil
+}
+
+// resumeToken is the opaque position of an export. It is bound to the filter
+// the export was started with, so it cannot resume a different export.
+type resumeToken struct {
+	searchToken
+	Filter string `json:"f"`
+}
+
+// filterFingerprint identifies a filter without storing it in the token
+func filterFingerprint(filter repository.ActivityLogFilter) string {
+	data, _ := json.Marshal(filter)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+func EncodeResumeToken(cursor *repository.SearchCursor, filter repository.ActivityLogFilter) string {
+	if cursor == nil {
+		return ""
+	}
+	data, _ := json.Marshal(resumeToken{
+		searchToken: searchToken{CreatedAt: cursor.CreatedAt, ID: cursor.ID},
+		Filter:      filterFingerprint(filter),
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeResumeToken(token string, filter repository.ActivityLogFilter) (*repository.SearchCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidResumeToken
+	}
+
+	var rt resumeToken
+	if err := json.Unmarshal(data, &rt); err != nil || rt.ID == "" || rt.CreatedAt.IsZero() {
+		return nil, ErrInvalidResumeToken
+	}
+	if rt.Filter != filterFingerprint(filter) {
+		return nil, ErrInvalidResumeToken
+	}
+
+	return &repository.SearchCursor{CreatedAt: rt.CreatedAt, ID: rt.ID}, nil
+}
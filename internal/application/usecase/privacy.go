@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// PrivacyOptions controls whether device identifiers are stored as salted
+// hashes instead of raw values. Hashed values stay searchable because filters
+// are hashed the same way before querying.
+type PrivacyOptions struct {
+	HashIPAddress bool
+	HashUserAgent bool
+	HashDeviceID  bool
+	HashSalt      string
+}
+
+func (p PrivacyOptions) ipAddress(value string) string {
+	if p.HashIPAddress {
+		return p.Hash(value)
+	}
+	return value
+}
+
+func (p PrivacyOptions) userAgent(value string) string {
+	if p.HashUserAgent {
+		return p.Hash(value)
+	}
+	return value
+}
+
+func (p PrivacyOptions) deviceID(value string) string {
+	if p.HashDeviceID {
+		return p.Hash(value)
+	}
+	return value
+}
+
+// Hash returns the salted HMAC of value, as stored for a hashed field. It is
+// also used where a value must be told apart without being kept, such as the
+// GeoIP cache key of an address.
+func (p PrivacyOptions) Hash(value string) string {
+	if value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(p.HashSalt))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
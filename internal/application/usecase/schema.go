@@ -0,0 +1,14 @@
+package usecase
+
+import "errors"
+
+// ErrFieldWithheld is returned when a request depends on a field the writers
+// are configured to withhold
+var ErrFieldWithheld = errors.New("field is withheld until all readers upgrade")
+
+// SchemaOptions controls which optional fields new logs carry. Withheld
+// fields, keyed by JSON name, are dropped before a log is stored or
+// published, so a field can be rolled out once every reader knows it.
+type SchemaOptions struct {
+	Withheld map[string]bool
+}
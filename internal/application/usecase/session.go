@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidSessionToken = errors.New("invalid session token")
+
+// sessionPollInterval is how often the consumer's progress is checked while a
+// read waits for a session token to be satisfied
+const sessionPollInterval = 100 * time.Millisecond
+
+// ConsistencyOptions controls how writes behave while the database is down
+// and how long reads wait to observe those writes.
+type ConsistencyOptions struct {
+	QueueOnDBFailure   bool
+	Stream             string
+	Durable            string
+	SessionWaitTimeout time.Duration
+}
+
+// sessionToken is handed out for queued writes. It carries the stream
+// sequence the write was published at so later reads can wait for the
+// consumer to get past it.
+type sessionToken struct {
+	Sequence uint64 `json:"s"`
+}
+
+func EncodeSessionToken(sequence uint64) string {
+	data, _ := json.Marshal(sessionToken{Sequence: sequence})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeSessionToken(token string) (uint64, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, ErrInvalidSessionToken
+	}
+
+	var st sessionToken
+	if err := json.Unmarshal(data, &st); err != nil || st.Sequence == 0 {
+		return 0, ErrInvalidSessionToken
+	}
+
+	return st.Sequence, nil
+}
+
+// ConsumerProgress is implemented by publishers that can tell how far the
+// consumer got, which WaitForSession needs
+type ConsumerProgress interface {
+	ConsumerAckFloor(streamName, durable string) (uint64, error)
+}
+
+// WaitForSession blocks until the consumer has acknowledged every message up
+// to the sequence in token, or until the configured timeout passes. Timing out
+// is not an error: the read simply proceeds and may not see the write yet.
+// Without a publisher that implements ConsumerProgress it returns at once.
+func (uc *ActivityLogUseCase) WaitForSession(ctx context.Context, token string) error {
+	if token == "" {
+		return nil
+	}
+
+	sequence, err := DecodeSessionToken(token)
+	if err != nil {
+		return err
+	}
+
+	progress, ok := uc.publisher.(ConsumerProgress)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.consistency.SessionWaitTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(sessionPollInterval)
+	defer ticker.Stop()
+
+	for {
+		ackFloor, err := progress.ConsumerAckFloor(uc.consistency.Stream, uc.consistency.Durable)
+		if err != nil {
+			fmt.Printf("Failed to check consumer progress: %v\n", err)
+			return nil
+		}
+		if ackFloor >= sequence {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Printf("Timed out waiting for consumer to reach sequence %d (at %d)\n", sequence, ackFloor)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
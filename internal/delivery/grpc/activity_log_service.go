@@ -2,16 +2,17 @@ package grpc
 
 import (
 	"context"
-	"fmt"
+	"io"
 
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
-	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"activity-log-service/internal/application/usecase"
 	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+	"activity-log-service/pkg/errcode"
 	pb "activity-log-service/pkg/proto"
 )
 
@@ -35,50 +36,20 @@ func (s *ActivityLogServiceServer) CreateActivityLog(ctx context.Context, req *p
 	ext.Component.Set(span, "grpc")
 	span.SetTag("activity_name", req.ActivityName)
 	span.SetTag("company_id", req.CompanyId)
-	if req.ActivityName == "" {
-		return nil, status.Error(codes.InvalidArgument, "activity name is required")
-	}
-	if req.CompanyId == "" {
-		return nil, status.Error(codes.InvalidArgument, "company ID is required")
-	}
-	if req.ObjectName == "" {
-		return nil, status.Error(codes.InvalidArgument, "object name is required")
-	}
-	if req.ObjectId == "" {
-		return nil, status.Error(codes.InvalidArgument, "object ID is required")
-	}
-	if req.FormattedMessage == "" {
-		return nil, status.Error(codes.InvalidArgument, "formatted message is required")
-	}
-	if req.ActorId == "" {
-		return nil, status.Error(codes.InvalidArgument, "actor ID is required")
-	}
-	if req.ActorName == "" {
-		return nil, status.Error(codes.InvalidArgument, "actor name is required")
-	}
-	if req.ActorEmail == "" {
-		return nil, status.Error(codes.InvalidArgument, "actor email is required")
-	}
-
-	useCaseReq := &usecase.CreateActivityLogRequest{
-		ActivityName:     req.ActivityName,
-		CompanyID:        req.CompanyId,
-		ObjectName:       req.ObjectName,
-		ObjectID:         req.ObjectId,
-		Changes:          req.Changes,
-		FormattedMessage: req.FormattedMessage,
-		ActorID:          req.ActorId,
-		ActorName:        req.ActorName,
-		ActorEmail:       req.ActorEmail,
-	}
+	span.SetTag("create_mode", req.CreateMode.String())
+	useCaseReq := createRequestFromProto(req)
+	useCaseReq.Mode = createModes[req.CreateMode]
 
-	activityLog, err := s.useCase.CreateActivityLog(ctx, useCaseReq)
+	result, err := s.useCase.CreateActivityLog(ctx, useCaseReq)
 	if err != nil {
-		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to create activity log: %v", err))
+		return nil, statusError(err, "create activity log")
 	}
 
 	return &pb.CreateActivityLogResponse{
-		ActivityLog: s.entityToProto(activityLog),
+		ActivityLog:  s.entityToProto(result.ActivityLog),
+		Queued:       result.Queued,
+		SessionToken: result.SessionToken,
+		Outcome:      createOutcomes[result.Outcome],
 	}, nil
 }
 
@@ -88,16 +59,13 @@ func (s *ActivityLogServiceServer) GetActivityLog(ctx context.Context, req *pb.G
 
 	ext.Component.Set(span, "grpc")
 	span.SetTag("activity_log_id", req.Id)
-	if req.Id == "" {
-		return nil, status.Error(codes.InvalidArgument, "activity log ID is required")
+	if err := s.useCase.WaitForSession(ctx, req.SessionToken); err != nil {
+		return nil, usecase.AsCodedError(err)
 	}
 
 	activityLog, err := s.useCase.GetActivityLog(ctx, req.Id)
 	if err != nil {
-		if err == entity.ErrActivityLogNotFound {
-			return nil, status.Error(codes.NotFound, "activity log not found")
-		}
-		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get activity log: %v", err))
+		return nil, statusError(err, "get activity log")
 	}
 
 	return &pb.GetActivityLogResponse{
@@ -105,6 +73,29 @@ func (s *ActivityLogServiceServer) GetActivityLog(ctx context.Context, req *pb.G
 	}, nil
 }
 
+func (s *ActivityLogServiceServer) BatchGetActivityLogs(ctx context.Context, req *pb.BatchGetActivityLogsRequest) (*pb.BatchGetActivityLogsResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "BatchGetActivityLogs")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("company_id", req.CompanyId)
+	span.SetTag("ids_count", len(req.Ids))
+
+	activityLogs, missing, err := s.useCase.GetActivityLogs(ctx, req.CompanyId, req.Ids)
+	if err != nil {
+		return nil, statusError(err, "get activity logs")
+	}
+
+	response := &pb.BatchGetActivityLogsResponse{
+		ActivityLogs: make([]*pb.ActivityLog, len(activityLogs)),
+		MissingIds:   missing,
+	}
+	for i, activityLog := range activityLogs {
+		response.ActivityLogs[i] = s.entityToProto(activityLog)
+	}
+	return response, nil
+}
+
 func (s *ActivityLogServiceServer) ListActivityLogs(ctx context.Context, req *pb.ListActivityLogsRequest) (*pb.ListActivityLogsResponse, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "ListActivityLogs")
 	defer span.Finish()
@@ -113,9 +104,8 @@ func (s *ActivityLogServiceServer) ListActivityLogs(ctx context.Context, req *pb
 	span.SetTag("company_id", req.CompanyId)
 	span.SetTag("page", req.Page)
 	span.SetTag("limit", req.Limit)
-	if req.CompanyId == "" {
-		return nil, status.Error(codes.InvalidArgument, "company ID is required")
-	}
+	span.SetTag("device_id", req.DeviceId)
+	span.SetTag("country_code", req.CountryCode)
 
 	page := int(req.Page)
 	limit := int(req.Limit)
@@ -125,10 +115,33 @@ func (s *ActivityLogServiceServer) ListActivityLogs(ctx context.Context, req *pb
 	if limit < 1 || limit > 100 {
 		limit = 10
 	}
+	if req.PageToken != "" {
+		var err error
+		page, limit, err = usecase.DecodePageToken(req.PageToken)
+		if err != nil {
+			return nil, usecase.AsCodedError(err)
+		}
+	}
+	if err := s.useCase.WaitForSession(ctx, req.SessionToken); err != nil {
+		return nil, usecase.AsCodedError(err)
+	}
 
-	activityLogs, total, err := s.useCase.ListActivityLogs(ctx, req.CompanyId, page, limit)
+	var (
+		activityLogs []*entity.ActivityLog
+		total        int
+		err          error
+	)
+	if req.DeviceId != "" && req.CountryCode != "" {
+		activityLogs, total, err = s.useCase.ListActivityLogsByDeviceAndCountry(ctx, req.CompanyId, req.DeviceId, req.CountryCode, page, limit)
+	} else if req.DeviceId != "" {
+		activityLogs, total, err = s.useCase.ListActivityLogsByDevice(ctx, req.CompanyId, req.DeviceId, page, limit)
+	} else if req.CountryCode != "" {
+		activityLogs, total, err = s.useCase.ListActivityLogsByCountry(ctx, req.CompanyId, req.CountryCode, page, limit)
+	} else {
+		activityLogs, total, err = s.useCase.ListActivityLogs(ctx, req.CompanyId, page, limit)
+	}
 	if err != nil {
-		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list activity logs: %v", err))
+		return nil, statusError(err, "list activity logs")
 	}
 
 	protoLogs := make([]*pb.ActivityLog, len(activityLogs))
@@ -137,13 +150,411 @@ func (s *ActivityLogServiceServer) ListActivityLogs(ctx context.Context, req *pb
 	}
 
 	return &pb.ListActivityLogsResponse{
+		ActivityLogs:  protoLogs,
+		Total:         int32(total),
+		Page:          int32(page),
+		Limit:         int32(limit),
+		HasMore:       usecase.HasMore(page, limit, total),
+		NextPageToken: usecase.NextPageToken(page, limit, total),
+	}, nil
+}
+
+func (s *ActivityLogServiceServer) StreamActivityLogs(req *pb.StreamActivityLogsRequest, stream pb.ActivityLogService_StreamActivityLogsServer) error {
+	span, ctx := opentracing.StartSpanFromContext(stream.Context(), "StreamActivityLogs")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("company_id", req.CompanyId)
+
+	filter := repository.ActivityLogFilter{
+		CompanyID:    req.CompanyId,
+		ObjectID:     req.ObjectId,
+		ActivityName: req.ActivityName,
+		ActorID:      req.ActorId,
+		DeviceID:     req.DeviceId,
+		CountryCode:  req.CountryCode,
+	}
+	if req.StartDate != nil {
+		filter.StartDate = req.StartDate.AsTime()
+	}
+	if req.EndDate != nil {
+		filter.EndDate = req.EndDate.AsTime()
+	}
+
+	sent := 0
+	err := s.useCase.StreamActivityLogs(ctx, filter, func(activityLog *entity.ActivityLog) error {
+		if err := stream.Send(s.entityToProto(activityLog)); err != nil {
+			return err
+		}
+		sent++
+		return nil
+	})
+	span.SetTag("sent", sent)
+	if err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return statusError(err, "stream activity logs")
+	}
+
+	return nil
+}
+
+func (s *ActivityLogServiceServer) ExportActivityLogs(req *pb.ExportActivityLogsRequest, stream pb.ActivityLogService_ExportActivityLogsServer) error {
+	span, ctx := opentracing.StartSpanFromContext(stream.Context(), "ExportActivityLogs")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("company_id", req.CompanyId)
+	span.SetTag("resumed", req.ResumeToken != "")
+
+	filter := repository.ActivityLogFilter{
+		CompanyID:    req.CompanyId,
+		ObjectID:     req.ObjectId,
+		ActivityName: req.ActivityName,
+		ActorID:      req.ActorId,
+		DeviceID:     req.DeviceId,
+		CountryCode:  req.CountryCode,
+	}
+	if req.StartDate != nil {
+		filter.StartDate = req.StartDate.AsTime()
+	}
+	if req.EndDate != nil {
+		filter.EndDate = req.EndDate.AsTime()
+	}
+
+	exported := 0
+	err := s.useCase.ExportActivityLogs(ctx, &usecase.ExportActivityLogsRequest{
+		Filter:      filter,
+		ChunkSize:   int(req.ChunkSize),
+		ResumeToken: req.ResumeToken,
+	}, func(chunk *usecase.ExportChunk) error {
+		protoLogs := make([]*pb.ActivityLog, len(chunk.ActivityLogs))
+		for i, log := range chunk.ActivityLogs {
+			protoLogs[i] = s.entityToProto(log)
+		}
+		if err := stream.Send(&pb.ExportChunk{
+			ActivityLogs:     protoLogs,
+			ResumeToken:      chunk.ResumeToken,
+			Last:             chunk.Last,
+			EncryptedPayload: chunk.Encrypted,
+			KeyId:            chunk.KeyID,
+		}); err != nil {
+			return err
+		}
+		exported += len(protoLogs)
+		return nil
+	})
+	span.SetTag("exported", exported)
+	if err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return statusError(err, "export activity logs")
+	}
+
+	return nil
+}
+
+func (s *ActivityLogServiceServer) SearchActivityLogs(ctx context.Context, req *pb.SearchActivityLogsRequest) (*pb.SearchActivityLogsResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "SearchActivityLogs")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("company_id", req.CompanyId)
+	span.SetTag("limit", req.Limit)
+
+	filter := repository.ActivityLogFilter{
+		CompanyID:    req.CompanyId,
+		ActorID:      req.ActorId,
+		ObjectID:     req.ObjectId,
+		ActivityName: req.ActivityName,
+		DeviceID:     req.DeviceId,
+		IPAddress:    req.IpAddress,
+		UserAgent:    req.UserAgent,
+		Text:         req.Text,
+		Tags:         req.Tags,
+	}
+	if req.StartDate != nil {
+		filter.StartDate = req.StartDate.AsTime()
+	}
+	if req.EndDate != nil {
+		filter.EndDate = req.EndDate.AsTime()
+	}
+
+	result, err := s.useCase.SearchActivityLogs(ctx, &usecase.SearchActivityLogsRequest{
+		Filter: filter,
+		Cursor: req.Cursor,
+		Limit:  int(req.Limit),
+	})
+	if err != nil {
+		return nil, statusError(err, "search activity logs")
+	}
+
+	protoLogs := make([]*pb.ActivityLog, len(result.ActivityLogs))
+	for i, log := range result.ActivityLogs {
+		protoLogs[i] = s.entityToProto(log)
+	}
+
+	return &pb.SearchActivityLogsResponse{
 		ActivityLogs: protoLogs,
-		Total:        int32(total),
-		Page:         int32(page),
-		Limit:        int32(limit),
+		HasMore:      result.NextCursor != "",
+		NextCursor:   result.NextCursor,
 	}, nil
 }
 
+var statsGroupBy = map[pb.StatsGroupBy]repository.GroupBy{
+	pb.StatsGroupBy_STATS_GROUP_BY_ACTIVITY_NAME: repository.GroupByActivityName,
+	pb.StatsGroupBy_STATS_GROUP_BY_ACTOR:         repository.GroupByActor,
+	pb.StatsGroupBy_STATS_GROUP_BY_DAY:           repository.GroupByDay,
+}
+
+func (s *ActivityLogServiceServer) GetActivityStats(ctx context.Context, req *pb.GetActivityStatsRequest) (*pb.GetActivityStatsResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "GetActivityStats")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("company_id", req.CompanyId)
+	span.SetTag("group_by", req.GroupBy.String())
+
+	stats, err := s.useCase.GetActivityStats(ctx, &usecase.ActivityStatsRequest{
+		CompanyID: req.CompanyId,
+		GroupBy:   statsGroupBy[req.GroupBy],
+		StartDate: req.StartDate.AsTime(),
+		EndDate:   req.EndDate.AsTime(),
+		Limit:     int(req.Limit),
+	})
+	if err != nil {
+		return nil, statusError(err, "get activity stats")
+	}
+
+	response := &pb.GetActivityStatsResponse{
+		Stats:     make([]*pb.ActivityStat, len(stats.Groups)),
+		Truncated: stats.Truncated,
+	}
+	for i, group := range stats.Groups {
+		response.Stats[i] = &pb.ActivityStat{Key: group.Key, Count: int64(group.Count)}
+	}
+	return response, nil
+}
+
+func (s *ActivityLogServiceServer) ListActivityNames(ctx context.Context, req *pb.ListActivityNamesRequest) (*pb.ListActivityNamesResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ListActivityNames")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("company_id", req.CompanyId)
+
+	names, err := s.useCase.ListActivityNames(ctx, req.CompanyId)
+	if err != nil {
+		return nil, statusError(err, "list activity names")
+	}
+
+	return &pb.ListActivityNamesResponse{ActivityNames: names}, nil
+}
+
+func (s *ActivityLogServiceServer) ListActors(ctx context.Context, req *pb.ListActorsRequest) (*pb.ListActorsResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ListActors")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("company_id", req.CompanyId)
+
+	actors, err := s.useCase.ListActors(ctx, req.CompanyId)
+	if err != nil {
+		return nil, statusError(err, "list actors")
+	}
+
+	response := &pb.ListActorsResponse{Actors: make([]*pb.Actor, len(actors))}
+	for i, actor := range actors {
+		response.Actors[i] = &pb.Actor{
+			Id:         actor.ID,
+			Name:       actor.Name,
+			Email:      actor.Email,
+			LastSeenAt: timestamppb.New(actor.LastSeenAt),
+		}
+	}
+	return response, nil
+}
+
+// ingestBatchSize is how many streamed records are buffered before they are
+// written to the database in one bulk insert
+const ingestBatchSize = 500
+
+func (s *ActivityLogServiceServer) IngestActivityLogs(stream pb.ActivityLogService_IngestActivityLogsServer) error {
+	span, ctx := opentracing.StartSpanFromContext(stream.Context(), "IngestActivityLogs")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+
+	response := &pb.IngestActivityLogsResponse{}
+	batch := make([]*usecase.CreateActivityLogRequest, 0, ingestBatchSize)
+	batchIndexes := make([]int32, 0, ingestBatchSize)
+
+	addFailure := func(index int32, err error) {
+		response.Failed++
+		response.Failures = append(response.Failures, &pb.IngestFailure{
+			Index: index,
+			Error: status.Convert(err).Message(),
+		})
+	}
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		results, err := s.useCase.CreateActivityLogs(ctx, batch)
+		if err != nil {
+			return statusError(err, "ingest activity logs")
+		}
+		for i, result := range results {
+			if result != nil {
+				addFailure(batchIndexes[i], result)
+				continue
+			}
+			response.Accepted++
+		}
+		batch = batch[:0]
+		batchIndexes = batchIndexes[:0]
+		return nil
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		index := response.Received
+		response.Received++
+
+		// Records arrive through RecvMsg, past the validation interceptor,
+		// so each one is checked here and reported as a per-record failure
+		if err := validateMessage(req); err != nil {
+			addFailure(index, err)
+			continue
+		}
+
+		batch = append(batch, createRequestFromProto(req))
+		batchIndexes = append(batchIndexes, index)
+		if len(batch) >= ingestBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+
+	span.SetTag("received", response.Received)
+	span.SetTag("failed", response.Failed)
+
+	return stream.SendAndClose(response)
+}
+
+// BatchCreateActivityLogs stores up to 500 logs in one call and reports the
+// outcome of each. Items are validated individually so that, unless the batch
+// is atomic, one bad item does not fail the others.
+func (s *ActivityLogServiceServer) BatchCreateActivityLogs(ctx context.Context, req *pb.BatchCreateActivityLogsRequest) (*pb.BatchCreateActivityLogsResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "BatchCreateActivityLogs")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("size", len(req.Requests))
+	span.SetTag("atomic", req.Atomic)
+
+	response := &pb.BatchCreateActivityLogsResponse{
+		Results: make([]*pb.BatchCreateResult, len(req.Requests)),
+	}
+	addFailure := func(index int, err error) {
+		coded := usecase.AsCodedError(err)
+		response.Failed++
+		response.Results[index] = &pb.BatchCreateResult{
+			Index:     int32(index),
+			Error:     coded.Message,
+			ErrorCode: string(coded.Code),
+		}
+	}
+
+	reqs := make([]*usecase.CreateActivityLogRequest, 0, len(req.Requests))
+	indexes := make([]int, 0, len(req.Requests))
+	for i, item := range req.Requests {
+		if err := validateMessage(item); err != nil {
+			addFailure(i, err)
+			continue
+		}
+		reqs = append(reqs, createRequestFromProto(item))
+		indexes = append(indexes, i)
+	}
+
+	if req.Atomic && len(reqs) < len(req.Requests) {
+		reqs = nil
+		for _, i := range indexes {
+			addFailure(i, entity.ErrBatchAborted)
+		}
+	}
+
+	if len(reqs) > 0 {
+		results, err := s.useCase.BatchCreateActivityLogs(ctx, reqs, req.Atomic)
+		if err != nil {
+			return nil, statusError(err, "batch create activity logs")
+		}
+		for j, result := range results {
+			if result.Err != nil {
+				addFailure(indexes[j], result.Err)
+				continue
+			}
+			response.Accepted++
+			response.Results[indexes[j]] = &pb.BatchCreateResult{
+				Index:       int32(indexes[j]),
+				ActivityLog: s.entityToProto(result.ActivityLog),
+			}
+			if result.PublishErr != nil {
+				response.Results[indexes[j]].Warning = result.PublishErr.Error()
+			}
+		}
+	}
+
+	span.SetTag("failed", response.Failed)
+	return response, nil
+}
+
+var createModes = map[pb.CreateMode]usecase.CreateMode{
+	pb.CreateMode_CREATE_MODE_ALWAYS:         usecase.CreateModeAlways,
+	pb.CreateMode_CREATE_MODE_SKIP_IF_EXISTS: usecase.CreateModeSkipIfExists,
+	pb.CreateMode_CREATE_MODE_UPSERT:         usecase.CreateModeUpsert,
+}
+
+var createOutcomes = map[repository.WriteOutcome]pb.CreateOutcome{
+	repository.WriteCreated: pb.CreateOutcome_CREATE_OUTCOME_CREATED,
+	repository.WriteSkipped: pb.CreateOutcome_CREATE_OUTCOME_SKIPPED,
+	repository.WriteUpdated: pb.CreateOutcome_CREATE_OUTCOME_UPDATED,
+}
+
+func createRequestFromProto(req *pb.CreateActivityLogRequest) *usecase.CreateActivityLogRequest {
+	return &usecase.CreateActivityLogRequest{
+		ActivityName:     req.ActivityName,
+		CompanyID:        req.CompanyId,
+		ObjectName:       req.ObjectName,
+		ObjectID:         req.ObjectId,
+		Changes:          req.Changes,
+		FormattedMessage: req.FormattedMessage,
+		ActorID:          req.ActorId,
+		ActorName:        req.ActorName,
+		ActorEmail:       req.ActorEmail,
+		UserAgent:        req.UserAgent,
+		IPAddress:        req.IpAddress,
+		DeviceID:         req.DeviceId,
+		Tags:             req.Tags,
+		ExternalID:       req.ExternalId,
+	}
+}
+
 func (s *ActivityLogServiceServer) entityToProto(entity *entity.ActivityLog) *pb.ActivityLog {
 	return &pb.ActivityLog{
 		Id:               entity.ID.String(),
@@ -157,5 +568,23 @@ func (s *ActivityLogServiceServer) entityToProto(entity *entity.ActivityLog) *pb
 		ActorName:        entity.ActorName,
 		ActorEmail:       entity.ActorEmail,
 		CreatedAt:        timestamppb.New(entity.CreatedAt),
+		UserAgent:        entity.UserAgent,
+		IpAddress:        entity.IPAddress,
+		DeviceId:         entity.DeviceID,
+		CountryCode:      entity.CountryCode,
+		City:             entity.City,
+		Tags:             entity.Tags,
+		ExternalId:       entity.ExternalID,
+	}
+}
+
+// statusError converts a use case error into an error carrying its catalog
+// code, which grpc sends as a status with an ErrorInfo detail. Unexpected
+// failures keep the "failed to ..." context.
+func statusError(err error, action string) error {
+	coded := usecase.AsCodedError(err)
+	if coded.Code == errcode.Internal {
+		return errcode.Newf(errcode.Internal, "failed to %s: %v", action, err)
 	}
+	return coded
 }
@@ -0,0 +1,444 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"activity-log-service/internal/application/usecase"
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+	"activity-log-service/pkg/errcode"
+	pb "activity-log-service/pkg/proto"
+)
+
+// CacheFlusher drops cached reads, for everyone or for a single company
+type CacheFlusher interface {
+	ClearCache(ctx context.Context) error
+	ClearCacheForCompany(ctx context.Context, companyID string) error
+}
+
+// JobRunner runs cron jobs on demand. RunJob fails with ErrUnknownJob (or an
+// error wrapping it) for a job that does not exist, and stops the job once
+// ctx ends.
+type JobRunner interface {
+	JobNames() []string
+	RunJob(ctx context.Context, name string) error
+}
+
+// ErrUnknownJob is what JobRunner implementations wrap for an unknown job
+var ErrUnknownJob = errors.New("unknown cron job")
+
+type AdminServiceServer struct {
+	pb.UnimplementedAdminServiceServer
+	cache   CacheFlusher
+	jobs    JobRunner
+	indexes repository.IndexManager
+	purger  repository.CompanyPurger
+	archive *usecase.ArchiveUseCase
+	runs    repository.JobRunRepository
+	logs    *usecase.ActivityLogUseCase
+	tracer  opentracing.Tracer
+}
+
+// NewAdminServiceServer builds the admin service. cache may be nil when the
+// service runs without Redis; FlushCache then fails with FailedPrecondition.
+// purger is nil unless companies have their own collections, and
+// PurgeCompany fails the same way without it, as do the archive calls when
+// archive is nil and ListJobRuns when runs is nil.
+func NewAdminServiceServer(cache CacheFlusher, jobs JobRunner, indexes repository.IndexManager, purger repository.CompanyPurger, archive *usecase.ArchiveUseCase, runs repository.JobRunRepository, logs *usecase.ActivityLogUseCase, tracer opentracing.Tracer) *AdminServiceServer {
+	return &AdminServiceServer{
+		cache:   cache,
+		jobs:    jobs,
+		indexes: indexes,
+		purger:  purger,
+		archive: archive,
+		runs:    runs,
+		logs:    logs,
+		tracer:  tracer,
+	}
+}
+
+func (s *AdminServiceServer) FlushCache(ctx context.Context, req *pb.FlushCacheRequest) (*pb.FlushCacheResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "FlushCache")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("company_id", req.CompanyId)
+
+	if s.cache == nil {
+		return nil, status.Error(codes.FailedPrecondition, "cache is not enabled")
+	}
+
+	var err error
+	if req.CompanyId == "" {
+		err = s.cache.ClearCache(ctx)
+	} else {
+		err = s.cache.ClearCacheForCompany(ctx, req.CompanyId)
+	}
+	if err != nil {
+		return nil, statusError(err, "flush cache")
+	}
+
+	return &pb.FlushCacheResponse{}, nil
+}
+
+func (s *AdminServiceServer) TriggerCronJob(ctx context.Context, req *pb.TriggerCronJobRequest) (*pb.TriggerCronJobResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "TriggerCronJob")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("job", req.Job)
+
+	if err := s.jobs.RunJob(ctx, req.Job); err != nil {
+		if errors.Is(err, ErrUnknownJob) {
+			return nil, errcode.Newf(errcode.Validation, "unknown cron job %q, expected one of: %s", req.Job, strings.Join(s.jobs.JobNames(), ", "))
+		}
+		return nil, statusError(err, "run cron job")
+	}
+
+	return &pb.TriggerCronJobResponse{}, nil
+}
+
+// defaultJobRunsLimit is how many runs ListJobRuns returns without a limit
+const defaultJobRunsLimit = 50
+
+func (s *AdminServiceServer) ListJobRuns(ctx context.Context, req *pb.ListJobRunsRequest) (*pb.ListJobRunsResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ListJobRuns")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("job", req.Job)
+
+	if s.runs == nil {
+		return nil, status.Error(codes.FailedPrecondition, "job history is not enabled")
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultJobRunsLimit
+	}
+	runs, err := s.runs.List(ctx, req.Job, limit)
+	if err != nil {
+		return nil, statusError(err, "list job runs")
+	}
+
+	response := &pb.ListJobRunsResponse{Runs: make([]*pb.JobRun, len(runs))}
+	for i, run := range runs {
+		response.Runs[i] = &pb.JobRun{
+			Job:        run.Job,
+			StartedAt:  timestamppb.New(run.StartedAt),
+			FinishedAt: timestamppb.New(run.FinishedAt),
+			DurationMs: run.Duration.Milliseconds(),
+			Status:     string(run.Status),
+			Error:      run.Error,
+			Items:      int32(run.Items),
+		}
+	}
+	return response, nil
+}
+
+func (s *AdminServiceServer) Reindex(ctx context.Context, req *pb.ReindexRequest) (*pb.ReindexResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Reindex")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+
+	statuses, err := s.indexes.Reindex(ctx)
+	if err != nil {
+		return nil, statusError(err, "reindex")
+	}
+
+	response := &pb.ReindexResponse{Indexes: make([]*pb.IndexStatus, len(statuses))}
+	for i, index := range statuses {
+		response.Indexes[i] = &pb.IndexStatus{Name: index.Name, Action: string(index.Action)}
+	}
+	return response, nil
+}
+
+// PurgeCompany drops the company's logs and then its cached reads
+func (s *AdminServiceServer) PurgeCompany(ctx context.Context, req *pb.PurgeCompanyRequest) (*pb.PurgeCompanyResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "PurgeCompany")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("company_id", req.CompanyId)
+
+	if s.purger == nil {
+		return nil, status.Error(codes.FailedPrecondition, "purging needs arango.collection_per_company")
+	}
+
+	if err := s.purger.PurgeCompany(ctx, req.CompanyId); err != nil {
+		return nil, statusError(err, "purge company")
+	}
+	if s.cache != nil {
+		if err := s.cache.ClearCacheForCompany(ctx, req.CompanyId); err != nil {
+			return nil, statusError(err, "flush cache")
+		}
+	}
+
+	return &pb.PurgeCompanyResponse{}, nil
+}
+
+func (s *AdminServiceServer) ListArchives(ctx context.Context, req *pb.ListArchivesRequest) (*pb.ListArchivesResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ListArchives")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("company_id", req.CompanyId)
+
+	if s.archive == nil {
+		return nil, status.Error(codes.FailedPrecondition, "archive is not enabled")
+	}
+
+	manifests, err := s.archive.ListArchives(ctx, req.CompanyId)
+	if err != nil {
+		return nil, statusError(err, "list archives")
+	}
+
+	response := &pb.ListArchivesResponse{Archives: make([]*pb.ArchiveManifest, len(manifests))}
+	for i, manifest := range manifests {
+		response.Archives[i] = archiveManifestToProto(manifest)
+	}
+	return response, nil
+}
+
+// GetArchiveKey returns the wrapped data key of an encrypted archive for the
+// company to unwrap before RestoreArchive
+func (s *AdminServiceServer) GetArchiveKey(ctx context.Context, req *pb.GetArchiveKeyRequest) (*pb.GetArchiveKeyResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "GetArchiveKey")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("archive_id", req.Id)
+
+	if s.archive == nil {
+		return nil, status.Error(codes.FailedPrecondition, "archive is not enabled")
+	}
+
+	manifest, header, err := s.archive.ArchiveKeyHeader(ctx, req.Id)
+	if err != nil {
+		return nil, statusError(err, "get archive key")
+	}
+
+	return &pb.GetArchiveKeyResponse{Archive: archiveManifestToProto(manifest), EnvelopeHeader: header}, nil
+}
+
+// RestoreArchive brings an archive's logs back for a legal hold and drops the
+// company's cached reads, which do not include them yet
+func (s *AdminServiceServer) RestoreArchive(ctx context.Context, req *pb.RestoreArchiveRequest) (*pb.RestoreArchiveResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "RestoreArchive")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("archive_id", req.Id)
+
+	if s.archive == nil {
+		return nil, status.Error(codes.FailedPrecondition, "archive is not enabled")
+	}
+
+	manifest, err := s.archive.RestoreArchive(ctx, req.Id, req.DataKey)
+	if err != nil {
+		return nil, statusError(err, "restore archive")
+	}
+	if s.cache != nil {
+		if err := s.cache.ClearCacheForCompany(ctx, manifest.CompanyID); err != nil {
+			return nil, statusError(err, "flush cache")
+		}
+	}
+
+	return &pb.RestoreArchiveResponse{Archive: archiveManifestToProto(manifest)}, nil
+}
+
+func (s *AdminServiceServer) ReleaseArchive(ctx context.Context, req *pb.ReleaseArchiveRequest) (*pb.ReleaseArchiveResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ReleaseArchive")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("archive_id", req.Id)
+
+	if s.archive == nil {
+		return nil, status.Error(codes.FailedPrecondition, "archive is not enabled")
+	}
+
+	if err := s.archive.ReleaseArchive(ctx, req.Id); err != nil {
+		return nil, statusError(err, "release archive")
+	}
+
+	return &pb.ReleaseArchiveResponse{}, nil
+}
+
+// EraseActivityLog is the privileged path around append-only mode. The
+// deleted log is dropped from the cache by the repository.
+func (s *AdminServiceServer) EraseActivityLog(ctx context.Context, req *pb.EraseActivityLogRequest) (*pb.EraseActivityLogResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "EraseActivityLog")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("activity_log_id", req.Id)
+
+	if err := s.logs.EraseActivityLog(ctx, req.Id, req.Reason); err != nil {
+		return nil, statusError(err, "erase activity log")
+	}
+
+	return &pb.EraseActivityLogResponse{}, nil
+}
+
+func (s *AdminServiceServer) ListAccessLog(ctx context.Context, req *pb.ListAccessLogRequest) (*pb.ListAccessLogResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ListAccessLog")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("company_id", req.CompanyId)
+
+	page := int(req.Page)
+	limit := int(req.Limit)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	entries, total, err := s.logs.ListAccessLog(ctx, req.CompanyId, page, limit)
+	if err != nil {
+		return nil, statusError(err, "list access log")
+	}
+
+	response := &pb.ListAccessLogResponse{
+		Entries: make([]*pb.AccessLogEntry, len(entries)),
+		Total:   int32(total),
+		Page:    int32(page),
+		Limit:   int32(limit),
+		HasMore: usecase.HasMore(page, limit, total),
+	}
+	for i, entry := range entries {
+		response.Entries[i] = &pb.AccessLogEntry{
+			Principal:   entry.Principal,
+			Operation:   entry.Operation,
+			Filter:      entry.Filter,
+			ResultCount: int32(entry.ResultCount),
+			AccessedAt:  timestamppb.New(entry.AccessedAt),
+		}
+	}
+	return response, nil
+}
+
+func (s *AdminServiceServer) SetExportKey(ctx context.Context, req *pb.SetExportKeyRequest) (*pb.SetExportKeyResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "SetExportKey")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("company_id", req.CompanyId)
+
+	key, err := s.logs.SetExportKey(ctx, req.CompanyId, req.PublicKeyPem)
+	if err != nil {
+		return nil, statusError(err, "set export key")
+	}
+
+	return &pb.SetExportKeyResponse{
+		KeyId:     key.KeyID,
+		Algorithm: key.Algorithm,
+		CreatedAt: timestamppb.New(key.CreatedAt),
+	}, nil
+}
+
+func (s *AdminServiceServer) DeleteExportKey(ctx context.Context, req *pb.DeleteExportKeyRequest) (*pb.DeleteExportKeyResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DeleteExportKey")
+	defer span.Finish()
+
+	ext.Component.Set(span, "grpc")
+	span.SetTag("company_id", req.CompanyId)
+
+	if err := s.logs.DeleteExportKey(ctx, req.CompanyId); err != nil {
+		return nil, statusError(err, "delete export key")
+	}
+
+	return &pb.DeleteExportKeyResponse{}, nil
+}
+
+func archiveManifestToProto(manifest *entity.ArchiveManifest) *pb.ArchiveManifest {
+	archive := &pb.ArchiveManifest{
+		Id:         manifest.ID,
+		CompanyId:  manifest.CompanyID,
+		Object:     manifest.Object,
+		From:       timestamppb.New(manifest.From),
+		To:         timestamppb.New(manifest.To),
+		Count:      int32(manifest.Count),
+		Bytes:      manifest.Bytes,
+		ArchivedAt: timestamppb.New(manifest.ArchivedAt),
+		OnHold:     manifest.OnHold,
+		KeyId:      manifest.KeyID,
+	}
+	if manifest.RestoredAt != nil {
+		archive.RestoredAt = timestamppb.New(*manifest.RestoredAt)
+	}
+	return archive
+}
+
+// adminAuthHeader carries the admin token as "Bearer <token>"
+const adminAuthHeader = "authorization"
+
+// adminMethodPrefixes are the methods only admin callers may use. Reflection
+// is included so the admin API surface is not advertised to data clients.
+var adminMethodPrefixes = []string{
+	"/" + pb.AdminService_ServiceDesc.ServiceName + "/",
+	"/grpc.reflection.",
+}
+
+func isAdminMethod(fullMethod string) bool {
+	for _, prefix := range adminMethodPrefixes {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAdminToken compares the caller's bearer token with the configured one
+// in constant time
+func checkAdminToken(ctx context.Context, token string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(adminAuthHeader) {
+		presented, ok := strings.CutPrefix(value, "Bearer ")
+		if ok && subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "admin token required")
+}
+
+// UnaryAdminAuth rejects admin calls without the admin token. Other methods
+// pass through untouched.
+func UnaryAdminAuth(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if isAdminMethod(info.FullMethod) {
+			if err := checkAdminToken(ctx, token); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAdminAuth is the streaming counterpart of UnaryAdminAuth; it guards
+// the reflection service
+func StreamAdminAuth(token string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isAdminMethod(info.FullMethod) {
+			if err := checkAdminToken(ss.Context(), token); err != nil {
+				return err
+			}
+		}
+		return handler(srv, ss)
+	}
+}
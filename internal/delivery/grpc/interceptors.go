@@ -0,0 +1,367 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/netip"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"activity-log-service/internal/infrastructure/metrics"
+	"activity-log-service/internal/infrastructure/overload"
+	"activity-log-service/pkg/errcode"
+	pb "activity-log-service/pkg/proto"
+)
+
+// healthMethodPrefix identifies probe traffic, which is logged at debug level
+// so load balancer checks do not flood the logs
+const healthMethodPrefix = "/grpc.health.v1.Health/"
+
+// metadataCarrier adapts incoming gRPC metadata to opentracing's TextMap
+// reader so span contexts propagated by clients can be extracted
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) ForeachKey(handler func(key, val string) error) error {
+	for key, values := range c {
+		for _, value := range values {
+			if err := handler(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// UnaryInterceptors returns the interceptor chain applied to every unary
+// call. Order matters: tracing is outermost so the span covers everything,
+// load shedding runs before logging so shed calls do not flood the logs, and
+// recovery wraps request validation so a panic becomes an error the other
+// interceptors observe. The caller principal is attached innermost, for the
+// access log, from the principal header only for the trustedProxies, which
+// along with callers holding a client certificate may also raise a call's
+// priority. A nil
+// shedder disables load shedding; a zero timeout leaves calls bounded only by
+// the client's own deadline.
+func UnaryInterceptors(tracer opentracing.Tracer, shedder *overload.Shedder, logger *logrus.Logger, timeout time.Duration, trustedProxies []netip.Prefix) []grpc.UnaryServerInterceptor {
+	interceptors := []grpc.UnaryServerInterceptor{
+		unaryTracing(tracer),
+		unaryMetrics(),
+		unaryDeadline(timeout),
+	}
+	if shedder != nil {
+		interceptors = append(interceptors, unaryLoadShedding(shedder, trustedProxies))
+	}
+	return append(interceptors,
+		unaryLogging(logger),
+		unaryRecovery(logger),
+		unaryValidation(),
+		unaryPrincipal(trustedProxies),
+		unaryQueryTag(),
+	)
+}
+
+// StreamInterceptors is the streaming counterpart of UnaryInterceptors.
+// Streams are not given the server timeout since exports legitimately run
+// long; they still end as soon as the client cancels or goes away.
+func StreamInterceptors(tracer opentracing.Tracer, shedder *overload.Shedder, logger *logrus.Logger, trustedProxies []netip.Prefix) []grpc.StreamServerInterceptor {
+	interceptors := []grpc.StreamServerInterceptor{
+		streamTracing(tracer),
+		streamMetrics(),
+	}
+	if shedder != nil {
+		interceptors = append(interceptors, streamLoadShedding(shedder, trustedProxies))
+	}
+	return append(interceptors,
+		streamLogging(logger),
+		streamRecovery(logger),
+		streamValidation(),
+		streamPrincipal(trustedProxies),
+		streamQueryTag(),
+	)
+}
+
+// unaryDeadline bounds a call by the server timeout, keeping an earlier client
+// deadline, and reports an expired or cancelled call with its gRPC status
+// rather than whatever error the aborted query surfaced as
+func unaryDeadline(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil && ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		return resp, err
+	}
+}
+
+func startServerSpan(ctx context.Context, tracer opentracing.Tracer, method string) (opentracing.Span, context.Context) {
+	var opts []opentracing.StartSpanOption
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if spanCtx, err := tracer.Extract(opentracing.TextMap, metadataCarrier(md)); err == nil {
+			opts = append(opts, ext.RPCServerOption(spanCtx))
+		}
+	}
+	if len(opts) == 0 {
+		opts = append(opts, ext.SpanKindRPCServer)
+	}
+
+	span := tracer.StartSpan(method, opts...)
+	ext.Component.Set(span, "grpc")
+	return span, opentracing.ContextWithSpan(ctx, span)
+}
+
+func finishSpan(span opentracing.Span, err error) {
+	if err != nil {
+		ext.Error.Set(span, true)
+		span.SetTag("error.message", err.Error())
+	}
+	span.SetTag("grpc.code", status.Code(err).String())
+	span.Finish()
+}
+
+func unaryTracing(tracer opentracing.Tracer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		span, ctx := startServerSpan(ctx, tracer, info.FullMethod)
+		resp, err := handler(ctx, req)
+		finishSpan(span, err)
+		return resp, err
+	}
+}
+
+func streamTracing(tracer opentracing.Tracer) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		span, ctx := startServerSpan(ss.Context(), tracer, info.FullMethod)
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		finishSpan(span, err)
+		return err
+	}
+}
+
+func unaryMetrics() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		metrics.RecordGRPCRequest(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return resp, err
+	}
+}
+
+func streamMetrics() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		metrics.RecordGRPCRequest(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return err
+	}
+}
+
+func logCall(logger *logrus.Logger, method string, duration time.Duration, err error) {
+	entry := logger.WithFields(logrus.Fields{
+		"method":   method,
+		"code":     status.Code(err).String(),
+		"duration": duration,
+	})
+
+	switch {
+	case err != nil:
+		if coded, ok := errcode.FromGRPC(err); ok {
+			entry = entry.WithField("error_code", coded.Code)
+		}
+		entry.WithError(err).Warn("gRPC request failed")
+	case strings.HasPrefix(method, healthMethodPrefix):
+		entry.Debug("gRPC request")
+	default:
+		entry.Info("gRPC request")
+	}
+}
+
+func unaryLogging(logger *logrus.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(logger, info.FullMethod, time.Since(start), err)
+		return resp, err
+	}
+}
+
+func streamLogging(logger *logrus.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logCall(logger, info.FullMethod, time.Since(start), err)
+		return err
+	}
+}
+
+func recoverPanic(logger *logrus.Logger, method string, err *error) {
+	if r := recover(); r != nil {
+		logger.WithFields(logrus.Fields{
+			"method": method,
+			"panic":  r,
+			"stack":  string(debug.Stack()),
+		}).Error("Recovered from panic in gRPC handler")
+		*err = errcode.New(errcode.Internal, fmt.Sprintf("internal error: %v", r))
+	}
+}
+
+func unaryRecovery(logger *logrus.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer recoverPanic(logger, info.FullMethod, &err)
+		return handler(ctx, req)
+	}
+}
+
+func streamRecovery(logger *logrus.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer recoverPanic(logger, info.FullMethod, &err)
+		return handler(srv, ss)
+	}
+}
+
+// validator is implemented by every message generated by protoc-gen-validate.
+// ValidateAll reports all violated rules rather than stopping at the first.
+type validator interface {
+	ValidateAll() error
+}
+
+// validateMessage checks msg against the rules declared in the proto file.
+// Messages without rules pass.
+func validateMessage(msg interface{}) error {
+	v, ok := msg.(validator)
+	if !ok {
+		return nil
+	}
+	if err := v.ValidateAll(); err != nil {
+		return errcode.New(errcode.Validation, err.Error())
+	}
+	return nil
+}
+
+func unaryValidation() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := validateMessage(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// streamValidation validates the request of server streaming calls. Client
+// streams are left to the handler, which reports invalid records per message
+// instead of aborting the whole stream.
+func streamValidation() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if info.IsClientStream {
+			return handler(srv, ss)
+		}
+		return handler(srv, &validatingStream{ServerStream: ss})
+	}
+}
+
+// validatingStream rejects invalid messages as they are received
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validateMessage(m)
+}
+
+// methodClass classifies a call for load shedding: health probes are
+// critical, the methods in writeMethods are writes, everything else is a
+// read. Callers with a verified client certificate and the trusted proxies
+// can change the class with x-priority; others cannot opt out of shedding.
+func methodClass(ctx context.Context, fullMethod string, trustedProxies []netip.Prefix) overload.Class {
+	if strings.HasPrefix(fullMethod, healthMethodPrefix) {
+		return overload.ClassCritical
+	}
+
+	class := overload.ClassRead
+	if writeMethods[fullMethod] {
+		class = overload.ClassWrite
+	}
+
+	if !trustedCaller(ctx, trustedProxies) {
+		return class
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(overload.PriorityHeader); len(values) > 0 {
+			class = overload.ParseClass(strings.ToLower(values[0]), class)
+		}
+	}
+	return class
+}
+
+// writeMethods are the methods that change data, which are not shed as
+// reads. A new mutating method must be added here.
+var writeMethods = map[string]bool{
+	pb.ActivityLogService_CreateActivityLog_FullMethodName:       true,
+	pb.ActivityLogService_BatchCreateActivityLogs_FullMethodName: true,
+	pb.ActivityLogService_IngestActivityLogs_FullMethodName:      true,
+	pb.AdminService_FlushCache_FullMethodName:                    true,
+	pb.AdminService_TriggerCronJob_FullMethodName:                true,
+	pb.AdminService_Reindex_FullMethodName:                       true,
+	pb.AdminService_PurgeCompany_FullMethodName:                  true,
+	pb.AdminService_RestoreArchive_FullMethodName:                true,
+	pb.AdminService_ReleaseArchive_FullMethodName:                true,
+	pb.AdminService_EraseActivityLog_FullMethodName:              true,
+	pb.AdminService_SetExportKey_FullMethodName:                  true,
+	pb.AdminService_DeleteExportKey_FullMethodName:               true,
+}
+
+func overloadedError(shedder *overload.Shedder) error {
+	return errcode.Newf(errcode.Overloaded, "read traffic is being shed, retry after %s", shedder.RetryAfter())
+}
+
+func unaryLoadShedding(shedder *overload.Shedder, trustedProxies []netip.Prefix) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		done, ok := shedder.Admit(methodClass(ctx, info.FullMethod, trustedProxies), "grpc")
+		if !ok {
+			grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(math.Ceil(shedder.RetryAfter().Seconds())))))
+			return nil, overloadedError(shedder)
+		}
+		defer done()
+		return handler(ctx, req)
+	}
+}
+
+func streamLoadShedding(shedder *overload.Shedder, trustedProxies []netip.Prefix) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		done, ok := shedder.Admit(methodClass(ss.Context(), info.FullMethod, trustedProxies), "grpc")
+		if !ok {
+			ss.SetHeader(metadata.Pairs("retry-after", strconv.Itoa(int(math.Ceil(shedder.RetryAfter().Seconds())))))
+			return overloadedError(shedder)
+		}
+		defer done()
+		return handler(srv, ss)
+	}
+}
+
+// contextStream overrides the context of a server stream so handlers see the
+// span started by the tracing interceptor
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,85 @@
+package grpc
+
+import (
+	"context"
+	"net/netip"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"activity-log-service/internal/application/usecase"
+)
+
+// principalHeader carries the caller identity set by a trusted gateway when
+// the connection itself is not authenticated with a client certificate
+const principalHeader = "x-principal"
+
+// callerPrincipal identifies the caller for the access log: the common name of
+// a verified client certificate wins over the principal header, which is only
+// read from the trusted proxies.
+func callerPrincipal(ctx context.Context, trustedProxies []netip.Prefix) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		for _, chain := range tlsInfo.State.VerifiedChains {
+			if len(chain) > 0 && chain[0].Subject.CommonName != "" {
+				return chain[0].Subject.CommonName
+			}
+		}
+	}
+	if p.Addr == nil || !trustedPeer(p.Addr.String(), trustedProxies) {
+		return ""
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(principalHeader); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// trustedCaller reports whether the caller presented a verified client
+// certificate or is one of the trusted proxies
+func trustedCaller(ctx context.Context, trustedProxies []netip.Prefix) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.VerifiedChains) > 0 {
+		return true
+	}
+	return p.Addr != nil && trustedPeer(p.Addr.String(), trustedProxies)
+}
+
+// trustedPeer reports whether the host:port address is one of the trusted
+// proxies
+func trustedPeer(address string, trustedProxies []netip.Prefix) bool {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return false
+	}
+	addr := addrPort.Addr().Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func unaryPrincipal(trustedProxies []netip.Prefix) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(usecase.WithPrincipal(ctx, callerPrincipal(ctx, trustedProxies)), req)
+	}
+}
+
+func streamPrincipal(trustedProxies []netip.Prefix) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := usecase.WithPrincipal(ss.Context(), callerPrincipal(ss.Context(), trustedProxies))
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
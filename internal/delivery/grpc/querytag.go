@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"activity-log-service/internal/infrastructure/querytag"
+)
+
+// requestIDHeader is the request ID a client or gateway may send along
+const requestIDHeader = "x-request-id"
+
+func callTag(ctx context.Context, method string) querytag.Tag {
+	tag := querytag.Tag{Route: method}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDHeader); len(values) > 0 {
+			tag.RequestID = values[0]
+		}
+	}
+	return tag
+}
+
+// unaryQueryTag records the method and request ID for the AQL annotations
+func unaryQueryTag() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(querytag.WithTag(ctx, callTag(ctx, info.FullMethod)), req)
+	}
+}
+
+func streamQueryTag() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := querytag.WithTag(ss.Context(), callTag(ss.Context(), info.FullMethod))
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
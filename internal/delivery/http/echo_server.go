@@ -2,8 +2,13 @@ package http
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/netip"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -15,7 +20,11 @@ import (
 
 	_ "activity-log-service/docs"
 	"activity-log-service/internal/application/usecase"
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
 	"activity-log-service/internal/infrastructure/metrics"
+	"activity-log-service/internal/infrastructure/overload"
+	"activity-log-service/pkg/errcode"
 )
 
 type EchoServer struct {
@@ -35,29 +44,152 @@ type ActivityLogResponse struct {
 	ActorID          string    `json:"actor_id" example:"actor_789"`
 	ActorName        string    `json:"actor_name" example:"System Administrator"`
 	ActorEmail       string    `json:"actor_email" example:"admin@company123.com"`
+	UserAgent        string    `json:"user_agent,omitempty" example:"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_0)"`
+	IPAddress        string    `json:"ip_address,omitempty" example:"203.0.113.10"`
+	DeviceID         string    `json:"device_id,omitempty" example:"device_abc123"`
+	CountryCode      string    `json:"country_code,omitempty" example:"DE"`
+	City             string    `json:"city,omitempty" example:"Berlin"`
+	Tags             []string  `json:"tags,omitempty" example:"billing,admin"`
+	ExternalID       string    `json:"external_id,omitempty" example:"evt_0001"`
 	CreatedAt        time.Time `json:"created_at" example:"2023-12-07T10:30:00Z"`
+	Outcome          string    `json:"outcome,omitempty" example:"created" enums:"created,skipped,updated"`
+	Queued           bool      `json:"queued,omitempty" example:"false"`
+	SessionToken     string    `json:"session_token,omitempty" example:"eyJzIjo0Mn0"`
 }
 
 type CreateActivityLogRequest struct {
-	ActivityName     string `json:"activity_name" validate:"required" example:"user_created"`
-	CompanyID        string `json:"company_id" validate:"required" example:"company_123"`
-	ObjectName       string `json:"object_name" validate:"required" example:"user"`
-	ObjectID         string `json:"object_id" validate:"required" example:"user_456"`
-	Changes          string `json:"changes,omitempty" example:"{\"name\": \"John Doe\"}"`
-	FormattedMessage string `json:"formatted_message" validate:"required" example:"User John Doe was created"`
-	ActorID          string `json:"actor_id" validate:"required" example:"actor_789"`
-	ActorName        string `json:"actor_name" validate:"required" example:"System Administrator"`
-	ActorEmail       string `json:"actor_email" validate:"required,email" example:"admin@company123.com"`
+	ActivityName     string   `json:"activity_name" validate:"required" example:"user_created"`
+	CompanyID        string   `json:"company_id" validate:"required" example:"company_123"`
+	ObjectName       string   `json:"object_name" validate:"required" example:"user"`
+	ObjectID         string   `json:"object_id" validate:"required" example:"user_456"`
+	Changes          string   `json:"changes,omitempty" example:"{\"name\": \"John Doe\"}"`
+	FormattedMessage string   `json:"formatted_message" validate:"required" example:"User John Doe was created"`
+	ActorID          string   `json:"actor_id" validate:"required" example:"actor_789"`
+	ActorName        string   `json:"actor_name" validate:"required" example:"System Administrator"`
+	ActorEmail       string   `json:"actor_email" validate:"required,email" example:"admin@company123.com"`
+	UserAgent        string   `json:"user_agent,omitempty" example:"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_0)"`
+	IPAddress        string   `json:"ip_address,omitempty" validate:"omitempty,ip" example:"203.0.113.10"`
+	DeviceID         string   `json:"device_id,omitempty" example:"device_abc123"`
+	Tags             []string `json:"tags,omitempty" example:"billing,admin"`
+	ExternalID       string   `json:"external_id,omitempty" example:"evt_0001"`
+	CreateMode       string   `json:"create_mode,omitempty" example:"skip_if_exists" enums:"skip_if_exists,upsert"`
+}
+
+type BatchGetActivityLogsRequest struct {
+	CompanyID string   `json:"company_id" validate:"required" example:"company_123"`
+	IDs       []string `json:"ids" validate:"required,min=1,max=100" example:"01HZX3K9ABCDEF,01HZX3K9GHIJKL"`
+}
+
+type BatchGetActivityLogsResponse struct {
+	ActivityLogs []*ActivityLogResponse `json:"activity_logs"`
+	// MissingIDs lists the requested IDs the company has no log for
+	MissingIDs []string `json:"missing_ids" example:"01HZX3K9MNOPQR"`
 }
 
 type ListActivityLogsResponse struct {
+	ActivityLogs  []*ActivityLogResponse `json:"activity_logs"`
+	Total         int                    `json:"total" example:"150"`
+	Page          int                    `json:"page" example:"1"`
+	Limit         int                    `json:"limit" example:"10"`
+	HasMore       bool                   `json:"has_more" example:"true"`
+	NextPageToken string                 `json:"next_page_token,omitempty" example:"eyJwIjoyLCJsIjoxMH0"`
+}
+
+type SearchActivityLogsResponse struct {
 	ActivityLogs []*ActivityLogResponse `json:"activity_logs"`
-	Total        int                    `json:"total" example:"150"`
-	Page         int                    `json:"page" example:"1"`
-	Limit        int                    `json:"limit" example:"10"`
+	HasMore      bool                   `json:"has_more" example:"true"`
+	NextCursor   string                 `json:"next_cursor,omitempty" example:"eyJ0IjoiMjAyMy0xMi0wN1QxMDozMDowMFoiLCJpIjoiYWJjIn0"`
+}
+
+type CountryFacetsResponse struct {
+	CompanyID string         `json:"company_id" example:"company_123"`
+	Countries map[string]int `json:"countries"`
+}
+
+type ActivityNamesResponse struct {
+	CompanyID     string   `json:"company_id" example:"company_123"`
+	ActivityNames []string `json:"activity_names"`
+}
+
+type ActorResponse struct {
+	ID         string    `json:"id" example:"user_123"`
+	Name       string    `json:"name" example:"John Doe"`
+	Email      string    `json:"email" example:"john@example.com"`
+	LastSeenAt time.Time `json:"last_seen_at" example:"2023-01-01T00:00:00Z"`
+}
+
+type ActorsResponse struct {
+	CompanyID string           `json:"company_id" example:"company_123"`
+	Actors    []*ActorResponse `json:"actors"`
+}
+
+type ActiveCompanyResponse struct {
+	CompanyID      string    `json:"company_id" example:"company_123"`
+	LastActivityAt time.Time `json:"last_activity_at" example:"2023-01-01T00:00:00Z"`
+}
+
+type ActiveCompaniesResponse struct {
+	Since     time.Time                `json:"since" example:"2023-01-01T00:00:00Z"`
+	Companies []*ActiveCompanyResponse `json:"companies"`
+}
+
+type AccessLogEntryResponse struct {
+	Principal   string            `json:"principal" example:"auditor@company123.com"`
+	Operation   string            `json:"operation" example:"search"`
+	Filter      map[string]string `json:"filter,omitempty"`
+	ResultCount int               `json:"result_count" example:"25"`
+	AccessedAt  time.Time         `json:"accessed_at" example:"2023-01-01T00:00:00Z"`
+}
+
+type AccessLogResponse struct {
+	CompanyID string                    `json:"company_id" example:"company123"`
+	Entries   []*AccessLogEntryResponse `json:"entries"`
+	Total     int                       `json:"total" example:"150"`
+	Page      int                       `json:"page" example:"1"`
+	Limit     int                       `json:"limit" example:"10"`
+	HasMore   bool                      `json:"has_more" example:"true"`
+}
+
+type SetExportKeyRequest struct {
+	PublicKeyPEM string `json:"public_key_pem" example:"-----BEGIN PUBLIC KEY-----\n..."`
+}
+
+type ExportKeyResponse struct {
+	CompanyID string    `json:"company_id" example:"company123"`
+	KeyID     string    `json:"key_id" example:"3f9a0c1d2b4e5f60"`
+	Algorithm string    `json:"algorithm" example:"x25519"`
+	CreatedAt time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+}
+
+type TemplateInput struct {
+	Name   string `json:"name" example:"welcome_email"`
+	Type   string `json:"type" example:"activity_log" enums:"activity_log,daily_summary,message"`
+	Source string `json:"source" example:"{{.ActivityLog.ActorName}} did {{.ActivityLog.ActivityName}}"`
+}
+
+type ValidateTemplatesRequest struct {
+	Templates []TemplateInput `json:"templates"`
+}
+
+type TemplateValidationResponse struct {
+	Name     string   `json:"name" example:"welcome_email"`
+	Type     string   `json:"type" example:"activity_log"`
+	Valid    bool     `json:"valid" example:"true"`
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings"`
+	Preview  string   `json:"preview,omitempty"`
+}
+
+type ValidateTemplatesResponse struct {
+	Valid   bool                          `json:"valid" example:"true"`
+	Results []*TemplateValidationResponse `json:"results"`
 }
 
+// ErrorResponse is an RFC 7807 problem document carrying a catalog error
+// code. Error, Message and Code predate the catalog and are kept so existing
+// clients keep working.
 type ErrorResponse struct {
+	errcode.Problem
 	Error   string `json:"error" example:"Invalid request parameters"`
 	Message string `json:"message,omitempty" example:"company_id is required"`
 	Code    int    `json:"code" example:"400"`
@@ -69,7 +201,9 @@ type HealthResponse struct {
 	Version string `json:"version" example:"1.0.0"`
 }
 
-func NewEchoServer(useCase *usecase.ActivityLogUseCase, tracer opentracing.Tracer) *EchoServer {
+// NewEchoServer creates the HTTP API. A nil shedder disables overload
+// protection. The X-Principal header is only read from the trustedProxies.
+func NewEchoServer(useCase *usecase.ActivityLogUseCase, shedder *overload.Shedder, tracer opentracing.Tracer, trustedProxies []netip.Prefix) *EchoServer {
 	e := echo.New()
 
 	// Middleware
@@ -78,6 +212,8 @@ func NewEchoServer(useCase *usecase.ActivityLogUseCase, tracer opentracing.Trace
 	e.Use(middleware.CORS())
 	e.Use(middleware.Secure())
 	e.Use(middleware.RequestID())
+	e.Use(principal(trustedProxies))
+	e.Use(queryTag())
 
 	// Distributed tracing middleware
 	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -120,6 +256,11 @@ func NewEchoServer(useCase *usecase.ActivityLogUseCase, tracer opentracing.Trace
 		}
 	})
 
+	// Overload protection; runs after metrics so shed requests are counted
+	if shedder != nil {
+		e.Use(loadShedding(shedder, trustedProxies))
+	}
+
 	// Validator
 	e.Validator = &CustomValidator{}
 
@@ -149,7 +290,23 @@ func (s *EchoServer) setupRoutes() {
 	// Activity logs routes
 	api.POST("/activity-logs", s.createActivityLog)
 	api.GET("/activity-logs/:id", s.getActivityLog)
+	api.POST("/activity-logs/batch-get", s.batchGetActivityLogs)
 	api.GET("/activity-logs", s.listActivityLogs)
+	api.GET("/activity-logs/search", s.searchActivityLogs)
+	api.GET("/activity-logs/facets/countries", s.getCountryFacets)
+	api.GET("/activity-logs/facets/activity-names", s.getActivityNameFacets)
+	api.GET("/activity-logs/facets/actors", s.getActorFacets)
+}
+
+// EnableAdmin registers the /api/v1/admin routes; callers authenticate with
+// "Authorization: Bearer <token>". Without it they are not served at all.
+func (s *EchoServer) EnableAdmin(token string) {
+	admin := s.echo.Group("/api/v1/admin", adminAuth(token))
+	admin.GET("/companies/active", s.listActiveCompanies)
+	admin.POST("/templates/validate", s.validateTemplates)
+	admin.GET("/companies/:company_id/access-log", s.listAccessLog)
+	admin.PUT("/companies/:company_id/export-key", s.setExportKey)
+	admin.DELETE("/companies/:company_id/export-key", s.deleteExportKey)
 }
 
 // @Summary Health Check
@@ -174,25 +331,19 @@ func (s *EchoServer) healthCheck(c echo.Context) error {
 // @Produce json
 // @Param request body CreateActivityLogRequest true "Create activity log request"
 // @Success 201 {object} ActivityLogResponse
+// @Success 200 {object} ActivityLogResponse "Skipped or updated by create_mode"
+// @Success 202 {object} ActivityLogResponse "Accepted while the database is unavailable"
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/activity-logs [post]
 func (s *EchoServer) createActivityLog(c echo.Context) error {
 	var req CreateActivityLogRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Invalid request body",
-			Message: err.Error(),
-			Code:    http.StatusBadRequest,
-		})
+		return errorResponse(c, errcode.Validation, "Invalid request body", err.Error())
 	}
 
 	if err := c.Validate(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Validation failed",
-			Message: err.Error(),
-			Code:    http.StatusBadRequest,
-		})
+		return errorResponse(c, errcode.Validation, "Validation failed", err.Error())
 	}
 
 	useCaseReq := &usecase.CreateActivityLogRequest{
@@ -205,29 +356,32 @@ func (s *EchoServer) createActivityLog(c echo.Context) error {
 		ActorID:          req.ActorID,
 		ActorName:        req.ActorName,
 		ActorEmail:       req.ActorEmail,
+		UserAgent:        req.UserAgent,
+		IPAddress:        req.IPAddress,
+		DeviceID:         req.DeviceID,
+		Tags:             req.Tags,
+		ExternalID:       req.ExternalID,
+		Mode:             usecase.CreateMode(req.CreateMode),
 	}
 
-	activityLog, err := s.useCase.CreateActivityLog(c.Request().Context(), useCaseReq)
+	result, err := s.useCase.CreateActivityLog(c.Request().Context(), useCaseReq)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to create activity log",
-			Message: err.Error(),
-			Code:    http.StatusInternalServerError,
-		})
+		return errorResponseFor(c, err, "Failed to create activity log")
 	}
 
-	response := &ActivityLogResponse{
-		ID:               activityLog.ID.String(),
-		ActivityName:     activityLog.ActivityName,
-		CompanyID:        activityLog.CompanyID,
-		ObjectName:       activityLog.ObjectName,
-		ObjectID:         activityLog.ObjectID,
-		Changes:          string(activityLog.Changes),
-		FormattedMessage: activityLog.FormattedMessage,
-		ActorID:          activityLog.ActorID,
-		ActorName:        activityLog.ActorName,
-		ActorEmail:       activityLog.ActorEmail,
-		CreatedAt:        activityLog.CreatedAt,
+	response := toActivityLogResponse(result.ActivityLog)
+	response.Outcome = string(result.Outcome)
+
+	// The database was down and the log only made it onto the event stream;
+	// it becomes readable once the consumer catches up
+	if result.Queued {
+		response.Queued = true
+		response.SessionToken = result.SessionToken
+		c.Response().Header().Set(sessionTokenHeader, result.SessionToken)
+		return c.JSON(http.StatusAccepted, response)
+	}
+	if result.Outcome != repository.WriteCreated {
+		return c.JSON(http.StatusOK, response)
 	}
 
 	return c.JSON(http.StatusCreated, response)
@@ -239,6 +393,7 @@ func (s *EchoServer) createActivityLog(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param id path string true "Activity Log ID"
+// @Param session_token query string false "Session token from a queued create; waits until that write is readable"
 // @Success 200 {object} ActivityLogResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -247,43 +402,61 @@ func (s *EchoServer) createActivityLog(c echo.Context) error {
 func (s *EchoServer) getActivityLog(c echo.Context) error {
 	id := c.Param("id")
 	if id == "" {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Invalid activity log ID",
-			Message: "ID parameter is required",
-			Code:    http.StatusBadRequest,
-		})
+		return errorResponse(c, errcode.Validation, "Invalid activity log ID", "ID parameter is required")
+	}
+
+	if err := s.waitForSession(c); err != nil {
+		return errorResponse(c, usecase.ErrorCode(err), "Invalid request parameters", err.Error())
 	}
 
 	activityLog, err := s.useCase.GetActivityLog(c.Request().Context(), id)
 	if err != nil {
 		if err.Error() == "activity log not found" {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "Activity log not found",
-				Message: err.Error(),
-				Code:    http.StatusNotFound,
-			})
+			return errorResponse(c, errcode.NotFound, "Activity log not found", err.Error())
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to get activity log",
-			Message: err.Error(),
-			Code:    http.StatusInternalServerError,
-		})
+		return errorResponseFor(c, err, "Failed to get activity log")
 	}
 
-	response := &ActivityLogResponse{
-		ID:               activityLog.ID.String(),
-		ActivityName:     activityLog.ActivityName,
-		CompanyID:        activityLog.CompanyID,
-		ObjectName:       activityLog.ObjectName,
-		ObjectID:         activityLog.ObjectID,
-		Changes:          string(activityLog.Changes),
-		FormattedMessage: activityLog.FormattedMessage,
-		ActorID:          activityLog.ActorID,
-		ActorName:        activityLog.ActorName,
-		ActorEmail:       activityLog.ActorEmail,
-		CreatedAt:        activityLog.CreatedAt,
+	response := toActivityLogResponse(activityLog)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// @Summary Batch Get Activity Logs
+// @Description Get up to 100 of a company's activity logs by ID, in the order asked for
+// @Tags Activity Logs
+// @Accept json
+// @Produce json
+// @Param request body BatchGetActivityLogsRequest true "Batch get request"
+// @Success 200 {object} BatchGetActivityLogsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/activity-logs/batch-get [post]
+func (s *EchoServer) batchGetActivityLogs(c echo.Context) error {
+	var req BatchGetActivityLogsRequest
+	if err := c.Bind(&req); err != nil {
+		return errorResponse(c, errcode.Validation, "Invalid request body", err.Error())
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return errorResponse(c, errcode.Validation, "Validation failed", err.Error())
+	}
+
+	activityLogs, missing, err := s.useCase.GetActivityLogs(c.Request().Context(), req.CompanyID, req.IDs)
+	if err != nil {
+		return errorResponseFor(c, err, "Failed to get activity logs")
 	}
 
+	response := &BatchGetActivityLogsResponse{
+		ActivityLogs: make([]*ActivityLogResponse, len(activityLogs)),
+		MissingIDs:   missing,
+	}
+	for i, activityLog := range activityLogs {
+		response.ActivityLogs[i] = toActivityLogResponse(activityLog)
+	}
+	if response.MissingIDs == nil {
+		response.MissingIDs = []string{}
+	}
 	return c.JSON(http.StatusOK, response)
 }
 
@@ -295,6 +468,10 @@ func (s *EchoServer) getActivityLog(c echo.Context) error {
 // @Param company_id query string true "Company ID"
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
+// @Param device_id query string false "Only return logs performed from this device"
+// @Param country_code query string false "Only return logs whose IP resolved to this ISO country code; combined with device_id when both are set"
+// @Param page_token query string false "Token from a previous response's next_page_token; overrides page and limit"
+// @Param session_token query string false "Session token from a queued create; waits until that write is readable"
 // @Success 200 {object} ListActivityLogsResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -302,11 +479,7 @@ func (s *EchoServer) getActivityLog(c echo.Context) error {
 func (s *EchoServer) listActivityLogs(c echo.Context) error {
 	companyID := c.QueryParam("company_id")
 	if companyID == "" {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Invalid request parameters",
-			Message: "company_id is required",
-			Code:    http.StatusBadRequest,
-		})
+		return errorResponse(c, errcode.Validation, "Invalid request parameters", "company_id is required")
 	}
 
 	page, _ := strconv.Atoi(c.QueryParam("page"))
@@ -319,42 +492,506 @@ func (s *EchoServer) listActivityLogs(c echo.Context) error {
 		limit = 10
 	}
 
-	activityLogs, total, err := s.useCase.ListActivityLogs(c.Request().Context(), companyID, page, limit)
+	if pageToken := c.QueryParam("page_token"); pageToken != "" {
+		var err error
+		page, limit, err = usecase.DecodePageToken(pageToken)
+		if err != nil {
+			return errorResponse(c, errcode.InvalidPageToken, "Invalid request parameters", err.Error())
+		}
+	}
+
+	if err := s.waitForSession(c); err != nil {
+		return errorResponse(c, usecase.ErrorCode(err), "Invalid request parameters", err.Error())
+	}
+
+	var (
+		activityLogs []*entity.ActivityLog
+		total        int
+		err          error
+	)
+	deviceID, countryCode := c.QueryParam("device_id"), c.QueryParam("country_code")
+	if deviceID != "" && countryCode != "" {
+		activityLogs, total, err = s.useCase.ListActivityLogsByDeviceAndCountry(c.Request().Context(), companyID, deviceID, countryCode, page, limit)
+	} else if deviceID != "" {
+		activityLogs, total, err = s.useCase.ListActivityLogsByDevice(c.Request().Context(), companyID, deviceID, page, limit)
+	} else if countryCode != "" {
+		activityLogs, total, err = s.useCase.ListActivityLogsByCountry(c.Request().Context(), companyID, countryCode, page, limit)
+	} else {
+		activityLogs, total, err = s.useCase.ListActivityLogs(c.Request().Context(), companyID, page, limit)
+	}
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to list activity logs",
-			Message: err.Error(),
-			Code:    http.StatusInternalServerError,
-		})
+		return errorResponseFor(c, err, "Failed to list activity logs")
 	}
 
 	responseItems := make([]*ActivityLogResponse, len(activityLogs))
 	for i, log := range activityLogs {
-		responseItems[i] = &ActivityLogResponse{
-			ID:               log.ID.String(),
-			ActivityName:     log.ActivityName,
-			CompanyID:        log.CompanyID,
-			ObjectName:       log.ObjectName,
-			ObjectID:         log.ObjectID,
-			Changes:          string(log.Changes),
-			FormattedMessage: log.FormattedMessage,
-			ActorID:          log.ActorID,
-			ActorName:        log.ActorName,
-			ActorEmail:       log.ActorEmail,
-			CreatedAt:        log.CreatedAt,
-		}
+		responseItems[i] = toActivityLogResponse(log)
 	}
 
 	response := &ListActivityLogsResponse{
+		ActivityLogs:  responseItems,
+		Total:         total,
+		Page:          page,
+		Limit:         limit,
+		HasMore:       usecase.HasMore(page, limit, total),
+		NextPageToken: usecase.NextPageToken(page, limit, total),
+	}
+
+	if link := buildLinkHeader(c.Request().URL, page, limit, total); link != "" {
+		c.Response().Header().Set("Link", link)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// @Summary Search Activity Logs
+// @Description Search a company's activity logs with any combination of filters, newest first
+// @Tags Activity Logs
+// @Accept json
+// @Produce json
+// @Param company_id query string true "Company ID"
+// @Param actor_id query string false "Actor ID"
+// @Param object_id query string false "Object ID"
+// @Param activity_name query string false "Activity name"
+// @Param device_id query string false "Device ID"
+// @Param ip_address query string false "IP address"
+// @Param user_agent query string false "User agent"
+// @Param start_date query string false "RFC3339 lower bound on created_at"
+// @Param end_date query string false "RFC3339 upper bound on created_at"
+// @Param q query string false "Case-insensitive text to find in the formatted message"
+// @Param tags query string false "Comma-separated tags that must all be present"
+// @Param cursor query string false "Token from a previous response's next_cursor"
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} SearchActivityLogsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/activity-logs/search [get]
+func (s *EchoServer) searchActivityLogs(c echo.Context) error {
+	companyID := c.QueryParam("company_id")
+	if companyID == "" {
+		return errorResponse(c, errcode.Validation, "Invalid request parameters", "company_id is required")
+	}
+
+	filter := repository.ActivityLogFilter{
+		CompanyID:    companyID,
+		ActorID:      c.QueryParam("actor_id"),
+		ObjectID:     c.QueryParam("object_id"),
+		ActivityName: c.QueryParam("activity_name"),
+		DeviceID:     c.QueryParam("device_id"),
+		IPAddress:    c.QueryParam("ip_address"),
+		UserAgent:    c.QueryParam("user_agent"),
+		Text:         c.QueryParam("q"),
+	}
+	if tags := c.QueryParam("tags"); tags != "" {
+		filter.Tags = strings.Split(tags, ",")
+	}
+	for param, dest := range map[string]*time.Time{"start_date": &filter.StartDate, "end_date": &filter.EndDate} {
+		value := c.QueryParam(param)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return errorResponse(c, errcode.Validation, "Invalid request parameters", fmt.Sprintf("%s must be an RFC3339 timestamp", param))
+		}
+		*dest = t
+	}
+
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+	result, err := s.useCase.SearchActivityLogs(c.Request().Context(), &usecase.SearchActivityLogsRequest{
+		Filter: filter,
+		Cursor: c.QueryParam("cursor"),
+		Limit:  limit,
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidSearchToken) {
+			return errorResponse(c, errcode.InvalidPageToken, "Invalid request parameters", err.Error())
+		}
+		return errorResponseFor(c, err, "Failed to search activity logs")
+	}
+
+	responseItems := make([]*ActivityLogResponse, len(result.ActivityLogs))
+	for i, log := range result.ActivityLogs {
+		responseItems[i] = toActivityLogResponse(log)
+	}
+
+	return c.JSON(http.StatusOK, &SearchActivityLogsResponse{
 		ActivityLogs: responseItems,
-		Total:        total,
-		Page:         page,
-		Limit:        limit,
+		HasMore:      result.NextCursor != "",
+		NextCursor:   result.NextCursor,
+	})
+}
+
+// @Summary Country Facets
+// @Description Get activity log counts grouped by resolved country for a company
+// @Tags Activity Logs
+// @Accept json
+// @Produce json
+// @Param company_id query string true "Company ID"
+// @Success 200 {object} CountryFacetsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/activity-logs/facets/countries [get]
+func (s *EchoServer) getCountryFacets(c echo.Context) error {
+	companyID := c.QueryParam("company_id")
+	if companyID == "" {
+		return errorResponse(c, errcode.Validation, "Invalid request parameters", "company_id is required")
+	}
+
+	counts, err := s.useCase.GetCountryFacets(c.Request().Context(), companyID)
+	if err != nil {
+		return errorResponseFor(c, err, "Failed to get country facets")
 	}
 
+	return c.JSON(http.StatusOK, &CountryFacetsResponse{
+		CompanyID: companyID,
+		Countries: counts,
+	})
+}
+
+// @Summary Activity Name Facets
+// @Description List the distinct activity names a company has logged, for filter dropdowns
+// @Tags Activity Logs
+// @Accept json
+// @Produce json
+// @Param company_id query string true "Company ID"
+// @Success 200 {object} ActivityNamesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/activity-logs/facets/activity-names [get]
+func (s *EchoServer) getActivityNameFacets(c echo.Context) error {
+	companyID := c.QueryParam("company_id")
+	if companyID == "" {
+		return errorResponse(c, errcode.Validation, "Invalid request parameters", "company_id is required")
+	}
+
+	names, err := s.useCase.ListActivityNames(c.Request().Context(), companyID)
+	if err != nil {
+		return errorResponseFor(c, err, "Failed to list activity names")
+	}
+
+	return c.JSON(http.StatusOK, &ActivityNamesResponse{
+		CompanyID:     companyID,
+		ActivityNames: names,
+	})
+}
+
+// @Summary Actor Facets
+// @Description List everyone with activity logs in a company, sorted by name, for filter dropdowns
+// @Tags Activity Logs
+// @Accept json
+// @Produce json
+// @Param company_id query string true "Company ID"
+// @Success 200 {object} ActorsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/activity-logs/facets/actors [get]
+func (s *EchoServer) getActorFacets(c echo.Context) error {
+	companyID := c.QueryParam("company_id")
+	if companyID == "" {
+		return errorResponse(c, errcode.Validation, "Invalid request parameters", "company_id is required")
+	}
+
+	actors, err := s.useCase.ListActors(c.Request().Context(), companyID)
+	if err != nil {
+		return errorResponseFor(c, err, "Failed to list actors")
+	}
+
+	response := &ActorsResponse{
+		CompanyID: companyID,
+		Actors:    make([]*ActorResponse, len(actors)),
+	}
+	for i, actor := range actors {
+		response.Actors[i] = &ActorResponse{
+			ID:         actor.ID,
+			Name:       actor.Name,
+			Email:      actor.Email,
+			LastSeenAt: actor.LastSeenAt,
+		}
+	}
 	return c.JSON(http.StatusOK, response)
 }
 
+// @Summary List Active Companies
+// @Description List companies ordered by their most recent activity
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param since query string false "RFC3339 timestamp or duration such as 24h (default 24h)"
+// @Success 200 {object} ActiveCompaniesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/companies/active [get]
+func (s *EchoServer) listActiveCompanies(c echo.Context) error {
+	since := time.Now().UTC().Add(-24 * time.Hour)
+	if sinceParam := c.QueryParam("since"); sinceParam != "" {
+		if t, err := time.Parse(time.RFC3339, sinceParam); err == nil {
+			since = t
+		} else if d, err := time.ParseDuration(sinceParam); err == nil && d > 0 {
+			since = time.Now().UTC().Add(-d)
+		} else {
+			return errorResponse(c, errcode.Validation, "Invalid request parameters", "since must be an RFC3339 timestamp or a duration")
+		}
+	}
+
+	companies, err := s.useCase.ListActiveCompanies(c.Request().Context(), since)
+	if err != nil {
+		return errorResponseFor(c, err, "Failed to list active companies")
+	}
+
+	responseItems := make([]*ActiveCompanyResponse, len(companies))
+	for i, company := range companies {
+		responseItems[i] = &ActiveCompanyResponse{
+			CompanyID:      company.CompanyID,
+			LastActivityAt: company.LastActivityAt,
+		}
+	}
+
+	return c.JSON(http.StatusOK, &ActiveCompaniesResponse{
+		Since:     since,
+		Companies: responseItems,
+	})
+}
+
+// @Summary List Access Log
+// @Description List who read a company's activity data, newest first; only for the company's access_log.readers
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param company_id path string true "Company ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 10, max: 100)"
+// @Success 200 {object} AccessLogResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/companies/{company_id}/access-log [get]
+func (s *EchoServer) listAccessLog(c echo.Context) error {
+	companyID := c.Param("company_id")
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	entries, total, err := s.useCase.ListAccessLog(c.Request().Context(), companyID, page, limit)
+	if err != nil {
+		return errorResponseFor(c, err, "Failed to list access log")
+	}
+
+	responseItems := make([]*AccessLogEntryResponse, len(entries))
+	for i, entry := range entries {
+		responseItems[i] = &AccessLogEntryResponse{
+			Principal:   entry.Principal,
+			Operation:   entry.Operation,
+			Filter:      entry.Filter,
+			ResultCount: entry.ResultCount,
+			AccessedAt:  entry.AccessedAt,
+		}
+	}
+
+	return c.JSON(http.StatusOK, &AccessLogResponse{
+		CompanyID: companyID,
+		Entries:   responseItems,
+		Total:     total,
+		Page:      page,
+		Limit:     limit,
+		HasMore:   usecase.HasMore(page, limit, total),
+	})
+}
+
+// @Summary Set Export Key
+// @Description Register the public key (PEM, RSA >= 2048 bits or X25519) a company's exports are encrypted to
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param company_id path string true "Company ID"
+// @Param request body SetExportKeyRequest true "Public key"
+// @Success 200 {object} ExportKeyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/companies/{company_id}/export-key [put]
+func (s *EchoServer) setExportKey(c echo.Context) error {
+	var req SetExportKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return errorResponse(c, errcode.Validation, "Invalid request body", err.Error())
+	}
+
+	key, err := s.useCase.SetExportKey(c.Request().Context(), c.Param("company_id"), req.PublicKeyPEM)
+	if err != nil {
+		return errorResponseFor(c, err, "Failed to set export key")
+	}
+
+	return c.JSON(http.StatusOK, &ExportKeyResponse{
+		CompanyID: key.CompanyID,
+		KeyID:     key.KeyID,
+		Algorithm: key.Algorithm,
+		CreatedAt: key.CreatedAt,
+	})
+}
+
+// @Summary Delete Export Key
+// @Description Remove a company's export key; later exports are plaintext
+// @Tags Admin
+// @Param company_id path string true "Company ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/companies/{company_id}/export-key [delete]
+func (s *EchoServer) deleteExportKey(c echo.Context) error {
+	if err := s.useCase.DeleteExportKey(c.Request().Context(), c.Param("company_id")); err != nil {
+		return errorResponseFor(c, err, "Failed to delete export key")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// @Summary Validate Templates
+// @Description Parse message/email templates, check referenced fields and render them against sample data
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body ValidateTemplatesRequest true "Templates to validate"
+// @Success 200 {object} ValidateTemplatesResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/admin/templates/validate [post]
+func (s *EchoServer) validateTemplates(c echo.Context) error {
+	var req ValidateTemplatesRequest
+	if err := c.Bind(&req); err != nil {
+		return errorResponse(c, errcode.Validation, "Invalid request body", err.Error())
+	}
+	if len(req.Templates) == 0 {
+		return errorResponse(c, errcode.Validation, "Invalid request body", "at least one template is required")
+	}
+
+	response := &ValidateTemplatesResponse{
+		Valid:   true,
+		Results: make([]*TemplateValidationResponse, len(req.Templates)),
+	}
+	for i, tmpl := range req.Templates {
+		result := s.useCase.ValidateTemplate(tmpl.Type, tmpl.Source)
+		response.Results[i] = &TemplateValidationResponse{
+			Name:     tmpl.Name,
+			Type:     tmpl.Type,
+			Valid:    result.Valid,
+			Errors:   result.Errors,
+			Warnings: result.Warnings,
+			Preview:  result.Preview,
+		}
+		if !result.Valid {
+			response.Valid = false
+		}
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// sessionTokenHeader carries the session token of a queued create; reads may
+// send it back (or pass session_token) to wait for that write to land
+const sessionTokenHeader = "X-Session-Token"
+
+// problemContentType is the media type of RFC 7807 error bodies
+const problemContentType = "application/problem+json"
+
+// errorResponse writes a problem response for code; the HTTP status is taken
+// from the error catalog so it matches what gRPC clients see for the same code
+func errorResponse(c echo.Context, code errcode.Code, summary, detail string) error {
+	def := errcode.Lookup(code)
+	c.Response().Header().Set(echo.HeaderContentType, problemContentType)
+	return c.JSON(def.HTTPStatus, ErrorResponse{
+		Problem: errcode.Problem{
+			Type:   def.Code.TypeURI(),
+			Title:  def.Title,
+			Status: def.HTTPStatus,
+			Detail: detail,
+			Code:   def.Code,
+		},
+		Error:   summary,
+		Message: detail,
+		Code:    def.HTTPStatus,
+	})
+}
+
+// errorResponseFor writes a problem response for an error returned by the use
+// case, picking its code from the catalog mapping
+func errorResponseFor(c echo.Context, err error, summary string) error {
+	return errorResponse(c, usecase.ErrorCode(err), summary, err.Error())
+}
+
+// waitForSession honours a session token presented by the caller so it reads
+// its own queued writes. Only a malformed token yields an error.
+func (s *EchoServer) waitForSession(c echo.Context) error {
+	token := c.Request().Header.Get(sessionTokenHeader)
+	if token == "" {
+		token = c.QueryParam("session_token")
+	}
+	return s.useCase.WaitForSession(c.Request().Context(), token)
+}
+
+// buildLinkHeader returns an RFC 8288 Link header with next/prev relations
+// pointing at the same request URL with the page adjusted.
+func buildLinkHeader(requestURL *url.URL, page, limit, total int) string {
+	pageURL := func(p int) string {
+		u := *requestURL
+		q := u.Query()
+		q.Del("page_token")
+		q.Set("page", strconv.Itoa(p))
+		q.Set("limit", strconv.Itoa(limit))
+		u.RawQuery = q.Encode()
+		return u.RequestURI()
+	}
+
+	var links []string
+	if usecase.HasMore(page, limit, total) {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(page+1)))
+	}
+	if page > 1 {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pageURL(page-1)))
+	}
+	return strings.Join(links, ", ")
+}
+
+func toActivityLogResponse(activityLog *entity.ActivityLog) *ActivityLogResponse {
+	return &ActivityLogResponse{
+		ID:               activityLog.ID.String(),
+		ActivityName:     activityLog.ActivityName,
+		CompanyID:        activityLog.CompanyID,
+		ObjectName:       activityLog.ObjectName,
+		ObjectID:         activityLog.ObjectID,
+		Changes:          string(activityLog.Changes),
+		FormattedMessage: activityLog.FormattedMessage,
+		ActorID:          activityLog.ActorID,
+		ActorName:        activityLog.ActorName,
+		ActorEmail:       activityLog.ActorEmail,
+		UserAgent:        activityLog.UserAgent,
+		IPAddress:        activityLog.IPAddress,
+		DeviceID:         activityLog.DeviceID,
+		CountryCode:      activityLog.CountryCode,
+		City:             activityLog.City,
+		Tags:             activityLog.Tags,
+		ExternalID:       activityLog.ExternalID,
+		CreatedAt:        activityLog.CreatedAt,
+	}
+}
+
+// SetTimeouts configures the underlying http.Server and bounds how long a
+// handler may run; zero values leave the corresponding limit disabled
+func (s *EchoServer) SetTimeouts(read, write, idle, request time.Duration) {
+	s.echo.Server.ReadTimeout = read
+	s.echo.Server.WriteTimeout = write
+	s.echo.Server.IdleTimeout = idle
+	if request > 0 {
+		s.echo.Use(middleware.ContextTimeout(request))
+	}
+}
+
 func (s *EchoServer) Start(address string) error {
 	return s.echo.Start(address)
 }
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	"activity-log-service/internal/domain/entity"
+)
+
+// ErrJobLocked is what JobTrigger implementations wrap while another run
+// holds the job's lock
+var ErrJobLocked = errors.New("job is locked by another run")
+
+// JobTrigger runs cron jobs on demand. TriggerJob fails only when the job did
+// not run; a job that ran and failed is reported in the returned JobRun.
+type JobTrigger interface {
+	JobNames() []string
+	TriggerJob(ctx context.Context, name string) (*entity.JobRun, error)
+}
+
+// JobRunResponse summarizes a job run triggered through the admin endpoint
+type JobRunResponse struct {
+	Job        string    `json:"job"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	DurationMS int64     `json:"duration_ms"`
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	Items      int       `json:"items"`
+}
+
+// NewJobAdminServer serves the cron server's own endpoints. With a token it
+// serves POST /admin/jobs/:name/run, which runs a cron job immediately and
+// answers once it finished; callers authenticate with
+// "Authorization: Bearer <token>". With statuses it serves the status page
+// without authentication and without job errors. The server has no write timeout, since jobs such
+// as archive run for hours.
+func NewJobAdminServer(jobs JobTrigger, token string, statuses JobStatusLister) *echo.Echo {
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+	e.Use(middleware.Recover())
+
+	if token != "" {
+		admin := e.Group("/admin", adminAuth(token))
+		admin.POST("/jobs/:name/run", func(c echo.Context) error {
+			return runJob(c, jobs)
+		})
+	}
+	if statuses != nil {
+		registerJobStatusPage(e, statuses)
+	}
+	return e
+}
+
+// adminAuth accepts requests with "Authorization: Bearer <token>" only
+func adminAuth(token string) echo.MiddlewareFunc {
+	return middleware.KeyAuth(func(key string, c echo.Context) (bool, error) {
+		return subtle.ConstantTimeCompare([]byte(key), []byte(token)) == 1, nil
+	})
+}
+
+// runJob answers 200 when the job succeeded and 500 when it failed, both
+// with the run's summary
+func runJob(c echo.Context, jobs JobTrigger) error {
+	name := c.Param("name")
+	if !slices.Contains(jobs.JobNames(), name) {
+		return echo.NewHTTPError(http.StatusNotFound,
+			fmt.Sprintf("unknown cron job %q, expected one of: %s", name, strings.Join(jobs.JobNames(), ", ")))
+	}
+
+	run, err := jobs.TriggerJob(c.Request().Context(), name)
+	switch {
+	case errors.Is(err, ErrJobLocked):
+		return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("%s is locked by a recent or running run; retry after cron.lock_grace", name))
+	case err != nil:
+		return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+	}
+
+	status := http.StatusOK
+	if run.Status == entity.JobRunFailed {
+		status = http.StatusInternalServerError
+	}
+	return c.JSON(status, newJobRunResponse(run))
+}
+
+func newJobRunResponse(run *entity.JobRun) *JobRunResponse {
+	return &JobRunResponse{
+		Job:        run.Job,
+		StartedAt:  run.StartedAt,
+		FinishedAt: run.FinishedAt,
+		DurationMS: run.Duration.Milliseconds(),
+		Status:     string(run.Status),
+		Error:      run.Error,
+		Items:      run.Items,
+	}
+}
@@ -0,0 +1,116 @@
+package http
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"activity-log-service/internal/domain/entity"
+)
+
+// JobStatus is one scheduled cron job as the cron status page shows it
+type JobStatus struct {
+	Name     string
+	Schedule string
+	Timeout  time.Duration
+	// NextRun is zero until the scheduler started
+	NextRun time.Time
+	// LastRun is nil until the job ran
+	LastRun *entity.JobRun
+}
+
+// JobStatusLister lists the scheduled cron jobs for the status page
+type JobStatusLister interface {
+	JobStatuses(ctx context.Context) []JobStatus
+}
+
+type JobStatusResponse struct {
+	Name     string          `json:"name" example:"daily_summary"`
+	Schedule string          `json:"schedule" example:"0 0 9 * * *"`
+	Timeout  string          `json:"timeout" example:"30m0s"`
+	NextRun  *time.Time      `json:"next_run,omitempty" example:"2023-12-08T09:00:00Z"`
+	LastRun  *JobRunResponse `json:"last_run,omitempty"`
+}
+
+type JobStatusListResponse struct {
+	Jobs      []JobStatusResponse `json:"jobs"`
+	UpdatedAt time.Time           `json:"updated_at" example:"2023-12-07T10:30:00Z"`
+}
+
+// jobStatusTemplate renders the status page; it refreshes itself every
+// minute
+var jobStatusTemplate = template.Must(template.New("jobs").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="60">
+<title>Cron jobs</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+.succeeded { color: #2e7d32; }
+.failed { color: #c62828; }
+</style>
+</head>
+<body>
+<h1>Cron jobs</h1>
+<p>As of {{.UpdatedAt.Format "2006-01-02 15:04:05 UTC"}}</p>
+<table>
+<tr><th>Job</th><th>Schedule</th><th>Timeout</th><th>Next run</th><th>Last run</th><th>Outcome</th><th>Duration</th><th>Items</th></tr>
+{{range .Jobs}}<tr>
+<td>{{.Name}}</td>
+<td><code>{{.Schedule}}</code></td>
+<td>{{.Timeout}}</td>
+<td>{{with .NextRun}}{{.Format "2006-01-02 15:04:05"}}{{end}}</td>
+{{with .LastRun}}<td>{{.StartedAt.Format "2006-01-02 15:04:05"}}</td>
+<td class="{{.Status}}">{{.Status}}</td>
+<td>{{.DurationMS}} ms</td>
+<td>{{.Items}}</td>{{else}}<td colspan="4">never ran</td>{{end}}
+</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// registerJobStatusPage serves the scheduled jobs, when they run next and
+// how they last went, as HTML at GET /status and as JSON at GET /status.json.
+// The pages are not authenticated, so a failed run shows its outcome and
+// times but not its error, which may name companies.
+func registerJobStatusPage(e *echo.Echo, statuses JobStatusLister) {
+	e.GET("/status.json", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, jobStatusList(c.Request().Context(), statuses))
+	})
+	e.GET("/status", func(c echo.Context) error {
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+		c.Response().WriteHeader(http.StatusOK)
+		return jobStatusTemplate.Execute(c.Response(), jobStatusList(c.Request().Context(), statuses))
+	})
+}
+
+func jobStatusList(ctx context.Context, statuses JobStatusLister) *JobStatusListResponse {
+	jobs := statuses.JobStatuses(ctx)
+	response := &JobStatusListResponse{
+		Jobs:      make([]JobStatusResponse, len(jobs)),
+		UpdatedAt: time.Now().UTC(),
+	}
+	for i, job := range jobs {
+		response.Jobs[i] = JobStatusResponse{
+			Name:     job.Name,
+			Schedule: job.Schedule,
+			Timeout:  job.Timeout.String(),
+		}
+		if !job.NextRun.IsZero() {
+			next := job.NextRun.UTC()
+			response.Jobs[i].NextRun = &next
+		}
+		if job.LastRun != nil {
+			response.Jobs[i].LastRun = newJobRunResponse(job.LastRun)
+			response.Jobs[i].LastRun.Error = ""
+		}
+	}
+	return response
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"activity-log-service/internal/infrastructure/overload"
+	"activity-log-service/pkg/errcode"
+)
+
+// loadShedding rejects low priority reads with 429 and a Retry-After header
+// while the shedder reports overload
+func loadShedding(shedder *overload.Shedder, trustedProxies []netip.Prefix) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			done, ok := shedder.Admit(requestClass(c, trustedProxies), "http")
+			if !ok {
+				retryAfter := int(math.Ceil(shedder.RetryAfter().Seconds()))
+				c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				return errorResponse(c, errcode.Overloaded, "Service overloaded",
+					fmt.Sprintf("read traffic is being shed, retry after %ds", retryAfter))
+			}
+			defer done()
+			return next(c)
+		}
+	}
+}
+
+// requestClass treats every mutating request as a write. Probes are never
+// shed, and neither are callers that send X-Priority: critical with a verified
+// client certificate or through a trusted proxy; the header of other callers
+// is ignored.
+func requestClass(c echo.Context, trustedProxies []netip.Prefix) overload.Class {
+	req := c.Request()
+	switch req.URL.Path {
+	case "/health", "/metrics", statusPagePath:
+		return overload.ClassCritical
+	}
+
+	class := overload.ClassRead
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		class = overload.ClassWrite
+	}
+	if !trustedCaller(req, trustedProxies) {
+		return class
+	}
+	return overload.ParseClass(strings.ToLower(req.Header.Get(overload.PriorityHeader)), class)
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"net/http"
+	"net/netip"
+
+	"github.com/labstack/echo/v4"
+
+	"activity-log-service/internal/application/usecase"
+)
+
+// principalHeader carries the caller identity set by a trusted gateway
+const principalHeader = "X-Principal"
+
+// principal attaches the caller identity to the request context so reads are
+// attributed in the access log. A verified client certificate wins over the
+// header, which is only read from the trusted proxies.
+func principal(trustedProxies []netip.Prefix) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			var name string
+			if trustedPeer(req.RemoteAddr, trustedProxies) {
+				name = req.Header.Get(principalHeader)
+			}
+			if req.TLS != nil {
+				for _, chain := range req.TLS.VerifiedChains {
+					if len(chain) > 0 && chain[0].Subject.CommonName != "" {
+						name = chain[0].Subject.CommonName
+						break
+					}
+				}
+			}
+			c.SetRequest(req.WithContext(usecase.WithPrincipal(req.Context(), name)))
+			return next(c)
+		}
+	}
+}
+
+// trustedCaller reports whether the request came with a verified client
+// certificate or from one of the trusted proxies
+func trustedCaller(req *http.Request, trustedProxies []netip.Prefix) bool {
+	if req.TLS != nil && len(req.TLS.VerifiedChains) > 0 {
+		return true
+	}
+	return trustedPeer(req.RemoteAddr, trustedProxies)
+}
+
+// trustedPeer reports whether the host:port address is one of the trusted
+// proxies
+func trustedPeer(address string, trustedProxies []netip.Prefix) bool {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return false
+	}
+	addr := addrPort.Addr().Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
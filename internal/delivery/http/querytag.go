@@ -0,0 +1,23 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"activity-log-service/internal/infrastructure/querytag"
+)
+
+// queryTag records the route pattern and request ID for the AQL annotations.
+// It must run after the RequestID middleware.
+func queryTag() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			tag := querytag.Tag{
+				Route:     req.Method + " " + c.Path(),
+				RequestID: c.Response().Header().Get(echo.HeaderXRequestID),
+			}
+			c.SetRequest(req.WithContext(querytag.WithTag(req.Context(), tag)))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"activity-log-service/internal/infrastructure/health"
+)
+
+// ReadinessResponse lists the result of every dependency check
+type ReadinessResponse struct {
+	Status string          `json:"status" example:"ready"`
+	Checks []health.Result `json:"checks"`
+}
+
+type readiness struct {
+	checker *health.Checker
+}
+
+// EnableReadiness registers GET /ready for readiness probes. Unlike /health,
+// which only shows the process is up, it fails while a dependency such as
+// ArangoDB cannot be reached, so traffic is routed elsewhere until it can.
+func (s *EchoServer) EnableReadiness(checker *health.Checker) {
+	probe := &readiness{checker: checker}
+	s.echo.GET("/ready", probe.handle)
+}
+
+// @Summary Readiness Check
+// @Description Runs the dependency checks; 503 while any of them fails
+// @Tags Health
+// @Produce json
+// @Success 200 {object} ReadinessResponse
+// @Failure 503 {object} ReadinessResponse
+// @Router /ready [get]
+func (r *readiness) handle(c echo.Context) error {
+	results := r.checker.Run(c.Request().Context())
+	if !health.Healthy(results) {
+		return c.JSON(http.StatusServiceUnavailable, ReadinessResponse{Status: "unavailable", Checks: results})
+	}
+	return c.JSON(http.StatusOK, ReadinessResponse{Status: "ready", Checks: results})
+}
@@ -0,0 +1,213 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"golang.org/x/time/rate"
+
+	"activity-log-service/internal/application/usecase"
+	"activity-log-service/internal/infrastructure/health"
+	"activity-log-service/pkg/errcode"
+)
+
+// statusPagePath is served without authentication and must never expose
+// tenant data
+const statusPagePath = "/status.json"
+
+// statusPageBuildTimeout bounds the health checks and counts behind one
+// rendering of the status page
+const statusPageBuildTimeout = 10 * time.Second
+
+// Public component names; internal dependency names are not exposed
+var statusComponents = map[string]string{
+	"arangodb": "storage",
+	"nats":     "event_stream",
+	"redis":    "cache",
+}
+
+// Component and overall states reported on the status page
+const (
+	statusOperational = "operational"
+	statusDegraded    = "degraded"
+	statusOutage      = "major_outage"
+)
+
+type StatusPageConfig struct {
+	CacheTTL  time.Duration
+	RateLimit float64
+	RateBurst int
+}
+
+type StatusPageResponse struct {
+	Status     string              `json:"status" example:"operational" enums:"operational,degraded,major_outage"`
+	Components map[string]string   `json:"components"`
+	Throughput *ThroughputResponse `json:"throughput,omitempty"`
+	UpdatedAt  time.Time           `json:"updated_at" example:"2023-12-07T10:30:00Z"`
+}
+
+// ThroughputResponse holds service-wide totals rounded to two significant
+// digits
+type ThroughputResponse struct {
+	LastHour int `json:"activity_logs_last_hour" example:"1200"`
+	LastDay  int `json:"activity_logs_last_24h" example:"31000"`
+}
+
+// statusPage renders the public status document at most once per TTL no
+// matter how often it is requested
+type statusPage struct {
+	useCase *usecase.ActivityLogUseCase
+	checker *health.Checker
+	ttl     time.Duration
+
+	mu       sync.Mutex
+	response *StatusPageResponse
+	expires  time.Time
+	// building is closed once the rendering in flight is done; nil while
+	// none is
+	building chan struct{}
+}
+
+// EnableStatusPage registers GET /status.json. It is rate limited per client
+// IP and cached both in process and by downstream caches.
+func (s *EchoServer) EnableStatusPage(checker *health.Checker, cfg StatusPageConfig) {
+	page := &statusPage{
+		useCase: s.useCase,
+		checker: checker,
+		ttl:     cfg.CacheTTL,
+	}
+
+	limiter := middleware.RateLimiterWithConfig(middleware.RateLimiterConfig{
+		Store: middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+			Rate:      rate.Limit(cfg.RateLimit),
+			Burst:     cfg.RateBurst,
+			ExpiresIn: 3 * time.Minute,
+		}),
+		DenyHandler: func(c echo.Context, identifier string, err error) error {
+			c.Response().Header().Set("Retry-After", "1")
+			return errorResponse(c, errcode.QuotaExceeded, "Too many requests", "status page rate limit exceeded")
+		},
+	})
+
+	s.echo.GET(statusPagePath, page.handle, limiter)
+}
+
+// @Summary Public Status
+// @Description Coarse service health and aggregate throughput for public status pages; contains no tenant data
+// @Tags Health
+// @Produce json
+// @Success 200 {object} StatusPageResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /status.json [get]
+func (p *statusPage) handle(c echo.Context) error {
+	response, err := p.get(c.Request().Context())
+	if err != nil {
+		return errorResponse(c, errcode.Internal, "Status unavailable", err.Error())
+	}
+
+	maxAge := int(math.Ceil(time.Until(response.UpdatedAt.Add(p.ttl)).Seconds()))
+	if maxAge < 0 {
+		maxAge = 0
+	}
+	c.Response().Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+	c.Response().Header().Set("Last-Modified", response.UpdatedAt.UTC().Format(http.TimeFormat))
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// get returns the cached document, rendering it again once it expired. One
+// rendering runs at a time, detached from the requests waiting for it; a
+// request that gives up first is served the expired document, if any.
+func (p *statusPage) get(ctx context.Context) (*StatusPageResponse, error) {
+	p.mu.Lock()
+	if p.response != nil && time.Now().Before(p.expires) {
+		response := p.response
+		p.mu.Unlock()
+		return response, nil
+	}
+	if p.building == nil {
+		p.building = make(chan struct{})
+		go p.rebuild(p.building)
+	}
+	building := p.building
+	p.mu.Unlock()
+
+	select {
+	case <-building:
+	case <-ctx.Done():
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.response == nil {
+		return nil, ctx.Err()
+	}
+	return p.response, nil
+}
+
+func (p *statusPage) rebuild(done chan struct{}) {
+	ctx, cancel := context.WithTimeout(context.Background(), statusPageBuildTimeout)
+	defer cancel()
+	response := p.build(ctx)
+
+	p.mu.Lock()
+	p.response = response
+	p.expires = response.UpdatedAt.Add(p.ttl)
+	p.building = nil
+	p.mu.Unlock()
+	close(done)
+}
+
+func (p *statusPage) build(ctx context.Context) *StatusPageResponse {
+	response := &StatusPageResponse{
+		Status:     statusOperational,
+		Components: map[string]string{"api": statusOperational},
+		UpdatedAt:  time.Now(),
+	}
+
+	for _, result := range p.checker.Run(ctx) {
+		name, ok := statusComponents[result.Name]
+		if !ok {
+			continue
+		}
+		if result.Healthy {
+			response.Components[name] = statusOperational
+			continue
+		}
+
+		response.Components[name] = statusDegraded
+		if name == "storage" {
+			response.Components[name] = statusOutage
+			response.Status = statusOutage
+		} else if response.Status == statusOperational {
+			response.Status = statusDegraded
+		}
+	}
+
+	// Throughput is best effort; the page still reports health without it
+	if stats, err := p.useCase.GetThroughputStats(ctx); err == nil {
+		response.Throughput = &ThroughputResponse{
+			LastHour: coarse(stats.LastHour),
+			LastDay:  coarse(stats.LastDay),
+		}
+	}
+
+	return response
+}
+
+// coarse rounds n down to two significant digits so the public numbers do
+// not reveal exact volumes
+func coarse(n int) int {
+	if n < 100 {
+		return n
+	}
+	scale := int(math.Pow10(len(strconv.Itoa(n)) - 2))
+	return n / scale * scale
+}
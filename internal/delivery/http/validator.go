@@ -1,10 +1,15 @@
 package http
 
 import (
+	"fmt"
+	"net"
 	"net/http"
 	"regexp"
 
 	"github.com/labstack/echo/v4"
+
+	"activity-log-service/internal/application/usecase"
+	"activity-log-service/internal/domain/entity"
 )
 
 type CustomValidator struct{}
@@ -46,6 +51,28 @@ func (cv *CustomValidator) validateCreateActivityLogRequest(req *CreateActivityL
 	if !cv.isValidEmail(req.ActorEmail) {
 		return echo.NewHTTPError(http.StatusBadRequest, "actor_email must be a valid email address")
 	}
+	if req.IPAddress != "" && net.ParseIP(req.IPAddress) == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "ip_address must be a valid IP address")
+	}
+	if len(req.Tags) > entity.MaxTags {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("at most %d tags are allowed", entity.MaxTags))
+	}
+	for _, tag := range req.Tags {
+		if tag == "" || len(tag) > entity.MaxTagLength {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("tags must be between 1 and %d characters", entity.MaxTagLength))
+		}
+	}
+	if len(req.ExternalID) > entity.MaxExternalIDLength {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("external_id must be at most %d characters", entity.MaxExternalIDLength))
+	}
+	switch usecase.CreateMode(req.CreateMode) {
+	case usecase.CreateModeAlways, usecase.CreateModeSkipIfExists, usecase.CreateModeUpsert:
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "create_mode must be skip_if_exists or upsert")
+	}
+	if req.CreateMode != "" && req.ExternalID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "external_id is required with create_mode")
+	}
 	return nil
 }
 
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// AccessLogEntry records one read of a company's activity data: who asked,
+// what they asked for and how many logs they got back.
+type AccessLogEntry struct {
+	Principal   string            `json:"principal"`
+	CompanyID   string            `json:"company_id"`
+	Operation   string            `json:"operation"`
+	Filter      map[string]string `json:"filter,omitempty"`
+	ResultCount int               `json:"result_count"`
+	AccessedAt  time.Time         `json:"accessed_at"`
+}
@@ -26,6 +26,13 @@ type ActivityLog struct {
 	ActorID          string                    `json:"actor_id"`
 	ActorName        string                    `json:"actor_name"`
 	ActorEmail       string                    `json:"actor_email"`
+	UserAgent        string                    `json:"user_agent,omitempty"`
+	IPAddress        string                    `json:"ip_address,omitempty"`
+	DeviceID         string                    `json:"device_id,omitempty"`
+	CountryCode      string                    `json:"country_code,omitempty"`
+	City             string                    `json:"city,omitempty"`
+	Tags             []string                  `json:"tags,omitempty"`
+	ExternalID       string                    `json:"external_id,omitempty"`
 	CreatedAt        time.Time                 `json:"created_at"`
 }
 
@@ -55,6 +62,10 @@ func NewActivityLog(
 	}
 }
 
+// MaxExternalIDLength bounds the producer's own identifier of a log, which is
+// unique within its company
+const MaxExternalIDLength = 256
+
 func (al *ActivityLog) IsValid() error {
 	if al.ActivityName == "" {
 		return ErrInvalidActivityName
@@ -80,6 +91,12 @@ func (al *ActivityLog) IsValid() error {
 	if !isValidEmail(al.ActorEmail) {
 		return ErrInvalidActorEmail
 	}
+	if !isValidTags(al.Tags) {
+		return ErrInvalidTags
+	}
+	if len(al.ExternalID) > MaxExternalIDLength {
+		return ErrInvalidExternalID
+	}
 	return nil
 }
 
@@ -87,6 +104,23 @@ func (al *ActivityLog) ToJSON() ([]byte, error) {
 	return json.Marshal(al)
 }
 
+const (
+	MaxTags      = 20
+	MaxTagLength = 64
+)
+
+func isValidTags(tags []string) bool {
+	if len(tags) > MaxTags {
+		return false
+	}
+	for _, tag := range tags {
+		if tag == "" || len(tag) > MaxTagLength {
+			return false
+		}
+	}
+	return true
+}
+
 func isValidEmail(email string) bool {
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 	return emailRegex.MatchString(email)
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrArchiveNotFound is returned when no archive manifest has the given ID
+var ErrArchiveNotFound = errors.New("archive not found")
+
+// ArchiveManifest describes one object of archived logs: a gzipped NDJSON
+// file holding a company's logs created between From and To, both
+// inclusive. The logs themselves are no longer in the database unless the
+// archive has been restored.
+type ArchiveManifest struct {
+	ID        string    `json:"id"`
+	CompanyID string    `json:"company_id"`
+	Object    string    `json:"object"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	Count     int       `json:"count"`
+	Bytes     int64     `json:"bytes"`
+	// SHA256 is the hex digest of the stored object, checked on restore
+	SHA256 string `json:"sha256"`
+	// KeyID is the company export key the object is encrypted to; empty for
+	// a plaintext object
+	KeyID      string     `json:"key_id,omitempty"`
+	ArchivedAt time.Time  `json:"archived_at"`
+	RestoredAt *time.Time `json:"restored_at,omitempty"`
+	// OnHold is set while restored logs must stay in the database; the
+	// company is not archived again until every hold is released
+	OnHold bool `json:"on_hold"`
+}
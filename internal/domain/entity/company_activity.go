@@ -0,0 +1,9 @@
+package entity
+
+import "time"
+
+// CompanyActivity records when a company last produced an activity log.
+type CompanyActivity struct {
+	CompanyID      string    `json:"company_id"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrDailyExportNotFound is returned when a company's day has not been
+// exported yet
+var ErrDailyExportNotFound = errors.New("daily export not found")
+
+// DailyExportStatus is how the last attempt to export a day ended
+type DailyExportStatus string
+
+const (
+	DailyExportComplete DailyExportStatus = "complete"
+	DailyExportFailed   DailyExportStatus = "failed"
+)
+
+// DailyExport is the manifest of one company's logs of one UTC day, copied
+// to the object store as gzipped NDJSON objects. A failed export is retried
+// by later runs until it completes or runs out of attempts.
+type DailyExport struct {
+	CompanyID string `json:"company_id"`
+	// Day is the UTC midnight the exported day starts at
+	Day     time.Time         `json:"day"`
+	Status  DailyExportStatus `json:"status"`
+	Objects []string          `json:"objects"`
+	Count   int               `json:"count"`
+	Bytes   int64             `json:"bytes"`
+	// KeyID is the company export key the objects are encrypted to; empty
+	// for plaintext objects
+	KeyID      string    `json:"key_id,omitempty"`
+	Attempts   int       `json:"attempts"`
+	Error      string    `json:"error,omitempty"`
+	ExportedAt time.Time `json:"exported_at"`
+}
@@ -9,5 +9,12 @@ var (
 	ErrInvalidObjectID         = errors.New("invalid object id")
 	ErrInvalidFormattedMessage = errors.New("invalid formatted message")
 	ErrActivityLogNotFound     = errors.New("activity log not found")
+	ErrActivityLogExists       = errors.New("activity log already exists")
 	ErrInvalidActor            = errors.New("invalid actor")
+	ErrInvalidIPAddress        = errors.New("invalid ip address")
+	ErrDatabaseUnavailable     = errors.New("database unavailable")
+	ErrInvalidTags             = errors.New("invalid tags")
+	ErrInvalidExternalID       = errors.New("invalid external id")
+	ErrBatchAborted            = errors.New("batch aborted because another item failed")
+	ErrAppendOnly              = errors.New("activity logs are append-only")
 )
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrExportKeyNotFound is returned when a company has not registered an
+// export key
+var ErrExportKeyNotFound = errors.New("export key not found")
+
+// ExportKey is the public key a company's exports are encrypted to. Only the
+// public half is ever stored; the company keeps the private key.
+type ExportKey struct {
+	CompanyID    string    `json:"company_id"`
+	KeyID        string    `json:"key_id"`
+	Algorithm    string    `json:"algorithm"`
+	PublicKeyPEM string    `json:"public_key_pem"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,84 @@
+package entity
+
+import "fmt"
+
+// SchemaVersion is the newest activity log schema this build reads and
+// writes. Readers decode with unknown fields ignored, so an older reader
+// simply drops fields added after its version.
+const SchemaVersion = 5
+
+// Field is an optional activity log field added after the first schema
+// version, identified by its JSON name
+type Field struct {
+	Name  string
+	Since int
+}
+
+// Fields registers every optional field. A new field is added here with the
+// next SchemaVersion so writers can withhold it until all readers know it.
+var Fields = []Field{
+	{Name: "user_agent", Since: 2},
+	{Name: "ip_address", Since: 2},
+	{Name: "device_id", Since: 2},
+	{Name: "country_code", Since: 3},
+	{Name: "city", Since: 3},
+	{Name: "tags", Since: 4},
+	{Name: "external_id", Since: 5},
+}
+
+// ParseWithheldFields turns configured field names into a set, rejecting
+// names that are not registered
+func ParseWithheldFields(names []string) (map[string]bool, error) {
+	withheld := make(map[string]bool, len(names))
+	for _, name := range names {
+		if _, ok := lookupField(name); !ok {
+			return nil, fmt.Errorf("unknown activity log field %q", name)
+		}
+		withheld[name] = true
+	}
+	return withheld, nil
+}
+
+// FieldsUnknownTo lists the fields a writer withholding withheld would still
+// write that readers at readerVersion do not know
+func FieldsUnknownTo(readerVersion int, withheld map[string]bool) []Field {
+	var unknown []Field
+	for _, field := range Fields {
+		if field.Since > readerVersion && !withheld[field.Name] {
+			unknown = append(unknown, field)
+		}
+	}
+	return unknown
+}
+
+func lookupField(name string) (Field, bool) {
+	for _, field := range Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+// WithholdFields clears the withheld fields so they are neither stored nor
+// published
+func (al *ActivityLog) WithholdFields(withheld map[string]bool) {
+	for name := range withheld {
+		switch name {
+		case "user_agent":
+			al.UserAgent = ""
+		case "ip_address":
+			al.IPAddress = ""
+		case "device_id":
+			al.DeviceID = ""
+		case "country_code":
+			al.CountryCode = ""
+		case "city":
+			al.City = ""
+		case "tags":
+			al.Tags = nil
+		case "external_id":
+			al.ExternalID = ""
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// JobRunStatus is how a cron job run ended
+type JobRunStatus string
+
+const (
+	JobRunSucceeded JobRunStatus = "succeeded"
+	JobRunFailed    JobRunStatus = "failed"
+)
+
+// JobRun records one execution of a cron job. Items counts what the job
+// processed, such as deleted logs for log_rotation or sent emails for
+// daily_summary; jobs that process nothing countable leave it 0.
+type JobRun struct {
+	Job        string        `json:"job"`
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt time.Time     `json:"finished_at"`
+	Duration   time.Duration `json:"duration"`
+	Status     JobRunStatus  `json:"status"`
+	Error      string        `json:"error,omitempty"`
+	Items      int           `json:"items"`
+}
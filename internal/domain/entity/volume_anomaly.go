@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// VolumeAnomalyKind is how an hour's log volume departs from the baseline
+type VolumeAnomalyKind string
+
+const (
+	// VolumeSpike is an hour with many times the usual logs
+	VolumeSpike VolumeAnomalyKind = "spike"
+	// VolumeDrop is an hour without logs from a company that usually logs
+	// steadily, which mostly means its integration broke
+	VolumeDrop VolumeAnomalyKind = "drop"
+)
+
+// VolumeAnomaly is one company's hour whose log count departs from its
+// baseline, the mean logs per hour over the hours before it
+type VolumeAnomaly struct {
+	CompanyID string            `json:"company_id"`
+	Kind      VolumeAnomalyKind `json:"kind"`
+	HourStart time.Time         `json:"hour_start"`
+	Count     int               `json:"count"`
+	Baseline  float64           `json:"baseline"`
+}
+
+// Summary describes the anomaly in one line
+func (a VolumeAnomaly) Summary() string {
+	hour := a.HourStart.UTC().Format("2006-01-02 15:04 UTC")
+	if a.Kind == VolumeDrop {
+		return fmt.Sprintf("%s logged nothing in the hour from %s, against %.1f logs per hour usually", a.CompanyID, hour, a.Baseline)
+	}
+	return fmt.Sprintf("%s logged %d activities in the hour from %s, against %.1f per hour usually", a.CompanyID, a.Count, hour, a.Baseline)
+}
@@ -14,6 +14,12 @@ type ActivityLogCreated struct {
 	ActivityLog *entity.ActivityLog `json:"activity_log"`
 	Timestamp   time.Time           `json:"timestamp"`
 	Version     int                 `json:"version"`
+	// Trimmed is set when ActivityLog only holds identifying fields; the
+	// full log has been stored and can be fetched by AggregateID
+	Trimmed bool `json:"trimmed,omitempty"`
+	// Queued is set when the log has not been stored yet and the consumer
+	// is expected to write it
+	Queued bool `json:"queued,omitempty"`
 }
 
 func NewActivityLogCreated(activityLog *entity.ActivityLog) *ActivityLogCreated {
@@ -31,6 +37,10 @@ func (e *ActivityLogCreated) ToJSON() ([]byte, error) {
 	return json.Marshal(e)
 }
 
+func (e *ActivityLogCreated) GetEventID() string {
+	return e.EventID
+}
+
 func (e *ActivityLogCreated) GetEventType() string {
 	return e.EventType
 }
@@ -0,0 +1,51 @@
+package event
+
+import (
+	"encoding/json"
+	"time"
+
+	"activity-log-service/internal/domain/entity"
+)
+
+// ActivityLogDeleted announces that a log was deleted. It carries no more of
+// the log than needed to route it, since the log may have been deleted to
+// erase its contents.
+type ActivityLogDeleted struct {
+	EventID     string    `json:"event_id"`
+	EventType   string    `json:"event_type"`
+	AggregateID string    `json:"aggregate_id"`
+	CompanyID   string    `json:"company_id"`
+	Timestamp   time.Time `json:"timestamp"`
+	Version     int       `json:"version"`
+}
+
+func NewActivityLogDeleted(activityLog *entity.ActivityLog) *ActivityLogDeleted {
+	return &ActivityLogDeleted{
+		EventID:     generateEventID(),
+		EventType:   "activity_log_deleted",
+		AggregateID: activityLog.ID.String(),
+		CompanyID:   activityLog.CompanyID,
+		Timestamp:   time.Now().UTC(),
+		Version:     1,
+	}
+}
+
+func (e *ActivityLogDeleted) ToJSON() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+func (e *ActivityLogDeleted) GetEventID() string {
+	return e.EventID
+}
+
+func (e *ActivityLogDeleted) GetEventType() string {
+	return e.EventType
+}
+
+func (e *ActivityLogDeleted) GetAggregateID() string {
+	return e.AggregateID
+}
+
+func (e *ActivityLogDeleted) GetTimestamp() time.Time {
+	return e.Timestamp
+}
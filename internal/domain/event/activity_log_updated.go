@@ -0,0 +1,50 @@
+package event
+
+import (
+	"encoding/json"
+	"time"
+
+	"activity-log-service/internal/domain/entity"
+)
+
+// ActivityLogUpdated announces that a stored log was replaced, e.g. by an
+// upsert on its external ID. ActivityLog is the log as now stored.
+type ActivityLogUpdated struct {
+	EventID     string              `json:"event_id"`
+	EventType   string              `json:"event_type"`
+	AggregateID string              `json:"aggregate_id"`
+	ActivityLog *entity.ActivityLog `json:"activity_log"`
+	Timestamp   time.Time           `json:"timestamp"`
+	Version     int                 `json:"version"`
+}
+
+func NewActivityLogUpdated(activityLog *entity.ActivityLog) *ActivityLogUpdated {
+	return &ActivityLogUpdated{
+		EventID:     generateEventID(),
+		EventType:   "activity_log_updated",
+		AggregateID: activityLog.ID.String(),
+		ActivityLog: activityLog,
+		Timestamp:   time.Now().UTC(),
+		Version:     1,
+	}
+}
+
+func (e *ActivityLogUpdated) ToJSON() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+func (e *ActivityLogUpdated) GetEventID() string {
+	return e.EventID
+}
+
+func (e *ActivityLogUpdated) GetEventType() string {
+	return e.EventType
+}
+
+func (e *ActivityLogUpdated) GetAggregateID() string {
+	return e.AggregateID
+}
+
+func (e *ActivityLogUpdated) GetTimestamp() time.Time {
+	return e.Timestamp
+}
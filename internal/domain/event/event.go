@@ -0,0 +1,18 @@
+package event
+
+import "time"
+
+// Event is what every domain event offers the publisher
+type Event interface {
+	ToJSON() ([]byte, error)
+	GetEventID() string
+	GetEventType() string
+	GetAggregateID() string
+	GetTimestamp() time.Time
+}
+
+var (
+	_ Event = (*ActivityLogCreated)(nil)
+	_ Event = (*ActivityLogUpdated)(nil)
+	_ Event = (*ActivityLogDeleted)(nil)
+)
@@ -0,0 +1,71 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"activity-log-service/internal/domain/entity"
+)
+
+// PayloadPolicy decides how much of the activity log a published event
+// carries. Trimmed events keep enough to route and identify the log;
+// consumers that need the rest fetch it by ID.
+type PayloadPolicy string
+
+const (
+	// PayloadFull embeds the complete activity log
+	PayloadFull PayloadPolicy = "full"
+	// PayloadMetadata embeds only identifying fields
+	PayloadMetadata PayloadPolicy = "metadata"
+	// PayloadCapped embeds the complete log unless the encoded event exceeds
+	// the size cap, in which case it falls back to metadata
+	PayloadCapped PayloadPolicy = "capped"
+)
+
+// ParsePayloadPolicy validates a configured policy; empty means full
+func ParsePayloadPolicy(policy string) (PayloadPolicy, error) {
+	switch PayloadPolicy(policy) {
+	case "", PayloadFull:
+		return PayloadFull, nil
+	case PayloadMetadata, PayloadCapped:
+		return PayloadPolicy(policy), nil
+	}
+	return "", fmt.Errorf("unknown event payload policy %q", policy)
+}
+
+// WithPayloadPolicy returns the event as it should be published under
+// policy. Queued events are always returned in full because the event is the
+// only copy of the log. The receiver is never modified.
+func (e *ActivityLogCreated) WithPayloadPolicy(policy PayloadPolicy, maxBytes int) *ActivityLogCreated {
+	if e.Queued || e.Trimmed {
+		return e
+	}
+
+	switch policy {
+	case PayloadMetadata:
+		return e.trimmed()
+	case PayloadCapped:
+		data, err := json.Marshal(e)
+		if err == nil && len(data) <= maxBytes {
+			return e
+		}
+		return e.trimmed()
+	}
+	return e
+}
+
+func (e *ActivityLogCreated) trimmed() *ActivityLogCreated {
+	trimmed := *e
+	trimmed.Trimmed = true
+	trimmed.ActivityLog = &entity.ActivityLog{
+		ID:           e.ActivityLog.ID,
+		ActivityName: e.ActivityLog.ActivityName,
+		CompanyID:    e.ActivityLog.CompanyID,
+		ObjectName:   e.ActivityLog.ObjectName,
+		ObjectID:     e.ActivityLog.ObjectID,
+		ActorID:      e.ActivityLog.ActorID,
+		Tags:         e.ActivityLog.Tags,
+		CreatedAt:    e.ActivityLog.CreatedAt,
+	}
+	return &trimmed
+}
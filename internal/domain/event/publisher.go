@@ -0,0 +1,30 @@
+package event
+
+import "context"
+
+// Publisher announces activity log events to other services. Each method
+// returns the sequence the event was stored at by the broker, or zero if it
+// has none.
+type Publisher interface {
+	PublishActivityLogCreated(ctx context.Context, event *ActivityLogCreated) (uint64, error)
+	PublishActivityLogUpdated(ctx context.Context, event *ActivityLogUpdated) (uint64, error)
+	PublishActivityLogDeleted(ctx context.Context, event *ActivityLogDeleted) (uint64, error)
+}
+
+// NopPublisher drops every event. Queued creates rely on their event to be
+// stored, so it must not be used with queueing on database failure.
+type NopPublisher struct{}
+
+var _ Publisher = NopPublisher{}
+
+func (NopPublisher) PublishActivityLogCreated(ctx context.Context, event *ActivityLogCreated) (uint64, error) {
+	return 0, nil
+}
+
+func (NopPublisher) PublishActivityLogUpdated(ctx context.Context, event *ActivityLogUpdated) (uint64, error) {
+	return 0, nil
+}
+
+func (NopPublisher) PublishActivityLogDeleted(ctx context.Context, event *ActivityLogDeleted) (uint64, error) {
+	return 0, nil
+}
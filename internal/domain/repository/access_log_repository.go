@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"activity-log-service/internal/domain/entity"
+)
+
+// AccessLogRepository stores who read which company's activity data. Entries
+// live apart from the activity logs and expire on their own retention.
+type AccessLogRepository interface {
+	Record(ctx context.Context, entry *entity.AccessLogEntry) error
+	// GetByCompanyID lists a company's entries, newest first
+	GetByCompanyID(ctx context.Context, companyID string, page, limit int) ([]*entity.AccessLogEntry, int, error)
+}
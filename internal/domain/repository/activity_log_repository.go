@@ -2,21 +2,181 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"activity-log-service/internal/domain/entity"
 	"activity-log-service/internal/domain/valueobject"
 )
 
+// ActivityLogFilter narrows a query to logs of one company. Empty fields and
+// zero dates are ignored.
+type ActivityLogFilter struct {
+	CompanyID    string
+	ObjectID     string
+	ActivityName string
+	ActorID      string
+	DeviceID     string
+	IPAddress    string
+	UserAgent    string
+	CountryCode  string
+	StartDate    time.Time
+	EndDate      time.Time
+	// Text matches case-insensitively against the formatted message
+	Text string
+	// Tags requires every listed tag to be present on the log
+	Tags []string
+}
+
+// SearchCursor marks the last log of a page in created_at DESC, id DESC
+// order; the next page starts right after it.
+type SearchCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// SearchPage selects a page of Search results. Pages are walked with After,
+// the Next cursor of the previous page, or addressed directly with Number,
+// a 1-based page of Limit logs; only numbered pages report a Total.
+type SearchPage struct {
+	Limit  int
+	After  *SearchCursor
+	Number int
+}
+
+// ResultWindowError rejects a numbered page that starts deeper in the results
+// than the repository skips to. Cursor pages have no such limit.
+type ResultWindowError struct {
+	Offset int
+	Limit  int
+	Max    int
+}
+
+func (e *ResultWindowError) Error() string {
+	return fmt.Sprintf("%d logs from offset %d exceed the result window of %d; page with a cursor instead", e.Limit, e.Offset, e.Max)
+}
+
+type SearchResult struct {
+	ActivityLogs []*entity.ActivityLog
+	// Next is nil when there are no further results
+	Next *SearchCursor
+	// Total counts every matching log; it is only set for numbered pages
+	Total int
+}
+
+// GroupBy selects the dimension CountGrouped aggregates over
+type GroupBy string
+
+const (
+	GroupByActivityName GroupBy = "activity_name"
+	GroupByActor        GroupBy = "actor"
+	// GroupByDay buckets by UTC calendar day, keyed YYYY-MM-DD
+	GroupByDay GroupBy = "day"
+)
+
+// GroupCount is the number of logs sharing one group key
+type GroupCount struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+}
+
+// Actor is someone with logs in a company, named and addressed as on their
+// most recent one
+type Actor struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Email      string    `json:"email"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+// WriteOutcome reports what CreateByExternalID did
+type WriteOutcome string
+
+const (
+	WriteCreated WriteOutcome = "created"
+	WriteSkipped WriteOutcome = "skipped"
+	WriteUpdated WriteOutcome = "updated"
+)
+
+// CreateManyError reports the logs CreateMany could not store. Errs has one
+// entry per input log, in order, nil for the logs that were stored.
+type CreateManyError struct {
+	Errs []error
+}
+
+func (e *CreateManyError) Error() string {
+	failed := e.Unwrap()
+	if len(failed) == 0 {
+		return "no activity logs rejected"
+	}
+	return fmt.Sprintf("%d of %d activity logs rejected, first: %v", len(failed), len(e.Errs), failed[0])
+}
+
+// Unwrap exposes the individual rejections to errors.Is and errors.As
+func (e *CreateManyError) Unwrap() []error {
+	var failed []error
+	for _, err := range e.Errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	return failed
+}
+
+// ActivityLogIterator walks a result set one document at a time without
+// loading it into memory. Callers must Close it when done.
+type ActivityLogIterator interface {
+	HasMore() bool
+	Next(ctx context.Context) (*entity.ActivityLog, error)
+	Close() error
+}
+
 type ActivityLogRepository interface {
 	Create(ctx context.Context, activityLog *entity.ActivityLog) error
+	CreateBatch(ctx context.Context, activityLogs []*entity.ActivityLog) ([]error, error)
+	CreateBatchAtomic(ctx context.Context, activityLogs []*entity.ActivityLog) ([]error, error)
+	// CreateMany bulk-inserts any number of logs, a bounded number per
+	// request. Rejected logs are reported through a *CreateManyError; the
+	// others are stored regardless.
+	CreateMany(ctx context.Context, activityLogs []*entity.ActivityLog) error
+	// CreateByExternalID stores activityLog unless the company already has a
+	// log with its external ID. That log is returned unchanged, or with its
+	// content replaced by activityLog's when update is set; its ID and
+	// creation time are kept either way.
+	CreateByExternalID(ctx context.Context, activityLog *entity.ActivityLog, update bool) (*entity.ActivityLog, WriteOutcome, error)
 	GetByID(ctx context.Context, id valueobject.ActivityLogID) (*entity.ActivityLog, error)
+	// GetByIDs returns the logs with the given IDs in the order of ids.
+	// IDs without a log are left out rather than failing the call.
+	GetByIDs(ctx context.Context, ids []valueobject.ActivityLogID) ([]*entity.ActivityLog, error)
 	GetByCompanyID(ctx context.Context, companyID string, page, limit int) ([]*entity.ActivityLog, int, error)
 	Update(ctx context.Context, activityLog *entity.ActivityLog) error
 	Delete(ctx context.Context, id valueobject.ActivityLogID) error
-	GetByObjectID(ctx context.Context, companyID, objectID string, page, limit int) ([]*entity.ActivityLog, int, error)
-	GetByActivityName(ctx context.Context, companyID, activityName string, page, limit int) ([]*entity.ActivityLog, int, error)
-	GetByDateRange(ctx context.Context, companyID string, startDate, endDate time.Time, page, limit int) ([]*entity.ActivityLog, int, error)
-	GetByActor(ctx context.Context, companyID, actorID string, page, limit int) ([]*entity.ActivityLog, int, error)
+	// DeleteOlderThan removes up to limit of a company's logs created before
+	// cutoff, or all of them if limit is 0, and returns how many were removed
+	DeleteOlderThan(ctx context.Context, companyID string, cutoff time.Time, limit int) (int, error)
+	// CompaniesWithLogsBefore lists the companies with logs created before
+	// cutoff, i.e. the candidates for DeleteOlderThan
+	CompaniesWithLogsBefore(ctx context.Context, cutoff time.Time) ([]string, error)
 	CountByCompanyID(ctx context.Context, companyID string) (int, error)
+	CountSince(ctx context.Context, since time.Time) (int, error)
+	CountByCountryCode(ctx context.Context, companyID string) (map[string]int, error)
+	GetActiveCompanies(ctx context.Context, since time.Time) ([]*entity.CompanyActivity, error)
+	OpenCursor(ctx context.Context, filter ActivityLogFilter) (ActivityLogIterator, error)
+	// Iterate calls fn with every log matching filter, newest first, reading
+	// them in batches rather than all at once. It stops at the first error fn
+	// returns and returns that error unchanged.
+	Iterate(ctx context.Context, filter ActivityLogFilter, fn func(*entity.ActivityLog) error) error
+	// Search lists the logs matching every set field of filter, newest first
+	Search(ctx context.Context, filter ActivityLogFilter, page SearchPage) (*SearchResult, error)
+	CountGrouped(ctx context.Context, filter ActivityLogFilter, groupBy GroupBy, limit int) ([]GroupCount, error)
+	// CountByActivityName, CountByActor and CountPerDay count one company's
+	// logs created between start and end, both inclusive, per group key
+	CountByActivityName(ctx context.Context, companyID string, start, end time.Time) ([]GroupCount, error)
+	CountByActor(ctx context.Context, companyID string, start, end time.Time) ([]GroupCount, error)
+	CountPerDay(ctx context.Context, companyID string, start, end time.Time) ([]GroupCount, error)
+	// DistinctActivityNames lists every activity name a company's logs use,
+	// sorted
+	DistinctActivityNames(ctx context.Context, companyID string) ([]string, error)
+	// DistinctActors lists everyone with logs in a company, sorted by name
+	DistinctActors(ctx context.Context, companyID string) ([]Actor, error)
 }
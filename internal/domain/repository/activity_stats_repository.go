@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Granularity is the bucket size of a stats rollup
+type Granularity string
+
+const (
+	GranularityHour Granularity = "hour"
+	GranularityDay  Granularity = "day"
+)
+
+// Duration is the length of one bucket
+func (g Granularity) Duration() time.Duration {
+	if g == GranularityDay {
+		return 24 * time.Hour
+	}
+	return time.Hour
+}
+
+// Truncate returns the start of the UTC bucket containing t
+func (g Granularity) Truncate(t time.Time) time.Time {
+	return t.UTC().Truncate(g.Duration())
+}
+
+// ActivityStatsRepository stores pre-aggregated log counts so stats over long
+// ranges do not scan the raw logs. Buckets are rolled up as a whole and
+// marked complete; readers must only rely on buckets RolledUpBuckets reports.
+type ActivityStatsRepository interface {
+	// Rollup (re)computes the counts of every company for the bucket starting
+	// at bucketStart and marks the bucket complete
+	Rollup(ctx context.Context, granularity Granularity, bucketStart time.Time) error
+	// RolledUpBuckets lists the start of every complete bucket in [from, to)
+	RolledUpBuckets(ctx context.Context, granularity Granularity, from, to time.Time) ([]time.Time, error)
+	// CountGrouped sums the rolled up counts of one company over the buckets
+	// starting in [from, to)
+	CountGrouped(ctx context.Context, companyID string, groupBy GroupBy, granularity Granularity, from, to time.Time) ([]GroupCount, error)
+	// TopCompanies sums every company's rolled up counts over the buckets
+	// starting in [from, to) and returns the limit companies with the most
+	// logs, busiest first, keyed by company ID
+	TopCompanies(ctx context.Context, granularity Granularity, from, to time.Time, limit int) ([]GroupCount, error)
+}
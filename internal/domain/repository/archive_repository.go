@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"activity-log-service/internal/domain/entity"
+)
+
+// ArchiveRepository records the manifests of archived log objects
+type ArchiveRepository interface {
+	Create(ctx context.Context, manifest *entity.ArchiveManifest) error
+	// Get fails with entity.ErrArchiveNotFound for an unknown ID
+	Get(ctx context.Context, id string) (*entity.ArchiveManifest, error)
+	// ListByCompanyID returns the company's manifests, newest logs first
+	ListByCompanyID(ctx context.Context, companyID string) ([]*entity.ArchiveManifest, error)
+	// MarkRestored records the restore and puts the archive on hold. It fails
+	// with entity.ErrArchiveNotFound for an unknown ID.
+	MarkRestored(ctx context.Context, id string, restoredAt time.Time) error
+	// ReleaseHold fails with entity.ErrArchiveNotFound for an unknown ID
+	ReleaseHold(ctx context.Context, id string) error
+	// ListHeldCompanies returns the companies with an archive on hold
+	ListHeldCompanies(ctx context.Context) ([]string, error)
+}
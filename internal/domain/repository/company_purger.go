@@ -0,0 +1,11 @@
+package repository
+
+import "context"
+
+// CompanyPurger removes everything stored for a company at once. It is only
+// available when each company's logs live in their own collection.
+type CompanyPurger interface {
+	// PurgeCompany drops the company's logs; purging a company without any
+	// is not an error
+	PurgeCompany(ctx context.Context, companyID string) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"activity-log-service/internal/domain/entity"
+)
+
+// DailyExportRepository records the manifests of daily exports, one per
+// company and day
+type DailyExportRepository interface {
+	// Get fails with entity.ErrDailyExportNotFound for a day not exported yet
+	Get(ctx context.Context, companyID string, day time.Time) (*entity.DailyExport, error)
+	// Save creates or replaces the manifest of the export's company and day
+	Save(ctx context.Context, export *entity.DailyExport) error
+	// ListFailed returns the failed exports attempted fewer than maxAttempts
+	// times, oldest day first
+	ListFailed(ctx context.Context, maxAttempts int) ([]*entity.DailyExport, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import "context"
+
+type legalErasureKey struct{}
+
+// WithLegalErasure marks ctx as carrying a privileged legal erasure, which
+// may delete logs even when the repository is append-only. Only callers that
+// have checked the caller's privilege may set it.
+func WithLegalErasure(ctx context.Context) context.Context {
+	return context.WithValue(ctx, legalErasureKey{}, true)
+}
+
+// IsLegalErasure reports whether ctx was marked by WithLegalErasure
+func IsLegalErasure(ctx context.Context) bool {
+	erasure, _ := ctx.Value(legalErasureKey{}).(bool)
+	return erasure
+}
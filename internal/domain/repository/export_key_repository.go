@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"activity-log-service/internal/domain/entity"
+)
+
+// ExportKeyRepository stores at most one export key per company
+type ExportKeyRepository interface {
+	// Put registers key for its company, replacing any earlier key
+	Put(ctx context.Context, key *entity.ExportKey) error
+	// Get fails with entity.ErrExportKeyNotFound when the company has no key
+	Get(ctx context.Context, companyID string) (*entity.ExportKey, error)
+	// Delete fails with entity.ErrExportKeyNotFound when the company has no key
+	Delete(ctx context.Context, companyID string) error
+}
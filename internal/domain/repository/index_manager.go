@@ -0,0 +1,26 @@
+package repository
+
+import "context"
+
+// IndexAction reports what Reindex did to an index
+type IndexAction string
+
+const (
+	IndexCreated   IndexAction = "created"
+	IndexRebuilt   IndexAction = "rebuilt"
+	IndexUnchanged IndexAction = "unchanged"
+)
+
+// IndexStatus is the outcome of reconciling one index
+type IndexStatus struct {
+	Name   string      `json:"name"`
+	Action IndexAction `json:"action"`
+}
+
+// IndexManager reconciles the indexes the repository relies on with the
+// definitions it ships with
+type IndexManager interface {
+	// Reindex creates missing indexes and rebuilds those whose definition
+	// has drifted. Indexes that already match are left alone.
+	Reindex(ctx context.Context) ([]IndexStatus, error)
+}
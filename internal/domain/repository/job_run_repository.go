@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"activity-log-service/internal/domain/entity"
+)
+
+// JobRunRepository keeps the history of cron job runs. Runs expire on their
+// own retention.
+type JobRunRepository interface {
+	Record(ctx context.Context, run *entity.JobRun) error
+	// List returns up to limit runs of job, or of every job when job is
+	// empty, newest first
+	List(ctx context.Context, job string, limit int) ([]*entity.JobRun, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// PartitionManager drops whole time partitions of the activity logs, which
+// is far cheaper than deleting their logs one by one
+type PartitionManager interface {
+	// DropPartitionsBefore drops every partition holding only logs created
+	// before cutoff and returns the names of the dropped partitions.
+	// Partitions holding logs of any of the keep companies are left alone.
+	DropPartitionsBefore(ctx context.Context, cutoff time.Time, keep []string) ([]string, error)
+}
@@ -0,0 +1,31 @@
+package repository
+
+import "context"
+
+// IndexStats is the memory one index takes
+type IndexStats struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	MemoryBytes int64  `json:"memory_bytes"`
+}
+
+// CollectionStats describes the size of one collection and its indexes
+type CollectionStats struct {
+	Name          string       `json:"name"`
+	Documents     int64        `json:"documents"`
+	DocumentBytes int64        `json:"document_bytes"`
+	IndexBytes    int64        `json:"index_bytes"`
+	Indexes       []IndexStats `json:"indexes"`
+}
+
+// StorageMaintainer inspects and compacts the collections of the database
+// the service stores its data in
+type StorageMaintainer interface {
+	// CollectionStats lists the size of every collection of the service,
+	// including monthly partitions, per-company collections and the
+	// collections of optional components
+	CollectionStats(ctx context.Context) ([]CollectionStats, error)
+	// Compact reclaims the space deleted documents and their index entries
+	// still take in collection
+	Compact(ctx context.Context, collection string) error
+}
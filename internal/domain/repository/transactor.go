@@ -0,0 +1,12 @@
+package repository
+
+import "context"
+
+// Transactor runs several writes as one unit
+type Transactor interface {
+	// WithTransaction calls fn with a repository whose writes are committed
+	// together when fn returns nil and all discarded when it returns an
+	// error, which WithTransaction then returns unchanged. Reads through the
+	// repository see the transaction's own writes.
+	WithTransaction(ctx context.Context, fn func(repo ActivityLogRepository) error) error
+}
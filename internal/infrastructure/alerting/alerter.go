@@ -0,0 +1,145 @@
+// Package alerting tells operators and tenants about conditions the cron
+// server detects, by email, generic webhook and Slack incoming webhook.
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"activity-log-service/internal/infrastructure/email"
+	"activity-log-service/internal/infrastructure/metrics"
+)
+
+// Alert is one condition worth telling someone about. Kind labels it in the
+// delivery metrics and lets webhook receivers route it.
+type Alert struct {
+	Kind      string                 `json:"kind"`
+	CompanyID string                 `json:"company_id,omitempty"`
+	Subject   string                 `json:"subject"`
+	Message   string                 `json:"message"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	At        time.Time              `json:"at"`
+}
+
+// Labels of the webhook channels in the notification delivery metrics; the
+// email channel is labelled by the mailer
+const (
+	channelWebhook = "webhook"
+	channelSlack   = "slack"
+)
+
+// Config lists the channels alerts go to; empty ones are skipped
+type Config struct {
+	EmailRecipients []string
+	WebhookURL      string
+	SlackWebhookURL string
+	Timeout         time.Duration
+}
+
+// Alerter sends every alert to each configured channel
+type Alerter struct {
+	mailer     *email.Mailer
+	recipients []string
+	webhookURL string
+	slackURL   string
+	client     *http.Client
+	logger     *logrus.Logger
+}
+
+// NewAlerter sends email alerts through mailer, which may be nil when no
+// recipients are configured
+func NewAlerter(cfg Config, mailer *email.Mailer, logger *logrus.Logger) *Alerter {
+	return &Alerter{
+		mailer:     mailer,
+		recipients: cfg.EmailRecipients,
+		webhookURL: cfg.WebhookURL,
+		slackURL:   cfg.SlackWebhookURL,
+		client:     &http.Client{Timeout: cfg.Timeout},
+		logger:     logger,
+	}
+}
+
+// Send delivers alert to every channel, failing if any of them failed; the
+// others are still tried
+func (a *Alerter) Send(ctx context.Context, alert Alert) error {
+	if alert.At.IsZero() {
+		alert.At = time.Now().UTC()
+	}
+
+	var errs []error
+	if len(a.recipients) > 0 && a.mailer != nil {
+		err := a.mailer.SendAlert(ctx, a.recipients, email.AlertEmailData{
+			Kind:      alert.Kind,
+			CompanyID: alert.CompanyID,
+			Subject:   alert.Subject,
+			Message:   alert.Message,
+			Details:   alert.Details,
+			At:        alert.At,
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if a.webhookURL != "" {
+		if err := a.post(ctx, channelWebhook, "http", a.webhookURL, alert.Kind, alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if a.slackURL != "" {
+		text := fmt.Sprintf("*%s*\n%s", alert.Subject, alert.Message)
+		if err := a.post(ctx, channelSlack, "slack", a.slackURL, alert.Kind, map[string]string{"text": text}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// post sends payload as JSON to url; channel and provider label it in errors
+// and metrics
+func (a *Alerter) post(ctx context.Context, channel, provider, url, kind string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s alert: %w", channel, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build %s alert request: %w", channel, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+	err = a.do(req)
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+		a.logger.WithError(err).WithFields(logrus.Fields{
+			"channel": channel,
+			"kind":    kind,
+		}).Error("Failed to send alert")
+		err = fmt.Errorf("failed to send %s alert: %w", channel, err)
+	}
+	metrics.RecordNotificationDelivery(channel, provider, kind, outcome, time.Since(start))
+	return err
+}
+
+func (a *Alerter) do(req *http.Request) error {
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("status %d: %s", resp.StatusCode, detail)
+	}
+	return nil
+}
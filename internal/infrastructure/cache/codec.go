@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/snappy"
+)
+
+// Compression codecs for cached values
+const (
+	CompressionNone   = ""
+	CompressionSnappy = "snappy"
+	CompressionGzip   = "gzip"
+)
+
+// Compressed values start with a marker byte that JSON never starts with, so
+// values written before compression was enabled, or by an instance with it
+// off, are still read as plain JSON.
+const (
+	snappyMarker byte = 0x01
+	gzipMarker   byte = 0x02
+)
+
+// codec turns cached values into bytes and back. Values of at least minSize
+// bytes of JSON are compressed with compression; any codec is decoded on read.
+type codec struct {
+	compression string
+	minSize     int
+}
+
+func newCodec(compression string, minSize int) (codec, error) {
+	switch compression {
+	case CompressionNone, CompressionSnappy, CompressionGzip:
+		return codec{compression: compression, minSize: minSize}, nil
+	default:
+		return codec{}, fmt.Errorf("unknown cache compression %q", compression)
+	}
+}
+
+func (c codec) encode(value interface{}) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < c.minSize {
+		return data, nil
+	}
+
+	switch c.compression {
+	case CompressionSnappy:
+		return append([]byte{snappyMarker}, snappy.Encode(nil, data)...), nil
+	case CompressionGzip:
+		var buf bytes.Buffer
+		buf.WriteByte(gzipMarker)
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return data, nil
+	}
+}
+
+func (c codec) decode(data []byte, dest interface{}) error {
+	if len(data) > 0 {
+		switch data[0] {
+		case snappyMarker:
+			decoded, err := snappy.Decode(nil, data[1:])
+			if err != nil {
+				return fmt.Errorf("failed to decompress snappy value: %w", err)
+			}
+			data = decoded
+		case gzipMarker:
+			zr, err := gzip.NewReader(bytes.NewReader(data[1:]))
+			if err != nil {
+				return fmt.Errorf("failed to decompress gzip value: %w", err)
+			}
+			decoded, err := io.ReadAll(zr)
+			if err != nil {
+				return fmt.Errorf("failed to decompress gzip value: %w", err)
+			}
+			data = decoded
+		}
+	}
+	return json.Unmarshal(data, dest)
+}
@@ -0,0 +1,93 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrLockHeld is returned by Lock while another holder has the lock
+	ErrLockHeld = errors.New("lock is held by another holder")
+	// ErrLockLost is returned by Unlock and ExtendLock once the lock expired
+	// and may have been taken by someone else
+	ErrLockLost = errors.New("lock is no longer held")
+)
+
+// Only the holder's token may release or extend a lock, so a holder that
+// overran the TTL cannot drop a lock someone else has taken since
+var (
+	unlockScript = redis.NewScript(`
+		if redis.call("GET", KEYS[1]) == ARGV[1] then
+			return redis.call("DEL", KEYS[1])
+		end
+		return 0
+	`)
+	extendLockScript = redis.NewScript(`
+		if redis.call("GET", KEYS[1]) == ARGV[1] then
+			return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+		end
+		return 0
+	`)
+)
+
+// Lock takes the lock at key for ttl and returns the token to release it
+// with. The lock expires on its own if the holder dies, so ttl must outlast
+// the work it guards or be extended while the work runs. It fails with
+// ErrLockHeld, without waiting, when the lock is taken.
+func (c *RedisCache) Lock(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	token, err := lockToken()
+	if err != nil {
+		return "", err
+	}
+
+	acquired, err := c.client.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		c.logger.WithError(err).WithField("key", key).Error("Failed to take lock")
+		return "", fmt.Errorf("failed to take lock %s: %w", key, err)
+	}
+	if !acquired {
+		return "", ErrLockHeld
+	}
+	return token, nil
+}
+
+// Unlock releases the lock at key if token still holds it
+func (c *RedisCache) Unlock(ctx context.Context, key, token string) error {
+	released, err := unlockScript.Run(ctx, c.client, []string{key}, token).Int()
+	if err != nil {
+		c.logger.WithError(err).WithField("key", key).Error("Failed to release lock")
+		return fmt.Errorf("failed to release lock %s: %w", key, err)
+	}
+	if released == 0 {
+		return ErrLockLost
+	}
+	return nil
+}
+
+// ExtendLock resets the lock at key to expire ttl from now, if token still
+// holds it
+func (c *RedisCache) ExtendLock(ctx context.Context, key, token string, ttl time.Duration) error {
+	extended, err := extendLockScript.Run(ctx, c.client, []string{key}, token, ttl.Milliseconds()).Int()
+	if err != nil {
+		c.logger.WithError(err).WithField("key", key).Error("Failed to extend lock")
+		return fmt.Errorf("failed to extend lock %s: %w", key, err)
+	}
+	if extended == 0 {
+		return ErrLockLost
+	}
+	return nil
+}
+
+func lockToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate lock token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
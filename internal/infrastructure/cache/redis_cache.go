@@ -2,40 +2,64 @@ package cache
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/tls"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
+
+	"activity-log-service/internal/infrastructure/metrics"
 )
 
 type RedisCache struct {
 	client *redis.Client
+	codec  codec
 	logger *logrus.Logger
+
+	mu   sync.Mutex
+	subs []*redis.PubSub
 }
 
 type CacheConfig struct {
 	Address  string
+	Username string
 	Password string
 	DB       int
+	// TLS dials Redis over TLS when set
+	TLS *tls.Config
+	// Compression is one of the Compression codecs; values smaller than
+	// CompressMinSize bytes are stored uncompressed
+	Compression     string
+	CompressMinSize int
 }
 
-func NewRedisCache(config CacheConfig, logger *logrus.Logger) *RedisCache {
+func NewRedisCache(config CacheConfig, logger *logrus.Logger) (*RedisCache, error) {
+	codec, err := newCodec(config.Compression, config.CompressMinSize)
+	if err != nil {
+		return nil, err
+	}
+
 	client := redis.NewClient(&redis.Options{
-		Addr:     config.Address,
-		Password: config.Password,
-		DB:       config.DB,
+		Addr:      config.Address,
+		Username:  config.Username,
+		Password:  config.Password,
+		DB:        config.DB,
+		TLSConfig: config.TLS,
 	})
 
 	return &RedisCache{
 		client: client,
+		codec:  codec,
 		logger: logger,
-	}
+	}, nil
 }
 
 func (c *RedisCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
-	data, err := json.Marshal(value)
+	data, err := c.codec.encode(value)
 	if err != nil {
 		return fmt.Errorf("failed to marshal value for cache key %s: %w", key, err)
 	}
@@ -47,6 +71,7 @@ func (c *RedisCache) Set(ctx context.Context, key string, value interface{}, exp
 		}).Error("Failed to set cache value")
 		return fmt.Errorf("failed to set cache value for key %s: %w", key, err)
 	}
+	metrics.RecordCacheSet(keyClass(key))
 
 	c.logger.WithFields(logrus.Fields{
 		"key":        key,
@@ -56,9 +81,54 @@ func (c *RedisCache) Set(ctx context.Context, key string, value interface{}, exp
 	return nil
 }
 
+// Entry is one value to store with SetMany
+type Entry struct {
+	Key        string
+	Value      interface{}
+	Expiration time.Duration
+}
+
+// SetMany stores all entries in one pipelined round trip. Each entry keeps
+// its own expiration, which MSET cannot express.
+func (c *RedisCache) SetMany(ctx context.Context, entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	payloads := make([][]byte, len(entries))
+	for i, entry := range entries {
+		data, err := c.codec.encode(entry.Value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal value for cache key %s: %w", entry.Key, err)
+		}
+		payloads[i] = data
+	}
+
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, entry := range entries {
+			pipe.Set(ctx, entry.Key, payloads[i], entry.Expiration)
+		}
+		return nil
+	})
+	if err != nil {
+		c.logger.WithError(err).WithField("keys_count", len(entries)).Error("Failed to set cache values")
+		return fmt.Errorf("failed to set %d cache values: %w", len(entries), err)
+	}
+	for _, entry := range entries {
+		metrics.RecordCacheSet(keyClass(entry.Key))
+	}
+
+	c.logger.WithField("keys_count", len(entries)).Debug("Cache values set successfully")
+	return nil
+}
+
+// Get reads key into dest. Every read that does not fill dest, failed ones
+// included, counts as a miss: the caller falls back to the source either way.
 func (c *RedisCache) Get(ctx context.Context, key string, dest interface{}) error {
+	class := keyClass(key)
 	data, err := c.client.Get(ctx, key).Result()
 	if err != nil {
+		metrics.RecordCacheMiss(class)
 		if err == redis.Nil {
 			return fmt.Errorf("cache miss for key %s", key)
 		}
@@ -66,31 +136,88 @@ func (c *RedisCache) Get(ctx context.Context, key string, dest interface{}) erro
 		return fmt.Errorf("failed to get cache value for key %s: %w", key, err)
 	}
 
-	if err := json.Unmarshal([]byte(data), dest); err != nil {
+	if err := c.codec.decode([]byte(data), dest); err != nil {
+		metrics.RecordCacheMiss(class)
 		c.logger.WithError(err).WithField("key", key).Error("Failed to unmarshal cache value")
 		return fmt.Errorf("failed to unmarshal cache value for key %s: %w", key, err)
 	}
 
+	metrics.RecordCacheHit(class)
 	c.logger.WithField("key", key).Debug("Cache hit")
 	return nil
 }
 
+// GetMany reads every key in one MGET, decoding each value found into the
+// dest at the same index. It reports which keys were found; values that fail
+// to decode count as misses.
+func (c *RedisCache) GetMany(ctx context.Context, keys []string, dests []interface{}) ([]bool, error) {
+	if len(keys) != len(dests) {
+		return nil, fmt.Errorf("got %d destinations for %d cache keys", len(dests), len(keys))
+	}
+	found := make([]bool, len(keys))
+	if len(keys) == 0 {
+		return found, nil
+	}
+
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		for _, key := range keys {
+			metrics.RecordCacheMiss(keyClass(key))
+		}
+		c.logger.WithError(err).WithField("keys_count", len(keys)).Error("Failed to get cache values")
+		return nil, fmt.Errorf("failed to get %d cache values: %w", len(keys), err)
+	}
+
+	for i, value := range values {
+		class := keyClass(keys[i])
+		data, ok := value.(string)
+		if !ok {
+			metrics.RecordCacheMiss(class)
+			continue
+		}
+		if err := c.codec.decode([]byte(data), dests[i]); err != nil {
+			metrics.RecordCacheMiss(class)
+			c.logger.WithError(err).WithField("key", keys[i]).Error("Failed to unmarshal cache value")
+			continue
+		}
+		metrics.RecordCacheHit(class)
+		found[i] = true
+	}
+	return found, nil
+}
+
 func (c *RedisCache) Delete(ctx context.Context, key string) error {
 	if err := c.client.Del(ctx, key).Err(); err != nil {
 		c.logger.WithError(err).WithField("key", key).Error("Failed to delete cache value")
 		return fmt.Errorf("failed to delete cache value for key %s: %w", key, err)
 	}
+	metrics.RecordCacheInvalidation(keyClass(key))
 
 	c.logger.WithField("key", key).Debug("Cache value deleted successfully")
 	return nil
 }
 
+func (c *RedisCache) DeleteMany(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		c.logger.WithError(err).WithField("keys_count", len(keys)).Error("Failed to delete cache values")
+		return fmt.Errorf("failed to delete %d cache values: %w", len(keys), err)
+	}
+	metrics.RecordCacheInvalidation(keyClass(keys[0]))
+
+	c.logger.WithField("keys_count", len(keys)).Debug("Cache values deleted successfully")
+	return nil
+}
+
 func (c *RedisCache) DeleteByPattern(ctx context.Context, pattern string) error {
 	keys, err := c.client.Keys(ctx, pattern).Result()
 	if err != nil {
 		c.logger.WithError(err).WithField("pattern", pattern).Error("Failed to get keys by pattern")
 		return fmt.Errorf("failed to get keys by pattern %s: %w", pattern, err)
 	}
+	metrics.RecordCacheInvalidation(keyClass(pattern))
 
 	if len(keys) == 0 {
 		c.logger.WithField("pattern", pattern).Debug("No keys found for pattern")
@@ -113,6 +240,39 @@ func (c *RedisCache) DeleteByPattern(ctx context.Context, pattern string) error
 	return nil
 }
 
+// Version returns the counter at key. A missing counter starts at the
+// current time in nanoseconds rather than zero, so one lost to eviction never
+// repeats a version that keys still cached may carry.
+func (c *RedisCache) Version(ctx context.Context, key string) (int64, error) {
+	var get *redis.StringCmd
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SetNX(ctx, key, time.Now().UnixNano(), 0)
+		get = pipe.Get(ctx, key)
+		return nil
+	})
+	if err != nil {
+		c.logger.WithError(err).WithField("key", key).Error("Failed to get cache version")
+		return 0, fmt.Errorf("failed to get cache version %s: %w", key, err)
+	}
+	return get.Int64()
+}
+
+// BumpVersion moves the counter at key on, orphaning every key built with
+// the previous version. Orphaned keys are left to expire.
+func (c *RedisCache) BumpVersion(ctx context.Context, key string) error {
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SetNX(ctx, key, time.Now().UnixNano(), 0)
+		pipe.Incr(ctx, key)
+		return nil
+	})
+	if err != nil {
+		c.logger.WithError(err).WithField("key", key).Error("Failed to bump cache version")
+		return fmt.Errorf("failed to bump cache version %s: %w", key, err)
+	}
+	metrics.RecordCacheInvalidation(keyClass(key))
+	return nil
+}
+
 func (c *RedisCache) Exists(ctx context.Context, key string) (bool, error) {
 	count, err := c.client.Exists(ctx, key).Result()
 	if err != nil {
@@ -160,7 +320,45 @@ func (c *RedisCache) Ping(ctx context.Context) error {
 	return nil
 }
 
+// Publish sends message to the subscribers of channel on every instance
+func (c *RedisCache) Publish(ctx context.Context, channel, message string) error {
+	if err := c.client.Publish(ctx, channel, message).Err(); err != nil {
+		c.logger.WithError(err).WithField("channel", channel).Error("Failed to publish message")
+		return fmt.Errorf("failed to publish to %s: %w", channel, err)
+	}
+	return nil
+}
+
+// Subscribe calls fn with every message published on channel from when it
+// returns until the cache is closed. The subscription survives reconnects,
+// but messages published while Redis is unreachable are lost.
+func (c *RedisCache) Subscribe(ctx context.Context, channel string, fn func(message string)) error {
+	pubsub := c.client.Subscribe(ctx, channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return fmt.Errorf("failed to subscribe to %s: %w", channel, err)
+	}
+
+	c.mu.Lock()
+	c.subs = append(c.subs, pubsub)
+	c.mu.Unlock()
+
+	go func() {
+		for msg := range pubsub.Channel() {
+			fn(msg.Payload)
+		}
+	}()
+	return nil
+}
+
 func (c *RedisCache) Close() error {
+	c.mu.Lock()
+	for _, pubsub := range c.subs {
+		pubsub.Close()
+	}
+	c.subs = nil
+	c.mu.Unlock()
+
 	if err := c.client.Close(); err != nil {
 		c.logger.WithError(err).Error("Failed to close Redis client")
 		return fmt.Errorf("failed to close Redis client: %w", err)
@@ -175,20 +373,128 @@ func (c *RedisCache) FlushAll(ctx context.Context) error {
 		c.logger.WithError(err).Error("Failed to flush all Redis keys")
 		return fmt.Errorf("failed to flush all Redis keys: %w", err)
 	}
+	metrics.RecordCacheInvalidation("all")
 
 	c.logger.Info("All Redis keys flushed successfully")
 	return nil
 }
 
+type SortedSetMember struct {
+	Member string
+	Score  float64
+}
+
+func (c *RedisCache) AddToSortedSet(ctx context.Context, key, member string, score float64) error {
+	if err := c.client.ZAdd(ctx, key, redis.Z{Score: score, Member: member}).Err(); err != nil {
+		c.logger.WithError(err).WithFields(logrus.Fields{
+			"key":    key,
+			"member": member,
+		}).Error("Failed to add sorted set member")
+		return fmt.Errorf("failed to add member to sorted set %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// AddManyToSortedSet adds or updates several members with a single ZADD
+func (c *RedisCache) AddManyToSortedSet(ctx context.Context, key string, members []SortedSetMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	zs := make([]redis.Z, len(members))
+	for i, member := range members {
+		zs[i] = redis.Z{Score: member.Score, Member: member.Member}
+	}
+	if err := c.client.ZAdd(ctx, key, zs...).Err(); err != nil {
+		c.logger.WithError(err).WithFields(logrus.Fields{
+			"key":           key,
+			"members_count": len(members),
+		}).Error("Failed to add sorted set members")
+		return fmt.Errorf("failed to add members to sorted set %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// GetSortedSetByMinScore returns members with a score of at least min, highest score first
+func (c *RedisCache) GetSortedSetByMinScore(ctx context.Context, key string, min float64) ([]SortedSetMember, error) {
+	results, err := c.client.ZRevRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+		Min: strconv.FormatFloat(min, 'f', -1, 64),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		c.logger.WithError(err).WithField("key", key).Error("Failed to get sorted set members")
+		return nil, fmt.Errorf("failed to get members of sorted set %s: %w", key, err)
+	}
+
+	members := make([]SortedSetMember, 0, len(results))
+	for _, z := range results {
+		member, ok := z.Member.(string)
+		if !ok {
+			continue
+		}
+		members = append(members, SortedSetMember{Member: member, Score: z.Score})
+	}
+
+	return members, nil
+}
+
+// RemoveFromSortedSetBelowScore removes the members with a score below max
+// with a single ZREMRANGEBYSCORE
+func (c *RedisCache) RemoveFromSortedSetBelowScore(ctx context.Context, key string, max float64) (int, error) {
+	removed, err := c.client.ZRemRangeByScore(ctx, key, "-inf", "("+strconv.FormatFloat(max, 'f', -1, 64)).Result()
+	if err != nil {
+		c.logger.WithError(err).WithField("key", key).Error("Failed to remove sorted set members")
+		return 0, fmt.Errorf("failed to remove members from sorted set %s: %w", key, err)
+	}
+
+	return int(removed), nil
+}
+
+// keyClass is the prefix the key builders below start every key with, such
+// as activity_log or company_activity_logs. It labels the cache metrics, so
+// their cardinality stays that of the builders.
+func keyClass(key string) string {
+	class, _, _ := strings.Cut(key, ":")
+	return class
+}
+
 // Cache key builders
 func BuildActivityLogCacheKey(id string) string {
 	return fmt.Sprintf("activity_log:%s", id)
 }
 
-func BuildCompanyActivityLogsCacheKey(companyID string, page, limit int) string {
-	return fmt.Sprintf("company_activity_logs:%s:page:%d:limit:%d", companyID, page, limit)
+// BuildCompanyActivityLogsCacheKey includes the company's cache version, so
+// bumping the version invalidates every page at once
+func BuildCompanyActivityLogsCacheKey(companyID string, version int64, page, limit int) string {
+	return fmt.Sprintf("company_activity_logs:%s:v%d:page:%d:limit:%d", companyID, version, page, limit)
+}
+
+// BuildCompanyQueryCacheKey is versioned like the company's pages; hash
+// identifies the query's arguments
+func BuildCompanyQueryCacheKey(companyID string, version int64, op, hash string) string {
+	return fmt.Sprintf("company_query:%s:v%d:%s:%s", companyID, version, op, hash)
+}
+
+func BuildCompanyCacheVersionKey(companyID string) string {
+	return fmt.Sprintf("company_cache_version:%s", companyID)
 }
 
 func BuildActivityLogCountCacheKey(companyID string) string {
 	return fmt.Sprintf("activity_log_count:%s", companyID)
 }
+
+// BuildLockKey names the lock guarding name, such as a cron job
+func BuildLockKey(name string) string {
+	return fmt.Sprintf("lock:%s", name)
+}
+
+// BuildGeoIPCacheKey takes the hash of an address, never the address itself
+func BuildGeoIPCacheKey(ipHash string) string {
+	return fmt.Sprintf("geoip:%s", ipHash)
+}
+
+func BuildActiveCompaniesCacheKey() string {
+	return "active_companies"
+}
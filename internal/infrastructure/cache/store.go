@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Store is the cache the cached repository, the GeoIP resolver and the cron
+// server work against. Values are stored as JSON; Get fails for a missing key
+// as for any other error, and callers read through either way.
+type Store interface {
+	Get(ctx context.Context, key string, dest interface{}) error
+	// GetMany decodes the value of each key found into the dest at the same
+	// index and reports which were found
+	GetMany(ctx context.Context, keys []string, dests []interface{}) ([]bool, error)
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	// SetMany stores the entries together, each with its own expiration
+	SetMany(ctx context.Context, entries []Entry) error
+	Delete(ctx context.Context, key string) error
+	// DeleteMany deletes the keys in one request
+	DeleteMany(ctx context.Context, keys []string) error
+	Exists(ctx context.Context, key string) (bool, error)
+	// FlushAll drops every key in the store
+	FlushAll(ctx context.Context) error
+
+	// Version returns the counter at key, starting it when missing;
+	// BumpVersion moves it on, so keys built from the old value go unread
+	Version(ctx context.Context, key string) (int64, error)
+	BumpVersion(ctx context.Context, key string) error
+
+	AddToSortedSet(ctx context.Context, key, member string, score float64) error
+	AddManyToSortedSet(ctx context.Context, key string, members []SortedSetMember) error
+	GetSortedSetByMinScore(ctx context.Context, key string, min float64) ([]SortedSetMember, error)
+	// RemoveFromSortedSetBelowScore removes the members scoring less than max
+	// and returns how many it removed
+	RemoveFromSortedSetBelowScore(ctx context.Context, key string, max float64) (int, error)
+
+	// Publish and Subscribe broadcast messages between the instances sharing
+	// the store
+	Publish(ctx context.Context, channel, message string) error
+	Subscribe(ctx context.Context, channel string, fn func(message string)) error
+
+	// Lock takes the lock at key for ttl, failing with ErrLockHeld while it
+	// is taken, and returns the token Unlock and ExtendLock need
+	Lock(ctx context.Context, key string, ttl time.Duration) (string, error)
+	Unlock(ctx context.Context, key, token string) error
+	ExtendLock(ctx context.Context, key, token string, ttl time.Duration) error
+
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+var _ Store = (*RedisCache)(nil)
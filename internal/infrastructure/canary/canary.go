@@ -0,0 +1,235 @@
+package canary
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"activity-log-service/internal/infrastructure/messaging"
+	"activity-log-service/internal/infrastructure/metrics"
+)
+
+// Stages reported in the canary metrics
+const (
+	StageWrite    = "write"
+	StageRead     = "read"
+	StageConsumer = "consumer"
+)
+
+const pollInterval = 250 * time.Millisecond
+
+// errSLOExceeded marks a stage that did not complete within its SLO, as
+// opposed to one that failed outright
+var errSLOExceeded = errors.New("slo exceeded")
+
+type Config struct {
+	BaseURL     string
+	CompanyID   string
+	ReadSLO     time.Duration
+	ConsumerSLO time.Duration
+	Stream      string
+	Durable     string
+}
+
+// Canary writes a synthetic activity log through the public HTTP API and
+// measures how long it takes to become readable and to be processed by the
+// event consumer. A stalled stage shows up as a stale last-success gauge even
+// when no request is failing.
+type Canary struct {
+	config    Config
+	client    *http.Client
+	publisher *messaging.NATSPublisher
+	logger    *logrus.Logger
+}
+
+// NewCanary creates a canary. A nil publisher skips the consumer stage.
+func NewCanary(config Config, publisher *messaging.NATSPublisher, logger *logrus.Logger) *Canary {
+	return &Canary{
+		config:    config,
+		client:    &http.Client{Timeout: 10 * time.Second},
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+type createResponse struct {
+	ID           string `json:"id"`
+	SessionToken string `json:"session_token"`
+}
+
+// Run performs one write/read/consume probe. Each stage is recorded in the
+// metrics whether or not it succeeds; the first failing stage is returned.
+func (c *Canary) Run(ctx context.Context) error {
+	start := time.Now()
+
+	created, err := c.write(ctx, start)
+	c.record(StageWrite, time.Since(start), err)
+	if err != nil {
+		return fmt.Errorf("canary write failed: %w", err)
+	}
+
+	// Capture the stream position right after the write; once the consumer
+	// has acknowledged up to here it has seen the canary event
+	var sequence uint64
+	if c.publisher != nil {
+		sequence, err = c.publisher.StreamLastSequence(c.config.Stream)
+		if err != nil {
+			c.record(StageConsumer, 0, err)
+			return fmt.Errorf("canary failed to read stream position: %w", err)
+		}
+	}
+
+	err = c.waitReadable(ctx, created)
+	c.record(StageRead, time.Since(start), err)
+	if err != nil {
+		return fmt.Errorf("canary read failed: %w", err)
+	}
+
+	if c.publisher != nil {
+		err = c.waitConsumed(ctx, sequence)
+		c.record(StageConsumer, time.Since(start), err)
+		if err != nil {
+			return fmt.Errorf("canary consumer check failed: %w", err)
+		}
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"activity_log_id": created.ID,
+		"duration":        time.Since(start),
+	}).Debug("Canary probe succeeded")
+	return nil
+}
+
+func (c *Canary) write(ctx context.Context, now time.Time) (*createResponse, error) {
+	runID := strconv.FormatInt(now.UnixNano(), 10)
+	body, err := json.Marshal(map[string]interface{}{
+		"activity_name":     "canary_probe",
+		"company_id":        c.config.CompanyID,
+		"object_name":       "canary",
+		"object_id":         runID,
+		"formatted_message": "Canary probe " + runID,
+		"actor_id":          "canary",
+		"actor_name":        "Canary",
+		"actor_email":       "canary@activity-log-service.local",
+		"tags":              []string{"canary"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal canary request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.BaseURL+"/api/v1/activity-logs", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build canary request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send canary request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("unexpected status %d creating canary log", resp.StatusCode)
+	}
+
+	var created createResponse
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, fmt.Errorf("failed to decode canary response: %w", err)
+	}
+	if created.ID == "" {
+		return nil, fmt.Errorf("canary response has no id")
+	}
+	return &created, nil
+}
+
+func (c *Canary) waitReadable(ctx context.Context, created *createResponse) error {
+	ctx, cancel := context.WithTimeout(ctx, c.config.ReadSLO)
+	defer cancel()
+
+	return poll(ctx, func() (bool, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.config.BaseURL+"/api/v1/activity-logs/"+created.ID, nil)
+		if err != nil {
+			return false, fmt.Errorf("failed to build canary read: %w", err)
+		}
+		if created.SessionToken != "" {
+			req.Header.Set("X-Session-Token", created.SessionToken)
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			// Transient; keep polling until the SLO runs out
+			return false, nil
+		}
+		resp.Body.Close()
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+			return true, nil
+		case http.StatusNotFound:
+			return false, nil
+		default:
+			return false, fmt.Errorf("unexpected status %d reading canary log", resp.StatusCode)
+		}
+	})
+}
+
+func (c *Canary) waitConsumed(ctx context.Context, sequence uint64) error {
+	ctx, cancel := context.WithTimeout(ctx, c.config.ConsumerSLO)
+	defer cancel()
+
+	return poll(ctx, func() (bool, error) {
+		ackFloor, err := c.publisher.ConsumerAckFloor(c.config.Stream, c.config.Durable)
+		if err != nil {
+			return false, nil
+		}
+		return ackFloor >= sequence, nil
+	})
+}
+
+// poll calls check until it reports done, fails, or ctx expires
+func poll(ctx context.Context, check func() (bool, error)) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		done, err := check()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return errSLOExceeded
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Canary) record(stage string, duration time.Duration, err error) {
+	status := "success"
+	switch {
+	case errors.Is(err, errSLOExceeded):
+		status = "slo_exceeded"
+	case err != nil:
+		status = "error"
+	}
+	metrics.RecordCanaryStage(stage, status, duration)
+
+	if err != nil {
+		c.logger.WithError(err).WithField("stage", stage).Warn("Canary stage failed")
+	}
+}
@@ -0,0 +1,183 @@
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Client authentication modes accepted by ParseClientAuth
+const (
+	ClientAuthNone    = "none"
+	ClientAuthRequest = "request"
+	ClientAuthRequire = "require"
+)
+
+// ParseClientAuth maps a config value onto a tls.ClientAuthType. An empty
+// mode requires client certificates when a client CA is configured. The modes
+// that verify client certificates fail without a client CA, which would
+// otherwise verify them against the system roots.
+func ParseClientAuth(mode string, hasClientCA bool) (tls.ClientAuthType, error) {
+	switch mode {
+	case "":
+		if hasClientCA {
+			return tls.RequireAndVerifyClientCert, nil
+		}
+		return tls.NoClientCert, nil
+	case ClientAuthNone:
+		return tls.NoClientCert, nil
+	case ClientAuthRequest, ClientAuthRequire:
+		if !hasClientCA {
+			return tls.NoClientCert, fmt.Errorf("client auth mode %q needs a client CA file", mode)
+		}
+		if mode == ClientAuthRequest {
+			return tls.VerifyClientCertIfGiven, nil
+		}
+		return tls.RequireAndVerifyClientCert, nil
+	default:
+		return tls.NoClientCert, fmt.Errorf("unknown client auth mode %q", mode)
+	}
+}
+
+// Reloader serves a certificate and client CA pool loaded from disk and
+// reloads them when the files change, so rotated certificates take effect
+// without a restart. A failed reload keeps the previous material.
+type Reloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	logger       *logrus.Logger
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  map[string]time.Time
+}
+
+func NewReloader(certFile, keyFile, clientCAFile string, logger *logrus.Logger) (*Reloader, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("both cert_file and key_file are required for TLS")
+	}
+
+	r := &Reloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+		logger:       logger,
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Reloader) load() error {
+	modTimes, err := r.statFiles()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load key pair: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in client CA file %s", r.clientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *Reloader) statFiles() (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time, 3)
+	for _, path := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		modTimes[path] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+func (r *Reloader) changed() bool {
+	modTimes, err := r.statFiles()
+	if err != nil {
+		// Files may be mid-rotation; try again on the next tick
+		return false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for path, modTime := range modTimes {
+		if !modTime.Equal(r.modTimes[path]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Watch polls the files every interval until ctx is done and reloads them
+// when any of them changed
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !r.changed() {
+				continue
+			}
+			if err := r.load(); err != nil {
+				r.logger.WithError(err).Error("Failed to reload TLS certificates, keeping previous ones")
+				continue
+			}
+			r.logger.WithField("cert_file", r.certFile).Info("Reloaded TLS certificates")
+		}
+	}
+}
+
+// ServerConfig returns a TLS config that always presents the most recently
+// loaded certificate and verifies clients against the current CA pool
+func (r *Reloader) ServerConfig(clientAuth tls.ClientAuthType) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.cert},
+				ClientAuth:   clientAuth,
+				ClientCAs:    r.clientCAs,
+				NextProtos:   []string{"h2"},
+			}, nil
+		},
+	}
+}
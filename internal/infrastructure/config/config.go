@@ -2,31 +2,115 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Server  ServerConfig  `mapstructure:"server"`
-	Arango  ArangoConfig  `mapstructure:"arango"`
-	NATS    NATSConfig    `mapstructure:"nats"`
-	Logger  LoggerConfig  `mapstructure:"logger"`
-	Jaeger  JaegerConfig  `mapstructure:"jaeger"`
-	Metrics MetricsConfig `mapstructure:"metrics"`
-	Redis   RedisConfig   `mapstructure:"redis"`
-	Email   EmailConfig   `mapstructure:"email"`
-	Cron    CronConfig    `mapstructure:"cron"`
+	Server       ServerConfig       `mapstructure:"server"`
+	Storage      StorageConfig      `mapstructure:"storage"`
+	Arango       ArangoConfig       `mapstructure:"arango"`
+	NATS         NATSConfig         `mapstructure:"nats"`
+	Consumer     ConsumerConfig     `mapstructure:"consumer"`
+	Logger       LoggerConfig       `mapstructure:"logger"`
+	Jaeger       JaegerConfig       `mapstructure:"jaeger"`
+	Metrics      MetricsConfig      `mapstructure:"metrics"`
+	Redis        RedisConfig        `mapstructure:"redis"`
+	Cache        CacheConfig        `mapstructure:"cache"`
+	Email        EmailConfig        `mapstructure:"email"`
+	Cron         CronConfig         `mapstructure:"cron"`
+	Privacy      PrivacyConfig      `mapstructure:"privacy"`
+	GeoIP        GeoIPConfig        `mapstructure:"geoip"`
+	Canary       CanaryConfig       `mapstructure:"canary"`
+	Overload     OverloadConfig     `mapstructure:"overload"`
+	StatusPage   StatusPageConfig   `mapstructure:"status_page"`
+	Rollup       RollupConfig       `mapstructure:"rollup"`
+	AccessLog    AccessLogConfig    `mapstructure:"access_log"`
+	JobHistory   JobHistoryConfig   `mapstructure:"job_history"`
+	Schema       SchemaConfig       `mapstructure:"schema"`
+	Admin        AdminConfig        `mapstructure:"admin"`
+	Sampling     SamplingConfig     `mapstructure:"sampling"`
+	Retention    RetentionConfig    `mapstructure:"retention"`
+	ExportKeys   ExportKeysConfig   `mapstructure:"export_keys"`
+	Search       SearchConfig       `mapstructure:"search"`
+	Archive      ArchiveConfig      `mapstructure:"archive"`
+	DailyExport  DailyExportConfig  `mapstructure:"daily_export"`
+	CacheWarming CacheWarmingConfig `mapstructure:"cache_warming"`
+	Alerting     AlertingConfig     `mapstructure:"alerting"`
+	Anomaly      AnomalyConfig      `mapstructure:"anomaly_detection"`
+	Maintenance  MaintenanceConfig  `mapstructure:"database_maintenance"`
 }
 
 type ServerConfig struct {
-	Port              int           `mapstructure:"port"`
-	GRPCPort          int           `mapstructure:"grpc_port"`
-	Timeout           time.Duration `mapstructure:"timeout"`
-	ReadTimeout       time.Duration `mapstructure:"read_timeout"`
-	WriteTimeout      time.Duration `mapstructure:"write_timeout"`
-	MaxConnectionIdle time.Duration `mapstructure:"max_connection_idle"`
-	MaxConnectionAge  time.Duration `mapstructure:"max_connection_age"`
+	Port                int           `mapstructure:"port"`
+	GRPCPort            int           `mapstructure:"grpc_port"`
+	Timeout             time.Duration `mapstructure:"timeout"`
+	ReadTimeout         time.Duration `mapstructure:"read_timeout"`
+	WriteTimeout        time.Duration `mapstructure:"write_timeout"`
+	MaxConnectionIdle   time.Duration `mapstructure:"max_connection_idle"`
+	MaxConnectionAge    time.Duration `mapstructure:"max_connection_age"`
+	HealthCheckInterval time.Duration `mapstructure:"health_check_interval"`
+	HealthCheckTimeout  time.Duration `mapstructure:"health_check_timeout"`
+	GRPCTLS             TLSConfig     `mapstructure:"grpc_tls"`
+	// Message size limits in bytes for the gRPC server; gRPC's own default
+	// of 4MB is too small for some changes blobs
+	GRPCMaxRecvMsgSize int `mapstructure:"grpc_max_recv_msg_size"`
+	GRPCMaxSendMsgSize int `mapstructure:"grpc_max_send_msg_size"`
+	// TrustedProxies are the addresses or CIDR ranges of the gateways whose
+	// x-principal header names the caller; other callers' headers are ignored
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+}
+
+// TrustedProxyPrefixes parses TrustedProxies; a bare address trusts only
+// itself
+func (c ServerConfig) TrustedProxyPrefixes() ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(c.TrustedProxies))
+	for _, proxy := range c.TrustedProxies {
+		if addr, err := netip.ParseAddr(proxy); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid server.trusted_proxies entry %q: %w", proxy, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// TLSConfig enables TLS on a listener. Setting ClientCAFile turns on mutual
+// TLS; ClientAuth is one of none, request or require.
+type TLSConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`
+	CertFile       string        `mapstructure:"cert_file"`
+	KeyFile        string        `mapstructure:"key_file"`
+	ClientCAFile   string        `mapstructure:"client_ca_file"`
+	ClientAuth     string        `mapstructure:"client_auth"`
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+}
+
+// Storage drivers
+const (
+	StorageArango   = "arango"
+	StorageEmbedded = "embedded"
+)
+
+// StorageConfig selects where activity logs are kept. The embedded driver
+// keeps them in a single file at Path and needs no external services; it is
+// for local development only, and the components stored in their own
+// ArangoDB collections (rollups, access log, export keys) are unavailable
+// with it. AppendOnly rejects updates and deletes of stored logs; only
+// retention, archiving and the admin legal erasure remove them.
+type StorageConfig struct {
+	Driver     string `mapstructure:"driver"`
+	Path       string `mapstructure:"path"`
+	AppendOnly bool   `mapstructure:"append_only"`
 }
 
 type ArangoConfig struct {
@@ -35,16 +119,130 @@ type ArangoConfig struct {
 	Username   string `mapstructure:"username"`
 	Password   string `mapstructure:"password"`
 	Collection string `mapstructure:"collection"`
+	// FailoverURLs are tried, in order, whenever the current endpoint cannot
+	// be reached
+	FailoverURLs []string `mapstructure:"failover_urls"`
+	// ReadURLs, when set, serve list, search, export and stats queries so
+	// they stay off the coordinators taking writes. Lookups by ID and
+	// external ID still read from URL, right after the write.
+	ReadURLs []string `mapstructure:"read_urls"`
+	// Cluster is applied when the service creates the database or collection
+	Cluster ArangoClusterConfig `mapstructure:"cluster"`
+	// QueryAnnotations prefixes each AQL query with a comment naming the
+	// route, tenant hash and request ID; turn off in high-throughput mode
+	QueryAnnotations bool `mapstructure:"query_annotations"`
+	// SlowQueryThreshold logs AQL queries running longer, with sanitized
+	// bind vars; zero disables the slow query log
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"`
+	// QueryTimeout has the server kill AQL queries running longer; export
+	// cursors are exempt. Zero leaves queries unbounded.
+	QueryTimeout time.Duration `mapstructure:"query_timeout"`
+	// MaxResultWindow bounds how deep numbered pages reach, page×limit logs;
+	// deeper pages are rejected and must use cursors. Zero allows any depth.
+	MaxResultWindow int `mapstructure:"max_result_window"`
+	// Connect controls how long startup waits for ArangoDB to come up
+	Connect ArangoConnectConfig `mapstructure:"connect"`
+	// PartitionByMonth writes logs into one collection per month, named
+	// after Collection, so retention can drop whole collections
+	PartitionByMonth bool `mapstructure:"partition_by_month"`
+	// CollectionPerCompany keeps each company's logs in a collection of its
+	// own; it cannot be combined with PartitionByMonth
+	CollectionPerCompany bool `mapstructure:"collection_per_company"`
+}
+
+// Endpoints lists URL followed by the failover URLs
+func (c ArangoConfig) Endpoints() []string {
+	return append([]string{c.URL}, c.FailoverURLs...)
+}
+
+// ArangoConnectConfig retries the first contact with the server, doubling
+// the wait after each failed attempt up to MaxBackoff
+type ArangoConnectConfig struct {
+	MaxAttempts    int           `mapstructure:"max_attempts"`
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+}
+
+type ArangoClusterConfig struct {
+	NumberOfShards    int      `mapstructure:"number_of_shards"`
+	ReplicationFactor int      `mapstructure:"replication_factor"`
+	WriteConcern      int      `mapstructure:"write_concern"`
+	ShardKeys         []string `mapstructure:"shard_keys"`
 }
 
+// Event bus drivers
+const (
+	BusNATS   = "nats"
+	BusMemory = "memory"
+)
+
 type NATSConfig struct {
-	URL            string        `mapstructure:"url"`
-	Stream         string        `mapstructure:"stream"`
-	Subject        string        `mapstructure:"subject"`
+	// Driver is nats, or memory to publish events to workers in the same
+	// process; see messaging.MemoryBus
+	Driver string `mapstructure:"driver"`
+	URL    string `mapstructure:"url"`
+	// CredentialsFile is a .creds file with a user JWT and nkey seed; Token
+	// and Username/Password authenticate against plain server authorization
+	CredentialsFile string        `mapstructure:"credentials_file"`
+	Token           string        `mapstructure:"token"`
+	Username        string        `mapstructure:"username"`
+	Password        string        `mapstructure:"password"`
+	TLS             NATSTLSConfig `mapstructure:"tls"`
+	Stream          string        `mapstructure:"stream"`
+	Subject         string        `mapstructure:"subject"`
+	// UpdatedSubject and DeletedSubject receive the events of replaced and
+	// deleted logs, in the same stream; empty turns them off
+	UpdatedSubject string        `mapstructure:"updated_subject"`
+	DeletedSubject string        `mapstructure:"deleted_subject"`
 	Durable        string        `mapstructure:"durable"`
 	DeliverSubject string        `mapstructure:"deliver_subject"`
 	AckWait        time.Duration `mapstructure:"ack_wait"`
 	MaxDeliver     int           `mapstructure:"max_deliver"`
+	// QueueOnDBFailure accepts creates by publishing them to the stream only
+	// when ArangoDB is unreachable; the consumer writes them once it recovers
+	QueueOnDBFailure bool `mapstructure:"queue_on_db_failure"`
+	// SessionWaitTimeout bounds how long a read presenting a session token
+	// waits for the consumer to catch up
+	SessionWaitTimeout time.Duration `mapstructure:"session_wait_timeout"`
+	// PayloadPolicy is full, metadata or capped; see event.PayloadPolicy.
+	// Events for queued logs are always published in full.
+	PayloadPolicy   string `mapstructure:"payload_policy"`
+	MaxPayloadBytes int    `mapstructure:"max_payload_bytes"`
+	// EventEncoding is protobuf, json for consumers that predate it, or
+	// cloudevents; EventSource is the CloudEvents source attribute
+	EventEncoding string `mapstructure:"event_encoding"`
+	EventSource   string `mapstructure:"event_source"`
+	// DeadLetterSubject receives messages that failed MaxDeliver times, kept
+	// in DeadLetterStream for alctl dlq; empty disables dead-lettering
+	DeadLetterStream  string `mapstructure:"dead_letter_stream"`
+	DeadLetterSubject string `mapstructure:"dead_letter_subject"`
+}
+
+// ConsumerConfig sizes the consumer's worker pool. Messages wait in a queue
+// of QueueSize for one of Workers; each gets JobTimeout to be stored. Mode
+// "pull" instead runs Workers loops that each fetch and bulk insert up to
+// BatchSize messages at a time.
+type ConsumerConfig struct {
+	Mode       string              `mapstructure:"mode"`
+	Workers    int                 `mapstructure:"workers"`
+	QueueSize  int                 `mapstructure:"queue_size"`
+	JobTimeout time.Duration       `mapstructure:"job_timeout"`
+	BatchSize  int                 `mapstructure:"batch_size"`
+	FetchWait  time.Duration       `mapstructure:"fetch_wait"`
+	Retry      ConsumerRetryConfig `mapstructure:"retry"`
+	// MaxAckPending caps unacknowledged messages; 0 derives it from the
+	// pool. DrainTimeout bounds the drain of received messages on shutdown.
+	MaxAckPending int           `mapstructure:"max_ack_pending"`
+	DrainTimeout  time.Duration `mapstructure:"drain_timeout"`
+	// LagInterval is how often the durable's backlog is exported; 0 stops it
+	LagInterval time.Duration `mapstructure:"lag_interval"`
+}
+
+// ConsumerRetryConfig delays the redelivery of a failed message, doubling
+// the delay with each delivery up to MaxBackoff
+type ConsumerRetryConfig struct {
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
 }
 
 type LoggerConfig struct {
@@ -63,12 +261,77 @@ type JaegerConfig struct {
 type MetricsConfig struct {
 	Port int    `mapstructure:"port"`
 	Path string `mapstructure:"path"`
+	// NotificationCompanyGauge exports the last successful notification per
+	// company; it adds one series per notified company
+	NotificationCompanyGauge bool `mapstructure:"notification_company_gauge"`
 }
 
 type RedisConfig struct {
-	Address  string `mapstructure:"address"`
-	Password string `mapstructure:"password"`
-	DB       int    `mapstructure:"db"`
+	Address string `mapstructure:"address"`
+	// Username is the ACL user; leave empty to authenticate with the
+	// password alone
+	Username string         `mapstructure:"username"`
+	Password string         `mapstructure:"password"`
+	DB       int            `mapstructure:"db"`
+	TLS      RedisTLSConfig `mapstructure:"tls"`
+}
+
+// RedisTLSConfig dials Redis over TLS, as managed offerings require. CAFile
+// replaces the system roots; CertFile and KeyFile present a client
+// certificate.
+type RedisTLSConfig struct {
+	Enabled            bool   `mapstructure:"enabled"`
+	CAFile             string `mapstructure:"ca_file"`
+	CertFile           string `mapstructure:"cert_file"`
+	KeyFile            string `mapstructure:"key_file"`
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"`
+}
+
+// NATSTLSConfig dials NATS over TLS. CAFile replaces the system roots;
+// CertFile and KeyFile present a client certificate to servers that verify
+// clients.
+type NATSTLSConfig struct {
+	Enabled            bool   `mapstructure:"enabled"`
+	CAFile             string `mapstructure:"ca_file"`
+	CertFile           string `mapstructure:"cert_file"`
+	KeyFile            string `mapstructure:"key_file"`
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"`
+}
+
+// CacheConfig tunes the Redis read cache per kind of read
+type CacheConfig struct {
+	ActivityLog  CachePolicyConfig `mapstructure:"activity_log"`
+	CompanyPage  CachePolicyConfig `mapstructure:"company_page"`
+	CompanyCount CachePolicyConfig `mapstructure:"company_count"`
+	Query        CachePolicyConfig `mapstructure:"query"`
+	// Local keeps the hottest single logs in process in front of Redis;
+	// instances evict changed logs from each other's over Redis pub/sub
+	Local LocalCacheConfig `mapstructure:"local"`
+	// Compression shrinks large values, such as pages of logs with big
+	// changes, before they are stored
+	Compression CacheCompressionConfig `mapstructure:"compression"`
+	// ActiveCompaniesWindow is how far back the active companies are kept in
+	// Redis; older ones are trimmed by the cache_cleanup job and looked up in
+	// the database. Zero keeps every company.
+	ActiveCompaniesWindow time.Duration `mapstructure:"active_companies_window"`
+}
+
+// CacheCompressionConfig compresses cached values of at least MinSize bytes
+// with Codec: "snappy", "gzip", or empty for none
+type CacheCompressionConfig struct {
+	Codec   string `mapstructure:"codec"`
+	MinSize int    `mapstructure:"min_size"`
+}
+
+type LocalCacheConfig struct {
+	Enabled    bool          `mapstructure:"enabled"`
+	TTL        time.Duration `mapstructure:"ttl"`
+	MaxEntries int           `mapstructure:"max_entries"`
+}
+
+type CachePolicyConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	TTL     time.Duration `mapstructure:"ttl"`
 }
 
 type EmailConfig struct {
@@ -78,15 +341,368 @@ type EmailConfig struct {
 	Password string `mapstructure:"password"`
 	From     string `mapstructure:"from"`
 	Enabled  bool   `mapstructure:"enabled"`
+	// TemplatesDir holds templates replacing the embedded ones, and
+	// per-company overrides under companies/<company_id>
+	TemplatesDir string `mapstructure:"templates_dir"`
+	// TemplatesReloadInterval is how often TemplatesDir is checked for
+	// changes; zero loads it once at startup
+	TemplatesReloadInterval time.Duration `mapstructure:"templates_reload_interval"`
 }
 
 type CronConfig struct {
 	DailySummaryTime string `mapstructure:"daily_summary_time"`
-	CleanupInterval  string `mapstructure:"cleanup_interval"`
-	Enabled          bool   `mapstructure:"enabled"`
+	// DailySummaryRecipients lists who receives each company's daily
+	// summary; companies without an entry get none
+	DailySummaryRecipients []SummaryRecipients `mapstructure:"daily_summary_recipients"`
+	CleanupInterval        string              `mapstructure:"cleanup_interval"`
+	Enabled                bool                `mapstructure:"enabled"`
+	// LockTTL is how long a job's lock outlives its last renewal; a replica
+	// that crashed mid-job blocks that job for at most this long
+	LockTTL time.Duration `mapstructure:"lock_ttl"`
+	// LockGrace is how long a job's lock is kept once the job ends, so a
+	// replica whose clock lags by less does not run the job again; zero
+	// releases it right away
+	LockGrace time.Duration `mapstructure:"lock_grace"`
+	// AdminPort serves POST /admin/jobs/:name/run while admin is enabled,
+	// and the status page while StatusPage is set
+	AdminPort int `mapstructure:"admin_port"`
+	// StatusPage serves the scheduled jobs, their next runs and last
+	// outcomes at /status and /status.json on AdminPort, without
+	// authentication
+	StatusPage bool `mapstructure:"status_page"`
+	// Jobs overrides each job's schedule and enables or disables it, by job
+	// name
+	Jobs map[string]CronJobConfig `mapstructure:"jobs"`
+	// Retry is how every job's failed scheduled runs are retried
+	Retry RetryConfig `mapstructure:"retry"`
+	// NotifyFailures sends an alert through the alerting channels when a
+	// scheduled run failed its last attempt
+	NotifyFailures bool `mapstructure:"notify_failures"`
+}
+
+// CronJobConfig is one job's entry under cron.jobs. An empty Schedule keeps
+// the job's default: the schedule from its own section, e.g.
+// canary.schedule. A zero Timeout keeps the job's built-in timeout, and zero
+// Retry fields keep those of cron.retry.
+type CronJobConfig struct {
+	Schedule string        `mapstructure:"schedule"`
+	Enabled  bool          `mapstructure:"enabled"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+	Retry    RetryConfig   `mapstructure:"retry"`
+}
+
+// RetryConfig makes up to Attempts runs of a failed job in all, waiting
+// Backoff before the first retry and twice as long before each further one,
+// up to MaxBackoff. Each wait varies randomly by up to Jitter (a fraction)
+// of itself, so replicas and jobs failing together do not retry together.
+type RetryConfig struct {
+	Attempts   int           `mapstructure:"attempts"`
+	Backoff    time.Duration `mapstructure:"backoff"`
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+	Jitter     float64       `mapstructure:"jitter"`
+}
+
+type SummaryRecipients struct {
+	CompanyID  string   `mapstructure:"company_id"`
+	Recipients []string `mapstructure:"recipients"`
+}
+
+// SummaryRecipients returns who receives companyID's daily summary
+func (c CronConfig) SummaryRecipients(companyID string) []string {
+	for _, entry := range c.DailySummaryRecipients {
+		if entry.CompanyID == companyID {
+			return entry.Recipients
+		}
+	}
+	return nil
+}
+
+type PrivacyConfig struct {
+	HashIPAddress bool   `mapstructure:"hash_ip_address"`
+	HashUserAgent bool   `mapstructure:"hash_user_agent"`
+	HashDeviceID  bool   `mapstructure:"hash_device_id"`
+	HashSalt      string `mapstructure:"hash_salt"`
+}
+
+type GeoIPConfig struct {
+	Enabled         bool          `mapstructure:"enabled"`
+	Provider        string        `mapstructure:"provider"`
+	DatabasePath    string        `mapstructure:"database_path"`
+	DatabaseURL     string        `mapstructure:"database_url"`
+	APIURL          string        `mapstructure:"api_url"`
+	CacheTTL        time.Duration `mapstructure:"cache_ttl"`
+	RefreshSchedule string        `mapstructure:"refresh_schedule"`
+}
+
+// CanaryConfig controls the synthetic probe run by the cron server. CompanyID
+// is reserved for canary traffic and should not be used by real tenants.
+type CanaryConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	Schedule    string        `mapstructure:"schedule"`
+	BaseURL     string        `mapstructure:"base_url"`
+	CompanyID   string        `mapstructure:"company_id"`
+	ReadSLO     time.Duration `mapstructure:"read_slo"`
+	ConsumerSLO time.Duration `mapstructure:"consumer_slo"`
+}
+
+// OverloadConfig controls load shedding of read traffic on the HTTP and gRPC
+// servers. Writes and requests marked critical are never shed.
+type OverloadConfig struct {
+	Enabled      bool          `mapstructure:"enabled"`
+	P99Threshold time.Duration `mapstructure:"p99_threshold"`
+	MaxInFlight  int           `mapstructure:"max_in_flight"`
+	Window       time.Duration `mapstructure:"window"`
+	RetryAfter   time.Duration `mapstructure:"retry_after"`
+}
+
+// StatusPageConfig controls the unauthenticated /status.json endpoint.
+// RateLimit is requests per second per client IP.
+type StatusPageConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	CacheTTL  time.Duration `mapstructure:"cache_ttl"`
+	RateLimit float64       `mapstructure:"rate_limit"`
+	RateBurst int           `mapstructure:"rate_burst"`
+}
+
+// RollupConfig controls the pre-aggregated stats the cron server maintains.
+// A bucket is rolled up once it is older than RawWindow, so late writes have
+// settled; stats for the last RawWindow are always counted from raw logs.
+// CatchUpWindow bounds how far back missing buckets are filled in.
+type RollupConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`
+	Collection     string        `mapstructure:"collection"`
+	HourlySchedule string        `mapstructure:"hourly_schedule"`
+	DailySchedule  string        `mapstructure:"daily_schedule"`
+	RawWindow      time.Duration `mapstructure:"raw_window"`
+	CatchUpWindow  time.Duration `mapstructure:"catch_up_window"`
+}
+
+// SchemaConfig lists optional activity log fields, by JSON name, that writers
+// leave out of new logs until every reader has been upgraded to know them
+type SchemaConfig struct {
+	WithheldFields []string `mapstructure:"withheld_fields"`
+}
+
+// AccessLogConfig controls the record of who read which company's activity
+// data. Entries are kept in their own collection and expire after Retention.
+// Only Readers may list them.
+type AccessLogConfig struct {
+	Enabled    bool              `mapstructure:"enabled"`
+	Collection string            `mapstructure:"collection"`
+	Retention  time.Duration     `mapstructure:"retention"`
+	Readers    []AccessLogReader `mapstructure:"readers"`
+}
+
+// AccessLogReader lets Principal, a client certificate CN or x-principal
+// header, list the access log of Companies; "*" allows every company
+type AccessLogReader struct {
+	Principal string   `mapstructure:"principal"`
+	Companies []string `mapstructure:"companies"`
+}
+
+// JobHistoryConfig controls the record of cron job runs
+type JobHistoryConfig struct {
+	Enabled    bool          `mapstructure:"enabled"`
+	Collection string        `mapstructure:"collection"`
+	Retention  time.Duration `mapstructure:"retention"`
+}
+
+// AdminConfig enables the gRPC AdminService. Callers authenticate with
+// "authorization: Bearer <token>"; while it is enabled the reflection service
+// needs the token too.
+type AdminConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Token   string `mapstructure:"token"`
+}
+
+// SamplingConfig controls which logs trigger an email notification. The
+// decision hashes company, activity and object with Seed, so every replica
+// picks the same logs; all replicas must share the seed.
+type SamplingConfig struct {
+	Seed             string  `mapstructure:"seed"`
+	NotificationRate float64 `mapstructure:"notification_rate"`
+}
+
+// RetentionConfig controls how long activity logs are kept. Default applies to
+// every company without an override; zero keeps logs forever.
+type RetentionConfig struct {
+	Default   time.Duration       `mapstructure:"default"`
+	Overrides []RetentionOverride `mapstructure:"overrides"`
+	// BatchSize is the most logs log rotation deletes per query
+	BatchSize int `mapstructure:"batch_size"`
+}
+
+type RetentionOverride struct {
+	CompanyID string        `mapstructure:"company_id"`
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+// For returns the retention that applies to companyID
+func (c RetentionConfig) For(companyID string) time.Duration {
+	for _, override := range c.Overrides {
+		if override.CompanyID == companyID {
+			return override.Retention
+		}
+	}
+	return c.Default
+}
+
+// Shortest returns the shortest non-zero retention of the policy, or zero
+// when nothing ever expires
+func (c RetentionConfig) Shortest() time.Duration {
+	shortest := c.Default
+	for _, override := range c.Overrides {
+		if override.Retention > 0 && (shortest == 0 || override.Retention < shortest) {
+			shortest = override.Retention
+		}
+	}
+	return shortest
+}
+
+// Longest returns the longest retention of the policy and whether every
+// company's logs expire at all
+func (c RetentionConfig) Longest() (time.Duration, bool) {
+	longest := c.Default
+	finite := c.Default > 0
+	for _, override := range c.Overrides {
+		if override.Retention <= 0 {
+			finite = false
+		}
+		longest = max(longest, override.Retention)
+	}
+	return longest, finite
+}
+
+// ExportKeysConfig lets companies register a public key that their exports
+// are encrypted to. Keys are kept in their own collection.
+type ExportKeysConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	Collection string `mapstructure:"collection"`
+}
+
+// SearchConfig enables the secondary Elasticsearch/OpenSearch index. The
+// consumer indexes every created log; text searches and aggregations are read
+// from the index and fall back to ArangoDB when it fails.
+type SearchConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	URL      string        `mapstructure:"url"`
+	Username string        `mapstructure:"username"`
+	Password string        `mapstructure:"password"`
+	Index    string        `mapstructure:"index"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
+// Archive object stores
+const (
+	ArchiveStoreS3  = "s3"
+	ArchiveStoreDir = "dir"
+)
+
+// ArchiveConfig moves logs older than After out of ArangoDB into gzipped
+// NDJSON objects, one or more per company and run, whose manifests are kept
+// in Collection. Store is s3, which also covers GCS and MinIO, or dir for a
+// local directory at Path.
+type ArchiveConfig struct {
+	Enabled          bool            `mapstructure:"enabled"`
+	After            time.Duration   `mapstructure:"after"`
+	Schedule         string          `mapstructure:"schedule"`
+	Collection       string          `mapstructure:"collection"`
+	MaxLogsPerObject int             `mapstructure:"max_logs_per_object"`
+	Prefix           string          `mapstructure:"prefix"`
+	Store            string          `mapstructure:"store"`
+	Path             string          `mapstructure:"path"`
+	S3               ArchiveS3Config `mapstructure:"s3"`
+}
+
+// DailyExportConfig controls the daily copy of each company's logs of the
+// previous day to an object store, as gzipped NDJSON objects whose
+// manifests are kept in Collection. Store, Path and S3 are as for archive.
+type DailyExportConfig struct {
+	Enabled          bool            `mapstructure:"enabled"`
+	Schedule         string          `mapstructure:"schedule"`
+	Collection       string          `mapstructure:"collection"`
+	MaxLogsPerObject int             `mapstructure:"max_logs_per_object"`
+	MaxAttempts      int             `mapstructure:"max_attempts"`
+	Prefix           string          `mapstructure:"prefix"`
+	Store            string          `mapstructure:"store"`
+	Path             string          `mapstructure:"path"`
+	S3               ArchiveS3Config `mapstructure:"s3"`
+}
+
+// CacheWarmingConfig controls the job that reads the first listing pages and
+// the log counts of the busiest companies into the cache ahead of the
+// dashboards. Companies are ranked by their rolled up logs over Lookback, so
+// the job needs rollups. PageSizes lists the page sizes clients ask for.
+type CacheWarmingConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	Schedule  string        `mapstructure:"schedule"`
+	Companies int           `mapstructure:"companies"`
+	Lookback  time.Duration `mapstructure:"lookback"`
+	PageSizes []int         `mapstructure:"page_sizes"`
 }
 
+// AlertingConfig lists where the cron server's alerts go. Every channel
+// configured gets every alert; email needs the email section as well.
+type AlertingConfig struct {
+	EmailRecipients []string      `mapstructure:"email_recipients"`
+	WebhookURL      string        `mapstructure:"webhook_url"`
+	SlackWebhookURL string        `mapstructure:"slack_webhook_url"`
+	Timeout         time.Duration `mapstructure:"timeout"`
+}
+
+// Configured reports whether any alert channel is set
+func (c AlertingConfig) Configured() bool {
+	return len(c.EmailRecipients) > 0 || c.WebhookURL != "" || c.SlackWebhookURL != ""
+}
+
+// AnomalyConfig controls the hourly check of each company's log volume
+// against its mean over Baseline. An hour is a spike with at least
+// MinSpike logs and SpikeFactor times the mean, and a drop without logs
+// when the mean is at least MinBaseline. A company's alert of one kind is
+// repeated at most once per Cooldown, which needs the cache.
+type AnomalyConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	Schedule    string        `mapstructure:"schedule"`
+	Baseline    time.Duration `mapstructure:"baseline"`
+	SpikeFactor float64       `mapstructure:"spike_factor"`
+	MinSpike    int           `mapstructure:"min_spike"`
+	MinBaseline float64       `mapstructure:"min_baseline"`
+	Cooldown    time.Duration `mapstructure:"cooldown"`
+}
+
+// MaintenanceConfig adds optional work to the database_maintenance job,
+// which always reports collection and index sizes. Reindex creates missing
+// activity log indexes and rebuilds drifted ones; Compact compacts every
+// collection, reclaiming the space deleted logs leave behind.
+type MaintenanceConfig struct {
+	Reindex bool `mapstructure:"reindex"`
+	Compact bool `mapstructure:"compact"`
+}
+
+type ArchiveS3Config struct {
+	Endpoint        string        `mapstructure:"endpoint"`
+	Region          string        `mapstructure:"region"`
+	Bucket          string        `mapstructure:"bucket"`
+	AccessKeyID     string        `mapstructure:"access_key_id"`
+	SecretAccessKey string        `mapstructure:"secret_access_key"`
+	Timeout         time.Duration `mapstructure:"timeout"`
+}
+
+// LoadConfig loads configPath merged with the local override, if present
 func LoadConfig(configPath string) (*Config, error) {
+	return LoadProfile(configPath, "")
+}
+
+// LoadProfile loads the base file at configPath and merges overlays on top
+// of it, later layers winning:
+//
+//	configs/config.yaml          base, always required
+//	configs/config.<profile>.yaml environment overlay, required when profile is set
+//	configs/config.local.yaml    developer override, optional and not committed
+//
+// Overlays only need the keys that differ from the base. Environment
+// variables still take precedence over every file.
+func LoadProfile(configPath, profile string) (*Config, error) {
 	viper.SetConfigFile(configPath)
 
 	viper.AutomaticEnv()
@@ -98,20 +714,78 @@ func LoadConfig(configPath string) (*Config, error) {
 	viper.SetDefault("server.write_timeout", "15s")
 	viper.SetDefault("server.max_connection_idle", "5m")
 	viper.SetDefault("server.max_connection_age", "5m")
+	viper.SetDefault("server.health_check_interval", "10s")
+	viper.SetDefault("server.trusted_proxies", []string{})
+	viper.SetDefault("server.health_check_timeout", "3s")
+	viper.SetDefault("server.grpc_max_recv_msg_size", 16<<20)
+	viper.SetDefault("server.grpc_max_send_msg_size", 16<<20)
+	viper.SetDefault("server.grpc_tls.enabled", false)
+	viper.SetDefault("server.grpc_tls.client_auth", "")
+	viper.SetDefault("server.grpc_tls.reload_interval", "1m")
+
+	viper.SetDefault("storage.driver", StorageArango)
+	viper.SetDefault("storage.path", "data/activity_logs.jsonl")
+	viper.SetDefault("storage.append_only", false)
 
 	viper.SetDefault("arango.url", "http://localhost:8529")
 	viper.SetDefault("arango.database", "activity_logs")
 	viper.SetDefault("arango.username", "root")
 	viper.SetDefault("arango.password", "rootpassword")
 	viper.SetDefault("arango.collection", "activity_log")
+	viper.SetDefault("arango.cluster.number_of_shards", 3)
+	viper.SetDefault("arango.cluster.replication_factor", 2)
+	viper.SetDefault("arango.cluster.write_concern", 1)
+	viper.SetDefault("arango.cluster.shard_keys", []string{"company_id"})
+	viper.SetDefault("arango.query_annotations", true)
+	viper.SetDefault("arango.slow_query_threshold", "500ms")
+	viper.SetDefault("arango.query_timeout", "60s")
+	viper.SetDefault("arango.max_result_window", 10000)
+	viper.SetDefault("arango.failover_urls", []string{})
+	viper.SetDefault("arango.read_urls", []string{})
+	viper.SetDefault("arango.connect.max_attempts", 10)
+	viper.SetDefault("arango.connect.initial_backoff", "500ms")
+	viper.SetDefault("arango.connect.max_backoff", "15s")
+	viper.SetDefault("arango.partition_by_month", false)
+	viper.SetDefault("arango.collection_per_company", false)
 
+	viper.SetDefault("nats.driver", BusNATS)
 	viper.SetDefault("nats.url", "nats://localhost:4222")
+	viper.SetDefault("nats.credentials_file", "")
+	viper.SetDefault("nats.token", "")
+	viper.SetDefault("nats.username", "")
+	viper.SetDefault("nats.password", "")
+	viper.SetDefault("nats.tls.enabled", false)
+	viper.SetDefault("nats.tls.ca_file", "")
+	viper.SetDefault("nats.tls.cert_file", "")
+	viper.SetDefault("nats.tls.key_file", "")
+	viper.SetDefault("nats.tls.insecure_skip_verify", false)
 	viper.SetDefault("nats.stream", "ACTIVITY_LOGS")
 	viper.SetDefault("nats.subject", "activity.log.created")
+	viper.SetDefault("nats.updated_subject", "activity.log.updated")
+	viper.SetDefault("nats.deleted_subject", "activity.log.deleted")
 	viper.SetDefault("nats.durable", "activity-log-consumer")
 	viper.SetDefault("nats.deliver_subject", "activity.log.deliver")
 	viper.SetDefault("nats.ack_wait", "30s")
 	viper.SetDefault("nats.max_deliver", 3)
+	viper.SetDefault("nats.queue_on_db_failure", false)
+	viper.SetDefault("nats.session_wait_timeout", "5s")
+	viper.SetDefault("nats.payload_policy", "full")
+	viper.SetDefault("nats.max_payload_bytes", 65536)
+	viper.SetDefault("nats.event_encoding", "protobuf")
+	viper.SetDefault("nats.event_source", "/activity-log-service")
+	viper.SetDefault("nats.dead_letter_stream", "ACTIVITY_LOGS_DLQ")
+	viper.SetDefault("nats.dead_letter_subject", "activity.log.dlq")
+	viper.SetDefault("consumer.mode", "push")
+	viper.SetDefault("consumer.workers", 4)
+	viper.SetDefault("consumer.queue_size", 100)
+	viper.SetDefault("consumer.job_timeout", "30s")
+	viper.SetDefault("consumer.batch_size", 100)
+	viper.SetDefault("consumer.fetch_wait", "1s")
+	viper.SetDefault("consumer.max_ack_pending", 0)
+	viper.SetDefault("consumer.drain_timeout", "30s")
+	viper.SetDefault("consumer.lag_interval", "15s")
+	viper.SetDefault("consumer.retry.initial_backoff", "1s")
+	viper.SetDefault("consumer.retry.max_backoff", "1m")
 
 	viper.SetDefault("logger.level", "info")
 	viper.SetDefault("logger.format", "json")
@@ -124,10 +798,32 @@ func LoadConfig(configPath string) (*Config, error) {
 
 	viper.SetDefault("metrics.port", 2112)
 	viper.SetDefault("metrics.path", "/metrics")
+	viper.SetDefault("metrics.notification_company_gauge", false)
 
 	viper.SetDefault("redis.address", "localhost:6379")
+	viper.SetDefault("redis.username", "")
 	viper.SetDefault("redis.password", "")
 	viper.SetDefault("redis.db", 0)
+	viper.SetDefault("redis.tls.enabled", false)
+	viper.SetDefault("redis.tls.ca_file", "")
+	viper.SetDefault("redis.tls.cert_file", "")
+	viper.SetDefault("redis.tls.key_file", "")
+	viper.SetDefault("redis.tls.insecure_skip_verify", false)
+
+	viper.SetDefault("cache.activity_log.enabled", true)
+	viper.SetDefault("cache.activity_log.ttl", "1h")
+	viper.SetDefault("cache.company_page.enabled", true)
+	viper.SetDefault("cache.company_page.ttl", "30m")
+	viper.SetDefault("cache.company_count.enabled", true)
+	viper.SetDefault("cache.company_count.ttl", "5m")
+	viper.SetDefault("cache.query.enabled", true)
+	viper.SetDefault("cache.query.ttl", "5m")
+	viper.SetDefault("cache.local.enabled", false)
+	viper.SetDefault("cache.local.ttl", "30s")
+	viper.SetDefault("cache.local.max_entries", 10000)
+	viper.SetDefault("cache.compression.codec", "")
+	viper.SetDefault("cache.compression.min_size", 1024)
+	viper.SetDefault("cache.active_companies_window", "168h")
 
 	viper.SetDefault("email.host", "localhost")
 	viper.SetDefault("email.port", 1025)
@@ -135,15 +831,148 @@ func LoadConfig(configPath string) (*Config, error) {
 	viper.SetDefault("email.password", "")
 	viper.SetDefault("email.from", "activity-log-service@example.com")
 	viper.SetDefault("email.enabled", true)
+	viper.SetDefault("email.templates_dir", "")
+	viper.SetDefault("email.templates_reload_interval", "30s")
 
 	viper.SetDefault("cron.daily_summary_time", "08:00")
 	viper.SetDefault("cron.cleanup_interval", "24h")
 	viper.SetDefault("cron.enabled", true)
+	viper.SetDefault("cron.lock_ttl", "1m")
+	viper.SetDefault("cron.lock_grace", "5s")
+	viper.SetDefault("cron.admin_port", 8081)
+	viper.SetDefault("cron.status_page", false)
+	viper.SetDefault("cron.retry.attempts", 1)
+	viper.SetDefault("cron.retry.backoff", "1m")
+	viper.SetDefault("cron.retry.max_backoff", "30m")
+	viper.SetDefault("cron.retry.jitter", 0.2)
+	viper.SetDefault("cron.notify_failures", true)
+	for _, job := range []string{
+		"metrics_collection", "database_maintenance", "log_rotation", "cache_cleanup", "daily_summary",
+		"geoip_refresh", "canary", "rollup_hourly", "rollup_daily", "archive", "daily_export", "cache_warming", "anomaly_detection",
+	} {
+		viper.SetDefault("cron.jobs."+job+".enabled", true)
+	}
+	viper.SetDefault("cron.jobs.metrics_collection.schedule", "0 0 * * * *")
+	viper.SetDefault("cron.jobs.database_maintenance.schedule", "0 0 2 * * *")
+	viper.SetDefault("cron.jobs.log_rotation.schedule", "0 0 3 * * *")
+	viper.SetDefault("cron.jobs.cache_cleanup.schedule", "0 */5 * * * *")
+
+	viper.SetDefault("privacy.hash_ip_address", false)
+	viper.SetDefault("privacy.hash_user_agent", false)
+	viper.SetDefault("privacy.hash_device_id", false)
+	viper.SetDefault("privacy.hash_salt", "")
+
+	viper.SetDefault("geoip.enabled", false)
+	viper.SetDefault("geoip.provider", "maxmind")
+	viper.SetDefault("geoip.database_path", "data/GeoLite2-City.mmdb")
+	viper.SetDefault("geoip.database_url", "")
+	viper.SetDefault("geoip.api_url", "")
+	viper.SetDefault("geoip.cache_ttl", "24h")
+	viper.SetDefault("geoip.refresh_schedule", "0 0 4 * * 0")
+
+	viper.SetDefault("canary.enabled", false)
+	viper.SetDefault("canary.schedule", "*/30 * * * * *")
+	viper.SetDefault("canary.base_url", "http://localhost:8080")
+	viper.SetDefault("canary.company_id", "__canary__")
+	viper.SetDefault("canary.read_slo", "5s")
+	viper.SetDefault("canary.consumer_slo", "20s")
+
+	viper.SetDefault("overload.enabled", false)
+	viper.SetDefault("overload.p99_threshold", "500ms")
+	viper.SetDefault("overload.max_in_flight", 500)
+	viper.SetDefault("overload.window", "10s")
+	viper.SetDefault("overload.retry_after", "2s")
+
+	viper.SetDefault("status_page.enabled", false)
+	viper.SetDefault("status_page.cache_ttl", "30s")
+	viper.SetDefault("status_page.rate_limit", 2)
+	viper.SetDefault("status_page.rate_burst", 10)
+
+	viper.SetDefault("rollup.enabled", false)
+	viper.SetDefault("rollup.collection", "activity_stats")
+	viper.SetDefault("rollup.hourly_schedule", "0 5 * * * *")
+	viper.SetDefault("rollup.daily_schedule", "0 15 0 * * *")
+	viper.SetDefault("rollup.raw_window", "48h")
+	viper.SetDefault("rollup.catch_up_window", "168h")
+	viper.SetDefault("access_log.enabled", false)
+	viper.SetDefault("access_log.collection", "access_log")
+	viper.SetDefault("access_log.retention", "8760h")
+	viper.SetDefault("job_history.enabled", false)
+	viper.SetDefault("job_history.collection", "job_runs")
+	viper.SetDefault("job_history.retention", "2160h")
+	viper.SetDefault("schema.withheld_fields", []string{})
+	viper.SetDefault("admin.enabled", false)
+	viper.SetDefault("admin.token", "")
+	viper.SetDefault("sampling.seed", "")
+	viper.SetDefault("sampling.notification_rate", 1.0)
+	viper.SetDefault("retention.default", "0s")
+	viper.SetDefault("retention.batch_size", 10000)
+	viper.SetDefault("export_keys.enabled", false)
+	viper.SetDefault("export_keys.collection", "export_keys")
+	viper.SetDefault("search.enabled", false)
+	viper.SetDefault("search.url", "http://localhost:9200")
+	viper.SetDefault("search.index", "activity_logs")
+	viper.SetDefault("search.timeout", "5s")
+	viper.SetDefault("archive.enabled", false)
+	viper.SetDefault("archive.after", "8760h")
+	viper.SetDefault("archive.schedule", "0 30 1 * * *")
+	viper.SetDefault("archive.collection", "archive_manifests")
+	viper.SetDefault("archive.max_logs_per_object", 100000)
+	viper.SetDefault("archive.prefix", "activity-logs/")
+	viper.SetDefault("archive.store", ArchiveStoreS3)
+	viper.SetDefault("archive.path", "data/archive")
+	viper.SetDefault("archive.s3.region", "us-east-1")
+	viper.SetDefault("archive.s3.timeout", "5m")
+	viper.SetDefault("daily_export.enabled", false)
+	viper.SetDefault("daily_export.schedule", "0 0 1 * * *")
+	viper.SetDefault("daily_export.collection", "daily_exports")
+	viper.SetDefault("daily_export.max_logs_per_object", 100000)
+	viper.SetDefault("daily_export.max_attempts", 5)
+	viper.SetDefault("daily_export.prefix", "daily-exports/")
+	viper.SetDefault("daily_export.store", ArchiveStoreS3)
+	viper.SetDefault("daily_export.path", "data/exports")
+	viper.SetDefault("daily_export.s3.region", "us-east-1")
+	viper.SetDefault("daily_export.s3.timeout", "5m")
+
+	viper.SetDefault("cache_warming.enabled", false)
+	viper.SetDefault("cache_warming.schedule", "0 30 6 * * *")
+	viper.SetDefault("cache_warming.companies", 50)
+	viper.SetDefault("cache_warming.lookback", "168h")
+	viper.SetDefault("cache_warming.page_sizes", []int{10})
+
+	viper.SetDefault("alerting.timeout", "10s")
+
+	viper.SetDefault("database_maintenance.reindex", false)
+	viper.SetDefault("database_maintenance.compact", false)
+
+	viper.SetDefault("anomaly_detection.enabled", false)
+	viper.SetDefault("anomaly_detection.schedule", "0 5 * * * *")
+	viper.SetDefault("anomaly_detection.baseline", "168h")
+	viper.SetDefault("anomaly_detection.spike_factor", 5.0)
+	viper.SetDefault("anomaly_detection.min_spike", 100)
+	viper.SetDefault("anomaly_detection.min_baseline", 10.0)
+	viper.SetDefault("anomaly_detection.cooldown", "6h")
 
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	if profile != "" {
+		overlay := layerPath(configPath, profile)
+		viper.SetConfigFile(overlay)
+		if err := viper.MergeInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to merge %s profile from %s: %w", profile, overlay, err)
+		}
+	}
+
+	local := layerPath(configPath, "local")
+	if _, err := os.Stat(local); err == nil {
+		viper.SetConfigFile(local)
+		if err := viper.MergeInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to merge local overrides from %s: %w", local, err)
+		}
+	}
+
 	var config Config
 	if err := viper.Unmarshal(&config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
@@ -151,3 +980,10 @@ func LoadConfig(configPath string) (*Config, error) {
 
 	return &config, nil
 }
+
+// layerPath derives an overlay file name from the base path, e.g.
+// configs/config.yaml with layer "staging" gives configs/config.staging.yaml
+func layerPath(basePath, layer string) string {
+	ext := filepath.Ext(basePath)
+	return strings.TrimSuffix(basePath, ext) + "." + layer + ext
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/arangodb/go-driver"
+
+	"activity-log-service/internal/infrastructure/querytag"
+)
+
+// annotatedDatabase prefixes every AQL query with a comment naming the API
+// route, a hash of the tenant and the request ID, so a slow query in the
+// ArangoDB logs can be tied back to the call that ran it. The tenant is
+// hashed to keep company IDs out of the server logs.
+type annotatedDatabase struct {
+	driver.Database
+}
+
+func (d annotatedDatabase) Query(ctx context.Context, query string, bindVars map[string]interface{}) (driver.Cursor, error) {
+	return d.Database.Query(ctx, annotation(ctx, bindVars)+query, bindVars)
+}
+
+func annotation(ctx context.Context, bindVars map[string]interface{}) string {
+	tag, _ := querytag.FromContext(ctx)
+
+	var fields []string
+	if tag.Route != "" {
+		fields = append(fields, "route="+commentSafe(tag.Route))
+	}
+	if tenant := tenantHash(bindVars); tenant != "" {
+		fields = append(fields, "tenant="+tenant)
+	}
+	if tag.RequestID != "" {
+		fields = append(fields, "request_id="+commentSafe(tag.RequestID))
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return "/* " + strings.Join(fields, " ") + " */ "
+}
+
+// tenantHash is a short stable hash of the company the query is scoped to.
+// Queries name it companyID or companyId.
+func tenantHash(bindVars map[string]interface{}) string {
+	for _, key := range []string{"companyID", "companyId"} {
+		if companyID, ok := bindVars[key].(string); ok && companyID != "" {
+			sum := sha256.Sum256([]byte(companyID))
+			return hex.EncodeToString(sum[:6])
+		}
+	}
+	return ""
+}
+
+// commentSafe quotes caller supplied values such as request IDs so they
+// stay on one line and cannot close the comment early
+func commentSafe(value string) string {
+	return strconv.Quote(strings.ReplaceAll(value, "*/", "* /"))
+}
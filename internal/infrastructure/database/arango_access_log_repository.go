@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/arangodb/go-driver"
+
+	"activity-log-service/internal/domain/entity"
+)
+
+const accessLogTTLIndex = "idx_access_log_ttl"
+
+type ArangoAccessLogRepository struct {
+	database   driver.Database
+	collection driver.Collection
+}
+
+// NewArangoAccessLogRepository stores access log entries in collectionName next
+// to the logs repository. Entries expire retention after accessed_at through a
+// TTL index, which is recreated when the configured retention changes.
+func NewArangoAccessLogRepository(logs *ArangoActivityLogRepository, collectionName string, retention time.Duration, cluster ClusterOptions) (*ArangoAccessLogRepository, error) {
+	ctx := context.Background()
+
+	collection, err := logs.database.Collection(ctx, collectionName)
+	if driver.IsNotFound(err) {
+		collection, err = logs.database.CreateCollection(ctx, collectionName, cluster.collectionOptions())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create access log collection: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open access log collection: %w", err)
+	}
+
+	_, _, err = collection.EnsurePersistentIndex(ctx,
+		[]string{"company_id", "accessed_at"},
+		&driver.EnsurePersistentIndexOptions{Name: "idx_access_log_company"},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure access log index: %w", err)
+	}
+
+	if err := ensureRetention(ctx, collection, accessLogTTLIndex, "accessed_at", retention); err != nil {
+		return nil, err
+	}
+
+	return &ArangoAccessLogRepository{
+		database:   logs.database,
+		collection: collection,
+	}, nil
+}
+
+// ensureRetention keeps exactly one TTL index named indexName on field with
+// the configured expiry. ArangoDB will not change expireAfter in place, so a
+// stale index is dropped.
+func ensureRetention(ctx context.Context, collection driver.Collection, indexName, field string, retention time.Duration) error {
+	expireAfter := int(retention.Seconds())
+
+	indexes, err := collection.Indexes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list %s indexes: %w", collection.Name(), err)
+	}
+	for _, index := range indexes {
+		if index.UserName() != indexName || index.ExpireAfter() == expireAfter {
+			continue
+		}
+		if err := index.Remove(ctx); err != nil {
+			return fmt.Errorf("failed to drop stale %s retention index: %w", collection.Name(), err)
+		}
+	}
+
+	_, _, err = collection.EnsureTTLIndex(ctx, field, expireAfter,
+		&driver.EnsureTTLIndexOptions{Name: indexName},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to ensure %s retention index: %w", collection.Name(), err)
+	}
+
+	return nil
+}
+
+func (r *ArangoAccessLogRepository) Record(ctx context.Context, entry *entity.AccessLogEntry) error {
+	// The TTL index only understands ISO 8601 dates with at most millisecond
+	// precision
+	doc := *entry
+	doc.AccessedAt = entry.AccessedAt.UTC().Truncate(time.Millisecond)
+
+	if _, err := r.collection.CreateDocument(ctx, &doc); err != nil {
+		return fmt.Errorf("failed to record access log entry: %w", err)
+	}
+	return nil
+}
+
+func (r *ArangoAccessLogRepository) GetByCompanyID(ctx context.Context, companyID string, page, limit int) ([]*entity.AccessLogEntry, int, error) {
+	offset := (page - 1) * limit
+
+	query := `
+		FOR entry IN @@collection
+		FILTER entry.company_id == @companyId
+		SORT entry.accessed_at DESC
+		LIMIT @offset, @limit
+		RETURN entry
+	`
+
+	bindVars := map[string]interface{}{
+		"@collection": r.collection.Name(),
+		"companyId":   companyID,
+		"offset":      offset,
+		"limit":       limit,
+	}
+
+	cursor, err := r.database.Query(driver.WithQueryFullCount(ctx), query, bindVars)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query access log: %w", err)
+	}
+	defer cursor.Close()
+
+	var entries []*entity.AccessLogEntry
+	for cursor.HasMore() {
+		var entry entity.AccessLogEntry
+		if _, err := cursor.ReadDocument(ctx, &entry); err != nil {
+			return nil, 0, fmt.Errorf("failed to read document: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	return entries, int(cursor.Statistics().FullCount()), nil
+}
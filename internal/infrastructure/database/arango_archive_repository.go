@@ -0,0 +1,149 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/arangodb/go-driver"
+
+	"activity-log-service/internal/domain/entity"
+)
+
+type ArangoArchiveRepository struct {
+	database   driver.Database
+	collection driver.Collection
+}
+
+// archiveDocument stores a manifest under its ID
+type archiveDocument struct {
+	Key string `json:"_key"`
+	entity.ArchiveManifest
+}
+
+// NewArangoArchiveRepository stores archive manifests in collectionName next
+// to the logs repository
+func NewArangoArchiveRepository(logs *ArangoActivityLogRepository, collectionName string, cluster ClusterOptions) (*ArangoArchiveRepository, error) {
+	ctx := context.Background()
+
+	// Manifests are stored under their own _key, which a cluster refuses in
+	// a collection sharded by another attribute; there are few enough of
+	// them to shard by _key
+	cluster.ShardKeys = nil
+
+	collection, err := logs.database.Collection(ctx, collectionName)
+	if driver.IsNotFound(err) {
+		collection, err = logs.database.CreateCollection(ctx, collectionName, cluster.collectionOptions())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create archive collection: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open archive collection: %w", err)
+	}
+
+	_, _, err = collection.EnsurePersistentIndex(ctx,
+		[]string{"company_id", "to"},
+		&driver.EnsurePersistentIndexOptions{Name: "idx_archive_company"},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure archive index: %w", err)
+	}
+
+	return &ArangoArchiveRepository{
+		database:   logs.database,
+		collection: collection,
+	}, nil
+}
+
+func (r *ArangoArchiveRepository) Create(ctx context.Context, manifest *entity.ArchiveManifest) error {
+	doc := &archiveDocument{Key: manifest.ID, ArchiveManifest: *manifest}
+	if _, err := r.collection.CreateDocument(ctx, doc); err != nil {
+		return fmt.Errorf("failed to record archive manifest: %w", err)
+	}
+	return nil
+}
+
+func (r *ArangoArchiveRepository) Get(ctx context.Context, id string) (*entity.ArchiveManifest, error) {
+	var doc archiveDocument
+	_, err := r.collection.ReadDocument(ctx, id, &doc)
+	if driver.IsNotFound(err) {
+		return nil, entity.ErrArchiveNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive manifest: %w", err)
+	}
+	return &doc.ArchiveManifest, nil
+}
+
+func (r *ArangoArchiveRepository) ListByCompanyID(ctx context.Context, companyID string) ([]*entity.ArchiveManifest, error) {
+	query := `
+		FOR manifest IN @@collection
+		FILTER manifest.company_id == @companyId
+		SORT manifest.to DESC
+		RETURN manifest
+	`
+
+	bindVars := map[string]interface{}{
+		"@collection": r.collection.Name(),
+		"companyId":   companyID,
+	}
+
+	cursor, err := r.database.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archive manifests: %w", err)
+	}
+	defer cursor.Close()
+
+	var manifests []*entity.ArchiveManifest
+	for cursor.HasMore() {
+		var manifest entity.ArchiveManifest
+		if _, err := cursor.ReadDocument(ctx, &manifest); err != nil {
+			return nil, fmt.Errorf("failed to read document: %w", err)
+		}
+		manifests = append(manifests, &manifest)
+	}
+	return manifests, nil
+}
+
+func (r *ArangoArchiveRepository) MarkRestored(ctx context.Context, id string, restoredAt time.Time) error {
+	return r.update(ctx, id, map[string]interface{}{"restored_at": restoredAt.UTC(), "on_hold": true})
+}
+
+func (r *ArangoArchiveRepository) ReleaseHold(ctx context.Context, id string) error {
+	return r.update(ctx, id, map[string]interface{}{"on_hold": false})
+}
+
+func (r *ArangoArchiveRepository) ListHeldCompanies(ctx context.Context) ([]string, error) {
+	query := `
+		FOR manifest IN @@collection
+		FILTER manifest.on_hold == true
+		RETURN DISTINCT manifest.company_id
+	`
+
+	cursor, err := r.database.Query(ctx, query, map[string]interface{}{"@collection": r.collection.Name()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query held archives: %w", err)
+	}
+	defer cursor.Close()
+
+	var companies []string
+	for cursor.HasMore() {
+		var companyID string
+		if _, err := cursor.ReadDocument(ctx, &companyID); err != nil {
+			return nil, fmt.Errorf("failed to read document: %w", err)
+		}
+		companies = append(companies, companyID)
+	}
+	return companies, nil
+}
+
+func (r *ArangoArchiveRepository) update(ctx context.Context, id string, patch map[string]interface{}) error {
+	_, err := r.collection.UpdateDocument(ctx, id, patch)
+	if driver.IsNotFound(err) {
+		return entity.ErrArchiveNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update archive manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,570 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/arangodb/go-driver"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+	"activity-log-service/internal/domain/valueobject"
+)
+
+// CompanyActivityLogRepository keeps each company's logs in a collection of
+// its own, created on the company's first write. Queries for one company only
+// touch its collection, so tenants cannot see each other's data even through
+// a faulty filter, and purging a company drops its collection.
+//
+// Reads by ID and the cross-company statistics visit every company's
+// collection. Logs left in the base collection from before the mode was
+// enabled are not read.
+type CompanyActivityLogRepository struct {
+	base    *ArangoActivityLogRepository
+	cluster ClusterOptions
+	pattern *regexp.Regexp
+
+	mu sync.Mutex
+	// repos caches the companies' repositories by collection name; listedAt
+	// is when it last held every collection
+	repos    map[string]*ArangoActivityLogRepository
+	listedAt time.Time
+}
+
+func NewCompanyActivityLogRepository(base *ArangoActivityLogRepository, cluster ClusterOptions) *CompanyActivityLogRepository {
+	// A collection only ever holds one company, so sharding by company would
+	// put it on a single shard
+	cluster.ShardKeys = nil
+
+	return &CompanyActivityLogRepository{
+		base:    base,
+		cluster: cluster,
+		pattern: regexp.MustCompile("^" + regexp.QuoteMeta(base.collection.Name()) + `_c_[0-9a-f]{16}$`),
+		repos:   make(map[string]*ArangoActivityLogRepository),
+	}
+}
+
+// collectionName hashes companyID, since company IDs may hold characters
+// collection names cannot
+func (r *CompanyActivityLogRepository) collectionName(companyID string) string {
+	sum := sha256.Sum256([]byte(companyID))
+	return r.base.collection.Name() + "_c_" + hex.EncodeToString(sum[:8])
+}
+
+func (r *CompanyActivityLogRepository) repoFor(collection driver.Collection) *ArangoActivityLogRepository {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if repo, ok := r.repos[collection.Name()]; ok {
+		return repo
+	}
+	repo := &ArangoActivityLogRepository{
+		client:          r.base.client,
+		database:        r.base.database,
+		reader:          r.base.reader,
+		maxResultWindow: r.base.maxResultWindow,
+		collection:      collection,
+	}
+	r.repos[collection.Name()] = repo
+	return repo
+}
+
+// company returns the repository of companyID's collection. Without create it
+// returns nil when the company has no collection yet.
+func (r *CompanyActivityLogRepository) company(ctx context.Context, companyID string, create bool) (*ArangoActivityLogRepository, error) {
+	name := r.collectionName(companyID)
+
+	r.mu.Lock()
+	repo, ok := r.repos[name]
+	r.mu.Unlock()
+	if ok {
+		return repo, nil
+	}
+
+	collection, err := r.base.database.Collection(ctx, name)
+	if driver.IsNotFound(err) {
+		if !create {
+			return nil, nil
+		}
+		collection, err = createLogCollection(ctx, r.base.database, name, r.cluster.collectionOptions())
+	}
+	if err != nil {
+		return nil, collectionError(name, err)
+	}
+	return r.repoFor(collection), nil
+}
+
+// collectionError keeps unreachable servers recognizable as
+// ErrDatabaseUnavailable
+func collectionError(name string, err error) error {
+	if isUnavailable(err) {
+		return fmt.Errorf("failed to open collection %s: %w: %v", name, entity.ErrDatabaseUnavailable, err)
+	}
+	return fmt.Errorf("failed to open collection %s: %w", name, err)
+}
+
+// all returns the repositories of every company's collection
+func (r *CompanyActivityLogRepository) all(ctx context.Context) ([]*ArangoActivityLogRepository, error) {
+	r.mu.Lock()
+	fresh := time.Since(r.listedAt) < partitionListTTL
+	r.mu.Unlock()
+
+	if !fresh {
+		collections, err := r.base.database.Collections(ctx)
+		if err != nil {
+			return nil, collectionError(r.base.collection.Name()+"_c_*", err)
+		}
+
+		listed := make(map[string]*ArangoActivityLogRepository)
+		for _, collection := range collections {
+			if r.pattern.MatchString(collection.Name()) {
+				listed[collection.Name()] = r.repoFor(collection)
+			}
+		}
+
+		// Collections dropped elsewhere leave the cache here
+		r.mu.Lock()
+		r.repos = listed
+		r.listedAt = time.Now()
+		r.mu.Unlock()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	repos := make([]*ArangoActivityLogRepository, 0, len(r.repos))
+	for _, repo := range r.repos {
+		repos = append(repos, repo)
+	}
+	return repos, nil
+}
+
+// groupByCompany splits activityLogs by company and returns each group with
+// the input positions of its logs
+func groupByCompany(activityLogs []*entity.ActivityLog) ([]string, [][]*entity.ActivityLog, [][]int) {
+	var (
+		companies []string
+		groups    [][]*entity.ActivityLog
+		positions [][]int
+		index     = make(map[string]int)
+	)
+	for i, activityLog := range activityLogs {
+		j, ok := index[activityLog.CompanyID]
+		if !ok {
+			j = len(companies)
+			index[activityLog.CompanyID] = j
+			companies = append(companies, activityLog.CompanyID)
+			groups = append(groups, nil)
+			positions = append(positions, nil)
+		}
+		groups[j] = append(groups[j], activityLog)
+		positions[j] = append(positions[j], i)
+	}
+	return companies, groups, positions
+}
+
+// Ping verifies the server is reachable and the database can be used
+func (r *CompanyActivityLogRepository) Ping(ctx context.Context) error {
+	return r.base.Ping(ctx)
+}
+
+func (r *CompanyActivityLogRepository) Create(ctx context.Context, activityLog *entity.ActivityLog) error {
+	repo, err := r.company(ctx, activityLog.CompanyID, true)
+	if err != nil {
+		return err
+	}
+	return repo.Create(ctx, activityLog)
+}
+
+func (r *CompanyActivityLogRepository) CreateBatch(ctx context.Context, activityLogs []*entity.ActivityLog) ([]error, error) {
+	companies, groups, positions := groupByCompany(activityLogs)
+
+	errs := make([]error, len(activityLogs))
+	for j, companyID := range companies {
+		repo, err := r.company(ctx, companyID, true)
+		if err != nil {
+			return nil, err
+		}
+		groupErrs, err := repo.CreateBatch(ctx, groups[j])
+		if err != nil {
+			return nil, err
+		}
+		for k, groupErr := range groupErrs {
+			errs[positions[j][k]] = groupErr
+		}
+	}
+	return errs, nil
+}
+
+// CreateMany stores each company's logs with its own bulk inserts; if a
+// request fails outright the companies before it stay stored
+func (r *CompanyActivityLogRepository) CreateMany(ctx context.Context, activityLogs []*entity.ActivityLog) error {
+	companies, groups, positions := groupByCompany(activityLogs)
+
+	errs := make([]error, len(activityLogs))
+	rejected := false
+	for j, companyID := range companies {
+		repo, err := r.company(ctx, companyID, true)
+		if err != nil {
+			return err
+		}
+		err = repo.CreateMany(ctx, groups[j])
+		var createErr *repository.CreateManyError
+		if errors.As(err, &createErr) {
+			for k, groupErr := range createErr.Errs {
+				errs[positions[j][k]] = groupErr
+			}
+			rejected = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if rejected {
+		return &repository.CreateManyError{Errs: errs}
+	}
+	return nil
+}
+
+// CreateBatchAtomic inserts all logs in one stream transaction spanning the
+// collections of their companies
+func (r *CompanyActivityLogRepository) CreateBatchAtomic(ctx context.Context, activityLogs []*entity.ActivityLog) ([]error, error) {
+	companies, groups, positions := groupByCompany(activityLogs)
+
+	// Transactions cannot create collections, so they are set up before it
+	// starts
+	repos := make([]*ArangoActivityLogRepository, len(companies))
+	names := make([]string, len(companies))
+	for j, companyID := range companies {
+		repo, err := r.company(ctx, companyID, true)
+		if err != nil {
+			return nil, err
+		}
+		repos[j] = repo
+		names[j] = repo.collection.Name()
+	}
+	if len(repos) == 1 {
+		return repos[0].CreateBatchAtomic(ctx, activityLogs)
+	}
+
+	errs := make([]error, len(activityLogs))
+	err := runTransaction(ctx, r.base.database, names, func(tid driver.TransactionID) error {
+		rejected := false
+		for j, repo := range repos {
+			groupErrs, err := repo.inTx(tid).CreateBatch(ctx, groups[j])
+			if err != nil {
+				return err
+			}
+			for k, groupErr := range groupErrs {
+				errs[positions[j][k]] = groupErr
+				rejected = rejected || groupErr != nil
+			}
+		}
+		if rejected {
+			return errBatchRejected
+		}
+		return nil
+	})
+	if errors.Is(err, errBatchRejected) {
+		return errs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
+
+func (r *CompanyActivityLogRepository) CreateByExternalID(ctx context.Context, activityLog *entity.ActivityLog, update bool) (*entity.ActivityLog, repository.WriteOutcome, error) {
+	repo, err := r.company(ctx, activityLog.CompanyID, true)
+	if err != nil {
+		return nil, "", err
+	}
+	return repo.CreateByExternalID(ctx, activityLog, update)
+}
+
+// find returns the repository holding the log with id
+func (r *CompanyActivityLogRepository) find(ctx context.Context, id valueobject.ActivityLogID) (*ArangoActivityLogRepository, *entity.ActivityLog, error) {
+	repos, err := r.all(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, repo := range repos {
+		activityLog, err := repo.GetByID(ctx, id)
+		if errors.Is(err, entity.ErrActivityLogNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return repo, activityLog, nil
+	}
+	return nil, nil, entity.ErrActivityLogNotFound
+}
+
+// GetByID looks through every company's collection, since the ID alone does
+// not say which company the log belongs to
+func (r *CompanyActivityLogRepository) GetByID(ctx context.Context, id valueobject.ActivityLogID) (*entity.ActivityLog, error) {
+	_, activityLog, err := r.find(ctx, id)
+	return activityLog, err
+}
+
+// GetByIDs asks each company's collection for the logs not found yet
+func (r *CompanyActivityLogRepository) GetByIDs(ctx context.Context, ids []valueobject.ActivityLogID) ([]*entity.ActivityLog, error) {
+	repos, err := r.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[valueobject.ActivityLogID]*entity.ActivityLog, len(ids))
+	missing := ids
+	for _, repo := range repos {
+		if len(missing) == 0 {
+			break
+		}
+		activityLogs, err := repo.GetByIDs(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, activityLog := range activityLogs {
+			found[activityLog.ID] = activityLog
+		}
+		missing = slices.DeleteFunc(slices.Clone(missing), func(id valueobject.ActivityLogID) bool {
+			return found[id] != nil
+		})
+	}
+	return inIDOrder(ids, found), nil
+}
+
+func (r *CompanyActivityLogRepository) Update(ctx context.Context, activityLog *entity.ActivityLog) error {
+	repo, err := r.company(ctx, activityLog.CompanyID, false)
+	if err != nil {
+		return err
+	}
+	if repo == nil {
+		return entity.ErrActivityLogNotFound
+	}
+	return repo.Update(ctx, activityLog)
+}
+
+func (r *CompanyActivityLogRepository) Delete(ctx context.Context, id valueobject.ActivityLogID) error {
+	repo, _, err := r.find(ctx, id)
+	if err != nil {
+		return err
+	}
+	return repo.Delete(ctx, id)
+}
+
+func (r *CompanyActivityLogRepository) GetByCompanyID(ctx context.Context, companyID string, page, limit int) ([]*entity.ActivityLog, int, error) {
+	repo, err := r.company(ctx, companyID, false)
+	if err != nil || repo == nil {
+		return nil, 0, err
+	}
+	return repo.GetByCompanyID(ctx, companyID, page, limit)
+}
+
+func (r *CompanyActivityLogRepository) Search(ctx context.Context, filter repository.ActivityLogFilter, page repository.SearchPage) (*repository.SearchResult, error) {
+	repo, err := r.company(ctx, filter.CompanyID, false)
+	if err != nil {
+		return nil, err
+	}
+	if repo == nil {
+		return &repository.SearchResult{}, nil
+	}
+	return repo.Search(ctx, filter, page)
+}
+
+func (r *CompanyActivityLogRepository) OpenCursor(ctx context.Context, filter repository.ActivityLogFilter) (repository.ActivityLogIterator, error) {
+	repo, err := r.company(ctx, filter.CompanyID, false)
+	if err != nil {
+		return nil, err
+	}
+	if repo == nil {
+		return &sliceIterator{}, nil
+	}
+	return repo.OpenCursor(ctx, filter)
+}
+
+func (r *CompanyActivityLogRepository) Iterate(ctx context.Context, filter repository.ActivityLogFilter, fn func(*entity.ActivityLog) error) error {
+	repo, err := r.company(ctx, filter.CompanyID, false)
+	if err != nil || repo == nil {
+		return err
+	}
+	return repo.Iterate(ctx, filter, fn)
+}
+
+func (r *CompanyActivityLogRepository) DeleteOlderThan(ctx context.Context, companyID string, cutoff time.Time, limit int) (int, error) {
+	repo, err := r.company(ctx, companyID, false)
+	if err != nil || repo == nil {
+		return 0, err
+	}
+	return repo.DeleteOlderThan(ctx, companyID, cutoff, limit)
+}
+
+func (r *CompanyActivityLogRepository) CompaniesWithLogsBefore(ctx context.Context, cutoff time.Time) ([]string, error) {
+	repos, err := r.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var companies []string
+	for _, repo := range repos {
+		found, err := repo.CompaniesWithLogsBefore(ctx, cutoff)
+		if err != nil {
+			return nil, err
+		}
+		companies = append(companies, found...)
+	}
+	return companies, nil
+}
+
+// PurgeCompany drops the company's collection along with every log in it
+func (r *CompanyActivityLogRepository) PurgeCompany(ctx context.Context, companyID string) error {
+	repo, err := r.company(ctx, companyID, false)
+	if err != nil || repo == nil {
+		return err
+	}
+
+	if err := repo.collection.Remove(ctx); err != nil && !driver.IsNotFound(err) {
+		return fmt.Errorf("failed to drop collection %s: %w", repo.collection.Name(), err)
+	}
+
+	r.mu.Lock()
+	delete(r.repos, repo.collection.Name())
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *CompanyActivityLogRepository) CountByCompanyID(ctx context.Context, companyID string) (int, error) {
+	repo, err := r.company(ctx, companyID, false)
+	if err != nil || repo == nil {
+		return 0, err
+	}
+	return repo.CountByCompanyID(ctx, companyID)
+}
+
+func (r *CompanyActivityLogRepository) CountSince(ctx context.Context, since time.Time) (int, error) {
+	repos, err := r.all(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int
+	for _, repo := range repos {
+		n, err := repo.CountSince(ctx, since)
+		if err != nil {
+			return 0, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (r *CompanyActivityLogRepository) CountByCountryCode(ctx context.Context, companyID string) (map[string]int, error) {
+	repo, err := r.company(ctx, companyID, false)
+	if err != nil {
+		return nil, err
+	}
+	if repo == nil {
+		return map[string]int{}, nil
+	}
+	return repo.CountByCountryCode(ctx, companyID)
+}
+
+func (r *CompanyActivityLogRepository) GetActiveCompanies(ctx context.Context, since time.Time) ([]*entity.CompanyActivity, error) {
+	repos, err := r.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var companies []*entity.CompanyActivity
+	for _, repo := range repos {
+		found, err := repo.GetActiveCompanies(ctx, since)
+		if err != nil {
+			return nil, err
+		}
+		companies = append(companies, found...)
+	}
+	slices.SortFunc(companies, func(a, b *entity.CompanyActivity) int {
+		return b.LastActivityAt.Compare(a.LastActivityAt)
+	})
+	return companies, nil
+}
+
+func (r *CompanyActivityLogRepository) CountGrouped(ctx context.Context, filter repository.ActivityLogFilter, groupBy repository.GroupBy, limit int) ([]repository.GroupCount, error) {
+	repo, err := r.company(ctx, filter.CompanyID, false)
+	if err != nil {
+		return nil, err
+	}
+	if repo == nil {
+		return []repository.GroupCount{}, nil
+	}
+	return repo.CountGrouped(ctx, filter, groupBy, limit)
+}
+
+func (r *CompanyActivityLogRepository) CountByActivityName(ctx context.Context, companyID string, start, end time.Time) ([]repository.GroupCount, error) {
+	return r.CountGrouped(ctx, rangeFilter(companyID, start, end), repository.GroupByActivityName, 0)
+}
+
+func (r *CompanyActivityLogRepository) CountByActor(ctx context.Context, companyID string, start, end time.Time) ([]repository.GroupCount, error) {
+	return r.CountGrouped(ctx, rangeFilter(companyID, start, end), repository.GroupByActor, 0)
+}
+
+func (r *CompanyActivityLogRepository) CountPerDay(ctx context.Context, companyID string, start, end time.Time) ([]repository.GroupCount, error) {
+	return r.CountGrouped(ctx, rangeFilter(companyID, start, end), repository.GroupByDay, 0)
+}
+
+func (r *CompanyActivityLogRepository) DistinctActivityNames(ctx context.Context, companyID string) ([]string, error) {
+	repo, err := r.company(ctx, companyID, false)
+	if err != nil {
+		return nil, err
+	}
+	if repo == nil {
+		return []string{}, nil
+	}
+	return repo.DistinctActivityNames(ctx, companyID)
+}
+
+func (r *CompanyActivityLogRepository) DistinctActors(ctx context.Context, companyID string) ([]repository.Actor, error) {
+	repo, err := r.company(ctx, companyID, false)
+	if err != nil {
+		return nil, err
+	}
+	if repo == nil {
+		return []repository.Actor{}, nil
+	}
+	return repo.DistinctActors(ctx, companyID)
+}
+
+// Reindex reconciles the indexes of every company's collection. Index names
+// are prefixed with their collection.
+func (r *CompanyActivityLogRepository) Reindex(ctx context.Context) ([]repository.IndexStatus, error) {
+	repos, err := r.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []repository.IndexStatus
+	for _, repo := range repos {
+		companyStatuses, err := repo.Reindex(ctx)
+		for _, status := range companyStatuses {
+			status.Name = repo.collection.Name() + "/" + status.Name
+			statuses = append(statuses, status)
+		}
+		if err != nil {
+			return statuses, err
+		}
+	}
+	return statuses, nil
+}
+
+var (
+	_ repository.ActivityLogRepository = (*CompanyActivityLogRepository)(nil)
+	_ repository.IndexManager          = (*CompanyActivityLogRepository)(nil)
+	_ repository.CompanyPurger         = (*CompanyActivityLogRepository)(nil)
+)
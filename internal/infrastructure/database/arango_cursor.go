@@ -0,0 +1,239 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/arangodb/go-driver"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+)
+
+const cursorBatchSize = 500
+
+type arangoActivityLogIterator struct {
+	cursor driver.Cursor
+}
+
+func (it *arangoActivityLogIterator) HasMore() bool {
+	return it.cursor.HasMore()
+}
+
+func (it *arangoActivityLogIterator) Next(ctx context.Context) (*entity.ActivityLog, error) {
+	var activityLog entity.ActivityLog
+	if _, err := it.cursor.ReadDocument(ctx, &activityLog); err != nil {
+		return nil, fmt.Errorf("failed to read activity log: %w", err)
+	}
+	return &activityLog, nil
+}
+
+func (it *arangoActivityLogIterator) Close() error {
+	return it.cursor.Close()
+}
+
+// OpenCursor runs a streaming query so the server hands results back in
+// batches instead of building the full result set up front.
+func (r *ArangoActivityLogRepository) OpenCursor(ctx context.Context, filter repository.ActivityLogFilter) (repository.ActivityLogIterator, error) {
+	conditions, bindVars := buildFilterConditions(filter)
+	bindVars["@collection"] = r.collection.Name()
+
+	query := fmt.Sprintf(`
+		FOR log IN @@collection
+		FILTER %s
+		SORT log.created_at DESC
+		RETURN log
+	`, strings.Join(conditions, " AND "))
+
+	queryCtx := driver.WithQueryStream(untimed(ctx), true)
+	queryCtx = driver.WithQueryBatchSize(queryCtx, cursorBatchSize)
+
+	cursor, err := r.reader.Query(queryCtx, query, bindVars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open activity log cursor: %w", err)
+	}
+
+	return &arangoActivityLogIterator{cursor: cursor}, nil
+}
+
+func (r *ArangoActivityLogRepository) Iterate(ctx context.Context, filter repository.ActivityLogFilter, fn func(*entity.ActivityLog) error) error {
+	iterator, err := r.OpenCursor(ctx, filter)
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	for iterator.HasMore() {
+		activityLog, err := iterator.Next(ctx)
+		if err != nil {
+			return err
+		}
+		if err := fn(activityLog); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildFilterConditions(filter repository.ActivityLogFilter) ([]string, map[string]interface{}) {
+	conditions := []string{"log.company_id == @companyID"}
+	bindVars := map[string]interface{}{
+		"companyID": filter.CompanyID,
+	}
+
+	if filter.ObjectID != "" {
+		conditions = append(conditions, "log.object_id == @objectID")
+		bindVars["objectID"] = filter.ObjectID
+	}
+	if filter.ActivityName != "" {
+		conditions = append(conditions, "log.activity_name == @activityName")
+		bindVars["activityName"] = filter.ActivityName
+	}
+	if filter.ActorID != "" {
+		conditions = append(conditions, "log.actor_id == @actorID")
+		bindVars["actorID"] = filter.ActorID
+	}
+	if filter.DeviceID != "" {
+		conditions = append(conditions, "log.device_id == @deviceID")
+		bindVars["deviceID"] = filter.DeviceID
+	}
+	if filter.IPAddress != "" {
+		conditions = append(conditions, "log.ip_address == @ipAddress")
+		bindVars["ipAddress"] = filter.IPAddress
+	}
+	if filter.UserAgent != "" {
+		conditions = append(conditions, "log.user_agent == @userAgent")
+		bindVars["userAgent"] = filter.UserAgent
+	}
+	if filter.CountryCode != "" {
+		conditions = append(conditions, "log.country_code == @countryCode")
+		bindVars["countryCode"] = filter.CountryCode
+	}
+	if !filter.StartDate.IsZero() {
+		conditions = append(conditions, "log.created_at >= @startDate")
+		bindVars["startDate"] = filter.StartDate
+	}
+	if !filter.EndDate.IsZero() {
+		conditions = append(conditions, "log.created_at <= @endDate")
+		bindVars["endDate"] = filter.EndDate
+	}
+	if filter.Text != "" {
+		conditions = append(conditions, "CONTAINS(LOWER(log.formatted_message), LOWER(@text))")
+		bindVars["text"] = filter.Text
+	}
+	if len(filter.Tags) > 0 {
+		conditions = append(conditions, "@tags ALL IN (log.tags || [])")
+		bindVars["tags"] = filter.Tags
+	}
+
+	return conditions, bindVars
+}
+
+// Search returns one page of logs matching filter. Cursor pages use keyset
+// pagination, so deep pages cost the same as the first one; numbered pages
+// skip with an offset and count the total in the same query.
+func (r *ArangoActivityLogRepository) Search(ctx context.Context, filter repository.ActivityLogFilter, page repository.SearchPage) (*repository.SearchResult, error) {
+	conditions, bindVars := buildFilterConditions(filter)
+	bindVars["@collection"] = r.collection.Name()
+
+	if page.Number > 0 {
+		offset := (page.Number - 1) * page.Limit
+		activityLogs, total, err := r.findRange(ctx, filter, offset, page.Limit)
+		if err != nil {
+			return nil, err
+		}
+		return numberedResult(activityLogs, total, offset), nil
+	}
+
+	// Fetch one extra document to know whether another page follows
+	bindVars["limit"] = page.Limit + 1
+
+	if page.After != nil {
+		conditions = append(conditions, "(log.created_at < @afterCreatedAt OR (log.created_at == @afterCreatedAt AND log.id < @afterID))")
+		bindVars["afterCreatedAt"] = page.After.CreatedAt
+		bindVars["afterID"] = page.After.ID
+	}
+
+	query := fmt.Sprintf(`
+		FOR log IN @@collection
+		FILTER %s
+		SORT log.created_at DESC, log.id DESC
+		LIMIT @limit
+		RETURN log
+	`, strings.Join(conditions, " AND "))
+
+	cursor, err := r.reader.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search activity logs: %w", err)
+	}
+	defer cursor.Close()
+
+	var activityLogs []*entity.ActivityLog
+	for cursor.HasMore() {
+		var activityLog entity.ActivityLog
+		_, err := cursor.ReadDocument(ctx, &activityLog)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read activity log: %w", err)
+		}
+		activityLogs = append(activityLogs, &activityLog)
+	}
+
+	result := &repository.SearchResult{ActivityLogs: activityLogs}
+	if len(activityLogs) > page.Limit {
+		result.ActivityLogs = activityLogs[:page.Limit]
+		last := result.ActivityLogs[page.Limit-1]
+		result.Next = &repository.SearchCursor{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID.String(),
+		}
+	}
+
+	return result, nil
+}
+
+// findRange returns limit logs matching filter after skipping offset, and how
+// many match in total
+func (r *ArangoActivityLogRepository) findRange(ctx context.Context, filter repository.ActivityLogFilter, offset, limit int) ([]*entity.ActivityLog, int, error) {
+	if err := checkResultWindow(offset, limit, r.maxResultWindow); err != nil {
+		return nil, 0, err
+	}
+
+	conditions, bindVars := buildFilterConditions(filter)
+	bindVars["@collection"] = r.collection.Name()
+	bindVars["offset"] = offset
+	bindVars["limit"] = limit
+
+	query := fmt.Sprintf(`
+		FOR log IN @@collection
+		FILTER %s
+		SORT log.created_at DESC, log.id DESC
+		LIMIT @offset, @limit
+		RETURN log
+	`, strings.Join(conditions, " AND "))
+
+	return r.queryPage(ctx, query, bindVars, "search activity logs")
+}
+
+// checkResultWindow rejects a page reaching further than maxWindow logs into
+// the results. The server reads and drops every skipped log, so deep offsets
+// cost as much as returning them.
+func checkResultWindow(offset, limit, maxWindow int) error {
+	if maxWindow > 0 && offset+limit > maxWindow {
+		return &repository.ResultWindowError{Offset: offset, Limit: limit, Max: maxWindow}
+	}
+	return nil
+}
+
+// numberedResult builds a numbered page of the logs found at offset
+func numberedResult(activityLogs []*entity.ActivityLog, total, offset int) *repository.SearchResult {
+	result := &repository.SearchResult{ActivityLogs: activityLogs, Total: total}
+	if n := len(activityLogs); n > 0 && offset+n < total {
+		last := activityLogs[n-1]
+		result.Next = &repository.SearchCursor{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID.String(),
+		}
+	}
+	return result
+}
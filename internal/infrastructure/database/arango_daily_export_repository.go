@@ -0,0 +1,134 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/arangodb/go-driver"
+
+	"activity-log-service/internal/domain/entity"
+)
+
+type ArangoDailyExportRepository struct {
+	database   driver.Database
+	collection driver.Collection
+}
+
+// NewArangoDailyExportRepository stores daily export manifests in
+// collectionName next to the logs repository
+func NewArangoDailyExportRepository(logs *ArangoActivityLogRepository, collectionName string, cluster ClusterOptions) (*ArangoDailyExportRepository, error) {
+	ctx := context.Background()
+
+	collection, err := logs.database.Collection(ctx, collectionName)
+	if driver.IsNotFound(err) {
+		collection, err = logs.database.CreateCollection(ctx, collectionName, cluster.collectionOptions())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create daily export collection: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open daily export collection: %w", err)
+	}
+
+	// One manifest per company and day; also serves the UPSERT lookups
+	_, _, err = collection.EnsurePersistentIndex(ctx,
+		[]string{"company_id", "day"},
+		&driver.EnsurePersistentIndexOptions{Name: "idx_daily_export_day", Unique: true},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure daily export index: %w", err)
+	}
+	_, _, err = collection.EnsurePersistentIndex(ctx,
+		[]string{"status", "day"},
+		&driver.EnsurePersistentIndexOptions{Name: "idx_daily_export_status"},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure daily export index: %w", err)
+	}
+
+	return &ArangoDailyExportRepository{
+		database:   logs.database,
+		collection: collection,
+	}, nil
+}
+
+func (r *ArangoDailyExportRepository) Get(ctx context.Context, companyID string, day time.Time) (*entity.DailyExport, error) {
+	query := `
+		FOR export IN @@collection
+		FILTER export.company_id == @companyId AND export.day == @day
+		LIMIT 1
+		RETURN export
+	`
+
+	bindVars := map[string]interface{}{
+		"@collection": r.collection.Name(),
+		"companyId":   companyID,
+		"day":         day.UTC(),
+	}
+
+	exports, err := r.query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	if len(exports) == 0 {
+		return nil, entity.ErrDailyExportNotFound
+	}
+	return exports[0], nil
+}
+
+func (r *ArangoDailyExportRepository) Save(ctx context.Context, export *entity.DailyExport) error {
+	query := `
+		UPSERT { company_id: @doc.company_id, day: @doc.day }
+		INSERT @doc
+		REPLACE @doc
+		IN @@collection
+	`
+
+	doc := *export
+	doc.Day = export.Day.UTC()
+	bindVars := map[string]interface{}{
+		"@collection": r.collection.Name(),
+		"doc":         &doc,
+	}
+
+	cursor, err := r.database.Query(ctx, query, bindVars)
+	if err != nil {
+		return fmt.Errorf("failed to save daily export manifest: %w", err)
+	}
+	cursor.Close()
+	return nil
+}
+
+func (r *ArangoDailyExportRepository) ListFailed(ctx context.Context, maxAttempts int) ([]*entity.DailyExport, error) {
+	query := `
+		FOR export IN @@collection
+		FILTER export.status == @status AND export.attempts < @maxAttempts
+		SORT export.day ASC
+		RETURN export
+	`
+
+	bindVars := map[string]interface{}{
+		"@collection": r.collection.Name(),
+		"status":      entity.DailyExportFailed,
+		"maxAttempts": maxAttempts,
+	}
+	return r.query(ctx, query, bindVars)
+}
+
+func (r *ArangoDailyExportRepository) query(ctx context.Context, query string, bindVars map[string]interface{}) ([]*entity.DailyExport, error) {
+	cursor, err := r.database.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query daily export manifests: %w", err)
+	}
+	defer cursor.Close()
+
+	var exports []*entity.DailyExport
+	for cursor.HasMore() {
+		var export entity.DailyExport
+		if _, err := cursor.ReadDocument(ctx, &export); err != nil {
+			return nil, fmt.Errorf("failed to read document: %w", err)
+		}
+		exports = append(exports, &export)
+	}
+	return exports, nil
+}
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"activity-log-service/internal/domain/repository"
+)
+
+// DistinctActivityNames collects over idx_company_activity, so it reads the
+// index rather than the logs
+func (r *ArangoActivityLogRepository) DistinctActivityNames(ctx context.Context, companyID string) ([]string, error) {
+	query := `
+		FOR log IN @@collection
+		FILTER log.company_id == @companyID
+		COLLECT activityName = log.activity_name
+		SORT activityName
+		RETURN activityName
+	`
+	bindVars := map[string]interface{}{
+		"@collection": r.collection.Name(),
+		"companyID":   companyID,
+	}
+
+	cursor, err := r.reader.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list activity names: %w", err)
+	}
+	defer cursor.Close()
+
+	names := []string{}
+	for cursor.HasMore() {
+		var name string
+		if _, err := cursor.ReadDocument(ctx, &name); err != nil {
+			return nil, fmt.Errorf("failed to read activity name: %w", err)
+		}
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// DistinctActors takes each actor's name and email from their latest log.
+// Arrays compare element by element in AQL, and created_at sorts as text, so
+// MAX over [created_at, name, email] picks the most recent triple.
+func (r *ArangoActivityLogRepository) DistinctActors(ctx context.Context, companyID string) ([]repository.Actor, error) {
+	query := `
+		FOR log IN @@collection
+		FILTER log.company_id == @companyID
+		COLLECT actorID = log.actor_id AGGREGATE latest = MAX([log.created_at, log.actor_name, log.actor_email])
+		SORT latest[1], actorID
+		RETURN { id: actorID, name: latest[1], email: latest[2], last_seen_at: latest[0] }
+	`
+	bindVars := map[string]interface{}{
+		"@collection": r.collection.Name(),
+		"companyID":   companyID,
+	}
+
+	cursor, err := r.reader.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list actors: %w", err)
+	}
+	defer cursor.Close()
+
+	actors := []repository.Actor{}
+	for cursor.HasMore() {
+		var actor repository.Actor
+		if _, err := cursor.ReadDocument(ctx, &actor); err != nil {
+			return nil, fmt.Errorf("failed to read actor: %w", err)
+		}
+		actors = append(actors, actor)
+	}
+
+	return actors, nil
+}
+
+// mergeActors keeps the most recent entry of every actor seen in any of the
+// lists and orders them the way DistinctActors does
+func mergeActors(lists ...[]repository.Actor) []repository.Actor {
+	latest := make(map[string]repository.Actor)
+	for _, actors := range lists {
+		for _, actor := range actors {
+			if seen, ok := latest[actor.ID]; !ok || actor.LastSeenAt.After(seen.LastSeenAt) {
+				latest[actor.ID] = actor
+			}
+		}
+	}
+
+	actors := make([]repository.Actor, 0, len(latest))
+	for _, actor := range latest {
+		actors = append(actors, actor)
+	}
+	slices.SortFunc(actors, func(a, b repository.Actor) int {
+		if c := cmp.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return actors
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/arangodb/go-driver"
+
+	"activity-log-service/internal/domain/entity"
+)
+
+type ArangoExportKeyRepository struct {
+	collection driver.Collection
+}
+
+// exportKeyDocument stores a key under a document key derived from the
+// company ID, which may hold characters ArangoDB does not allow in _key
+type exportKeyDocument struct {
+	Key string `json:"_key"`
+	entity.ExportKey
+}
+
+// NewArangoExportKeyRepository stores export keys in collectionName next to
+// the logs repository
+func NewArangoExportKeyRepository(logs *ArangoActivityLogRepository, collectionName string, cluster ClusterOptions) (*ArangoExportKeyRepository, error) {
+	ctx := context.Background()
+
+	// Keys are stored under a _key derived from the company, which a cluster
+	// refuses in a collection sharded by another attribute; there is one per
+	// company at most, so shard by _key
+	cluster.ShardKeys = nil
+
+	collection, err := logs.database.Collection(ctx, collectionName)
+	if driver.IsNotFound(err) {
+		collection, err = logs.database.CreateCollection(ctx, collectionName, cluster.collectionOptions())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create export key collection: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open export key collection: %w", err)
+	}
+
+	return &ArangoExportKeyRepository{collection: collection}, nil
+}
+
+func exportKeyDocumentKey(companyID string) string {
+	sum := sha256.Sum256([]byte(companyID))
+	return hex.EncodeToString(sum[:])
+}
+
+func (r *ArangoExportKeyRepository) Put(ctx context.Context, key *entity.ExportKey) error {
+	doc := &exportKeyDocument{Key: exportKeyDocumentKey(key.CompanyID), ExportKey: *key}
+	if _, err := r.collection.CreateDocument(driver.WithOverwriteMode(ctx, driver.OverwriteModeReplace), doc); err != nil {
+		return fmt.Errorf("failed to store export key: %w", err)
+	}
+	return nil
+}
+
+func (r *ArangoExportKeyRepository) Get(ctx context.Context, companyID string) (*entity.ExportKey, error) {
+	var doc exportKeyDocument
+	_, err := r.collection.ReadDocument(ctx, exportKeyDocumentKey(companyID), &doc)
+	if driver.IsNotFound(err) {
+		return nil, entity.ErrExportKeyNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export key: %w", err)
+	}
+	return &doc.ExportKey, nil
+}
+
+func (r *ArangoExportKeyRepository) Delete(ctx context.Context, companyID string) error {
+	_, err := r.collection.RemoveDocument(ctx, exportKeyDocumentKey(companyID))
+	if driver.IsNotFound(err) {
+		return entity.ErrExportKeyNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete export key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arangodb/go-driver"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+)
+
+func (r *ArangoActivityLogRepository) CreateByExternalID(ctx context.Context, activityLog *entity.ActivityLog, update bool) (*entity.ActivityLog, repository.WriteOutcome, error) {
+	if activityLog.ExternalID == "" {
+		return nil, "", fmt.Errorf("external id is required: %w", entity.ErrInvalidExternalID)
+	}
+
+	if update {
+		return r.upsertByExternalID(ctx, activityLog)
+	}
+
+	// Insert first and let the unique index settle races between producers
+	// re-sending the same log
+	_, err := r.collection.CreateDocument(ctx, activityLog)
+	if err == nil {
+		return activityLog, repository.WriteCreated, nil
+	}
+	if !driver.IsConflict(err) {
+		return nil, "", createError(err)
+	}
+
+	existing, err := r.getByExternalID(ctx, activityLog.CompanyID, activityLog.ExternalID)
+	if err != nil {
+		return nil, "", err
+	}
+	return existing, repository.WriteSkipped, nil
+}
+
+// upsertByExternalID inserts or replaces the log in a single AQL UPSERT, so
+// there is no window between a rejected insert and the replace for the log
+// to disappear in. The lookup and the write are still not atomic: when two
+// producers insert the same external ID at once the unique index rejects
+// one of them, and that one runs again to find and replace the other's log.
+func (r *ArangoActivityLogRepository) upsertByExternalID(ctx context.Context, activityLog *entity.ActivityLog) (*entity.ActivityLog, repository.WriteOutcome, error) {
+	query := `
+		UPSERT { company_id: @companyId, external_id: @externalId }
+		INSERT @log
+		REPLACE MERGE(@log, { id: OLD.id, created_at: OLD.created_at })
+		IN @@collection
+		RETURN { log: NEW, created: OLD == null }
+	`
+	bindVars := map[string]interface{}{
+		"@collection": r.collection.Name(),
+		"companyId":   activityLog.CompanyID,
+		"externalId":  activityLog.ExternalID,
+		"log":         activityLog,
+	}
+
+	var result struct {
+		Log     entity.ActivityLog `json:"log"`
+		Created bool               `json:"created"`
+	}
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if err = r.queryOne(ctx, query, bindVars, &result); !driver.IsConflict(err) {
+			break
+		}
+	}
+	if err != nil {
+		if isUnavailable(err) {
+			return nil, "", fmt.Errorf("failed to upsert activity log by external id: %w: %v", entity.ErrDatabaseUnavailable, err)
+		}
+		return nil, "", fmt.Errorf("failed to upsert activity log by external id: %w", err)
+	}
+
+	if result.Created {
+		return activityLog, repository.WriteCreated, nil
+	}
+	return &result.Log, repository.WriteUpdated, nil
+}
+
+// queryOne runs a query that returns exactly one document into result
+func (r *ArangoActivityLogRepository) queryOne(ctx context.Context, query string, bindVars map[string]interface{}, result interface{}) error {
+	cursor, err := r.database.Query(ctx, query, bindVars)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	_, err = cursor.ReadDocument(ctx, result)
+	return err
+}
+
+func (r *ArangoActivityLogRepository) getByExternalID(ctx context.Context, companyID, externalID string) (*entity.ActivityLog, error) {
+	query := `
+		FOR log IN @@collection
+		FILTER log.company_id == @companyId AND log.external_id == @externalId
+		LIMIT 1
+		RETURN log
+	`
+	bindVars := map[string]interface{}{
+		"@collection": r.collection.Name(),
+		"companyId":   companyID,
+		"externalId":  externalID,
+	}
+
+	activityLog, err := r.readOne(ctx, query, bindVars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity log by external id: %w", err)
+	}
+	return activityLog, nil
+}
+
+// readOne runs a query expected to return a single log
+func (r *ArangoActivityLogRepository) readOne(ctx context.Context, query string, bindVars map[string]interface{}) (*entity.ActivityLog, error) {
+	cursor, err := r.database.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	if !cursor.HasMore() {
+		// Removed, e.g. between a rejected insert and this read
+		return nil, entity.ErrActivityLogNotFound
+	}
+
+	var activityLog entity.ActivityLog
+	if _, err := cursor.ReadDocument(ctx, &activityLog); err != nil {
+		return nil, err
+	}
+	return &activityLog, nil
+}
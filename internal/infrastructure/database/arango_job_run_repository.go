@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/arangodb/go-driver"
+
+	"activity-log-service/internal/domain/entity"
+)
+
+const jobRunTTLIndex = "idx_job_runs_ttl"
+
+type ArangoJobRunRepository struct {
+	database   driver.Database
+	collection driver.Collection
+}
+
+// NewArangoJobRunRepository stores cron job runs in collectionName next to
+// the logs repository. Runs expire retention after started_at through a TTL
+// index.
+func NewArangoJobRunRepository(logs *ArangoActivityLogRepository, collectionName string, retention time.Duration, cluster ClusterOptions) (*ArangoJobRunRepository, error) {
+	ctx := context.Background()
+
+	collection, err := logs.database.Collection(ctx, collectionName)
+	if driver.IsNotFound(err) {
+		collection, err = logs.database.CreateCollection(ctx, collectionName, cluster.collectionOptions())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create job run collection: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open job run collection: %w", err)
+	}
+
+	for name, fields := range map[string][]string{
+		"idx_job_runs_job":     {"job", "started_at"},
+		"idx_job_runs_started": {"started_at"},
+	} {
+		_, _, err = collection.EnsurePersistentIndex(ctx, fields, &driver.EnsurePersistentIndexOptions{Name: name})
+		if err != nil {
+			return nil, fmt.Errorf("failed to ensure job run index: %w", err)
+		}
+	}
+
+	if err := ensureRetention(ctx, collection, jobRunTTLIndex, "started_at", retention); err != nil {
+		return nil, err
+	}
+
+	return &ArangoJobRunRepository{
+		database:   logs.database,
+		collection: collection,
+	}, nil
+}
+
+func (r *ArangoJobRunRepository) Record(ctx context.Context, run *entity.JobRun) error {
+	// The TTL index only understands ISO 8601 dates with at most millisecond
+	// precision
+	doc := *run
+	doc.StartedAt = run.StartedAt.UTC().Truncate(time.Millisecond)
+	doc.FinishedAt = run.FinishedAt.UTC()
+
+	if _, err := r.collection.CreateDocument(ctx, &doc); err != nil {
+		return fmt.Errorf("failed to record job run: %w", err)
+	}
+	return nil
+}
+
+func (r *ArangoJobRunRepository) List(ctx context.Context, job string, limit int) ([]*entity.JobRun, error) {
+	bindVars := map[string]interface{}{
+		"@collection": r.collection.Name(),
+		"limit":       limit,
+	}
+	filter := ""
+	if job != "" {
+		filter = "FILTER run.job == @job"
+		bindVars["job"] = job
+	}
+
+	query := fmt.Sprintf(`
+		FOR run IN @@collection
+		%s
+		SORT run.started_at DESC
+		LIMIT @limit
+		RETURN run
+	`, filter)
+
+	cursor, err := r.database.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job runs: %w", err)
+	}
+	defer cursor.Close()
+
+	var runs []*entity.JobRun
+	for cursor.HasMore() {
+		var run entity.JobRun
+		if _, err := cursor.ReadDocument(ctx, &run); err != nil {
+			return nil, fmt.Errorf("failed to read document: %w", err)
+		}
+		runs = append(runs, &run)
+	}
+	return runs, nil
+}
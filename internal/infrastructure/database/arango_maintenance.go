@@ -0,0 +1,118 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"activity-log-service/internal/domain/repository"
+)
+
+var _ repository.StorageMaintainer = (*ArangoActivityLogRepository)(nil)
+
+// CollectionStats reports every collection of the database except the
+// system ones. Sizes are what the storage engine estimates, so they lag
+// behind recent writes.
+func (r *ArangoActivityLogRepository) CollectionStats(ctx context.Context) ([]repository.CollectionStats, error) {
+	collections, err := r.database.Collections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collections: %w", err)
+	}
+
+	var stats []repository.CollectionStats
+	for _, collection := range collections {
+		if strings.HasPrefix(collection.Name(), "_") {
+			continue
+		}
+
+		figures, err := collection.Statistics(ctx)
+		if err != nil {
+			return stats, fmt.Errorf("failed to get figures of %s: %w", collection.Name(), err)
+		}
+		indexes, err := r.indexStats(ctx, collection.Name())
+		if err != nil {
+			return stats, err
+		}
+
+		collectionStats := repository.CollectionStats{
+			Name:       collection.Name(),
+			Documents:  figures.Count,
+			IndexBytes: figures.Figures.Indexes.Size,
+			Indexes:    indexes,
+		}
+		if figures.Figures.DocumentsSize != nil {
+			collectionStats.DocumentBytes = *figures.Figures.DocumentsSize
+		}
+		stats = append(stats, collectionStats)
+	}
+	return stats, nil
+}
+
+// indexStats reads the figures of the collection's indexes, which the
+// driver does not expose
+func (r *ArangoActivityLogRepository) indexStats(ctx context.Context, collection string) ([]repository.IndexStats, error) {
+	conn := r.client.Connection()
+	req, err := conn.NewRequest("GET", r.apiPath("index"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build index stats request: %w", err)
+	}
+	req.SetQuery("collection", collection)
+	req.SetQuery("withStats", "true")
+
+	resp, err := conn.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get index stats of %s: %w", collection, err)
+	}
+	if err := resp.CheckStatus(200); err != nil {
+		return nil, fmt.Errorf("failed to get index stats of %s: %w", collection, err)
+	}
+
+	var data struct {
+		Indexes []struct {
+			Name    string `json:"name"`
+			Type    string `json:"type"`
+			Figures struct {
+				Memory int64 `json:"memory"`
+			} `json:"figures"`
+		} `json:"indexes"`
+	}
+	if err := resp.ParseBody("", &data); err != nil {
+		return nil, fmt.Errorf("failed to decode index stats of %s: %w", collection, err)
+	}
+
+	stats := make([]repository.IndexStats, len(data.Indexes))
+	for i, index := range data.Indexes {
+		stats[i] = repository.IndexStats{Name: index.Name, Type: index.Type, MemoryBytes: index.Figures.Memory}
+	}
+	return stats, nil
+}
+
+// Compact asks the storage engine to compact the collection's documents and
+// indexes. It runs synchronously and can take long on large collections.
+func (r *ArangoActivityLogRepository) Compact(ctx context.Context, collection string) error {
+	conn := r.client.Connection()
+	req, err := conn.NewRequest("PUT", r.apiPath("collection", collection, "compact"))
+	if err != nil {
+		return fmt.Errorf("failed to build compact request: %w", err)
+	}
+
+	resp, err := conn.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to compact %s: %w", collection, err)
+	}
+	if err := resp.CheckStatus(200); err != nil {
+		return fmt.Errorf("failed to compact %s: %w", collection, err)
+	}
+	return nil
+}
+
+// apiPath is the path of an HTTP API endpoint in the repository's database
+func (r *ArangoActivityLogRepository) apiPath(elements ...string) string {
+	escaped := []string{"_db", url.PathEscape(r.database.Name()), "_api"}
+	for _, element := range elements {
+		escaped = append(escaped, url.PathEscape(element))
+	}
+	return path.Join(escaped...)
+}
@@ -0,0 +1,928 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/arangodb/go-driver"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+	"activity-log-service/internal/domain/valueobject"
+)
+
+// partitionListTTL bounds how long a replica can miss a partition another
+// replica created
+const partitionListTTL = 30 * time.Second
+
+// partition is one collection of a partitioned repository. The base
+// collection holds the logs written before partitioning was enabled and has
+// no time range.
+type partition struct {
+	name  string
+	start time.Time
+	end   time.Time
+	repo  *ArangoActivityLogRepository
+}
+
+func (p *partition) isBase() bool {
+	return p.start.IsZero()
+}
+
+// overlaps reports whether the partition can hold logs created between start
+// and end; zero bounds are open
+func (p *partition) overlaps(start, end time.Time) bool {
+	if p.isBase() {
+		return true
+	}
+	if !start.IsZero() && !p.end.After(start) {
+		return false
+	}
+	return end.IsZero() || !p.start.After(end)
+}
+
+// PartitionedActivityLogRepository writes each log into the collection of
+// the month it was created in, e.g. activity_log_2024_06, and fans queries
+// out over the partitions their date range touches. Partitions are read
+// newest first, so list queries stop as soon as a page is full; logs written
+// to the base collection before partitioning was enabled are read last.
+// Reads by ID look through every partition.
+type PartitionedActivityLogRepository struct {
+	base    *ArangoActivityLogRepository
+	cluster ClusterOptions
+	pattern *regexp.Regexp
+
+	mu         sync.Mutex
+	partitions []*partition
+	listedAt   time.Time
+
+	// inTransaction is set on the repository WithTransaction hands out; its
+	// partitions are fixed to the ones the transaction declared
+	inTransaction bool
+}
+
+func NewPartitionedActivityLogRepository(base *ArangoActivityLogRepository, cluster ClusterOptions) *PartitionedActivityLogRepository {
+	return &PartitionedActivityLogRepository{
+		base:    base,
+		cluster: cluster,
+		pattern: regexp.MustCompile("^" + regexp.QuoteMeta(base.collection.Name()) + `_(\d{4})_(\d{2})$`),
+	}
+}
+
+func (r *PartitionedActivityLogRepository) partitionName(month time.Time) string {
+	return fmt.Sprintf("%s_%04d_%02d", r.base.collection.Name(), month.Year(), month.Month())
+}
+
+func monthOf(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (r *PartitionedActivityLogRepository) newPartition(collection driver.Collection, month time.Time) *partition {
+	return &partition{
+		name:  collection.Name(),
+		start: month,
+		end:   month.AddDate(0, 1, 0),
+		repo: &ArangoActivityLogRepository{
+			client:          r.base.client,
+			database:        r.base.database,
+			reader:          r.base.reader,
+			maxResultWindow: r.base.maxResultWindow,
+			collection:      collection,
+		},
+	}
+}
+
+// list returns every partition, newest first and the base collection last
+func (r *PartitionedActivityLogRepository) list(ctx context.Context) ([]*partition, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.inTransaction || time.Since(r.listedAt) < partitionListTTL {
+		return r.partitions, nil
+	}
+
+	collections, err := r.base.database.Collections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partitions: %w", err)
+	}
+
+	known := make(map[string]*partition, len(r.partitions))
+	for _, p := range r.partitions {
+		known[p.name] = p
+	}
+
+	partitions := []*partition{{name: r.base.collection.Name(), repo: r.base}}
+	for _, collection := range collections {
+		match := r.pattern.FindStringSubmatch(collection.Name())
+		if match == nil {
+			continue
+		}
+		if p, ok := known[collection.Name()]; ok {
+			partitions = append(partitions, p)
+			continue
+		}
+		year, _ := strconv.Atoi(match[1])
+		month, _ := strconv.Atoi(match[2])
+		partitions = append(partitions, r.newPartition(collection, time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)))
+	}
+	sortPartitions(partitions)
+
+	r.partitions = partitions
+	r.listedAt = time.Now()
+	return partitions, nil
+}
+
+func sortPartitions(partitions []*partition) {
+	slices.SortFunc(partitions, func(a, b *partition) int {
+		switch {
+		case a.isBase():
+			return 1
+		case b.isBase():
+			return -1
+		}
+		return b.start.Compare(a.start)
+	})
+}
+
+// relevant returns the partitions that can hold logs matching filter
+func (r *PartitionedActivityLogRepository) relevant(ctx context.Context, filter repository.ActivityLogFilter) ([]*partition, error) {
+	partitions, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []*partition
+	for _, p := range partitions {
+		if p.overlaps(filter.StartDate, filter.EndDate) {
+			matching = append(matching, p)
+		}
+	}
+	return matching, nil
+}
+
+// partitionFor returns the partition for logs created at createdAt, creating
+// its collection on first use
+func (r *PartitionedActivityLogRepository) partitionFor(ctx context.Context, createdAt time.Time) (*partition, error) {
+	month := monthOf(createdAt)
+	name := r.partitionName(month)
+
+	partitions, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range partitions {
+		if p.name == name {
+			return p, nil
+		}
+	}
+	if r.inTransaction {
+		return nil, fmt.Errorf("partition %s is not part of the transaction", name)
+	}
+
+	collection, err := createLogCollection(ctx, r.base.database, name, r.cluster.collectionOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	p := r.newPartition(collection, month)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.partitions {
+		if existing.name == name {
+			return existing, nil
+		}
+	}
+	r.partitions = append(slices.Clone(r.partitions), p)
+	sortPartitions(r.partitions)
+	return p, nil
+}
+
+// createLogCollection creates a collection for activity logs with the managed
+// indexes, or opens it if another replica created it first
+func createLogCollection(ctx context.Context, database driver.Database, name string, options *driver.CreateCollectionOptions) (driver.Collection, error) {
+	collection, err := database.CreateCollection(ctx, name, options)
+	if driver.IsConflict(err) {
+		collection, err = database.Collection(ctx, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create collection %s: %w", name, err)
+	}
+	if err := ensureIndexes(ctx, collection); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// groupByPartition splits activityLogs by the partition they belong in and
+// returns each group with the input positions of its logs
+func (r *PartitionedActivityLogRepository) groupByPartition(ctx context.Context, activityLogs []*entity.ActivityLog) ([]*partition, [][]*entity.ActivityLog, [][]int, error) {
+	var (
+		partitions []*partition
+		groups     [][]*entity.ActivityLog
+		positions  [][]int
+		index      = make(map[string]int)
+	)
+	for i, activityLog := range activityLogs {
+		p, err := r.partitionFor(ctx, activityLog.CreatedAt)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		j, ok := index[p.name]
+		if !ok {
+			j = len(partitions)
+			index[p.name] = j
+			partitions = append(partitions, p)
+			groups = append(groups, nil)
+			positions = append(positions, nil)
+		}
+		groups[j] = append(groups[j], activityLog)
+		positions[j] = append(positions[j], i)
+	}
+	return partitions, groups, positions, nil
+}
+
+// Ping verifies the server is reachable and the database can be used
+func (r *PartitionedActivityLogRepository) Ping(ctx context.Context) error {
+	return r.base.Ping(ctx)
+}
+
+func (r *PartitionedActivityLogRepository) Create(ctx context.Context, activityLog *entity.ActivityLog) error {
+	p, err := r.partitionFor(ctx, activityLog.CreatedAt)
+	if err != nil {
+		return createError(err)
+	}
+	return p.repo.Create(ctx, activityLog)
+}
+
+func (r *PartitionedActivityLogRepository) CreateBatch(ctx context.Context, activityLogs []*entity.ActivityLog) ([]error, error) {
+	partitions, groups, positions, err := r.groupByPartition(ctx, activityLogs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create activity logs: %w", err)
+	}
+
+	errs := make([]error, len(activityLogs))
+	for j, p := range partitions {
+		groupErrs, err := p.repo.CreateBatch(ctx, groups[j])
+		if err != nil {
+			return nil, err
+		}
+		for k, groupErr := range groupErrs {
+			errs[positions[j][k]] = groupErr
+		}
+	}
+	return errs, nil
+}
+
+// CreateMany stores each partition's logs with its own bulk inserts; if a
+// request fails outright the partitions before it stay stored
+func (r *PartitionedActivityLogRepository) CreateMany(ctx context.Context, activityLogs []*entity.ActivityLog) error {
+	partitions, groups, positions, err := r.groupByPartition(ctx, activityLogs)
+	if err != nil {
+		return fmt.Errorf("failed to create activity logs: %w", err)
+	}
+
+	errs := make([]error, len(activityLogs))
+	rejected := false
+	for j, p := range partitions {
+		err := p.repo.CreateMany(ctx, groups[j])
+		var createErr *repository.CreateManyError
+		if errors.As(err, &createErr) {
+			for k, groupErr := range createErr.Errs {
+				errs[positions[j][k]] = groupErr
+			}
+			rejected = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if rejected {
+		return &repository.CreateManyError{Errs: errs}
+	}
+	return nil
+}
+
+// CreateBatchAtomic inserts all logs in one stream transaction spanning
+// their partitions
+func (r *PartitionedActivityLogRepository) CreateBatchAtomic(ctx context.Context, activityLogs []*entity.ActivityLog) ([]error, error) {
+	// Transactions cannot create collections, so the partitions are set up
+	// before it starts
+	if _, _, _, err := r.groupByPartition(ctx, activityLogs); err != nil {
+		return nil, fmt.Errorf("failed to create activity logs: %w", err)
+	}
+
+	var errs []error
+	err := r.WithTransaction(ctx, func(tx repository.ActivityLogRepository) error {
+		var err error
+		errs, err = tx.CreateBatch(ctx, activityLogs)
+		if err != nil {
+			return err
+		}
+		for _, createErr := range errs {
+			if createErr != nil {
+				return errBatchRejected
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errBatchRejected) {
+		return errs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
+
+// CreateByExternalID looks for the external ID in every partition, since
+// the unique index only covers one collection. Two producers racing to
+// create the same log in different months can still both succeed.
+func (r *PartitionedActivityLogRepository) CreateByExternalID(ctx context.Context, activityLog *entity.ActivityLog, update bool) (*entity.ActivityLog, repository.WriteOutcome, error) {
+	if activityLog.ExternalID == "" {
+		return nil, "", fmt.Errorf("external id is required: %w", entity.ErrInvalidExternalID)
+	}
+
+	partitions, err := r.list(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, p := range partitions {
+		_, err := p.repo.getByExternalID(ctx, activityLog.CompanyID, activityLog.ExternalID)
+		if errors.Is(err, entity.ErrActivityLogNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		// The partition's unique index turns the insert into a skip or
+		// an update
+		return p.repo.CreateByExternalID(ctx, activityLog, update)
+	}
+
+	p, err := r.partitionFor(ctx, activityLog.CreatedAt)
+	if err != nil {
+		return nil, "", createError(err)
+	}
+	return p.repo.CreateByExternalID(ctx, activityLog, update)
+}
+
+// find returns the partition holding the log with id
+func (r *PartitionedActivityLogRepository) find(ctx context.Context, id valueobject.ActivityLogID) (*partition, *entity.ActivityLog, error) {
+	partitions, err := r.list(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, p := range partitions {
+		activityLog, err := p.repo.GetByID(ctx, id)
+		if errors.Is(err, entity.ErrActivityLogNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return p, activityLog, nil
+	}
+	return nil, nil, entity.ErrActivityLogNotFound
+}
+
+func (r *PartitionedActivityLogRepository) GetByID(ctx context.Context, id valueobject.ActivityLogID) (*entity.ActivityLog, error) {
+	_, activityLog, err := r.find(ctx, id)
+	return activityLog, err
+}
+
+// GetByIDs asks each partition, newest first, for the logs not found yet
+func (r *PartitionedActivityLogRepository) GetByIDs(ctx context.Context, ids []valueobject.ActivityLogID) ([]*entity.ActivityLog, error) {
+	partitions, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[valueobject.ActivityLogID]*entity.ActivityLog, len(ids))
+	missing := ids
+	for _, p := range partitions {
+		if len(missing) == 0 {
+			break
+		}
+		activityLogs, err := p.repo.GetByIDs(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, activityLog := range activityLogs {
+			found[activityLog.ID] = activityLog
+		}
+		missing = slices.DeleteFunc(slices.Clone(missing), func(id valueobject.ActivityLogID) bool {
+			return found[id] != nil
+		})
+	}
+	return inIDOrder(ids, found), nil
+}
+
+func (r *PartitionedActivityLogRepository) Update(ctx context.Context, activityLog *entity.ActivityLog) error {
+	p, _, err := r.find(ctx, activityLog.ID)
+	if err != nil {
+		return err
+	}
+	return p.repo.Update(ctx, activityLog)
+}
+
+func (r *PartitionedActivityLogRepository) Delete(ctx context.Context, id valueobject.ActivityLogID) error {
+	p, _, err := r.find(ctx, id)
+	if err != nil {
+		return err
+	}
+	return p.repo.Delete(ctx, id)
+}
+
+// findRange skips offset logs matching filter across partitions and returns
+// the next limit of them along with the total. Every partition is queried
+// once: its total comes with its page.
+func (r *PartitionedActivityLogRepository) findRange(ctx context.Context, filter repository.ActivityLogFilter, offset, limit int) ([]*entity.ActivityLog, int, error) {
+	if err := checkResultWindow(offset, limit, r.base.maxResultWindow); err != nil {
+		return nil, 0, err
+	}
+
+	partitions, err := r.relevant(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		activityLogs []*entity.ActivityLog
+		total        int
+	)
+	for _, p := range partitions {
+		page, count, err := p.repo.findRange(ctx, filter, offset, limit-len(activityLogs))
+		if err != nil {
+			return nil, 0, err
+		}
+		activityLogs = append(activityLogs, page...)
+		total += count
+		offset = max(offset-count, 0)
+	}
+	return activityLogs, total, nil
+}
+
+func (r *PartitionedActivityLogRepository) GetByCompanyID(ctx context.Context, companyID string, page, limit int) ([]*entity.ActivityLog, int, error) {
+	return r.findRange(ctx, repository.ActivityLogFilter{CompanyID: companyID}, (page-1)*limit, limit)
+}
+
+// Search walks the partitions newest first until the page is full. Cursor
+// pages skip the partitions entirely newer than the cursor.
+func (r *PartitionedActivityLogRepository) Search(ctx context.Context, filter repository.ActivityLogFilter, page repository.SearchPage) (*repository.SearchResult, error) {
+	if page.Number > 0 {
+		offset := (page.Number - 1) * page.Limit
+		activityLogs, total, err := r.findRange(ctx, filter, offset, page.Limit)
+		if err != nil {
+			return nil, err
+		}
+		return numberedResult(activityLogs, total, offset), nil
+	}
+
+	partitions, err := r.relevant(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &repository.SearchResult{}
+	for _, p := range partitions {
+		if page.After != nil && !p.isBase() && p.start.After(page.After.CreatedAt) {
+			continue
+		}
+
+		remaining := page.Limit - len(result.ActivityLogs)
+		if remaining == 0 {
+			// The page is full; any match left in an older partition
+			// means another page follows
+			probe, err := p.repo.Search(ctx, filter, repository.SearchPage{Limit: 1, After: page.After})
+			if err != nil {
+				return nil, err
+			}
+			if len(probe.ActivityLogs) > 0 {
+				last := result.ActivityLogs[len(result.ActivityLogs)-1]
+				result.Next = &repository.SearchCursor{CreatedAt: last.CreatedAt, ID: last.ID.String()}
+				return result, nil
+			}
+			continue
+		}
+
+		part, err := p.repo.Search(ctx, filter, repository.SearchPage{Limit: remaining, After: page.After})
+		if err != nil {
+			return nil, err
+		}
+		result.ActivityLogs = append(result.ActivityLogs, part.ActivityLogs...)
+		if part.Next != nil {
+			result.Next = part.Next
+			return result, nil
+		}
+	}
+	return result, nil
+}
+
+type partitionedIterator struct {
+	ctx        context.Context
+	filter     repository.ActivityLogFilter
+	partitions []*partition
+	current    repository.ActivityLogIterator
+	err        error
+}
+
+// HasMore opens the next partition's cursor once the current one is drained.
+// A failure to open it is reported by the following Next.
+func (it *partitionedIterator) HasMore() bool {
+	for {
+		if it.err != nil {
+			return true
+		}
+		if it.current != nil {
+			if it.current.HasMore() {
+				return true
+			}
+			it.current.Close()
+			it.current = nil
+		}
+		if len(it.partitions) == 0 {
+			return false
+		}
+		it.current, it.err = it.partitions[0].repo.OpenCursor(it.ctx, it.filter)
+		it.partitions = it.partitions[1:]
+	}
+}
+
+func (it *partitionedIterator) Next(ctx context.Context) (*entity.ActivityLog, error) {
+	if it.err != nil {
+		return nil, it.err
+	}
+	if it.current == nil {
+		return nil, errors.New("no more activity logs")
+	}
+	return it.current.Next(ctx)
+}
+
+func (it *partitionedIterator) Close() error {
+	if it.current == nil {
+		return nil
+	}
+	return it.current.Close()
+}
+
+// OpenCursor streams the partitions one after another, holding one server
+// cursor at a time
+func (r *PartitionedActivityLogRepository) OpenCursor(ctx context.Context, filter repository.ActivityLogFilter) (repository.ActivityLogIterator, error) {
+	partitions, err := r.relevant(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &partitionedIterator{ctx: ctx, filter: filter, partitions: partitions}, nil
+}
+
+func (r *PartitionedActivityLogRepository) Iterate(ctx context.Context, filter repository.ActivityLogFilter, fn func(*entity.ActivityLog) error) error {
+	partitions, err := r.relevant(ctx, filter)
+	if err != nil {
+		return err
+	}
+	for _, p := range partitions {
+		if err := p.repo.Iterate(ctx, filter, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteOlderThan only visits the partitions that start before cutoff, and
+// stops at the first that leaves none of the limit over
+func (r *PartitionedActivityLogRepository) DeleteOlderThan(ctx context.Context, companyID string, cutoff time.Time, limit int) (int, error) {
+	partitions, err := r.relevant(ctx, repository.ActivityLogFilter{EndDate: cutoff})
+	if err != nil {
+		return 0, err
+	}
+
+	var removed int
+	for _, p := range partitions {
+		remaining := 0
+		if limit > 0 {
+			remaining = limit - removed
+		}
+		n, err := p.repo.DeleteOlderThan(ctx, companyID, cutoff, remaining)
+		if err != nil {
+			return removed, err
+		}
+		removed += n
+		if limit > 0 && removed >= limit {
+			break
+		}
+	}
+	return removed, nil
+}
+
+func (r *PartitionedActivityLogRepository) CompaniesWithLogsBefore(ctx context.Context, cutoff time.Time) ([]string, error) {
+	partitions, err := r.relevant(ctx, repository.ActivityLogFilter{EndDate: cutoff})
+	if err != nil {
+		return nil, err
+	}
+
+	var companies []string
+	for _, p := range partitions {
+		found, err := p.repo.CompaniesWithLogsBefore(ctx, cutoff)
+		if err != nil {
+			return nil, err
+		}
+		for _, companyID := range found {
+			if !slices.Contains(companies, companyID) {
+				companies = append(companies, companyID)
+			}
+		}
+	}
+	return companies, nil
+}
+
+// DropPartitionsBefore drops the monthly partitions that end before cutoff,
+// except those holding logs of a keep company. The base collection is never
+// dropped; its expired logs are removed by DeleteOlderThan like any others.
+func (r *PartitionedActivityLogRepository) DropPartitionsBefore(ctx context.Context, cutoff time.Time, keep []string) ([]string, error) {
+	partitions, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var dropped []string
+	for _, p := range partitions {
+		if p.isBase() || p.end.After(cutoff) {
+			continue
+		}
+		kept, err := p.repo.hasLogsOf(ctx, keep)
+		if err != nil {
+			return dropped, fmt.Errorf("failed to check partition %s for held logs: %w", p.name, err)
+		}
+		if kept {
+			continue
+		}
+		if err := p.repo.collection.Remove(ctx); err != nil && !driver.IsNotFound(err) {
+			return dropped, fmt.Errorf("failed to drop partition %s: %w", p.name, err)
+		}
+		dropped = append(dropped, p.name)
+	}
+
+	if len(dropped) > 0 {
+		r.mu.Lock()
+		r.partitions = slices.DeleteFunc(slices.Clone(r.partitions), func(p *partition) bool {
+			return slices.Contains(dropped, p.name)
+		})
+		r.mu.Unlock()
+	}
+	return dropped, nil
+}
+
+// hasLogsOf reports whether the collection holds a log of any of companies
+func (r *ArangoActivityLogRepository) hasLogsOf(ctx context.Context, companies []string) (bool, error) {
+	if len(companies) == 0 {
+		return false, nil
+	}
+
+	query := `
+		FOR log IN @@collection
+		FILTER log.company_id IN @companies
+		LIMIT 1
+		RETURN true
+	`
+	bindVars := map[string]interface{}{
+		"@collection": r.collection.Name(),
+		"companies":   companies,
+	}
+
+	cursor, err := r.database.Query(ctx, query, bindVars)
+	if err != nil {
+		return false, err
+	}
+	defer cursor.Close()
+	return cursor.HasMore(), nil
+}
+
+func (r *PartitionedActivityLogRepository) CountByCompanyID(ctx context.Context, companyID string) (int, error) {
+	partitions, err := r.list(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int
+	for _, p := range partitions {
+		n, err := p.repo.CountByCompanyID(ctx, companyID)
+		if err != nil {
+			return 0, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (r *PartitionedActivityLogRepository) CountSince(ctx context.Context, since time.Time) (int, error) {
+	partitions, err := r.relevant(ctx, repository.ActivityLogFilter{StartDate: since})
+	if err != nil {
+		return 0, err
+	}
+
+	var total int
+	for _, p := range partitions {
+		n, err := p.repo.CountSince(ctx, since)
+		if err != nil {
+			return 0, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (r *PartitionedActivityLogRepository) CountByCountryCode(ctx context.Context, companyID string) (map[string]int, error) {
+	partitions, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, p := range partitions {
+		partCounts, err := p.repo.CountByCountryCode(ctx, companyID)
+		if err != nil {
+			return nil, err
+		}
+		for countryCode, n := range partCounts {
+			counts[countryCode] += n
+		}
+	}
+	return counts, nil
+}
+
+func (r *PartitionedActivityLogRepository) GetActiveCompanies(ctx context.Context, since time.Time) ([]*entity.CompanyActivity, error) {
+	partitions, err := r.relevant(ctx, repository.ActivityLogFilter{StartDate: since})
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]*entity.CompanyActivity)
+	for _, p := range partitions {
+		companies, err := p.repo.GetActiveCompanies(ctx, since)
+		if err != nil {
+			return nil, err
+		}
+		for _, company := range companies {
+			if seen, ok := latest[company.CompanyID]; !ok || company.LastActivityAt.After(seen.LastActivityAt) {
+				latest[company.CompanyID] = company
+			}
+		}
+	}
+
+	companies := make([]*entity.CompanyActivity, 0, len(latest))
+	for _, company := range latest {
+		companies = append(companies, company)
+	}
+	slices.SortFunc(companies, func(a, b *entity.CompanyActivity) int {
+		return b.LastActivityAt.Compare(a.LastActivityAt)
+	})
+	return companies, nil
+}
+
+// CountGrouped sums each partition's counts, so limit only applies once
+// they are merged
+func (r *PartitionedActivityLogRepository) CountGrouped(ctx context.Context, filter repository.ActivityLogFilter, groupBy repository.GroupBy, limit int) ([]repository.GroupCount, error) {
+	partitions, err := r.relevant(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]int)
+	for _, p := range partitions {
+		counts, err := p.repo.CountGrouped(ctx, filter, groupBy, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, count := range counts {
+			totals[count.Key] += count.Count
+		}
+	}
+	return mergeGroupCounts(totals, groupBy, limit), nil
+}
+
+func (r *PartitionedActivityLogRepository) CountByActivityName(ctx context.Context, companyID string, start, end time.Time) ([]repository.GroupCount, error) {
+	return r.CountGrouped(ctx, rangeFilter(companyID, start, end), repository.GroupByActivityName, 0)
+}
+
+func (r *PartitionedActivityLogRepository) CountByActor(ctx context.Context, companyID string, start, end time.Time) ([]repository.GroupCount, error) {
+	return r.CountGrouped(ctx, rangeFilter(companyID, start, end), repository.GroupByActor, 0)
+}
+
+func (r *PartitionedActivityLogRepository) CountPerDay(ctx context.Context, companyID string, start, end time.Time) ([]repository.GroupCount, error) {
+	return r.CountGrouped(ctx, rangeFilter(companyID, start, end), repository.GroupByDay, 0)
+}
+
+func (r *PartitionedActivityLogRepository) DistinctActivityNames(ctx context.Context, companyID string) ([]string, error) {
+	partitions, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	names := []string{}
+	for _, p := range partitions {
+		partNames, err := p.repo.DistinctActivityNames(ctx, companyID)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range partNames {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// DistinctActors names every actor as in the latest partition they appear in
+func (r *PartitionedActivityLogRepository) DistinctActors(ctx context.Context, companyID string) ([]repository.Actor, error) {
+	partitions, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lists := make([][]repository.Actor, 0, len(partitions))
+	for _, p := range partitions {
+		actors, err := p.repo.DistinctActors(ctx, companyID)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, actors)
+	}
+	return mergeActors(lists...), nil
+}
+
+// Reindex reconciles the indexes of every partition. Index names are
+// prefixed with their partition.
+func (r *PartitionedActivityLogRepository) Reindex(ctx context.Context) ([]repository.IndexStatus, error) {
+	partitions, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []repository.IndexStatus
+	for _, p := range partitions {
+		partStatuses, err := p.repo.Reindex(ctx)
+		for _, status := range partStatuses {
+			status.Name = p.name + "/" + status.Name
+			statuses = append(statuses, status)
+		}
+		if err != nil {
+			return statuses, err
+		}
+	}
+	return statuses, nil
+}
+
+// WithTransaction runs fn in a stream transaction over every partition that
+// exists when it starts. Writing a log into a month without a partition
+// fails inside the transaction.
+func (r *PartitionedActivityLogRepository) WithTransaction(ctx context.Context, fn func(repo repository.ActivityLogRepository) error) error {
+	if r.inTransaction {
+		return fn(r)
+	}
+
+	partitions, err := r.list(ctx)
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(partitions))
+	for i, p := range partitions {
+		names[i] = p.name
+	}
+
+	return runTransaction(ctx, r.base.database, names, func(tid driver.TransactionID) error {
+		tx := &PartitionedActivityLogRepository{
+			cluster:       r.cluster,
+			pattern:       r.pattern,
+			partitions:    make([]*partition, len(partitions)),
+			inTransaction: true,
+		}
+		for i, p := range partitions {
+			txPartition := *p
+			txPartition.repo = p.repo.inTx(tid)
+			tx.partitions[i] = &txPartition
+			if p.isBase() {
+				tx.base = txPartition.repo
+			}
+		}
+		return fn(tx)
+	})
+}
+
+var (
+	_ repository.ActivityLogRepository = (*PartitionedActivityLogRepository)(nil)
+	_ repository.IndexManager          = (*PartitionedActivityLogRepository)(nil)
+	_ repository.PartitionManager      = (*PartitionedActivityLogRepository)(nil)
+	_ repository.Transactor            = (*PartitionedActivityLogRepository)(nil)
+)
@@ -2,11 +2,16 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	nethttp "net/http"
+	"slices"
 	"time"
 
 	"github.com/arangodb/go-driver"
 	"github.com/arangodb/go-driver/http"
+	"github.com/sirupsen/logrus"
 
 	"activity-log-service/internal/domain/entity"
 	"activity-log-service/internal/domain/repository"
@@ -17,11 +22,66 @@ type ArangoActivityLogRepository struct {
 	client     driver.Client
 	database   driver.Database
 	collection driver.Collection
+	// reader runs the list, search, export and stats queries. It is database
+	// unless separate read endpoints are configured.
+	reader driver.Database
+	// maxResultWindow bounds how deep numbered pages reach
+	maxResultWindow int
+	// inTransaction is set on the repository WithTransaction hands out
+	inTransaction bool
 }
 
-func NewArangoActivityLogRepository(url, dbName, collectionName, username, password string) (*ArangoActivityLogRepository, error) {
+// ClusterOptions controls how the database and collection are laid out when
+// the repository creates them. They only apply at creation time; an existing
+// collection keeps its sharding. Zero values fall back to server defaults,
+// and a single server ignores them altogether.
+type ClusterOptions struct {
+	NumberOfShards    int
+	ReplicationFactor int
+	WriteConcern      int
+	ShardKeys         []string
+}
+
+// DatabaseOptions returns the defaults new collections in the database inherit
+func (o ClusterOptions) DatabaseOptions() *driver.CreateDatabaseOptions {
+	return &driver.CreateDatabaseOptions{
+		Options: driver.CreateDatabaseDefaultOptions{
+			ReplicationFactor: o.ReplicationFactor,
+			WriteConcern:      o.WriteConcern,
+		},
+	}
+}
+
+func (o ClusterOptions) collectionOptions() *driver.CreateCollectionOptions {
+	return &driver.CreateCollectionOptions{
+		NumberOfShards:    o.NumberOfShards,
+		ReplicationFactor: o.ReplicationFactor,
+		WriteConcern:      o.WriteConcern,
+		ShardKeys:         o.ShardKeys,
+	}
+}
+
+// ConnectOptions say where the server is and how long to wait for it. A
+// request that cannot reach one endpoint fails over to the next.
+type ConnectOptions struct {
+	Endpoints []string
+	Username  string
+	Password  string
+	// MaxAttempts bounds the tries to reach the server on start; the wait
+	// between them starts at InitialBackoff and doubles up to MaxBackoff
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// ReadEndpoints, when set, serve the heavy read queries in place of
+	// Endpoints, failing over among themselves
+	ReadEndpoints []string
+}
+
+// NewClient connects to the server, retrying while it is unreachable. Other
+// errors, such as rejected credentials, fail at once.
+func NewClient(ctx context.Context, opts ConnectOptions, logger *logrus.Logger) (driver.Client, error) {
 	conn, err := http.NewConnection(http.ConnectionConfig{
-		Endpoints: []string{url},
+		Endpoints: opts.Endpoints,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection: %w", err)
@@ -29,17 +89,47 @@ func NewArangoActivityLogRepository(url, dbName, collectionName, username, passw
 
 	client, err := driver.NewClient(driver.ClientConfig{
 		Connection:     conn,
-		Authentication: driver.BasicAuthentication(username, password),
+		Authentication: driver.BasicAuthentication(opts.Username, opts.Password),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
 
+	backoff := opts.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		_, err := client.Version(ctx)
+		if err == nil {
+			return client, nil
+		}
+		if !isUnavailable(err) || attempt >= opts.MaxAttempts {
+			return nil, fmt.Errorf("failed to reach arangodb after %d attempts: %w", attempt, err)
+		}
+
+		logger.WithError(err).WithFields(logrus.Fields{
+			"attempt": attempt,
+			"backoff": backoff,
+		}).Warn("ArangoDB unreachable, retrying")
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to reach arangodb: %w", ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, opts.MaxBackoff)
+	}
+}
+
+func NewArangoActivityLogRepository(connect ConnectOptions, dbName, collectionName string, cluster ClusterOptions, queries QueryOptions, logger *logrus.Logger) (*ArangoActivityLogRepository, error) {
 	ctx := context.Background()
 
+	client, err := NewClient(ctx, connect, logger)
+	if err != nil {
+		return nil, err
+	}
+
 	db, err := client.Database(ctx, dbName)
 	if driver.IsNotFound(err) {
-		db, err = client.CreateDatabase(ctx, dbName, nil)
+		db, err = client.CreateDatabase(ctx, dbName, cluster.DatabaseOptions())
 		if err != nil {
 			return nil, fmt.Errorf("failed to create database: %w", err)
 		}
@@ -49,7 +139,7 @@ func NewArangoActivityLogRepository(url, dbName, collectionName, username, passw
 
 	collection, err := db.Collection(ctx, collectionName)
 	if driver.IsNotFound(err) {
-		collection, err = db.CreateCollection(ctx, collectionName, nil)
+		collection, err = db.CreateCollection(ctx, collectionName, cluster.collectionOptions())
 		if err != nil {
 			return nil, fmt.Errorf("failed to create collection: %w", err)
 		}
@@ -57,389 +147,518 @@ func NewArangoActivityLogRepository(url, dbName, collectionName, username, passw
 		return nil, fmt.Errorf("failed to open collection: %w", err)
 	}
 
+	// Queries and conditional creates rely on the indexes, so they are
+	// ensured on start rather than left to the migrations
+	if err := ensureIndexes(ctx, collection); err != nil {
+		return nil, err
+	}
+
+	database := queries.wrap(db, logger)
+	reader := database
+	if len(connect.ReadEndpoints) > 0 {
+		readDB, err := openReadDatabase(ctx, connect, dbName, logger)
+		if err != nil {
+			return nil, err
+		}
+		reader = queries.wrap(readDB, logger)
+	}
+
 	return &ArangoActivityLogRepository{
 		client:     client,
-		database:   db,
+		database:   database,
+		reader:     reader,
 		collection: collection,
+
+		maxResultWindow: queries.MaxResultWindow,
 	}, nil
 }
 
-func (r *ArangoActivityLogRepository) Create(ctx context.Context, activityLog *entity.ActivityLog) error {
-	_, err := r.collection.CreateDocument(ctx, activityLog)
+// openReadDatabase connects to the read endpoints. The database must already
+// exist; the write endpoints create it.
+func openReadDatabase(ctx context.Context, connect ConnectOptions, dbName string, logger *logrus.Logger) (driver.Database, error) {
+	connect.Endpoints = connect.ReadEndpoints
+	client, err := NewClient(ctx, connect, logger)
 	if err != nil {
-		return fmt.Errorf("failed to create activity log: %w", err)
+		return nil, fmt.Errorf("read endpoints: %w", err)
+	}
+
+	db, err := client.Database(ctx, dbName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database on read endpoints: %w", err)
+	}
+	return db, nil
+}
+
+// Ping verifies the server is reachable and the database can be used
+func (r *ArangoActivityLogRepository) Ping(ctx context.Context) error {
+	if _, err := r.database.Info(ctx); err != nil {
+		return fmt.Errorf("arangodb ping failed: %w", err)
 	}
 	return nil
 }
 
-func (r *ArangoActivityLogRepository) GetByID(ctx context.Context, id valueobject.ActivityLogID) (*entity.ActivityLog, error) {
-	var activityLog entity.ActivityLog
-	_, err := r.collection.ReadDocument(ctx, id.String(), &activityLog)
-	if driver.IsNotFound(err) {
-		return nil, entity.ErrActivityLogNotFound
+// Create reports entity.ErrActivityLogExists if a log with the same ID is
+// already stored. Documents keep their generated _key, which a cluster
+// sharded by company_id requires; idx_id_company makes the ID unique.
+func (r *ArangoActivityLogRepository) Create(ctx context.Context, activityLog *entity.ActivityLog) error {
+	if _, err := r.collection.CreateDocument(ctx, activityLog); err != nil {
+		return createError(documentError(activityLog, err))
+	}
+	return nil
+}
+
+// documentError flags a rejected insert of a log without an external ID as a
+// duplicate, since its ID is the only unique key it can conflict on
+func documentError(activityLog *entity.ActivityLog, err error) error {
+	if err != nil && driver.IsConflict(err) && activityLog.ExternalID == "" {
+		return fmt.Errorf("%w: %v", entity.ErrActivityLogExists, err)
+	}
+	return err
+}
+
+// createError wraps a failed insert, flagging an unreachable database
+func createError(err error) error {
+	if isUnavailable(err) {
+		return fmt.Errorf("failed to create activity log: %w: %v", entity.ErrDatabaseUnavailable, err)
+	}
+	return fmt.Errorf("failed to create activity log: %w", err)
+}
+
+// isUnavailable reports whether err means the database could not be reached
+// at all, as opposed to it rejecting the request.
+func isUnavailable(err error) bool {
+	if driver.IsNoLeaderOrOngoing(err) || driver.IsTimeout(err) {
+		return true
+	}
+	if driver.IsArangoErrorWithCode(err, nethttp.StatusServiceUnavailable) {
+		return true
 	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// CreateBatch inserts all logs in a single request. The returned slice holds
+// the per-document errors in input order.
+func (r *ArangoActivityLogRepository) CreateBatch(ctx context.Context, activityLogs []*entity.ActivityLog) ([]error, error) {
+	_, errs, err := r.collection.CreateDocuments(ctx, activityLogs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read activity log: %w", err)
+		return nil, fmt.Errorf("failed to create activity logs: %w", err)
+	}
+	for i, docErr := range errs {
+		errs[i] = documentError(activityLogs[i], docErr)
 	}
-	return &activityLog, nil
+	return errs, nil
 }
 
-func (r *ArangoActivityLogRepository) GetByCompanyID(ctx context.Context, companyID string, page, limit int) ([]*entity.ActivityLog, int, error) {
-	offset := (page - 1) * limit
+// createManyChunkSize bounds how many documents one CreateMany request sends
+const createManyChunkSize = 1000
 
+// CreateMany inserts the logs with the batch document API, in chunks of
+// createManyChunkSize. If a request fails outright the chunks before it stay
+// stored.
+func (r *ArangoActivityLogRepository) CreateMany(ctx context.Context, activityLogs []*entity.ActivityLog) error {
+	errs := make([]error, 0, len(activityLogs))
+	for chunk := range slices.Chunk(activityLogs, createManyChunkSize) {
+		_, chunkErrs, err := r.collection.CreateDocuments(ctx, chunk)
+		if err != nil {
+			if isUnavailable(err) {
+				return fmt.Errorf("failed to create activity logs: %w: %v", entity.ErrDatabaseUnavailable, err)
+			}
+			return fmt.Errorf("failed to create activity logs: %w", err)
+		}
+		for i, docErr := range chunkErrs {
+			errs = append(errs, documentError(chunk[i], docErr))
+		}
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return &repository.CreateManyError{Errs: errs}
+		}
+	}
+	return nil
+}
+
+// errBatchRejected aborts the transaction of a batch with rejected documents
+var errBatchRejected = errors.New("batch rejected")
+
+// CreateBatchAtomic inserts all logs inside a stream transaction. If any
+// document is rejected the transaction is aborted and nothing is stored; the
+// returned slice then holds the per-document errors in input order.
+func (r *ArangoActivityLogRepository) CreateBatchAtomic(ctx context.Context, activityLogs []*entity.ActivityLog) ([]error, error) {
+	var errs []error
+	err := r.WithTransaction(ctx, func(tx repository.ActivityLogRepository) error {
+		var err error
+		errs, err = tx.CreateBatch(ctx, activityLogs)
+		if err != nil {
+			return err
+		}
+		for _, createErr := range errs {
+			if createErr != nil {
+				return errBatchRejected
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errBatchRejected) {
+		return errs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
+
+func (r *ArangoActivityLogRepository) GetByID(ctx context.Context, id valueobject.ActivityLogID) (*entity.ActivityLog, error) {
 	query := `
 		FOR log IN @@collection
-		FILTER log.company_id == @companyId
-		SORT log.created_at DESC
-		LIMIT @offset, @limit
+		FILTER log.id == @id
+		LIMIT 1
 		RETURN log
 	`
-
 	bindVars := map[string]interface{}{
 		"@collection": r.collection.Name(),
-		"companyId":   companyID,
-		"offset":      offset,
-		"limit":       limit,
+		"id":          id.String(),
 	}
 
-	cursor, err := r.database.Query(ctx, query, bindVars)
+	activityLog, err := r.readOne(ctx, query, bindVars)
+	if errors.Is(err, entity.ErrActivityLogNotFound) {
+		return nil, err
+	}
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query activity logs: %w", err)
+		return nil, fmt.Errorf("failed to read activity log: %w", err)
 	}
-	defer cursor.Close()
+	return activityLog, nil
+}
 
-	var logs []*entity.ActivityLog
-	for cursor.HasMore() {
-		var log entity.ActivityLog
-		if _, err := cursor.ReadDocument(ctx, &log); err != nil {
-			return nil, 0, fmt.Errorf("failed to read document: %w", err)
-		}
-		logs = append(logs, &log)
+// GetByIDs reads like GetByID from the primary, so callers see their own
+// writes
+func (r *ArangoActivityLogRepository) GetByIDs(ctx context.Context, ids []valueobject.ActivityLogID) ([]*entity.ActivityLog, error) {
+	if len(ids) == 0 {
+		return nil, nil
 	}
 
-	countQuery := `
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = id.String()
+	}
+	query := `
 		FOR log IN @@collection
-		FILTER log.company_id == @companyId
-		COLLECT WITH COUNT INTO total
-		RETURN total
+		FILTER log.id IN @ids
+		RETURN log
 	`
-
-	countBindVars := map[string]interface{}{
+	bindVars := map[string]interface{}{
 		"@collection": r.collection.Name(),
-		"companyId":   companyID,
+		"ids":         keys,
 	}
 
-	countCursor, err := r.database.Query(ctx, countQuery, countBindVars)
+	cursor, err := r.database.Query(ctx, query, bindVars)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count activity logs: %w", err)
+		return nil, fmt.Errorf("failed to read activity logs: %w", err)
 	}
-	defer countCursor.Close()
+	defer cursor.Close()
 
-	var total int
-	if countCursor.HasMore() {
-		if _, err := countCursor.ReadDocument(ctx, &total); err != nil {
-			return nil, 0, fmt.Errorf("failed to read count: %w", err)
+	found := make(map[valueobject.ActivityLogID]*entity.ActivityLog, len(ids))
+	for cursor.HasMore() {
+		var log entity.ActivityLog
+		if _, err := cursor.ReadDocument(ctx, &log); err != nil {
+			return nil, fmt.Errorf("failed to read document: %w", err)
 		}
+		found[log.ID] = &log
 	}
-
-	return logs, total, nil
+	return inIDOrder(ids, found), nil
 }
 
-func (r *ArangoActivityLogRepository) Update(ctx context.Context, activityLog *entity.ActivityLog) error {
-	_, err := r.collection.UpdateDocument(ctx, activityLog.ID.String(), activityLog)
-	if driver.IsNotFound(err) {
-		return entity.ErrActivityLogNotFound
-	}
-	if err != nil {
-		return fmt.Errorf("failed to update activity log: %w", err)
+// inIDOrder lists the found logs in the order of ids, once each
+func inIDOrder(ids []valueobject.ActivityLogID, found map[valueobject.ActivityLogID]*entity.ActivityLog) []*entity.ActivityLog {
+	activityLogs := make([]*entity.ActivityLog, 0, len(found))
+	for _, id := range ids {
+		if activityLog, ok := found[id]; ok {
+			activityLogs = append(activityLogs, activityLog)
+			delete(found, id)
+		}
 	}
-	return nil
+	return activityLogs
 }
 
-func (r *ArangoActivityLogRepository) Delete(ctx context.Context, id valueobject.ActivityLogID) error {
-	_, err := r.collection.RemoveDocument(ctx, id.String())
-	if driver.IsNotFound(err) {
-		return entity.ErrActivityLogNotFound
-	}
-	if err != nil {
-		return fmt.Errorf("failed to delete activity log: %w", err)
+func (r *ArangoActivityLogRepository) GetByCompanyID(ctx context.Context, companyID string, page, limit int) ([]*entity.ActivityLog, int, error) {
+	offset := (page - 1) * limit
+	if err := checkResultWindow(offset, limit, r.maxResultWindow); err != nil {
+		return nil, 0, err
 	}
-	return nil
-}
 
-func (r *ArangoActivityLogRepository) GetByObjectID(ctx context.Context, companyID, objectID string, page, limit int) ([]*entity.ActivityLog, int, error) {
-	offset := (page - 1) * limit
 	query := `
 		FOR log IN @@collection
-		FILTER log.company_id == @companyID AND log.object_id == @objectID
+		FILTER log.company_id == @companyId
 		SORT log.created_at DESC
 		LIMIT @offset, @limit
 		RETURN log
 	`
+
 	bindVars := map[string]interface{}{
 		"@collection": r.collection.Name(),
-		"companyID":   companyID,
-		"objectID":    objectID,
+		"companyId":   companyID,
 		"offset":      offset,
 		"limit":       limit,
 	}
 
+	return r.queryPage(ctx, query, bindVars, "query activity logs")
+}
+
+func (r *ArangoActivityLogRepository) DeleteOlderThan(ctx context.Context, companyID string, cutoff time.Time, limit int) (int, error) {
+	bindVars := map[string]interface{}{
+		"@collection": r.collection.Name(),
+		"companyID":   companyID,
+		"cutoff":      cutoff,
+	}
+	limitClause := ""
+	if limit > 0 {
+		limitClause = "LIMIT @limit"
+		bindVars["limit"] = limit
+	}
+	query := fmt.Sprintf(`
+		FOR log IN @@collection
+		FILTER log.company_id == @companyID AND log.created_at < @cutoff
+		%s
+		REMOVE log IN @@collection
+		COLLECT WITH COUNT INTO removed
+		RETURN removed
+	`, limitClause)
+
 	cursor, err := r.database.Query(ctx, query, bindVars)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query activity logs by object ID: %w", err)
+		return 0, fmt.Errorf("failed to delete activity logs older than %s: %w", cutoff.Format(time.RFC3339), err)
 	}
 	defer cursor.Close()
 
-	var logs []*entity.ActivityLog
-	for cursor.HasMore() {
-		var log entity.ActivityLog
-		_, err := cursor.ReadDocument(ctx, &log)
-		if err != nil {
-			return nil, 0, fmt.Errorf("failed to read document: %w", err)
+	var removed int
+	if cursor.HasMore() {
+		if _, err := cursor.ReadDocument(ctx, &removed); err != nil {
+			return 0, fmt.Errorf("failed to read removed count: %w", err)
 		}
-		logs = append(logs, &log)
 	}
+	return removed, nil
+}
 
-	// Get total count
-	countQuery := `
+func (r *ArangoActivityLogRepository) CompaniesWithLogsBefore(ctx context.Context, cutoff time.Time) ([]string, error) {
+	query := `
 		FOR log IN @@collection
-		FILTER log.company_id == @companyID AND log.object_id == @objectID
-		COLLECT WITH COUNT INTO total
-		RETURN total
+		FILTER log.created_at < @cutoff
+		COLLECT companyID = log.company_id
+		RETURN companyID
 	`
-	countCursor, err := r.database.Query(ctx, countQuery, map[string]interface{}{
+	bindVars := map[string]interface{}{
 		"@collection": r.collection.Name(),
-		"companyID":   companyID,
-		"objectID":    objectID,
-	})
+		"cutoff":      cutoff,
+	}
+
+	cursor, err := r.database.Query(ctx, query, bindVars)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count activity logs: %w", err)
+		return nil, fmt.Errorf("failed to query companies with old logs: %w", err)
 	}
-	defer countCursor.Close()
+	defer cursor.Close()
 
-	var total int
-	if countCursor.HasMore() {
-		_, err := countCursor.ReadDocument(ctx, &total)
-		if err != nil {
-			return nil, 0, fmt.Errorf("failed to read count: %w", err)
+	var companies []string
+	for cursor.HasMore() {
+		var companyID string
+		if _, err := cursor.ReadDocument(ctx, &companyID); err != nil {
+			return nil, fmt.Errorf("failed to read company ID: %w", err)
 		}
+		companies = append(companies, companyID)
 	}
-
-	return logs, total, nil
+	return companies, nil
 }
 
-func (r *ArangoActivityLogRepository) GetByActivityName(ctx context.Context, companyID, activityName string, page, limit int) ([]*entity.ActivityLog, int, error) {
-	offset := (page - 1) * limit
-	query := `
-		FOR log IN @@collection
-		FILTER log.company_id == @companyID AND log.activity_name == @activityName
-		SORT log.created_at DESC
-		LIMIT @offset, @limit
-		RETURN log
-	`
-	bindVars := map[string]interface{}{
-		"@collection":  r.collection.Name(),
-		"companyID":    companyID,
-		"activityName": activityName,
-		"offset":       offset,
-		"limit":        limit,
-	}
-
-	cursor, err := r.database.Query(ctx, query, bindVars)
+// queryPage runs a paged list query and reads the total the filter matches
+// from the cursor's fullCount, saving a second COLLECT WITH COUNT query
+func (r *ArangoActivityLogRepository) queryPage(ctx context.Context, query string, bindVars map[string]interface{}, action string) ([]*entity.ActivityLog, int, error) {
+	cursor, err := r.reader.Query(driver.WithQueryFullCount(ctx), query, bindVars)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query activity logs by activity name: %w", err)
+		return nil, 0, fmt.Errorf("failed to %s: %w", action, err)
 	}
 	defer cursor.Close()
 
 	var logs []*entity.ActivityLog
 	for cursor.HasMore() {
 		var log entity.ActivityLog
-		_, err := cursor.ReadDocument(ctx, &log)
-		if err != nil {
+		if _, err := cursor.ReadDocument(ctx, &log); err != nil {
 			return nil, 0, fmt.Errorf("failed to read document: %w", err)
 		}
 		logs = append(logs, &log)
 	}
 
-	// Get total count
-	countQuery := `
+	return logs, int(cursor.Statistics().FullCount()), nil
+}
+
+func (r *ArangoActivityLogRepository) Update(ctx context.Context, activityLog *entity.ActivityLog) error {
+	query := `
 		FOR log IN @@collection
-		FILTER log.company_id == @companyID AND log.activity_name == @activityName
-		COLLECT WITH COUNT INTO total
-		RETURN total
+		FILTER log.id == @id
+		LIMIT 1
+		UPDATE log WITH @log IN @@collection
+		RETURN NEW
 	`
-	countCursor, err := r.database.Query(ctx, countQuery, map[string]interface{}{
-		"@collection":  r.collection.Name(),
-		"companyID":    companyID,
-		"activityName": activityName,
-	})
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count activity logs: %w", err)
+	bindVars := map[string]interface{}{
+		"@collection": r.collection.Name(),
+		"id":          activityLog.ID.String(),
+		"log":         activityLog,
 	}
-	defer countCursor.Close()
 
-	var total int
-	if countCursor.HasMore() {
-		_, err := countCursor.ReadDocument(ctx, &total)
-		if err != nil {
-			return nil, 0, fmt.Errorf("failed to read count: %w", err)
-		}
+	_, err := r.readOne(ctx, query, bindVars)
+	if errors.Is(err, entity.ErrActivityLogNotFound) {
+		return err
 	}
-
-	return logs, total, nil
+	if err != nil {
+		return fmt.Errorf("failed to update activity log: %w", err)
+	}
+	return nil
 }
 
-func (r *ArangoActivityLogRepository) GetByDateRange(ctx context.Context, companyID string, startDate, endDate time.Time, page, limit int) ([]*entity.ActivityLog, int, error) {
-	offset := (page - 1) * limit
+func (r *ArangoActivityLogRepository) Delete(ctx context.Context, id valueobject.ActivityLogID) error {
 	query := `
 		FOR log IN @@collection
-		FILTER log.company_id == @companyID AND log.created_at >= @startDate AND log.created_at <= @endDate
-		SORT log.created_at DESC
-		LIMIT @offset, @limit
-		RETURN log
+		FILTER log.id == @id
+		REMOVE log IN @@collection
+		RETURN OLD
 	`
 	bindVars := map[string]interface{}{
 		"@collection": r.collection.Name(),
-		"companyID":   companyID,
-		"startDate":   startDate,
-		"endDate":     endDate,
-		"offset":      offset,
-		"limit":       limit,
+		"id":          id.String(),
 	}
 
-	cursor, err := r.database.Query(ctx, query, bindVars)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query activity logs by date range: %w", err)
+	_, err := r.readOne(ctx, query, bindVars)
+	if errors.Is(err, entity.ErrActivityLogNotFound) {
+		return err
 	}
-	defer cursor.Close()
-
-	var logs []*entity.ActivityLog
-	for cursor.HasMore() {
-		var log entity.ActivityLog
-		_, err := cursor.ReadDocument(ctx, &log)
-		if err != nil {
-			return nil, 0, fmt.Errorf("failed to read document: %w", err)
-		}
-		logs = append(logs, &log)
+	if err != nil {
+		return fmt.Errorf("failed to delete activity log: %w", err)
 	}
+	return nil
+}
 
-	// Get total count
-	countQuery := `
+func (r *ArangoActivityLogRepository) CountByCompanyID(ctx context.Context, companyID string) (int, error) {
+	query := `
 		FOR log IN @@collection
-		FILTER log.company_id == @companyID AND log.created_at >= @startDate AND log.created_at <= @endDate
+		FILTER log.company_id == @companyID
 		COLLECT WITH COUNT INTO total
 		RETURN total
 	`
-	countCursor, err := r.database.Query(ctx, countQuery, map[string]interface{}{
+	bindVars := map[string]interface{}{
 		"@collection": r.collection.Name(),
 		"companyID":   companyID,
-		"startDate":   startDate,
-		"endDate":     endDate,
-	})
+	}
+
+	cursor, err := r.reader.Query(ctx, query, bindVars)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count activity logs: %w", err)
+		return 0, fmt.Errorf("failed to count activity logs by company ID: %w", err)
 	}
-	defer countCursor.Close()
+	defer cursor.Close()
 
 	var total int
-	if countCursor.HasMore() {
-		_, err := countCursor.ReadDocument(ctx, &total)
+	if cursor.HasMore() {
+		_, err := cursor.ReadDocument(ctx, &total)
 		if err != nil {
-			return nil, 0, fmt.Errorf("failed to read count: %w", err)
+			return 0, fmt.Errorf("failed to read count: %w", err)
 		}
 	}
 
-	return logs, total, nil
+	return total, nil
 }
 
-func (r *ArangoActivityLogRepository) GetByActor(ctx context.Context, companyID, actorID string, page, limit int) ([]*entity.ActivityLog, int, error) {
-	offset := (page - 1) * limit
+// CountSince counts logs of every company created at or after since
+func (r *ArangoActivityLogRepository) CountSince(ctx context.Context, since time.Time) (int, error) {
 	query := `
 		FOR log IN @@collection
-		FILTER log.company_id == @companyID AND log.actor_id == @actorID
-		SORT log.created_at DESC
-		LIMIT @offset, @limit
-		RETURN log
+		FILTER log.created_at >= @since
+		COLLECT WITH COUNT INTO total
+		RETURN total
 	`
 	bindVars := map[string]interface{}{
 		"@collection": r.collection.Name(),
-		"companyID":   companyID,
-		"actorID":     actorID,
-		"offset":      offset,
-		"limit":       limit,
+		"since":       since,
 	}
 
-	cursor, err := r.database.Query(ctx, query, bindVars)
+	cursor, err := r.reader.Query(ctx, query, bindVars)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query activity logs by actor: %w", err)
+		return 0, fmt.Errorf("failed to count activity logs since %s: %w", since.Format(time.RFC3339), err)
 	}
 	defer cursor.Close()
 
-	var logs []*entity.ActivityLog
-	for cursor.HasMore() {
-		var log entity.ActivityLog
-		_, err := cursor.ReadDocument(ctx, &log)
+	var total int
+	if cursor.HasMore() {
+		_, err := cursor.ReadDocument(ctx, &total)
 		if err != nil {
-			return nil, 0, fmt.Errorf("failed to read document: %w", err)
+			return 0, fmt.Errorf("failed to read count: %w", err)
 		}
-		logs = append(logs, &log)
 	}
 
-	// Get total count
-	countQuery := `
+	return total, nil
+}
+
+func (r *ArangoActivityLogRepository) CountByCountryCode(ctx context.Context, companyID string) (map[string]int, error) {
+	query := `
 		FOR log IN @@collection
-		FILTER log.company_id == @companyID AND log.actor_id == @actorID
-		COLLECT WITH COUNT INTO total
-		RETURN total
+		FILTER log.company_id == @companyID AND log.country_code != null AND log.country_code != ""
+		COLLECT countryCode = log.country_code WITH COUNT INTO total
+		RETURN { country_code: countryCode, total: total }
 	`
-	countCursor, err := r.database.Query(ctx, countQuery, map[string]interface{}{
+	bindVars := map[string]interface{}{
 		"@collection": r.collection.Name(),
 		"companyID":   companyID,
-		"actorID":     actorID,
-	})
+	}
+
+	cursor, err := r.reader.Query(ctx, query, bindVars)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count activity logs: %w", err)
+		return nil, fmt.Errorf("failed to count activity logs by country code: %w", err)
 	}
-	defer countCursor.Close()
+	defer cursor.Close()
 
-	var total int
-	if countCursor.HasMore() {
-		_, err := countCursor.ReadDocument(ctx, &total)
+	counts := make(map[string]int)
+	for cursor.HasMore() {
+		var row struct {
+			CountryCode string `json:"country_code"`
+			Total       int    `json:"total"`
+		}
+		_, err := cursor.ReadDocument(ctx, &row)
 		if err != nil {
-			return nil, 0, fmt.Errorf("failed to read count: %w", err)
+			return nil, fmt.Errorf("failed to read count: %w", err)
 		}
+		counts[row.CountryCode] = row.Total
 	}
 
-	return logs, total, nil
+	return counts, nil
 }
 
-func (r *ArangoActivityLogRepository) CountByCompanyID(ctx context.Context, companyID string) (int, error) {
+var _ repository.ActivityLogRepository = (*ArangoActivityLogRepository)(nil)
+
+func (r *ArangoActivityLogRepository) GetActiveCompanies(ctx context.Context, since time.Time) ([]*entity.CompanyActivity, error) {
 	query := `
 		FOR log IN @@collection
-		FILTER log.company_id == @companyID
-		COLLECT WITH COUNT INTO total
-		RETURN total
+		FILTER log.created_at >= @since
+		COLLECT companyID = log.company_id AGGREGATE lastActivityAt = MAX(log.created_at)
+		SORT lastActivityAt DESC
+		RETURN { company_id: companyID, last_activity_at: lastActivityAt }
 	`
 	bindVars := map[string]interface{}{
 		"@collection": r.collection.Name(),
-		"companyID":   companyID,
+		"since":       since,
 	}
 
-	cursor, err := r.database.Query(ctx, query, bindVars)
+	cursor, err := r.reader.Query(ctx, query, bindVars)
 	if err != nil {
-		return 0, fmt.Errorf("failed to count activity logs by company ID: %w", err)
+		return nil, fmt.Errorf("failed to query active companies: %w", err)
 	}
 	defer cursor.Close()
 
-	var total int
-	if cursor.HasMore() {
-		_, err := cursor.ReadDocument(ctx, &total)
+	var companies []*entity.CompanyActivity
+	for cursor.HasMore() {
+		var company entity.CompanyActivity
+		_, err := cursor.ReadDocument(ctx, &company)
 		if err != nil {
-			return 0, fmt.Errorf("failed to read count: %w", err)
+			return nil, fmt.Errorf("failed to read active company: %w", err)
 		}
+		companies = append(companies, &company)
 	}
 
-	return total, nil
+	return companies, nil
 }
-
-var _ repository.ActivityLogRepository = (*ArangoActivityLogRepository)(nil)
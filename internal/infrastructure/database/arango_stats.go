@@ -0,0 +1,107 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"activity-log-service/internal/domain/repository"
+)
+
+// groupKeys maps each grouping onto the AQL expression that yields its key.
+// created_at is stored as an RFC 3339 UTC string, so its first ten
+// characters are the calendar day.
+var groupKeys = map[repository.GroupBy]string{
+	repository.GroupByActivityName: "log.activity_name",
+	repository.GroupByActor:        "log.actor_id",
+	repository.GroupByDay:          "LEFT(log.created_at, 10)",
+}
+
+// CountGrouped counts logs matching filter per group key. Days come back in
+// chronological order, other groupings by descending count; at most limit
+// groups are returned, or all of them when limit is zero.
+func (r *ArangoActivityLogRepository) CountGrouped(ctx context.Context, filter repository.ActivityLogFilter, groupBy repository.GroupBy, limit int) ([]repository.GroupCount, error) {
+	key, ok := groupKeys[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported grouping %q", groupBy)
+	}
+
+	conditions, bindVars := buildFilterConditions(filter)
+	bindVars["@collection"] = r.collection.Name()
+
+	sort := "total DESC, key ASC"
+	if groupBy == repository.GroupByDay {
+		sort = "key ASC"
+	}
+	limitClause := ""
+	if limit > 0 {
+		limitClause = "LIMIT @limit"
+		bindVars["limit"] = limit
+	}
+
+	query := fmt.Sprintf(`
+		FOR log IN @@collection
+		FILTER %s
+		COLLECT key = %s WITH COUNT INTO total
+		SORT %s
+		%s
+		RETURN { key: key, count: total }
+	`, strings.Join(conditions, " AND "), key, sort, limitClause)
+
+	cursor, err := r.reader.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count activity logs by %s: %w", groupBy, err)
+	}
+	defer cursor.Close()
+
+	var counts []repository.GroupCount
+	for cursor.HasMore() {
+		var count repository.GroupCount
+		if _, err := cursor.ReadDocument(ctx, &count); err != nil {
+			return nil, fmt.Errorf("failed to read count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, nil
+}
+
+// mergeGroupCounts orders summed per-key totals the way CountGrouped does and
+// keeps at most limit of them, or all when limit is zero
+func mergeGroupCounts(totals map[string]int, groupBy repository.GroupBy, limit int) []repository.GroupCount {
+	counts := make([]repository.GroupCount, 0, len(totals))
+	for key, total := range totals {
+		counts = append(counts, repository.GroupCount{Key: key, Count: total})
+	}
+	slices.SortFunc(counts, func(a, b repository.GroupCount) int {
+		if groupBy != repository.GroupByDay {
+			if c := cmp.Compare(b.Count, a.Count); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(a.Key, b.Key)
+	})
+	if limit > 0 && len(counts) > limit {
+		counts = counts[:limit]
+	}
+	return counts
+}
+
+func (r *ArangoActivityLogRepository) CountByActivityName(ctx context.Context, companyID string, start, end time.Time) ([]repository.GroupCount, error) {
+	return r.CountGrouped(ctx, rangeFilter(companyID, start, end), repository.GroupByActivityName, 0)
+}
+
+func (r *ArangoActivityLogRepository) CountByActor(ctx context.Context, companyID string, start, end time.Time) ([]repository.GroupCount, error) {
+	return r.CountGrouped(ctx, rangeFilter(companyID, start, end), repository.GroupByActor, 0)
+}
+
+func (r *ArangoActivityLogRepository) CountPerDay(ctx context.Context, companyID string, start, end time.Time) ([]repository.GroupCount, error) {
+	return r.CountGrouped(ctx, rangeFilter(companyID, start, end), repository.GroupByDay, 0)
+}
+
+func rangeFilter(companyID string, start, end time.Time) repository.ActivityLogFilter {
+	return repository.ActivityLogFilter{CompanyID: companyID, StartDate: start, EndDate: end}
+}
@@ -0,0 +1,239 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/arangodb/go-driver"
+
+	"activity-log-service/internal/domain/repository"
+)
+
+// Rollup documents hold one count per company, granularity, bucket, dimension
+// and key. The "total" dimension counts every log of the company in the
+// bucket; a document with dimension "_complete" and no company marks the
+// bucket as fully rolled up.
+const (
+	dimensionTotal    = "total"
+	dimensionComplete = "_complete"
+)
+
+// rollupDimensions maps each grouping onto the dimension it is read from
+var rollupDimensions = map[repository.GroupBy]string{
+	repository.GroupByActivityName: "activity_name",
+	repository.GroupByActor:        "actor",
+	repository.GroupByDay:          dimensionTotal,
+}
+
+type ArangoActivityStatsRepository struct {
+	database   driver.Database
+	logs       driver.Collection
+	collection driver.Collection
+}
+
+// NewArangoActivityStatsRepository stores rollups of the logs repository in
+// collectionName, creating it with the given cluster layout when missing
+func NewArangoActivityStatsRepository(logs *ArangoActivityLogRepository, collectionName string, cluster ClusterOptions) (*ArangoActivityStatsRepository, error) {
+	ctx := context.Background()
+
+	collection, err := logs.database.Collection(ctx, collectionName)
+	if driver.IsNotFound(err) {
+		collection, err = logs.database.CreateCollection(ctx, collectionName, cluster.collectionOptions())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stats collection: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open stats collection: %w", err)
+	}
+
+	// Serves both the UPSERT lookups and the range reads, and keeps a
+	// re-run rollup from duplicating counts
+	_, _, err = collection.EnsurePersistentIndex(ctx,
+		[]string{"granularity", "company_id", "dimension", "bucket_start", "key"},
+		&driver.EnsurePersistentIndexOptions{Name: "idx_rollup", Unique: true},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure stats index: %w", err)
+	}
+
+	// Serves TopCompanies, which reads the totals of every company
+	_, _, err = collection.EnsurePersistentIndex(ctx,
+		[]string{"granularity", "dimension", "bucket_start"},
+		&driver.EnsurePersistentIndexOptions{Name: "idx_rollup_totals"},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure stats totals index: %w", err)
+	}
+
+	return &ArangoActivityStatsRepository{
+		database:   logs.database,
+		logs:       logs.collection,
+		collection: collection,
+	}, nil
+}
+
+func (r *ArangoActivityStatsRepository) Rollup(ctx context.Context, granularity repository.Granularity, bucketStart time.Time) error {
+	query := `
+		FOR log IN @@logs
+		FILTER log.created_at >= @start AND log.created_at < @end
+		FOR dim IN [
+			{ name: "activity_name", key: log.activity_name },
+			{ name: "actor", key: log.actor_id },
+			{ name: @total, key: "" }
+		]
+		COLLECT companyID = log.company_id, dimension = dim.name, key = dim.key WITH COUNT INTO count
+		UPSERT { granularity: @granularity, company_id: companyID, dimension: dimension, bucket_start: @start, key: key }
+		INSERT { granularity: @granularity, company_id: companyID, dimension: dimension, bucket_start: @start, key: key, count: count }
+		UPDATE { count: count }
+		IN @@stats
+	`
+	bindVars := map[string]interface{}{
+		"@logs":       r.logs.Name(),
+		"@stats":      r.collection.Name(),
+		"granularity": string(granularity),
+		"total":       dimensionTotal,
+		"start":       bucketStart,
+		"end":         bucketStart.Add(granularity.Duration()),
+	}
+
+	cursor, err := r.database.Query(ctx, query, bindVars)
+	if err != nil {
+		return fmt.Errorf("failed to roll up %s bucket %s: %w", granularity, bucketStart.Format(time.RFC3339), err)
+	}
+	cursor.Close()
+
+	marker := `
+		UPSERT { granularity: @granularity, company_id: "", dimension: @complete, bucket_start: @start, key: "" }
+		INSERT { granularity: @granularity, company_id: "", dimension: @complete, bucket_start: @start, key: "", completed_at: DATE_ISO8601(DATE_NOW()) }
+		UPDATE { completed_at: DATE_ISO8601(DATE_NOW()) }
+		IN @@stats
+	`
+	cursor, err = r.database.Query(ctx, marker, map[string]interface{}{
+		"@stats":      r.collection.Name(),
+		"granularity": string(granularity),
+		"complete":    dimensionComplete,
+		"start":       bucketStart,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark %s bucket %s complete: %w", granularity, bucketStart.Format(time.RFC3339), err)
+	}
+	cursor.Close()
+
+	return nil
+}
+
+func (r *ArangoActivityStatsRepository) RolledUpBuckets(ctx context.Context, granularity repository.Granularity, from, to time.Time) ([]time.Time, error) {
+	query := `
+		FOR s IN @@stats
+		FILTER s.granularity == @granularity AND s.company_id == "" AND s.dimension == @complete
+		FILTER s.bucket_start >= @from AND s.bucket_start < @to
+		RETURN s.bucket_start
+	`
+	bindVars := map[string]interface{}{
+		"@stats":      r.collection.Name(),
+		"granularity": string(granularity),
+		"complete":    dimensionComplete,
+		"from":        from,
+		"to":          to,
+	}
+
+	cursor, err := r.database.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rolled up buckets: %w", err)
+	}
+	defer cursor.Close()
+
+	var buckets []time.Time
+	for cursor.HasMore() {
+		var bucket time.Time
+		if _, err := cursor.ReadDocument(ctx, &bucket); err != nil {
+			return nil, fmt.Errorf("failed to read bucket: %w", err)
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, nil
+}
+
+func (r *ArangoActivityStatsRepository) CountGrouped(ctx context.Context, companyID string, groupBy repository.GroupBy, granularity repository.Granularity, from, to time.Time) ([]repository.GroupCount, error) {
+	dimension, ok := rollupDimensions[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported grouping %q", groupBy)
+	}
+
+	key := "s.key"
+	if groupBy == repository.GroupByDay {
+		key = "LEFT(s.bucket_start, 10)"
+	}
+
+	query := fmt.Sprintf(`
+		FOR s IN @@stats
+		FILTER s.granularity == @granularity AND s.company_id == @companyID AND s.dimension == @dimension
+		FILTER s.bucket_start >= @from AND s.bucket_start < @to
+		COLLECT key = %s AGGREGATE total = SUM(s.count)
+		RETURN { key: key, count: total }
+	`, key)
+	bindVars := map[string]interface{}{
+		"@stats":      r.collection.Name(),
+		"granularity": string(granularity),
+		"companyID":   companyID,
+		"dimension":   dimension,
+		"from":        from,
+		"to":          to,
+	}
+
+	cursor, err := r.database.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read activity stats rollup: %w", err)
+	}
+	defer cursor.Close()
+
+	var counts []repository.GroupCount
+	for cursor.HasMore() {
+		var count repository.GroupCount
+		if _, err := cursor.ReadDocument(ctx, &count); err != nil {
+			return nil, fmt.Errorf("failed to read count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, nil
+}
+
+func (r *ArangoActivityStatsRepository) TopCompanies(ctx context.Context, granularity repository.Granularity, from, to time.Time, limit int) ([]repository.GroupCount, error) {
+	query := `
+		FOR s IN @@stats
+		FILTER s.granularity == @granularity AND s.dimension == @dimension
+		FILTER s.bucket_start >= @from AND s.bucket_start < @to
+		COLLECT companyID = s.company_id AGGREGATE total = SUM(s.count)
+		SORT total DESC, companyID
+		LIMIT @limit
+		RETURN { key: companyID, count: total }
+	`
+	bindVars := map[string]interface{}{
+		"@stats":      r.collection.Name(),
+		"granularity": string(granularity),
+		"dimension":   dimensionTotal,
+		"from":        from,
+		"to":          to,
+		"limit":       limit,
+	}
+
+	cursor, err := r.database.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read top companies: %w", err)
+	}
+	defer cursor.Close()
+
+	var counts []repository.GroupCount
+	for cursor.HasMore() {
+		var count repository.GroupCount
+		if _, err := cursor.ReadDocument(ctx, &count); err != nil {
+			return nil, fmt.Errorf("failed to read count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arangodb/go-driver"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+)
+
+// WithTransaction runs fn in a stream transaction on the activity log
+// collection. Starting another transaction from the repository passed to fn,
+// e.g. through CreateBatchAtomic, joins the running one.
+func (r *ArangoActivityLogRepository) WithTransaction(ctx context.Context, fn func(repo repository.ActivityLogRepository) error) error {
+	if r.inTransaction {
+		return fn(r)
+	}
+
+	return runTransaction(ctx, r.database, []string{r.collection.Name()}, func(tid driver.TransactionID) error {
+		return fn(r.inTx(tid))
+	})
+}
+
+// inTx returns a copy of the repository whose reads and writes run in the
+// stream transaction tid. Reads stay on the write endpoints there, which
+// hold the transaction.
+func (r *ArangoActivityLogRepository) inTx(tid driver.TransactionID) *ArangoActivityLogRepository {
+	database := transactionDatabase{Database: r.database, id: tid}
+	return &ArangoActivityLogRepository{
+		client:          r.client,
+		database:        database,
+		reader:          database,
+		maxResultWindow: r.maxResultWindow,
+		collection:      transactionCollection{Collection: r.collection, id: tid},
+		inTransaction:   true,
+	}
+}
+
+// runTransaction begins a stream transaction writing to collections, runs fn
+// in it, and commits unless fn fails
+func runTransaction(ctx context.Context, database driver.Database, collections []string, fn func(tid driver.TransactionID) error) error {
+	tid, err := database.BeginTransaction(ctx, driver.TransactionCollections{Write: collections}, nil)
+	if err != nil {
+		if isUnavailable(err) {
+			return fmt.Errorf("failed to begin transaction: %w: %v", entity.ErrDatabaseUnavailable, err)
+		}
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(tid); err != nil {
+		if abortErr := database.AbortTransaction(ctx, tid, nil); abortErr != nil {
+			return fmt.Errorf("failed to abort transaction after %v: %w", err, abortErr)
+		}
+		return err
+	}
+
+	if err := database.CommitTransaction(ctx, tid, nil); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// transactionDatabase runs every query inside a stream transaction
+type transactionDatabase struct {
+	driver.Database
+	id driver.TransactionID
+}
+
+func (d transactionDatabase) Query(ctx context.Context, query string, bindVars map[string]interface{}) (driver.Cursor, error) {
+	return d.Database.Query(driver.WithTransactionID(ctx, d.id), query, bindVars)
+}
+
+// transactionCollection runs the document operations the repository uses
+// inside a stream transaction
+type transactionCollection struct {
+	driver.Collection
+	id driver.TransactionID
+}
+
+func (c transactionCollection) ReadDocument(ctx context.Context, key string, result interface{}) (driver.DocumentMeta, error) {
+	return c.Collection.ReadDocument(driver.WithTransactionID(ctx, c.id), key, result)
+}
+
+func (c transactionCollection) CreateDocument(ctx context.Context, document interface{}) (driver.DocumentMeta, error) {
+	return c.Collection.CreateDocument(driver.WithTransactionID(ctx, c.id), document)
+}
+
+func (c transactionCollection) CreateDocuments(ctx context.Context, documents interface{}) (driver.DocumentMetaSlice, driver.ErrorSlice, error) {
+	return c.Collection.CreateDocuments(driver.WithTransactionID(ctx, c.id), documents)
+}
+
+func (c transactionCollection) UpdateDocument(ctx context.Context, key string, update interface{}) (driver.DocumentMeta, error) {
+	return c.Collection.UpdateDocument(driver.WithTransactionID(ctx, c.id), key, update)
+}
+
+func (c transactionCollection) RemoveDocument(ctx context.Context, key string) (driver.DocumentMeta, error) {
+	return c.Collection.RemoveDocument(driver.WithTransactionID(ctx, c.id), key)
+}
+
+var _ repository.Transactor = (*ArangoActivityLogRepository)(nil)
@@ -0,0 +1,49 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/arangodb/go-driver"
+)
+
+// deadlineDatabase passes the caller's deadline on to the server. A context
+// deadline alone only stops the client waiting; the server keeps running the
+// AQL query until it is told its maxRuntime and kills it. A non-zero timeout
+// caps the runtime of queries whose caller set no earlier deadline.
+type deadlineDatabase struct {
+	driver.Database
+	timeout time.Duration
+}
+
+type untimedKey struct{}
+
+// untimed exempts the queries run with ctx from the configured timeout.
+// Streaming cursors stay open while the caller works through them, so only
+// the caller's own deadline bounds them.
+func untimed(ctx context.Context) context.Context {
+	return context.WithValue(ctx, untimedKey{}, true)
+}
+
+func (d deadlineDatabase) Query(ctx context.Context, query string, bindVars map[string]interface{}) (driver.Cursor, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var runtime time.Duration
+	if d.timeout > 0 && ctx.Value(untimedKey{}) == nil {
+		runtime = d.timeout
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); runtime == 0 || remaining < runtime {
+			runtime = remaining
+		}
+		// maxRuntime 0 means unlimited, so never round a nearly expired
+		// deadline down to it
+		runtime = max(runtime, time.Millisecond)
+	}
+	if runtime > 0 {
+		ctx = driver.WithQueryMaxRuntime(ctx, runtime.Seconds())
+	}
+	return d.Database.Query(ctx, query, bindVars)
+}
@@ -0,0 +1,664 @@
+package database
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+	"activity-log-service/internal/domain/valueobject"
+)
+
+var (
+	errDuplicateExternalID = errors.New("the company already has an activity log with this external id")
+)
+
+// journalEntry is one line of the embedded store's file
+type journalEntry struct {
+	Op  string              `json:"op"`
+	Log *entity.ActivityLog `json:"log,omitempty"`
+	ID  string              `json:"id,omitempty"`
+}
+
+const (
+	opPut    = "put"
+	opDelete = "delete"
+)
+
+// EmbeddedActivityLogRepository keeps every log in memory and persists them
+// to a single append-only JSON lines file, so the service runs with no
+// database at all. Every query scans all logs: it is meant for local
+// development, not for production volumes.
+type EmbeddedActivityLogRepository struct {
+	mu   sync.RWMutex
+	logs map[string]*entity.ActivityLog
+	file *os.File
+}
+
+// NewEmbeddedActivityLogRepository opens the store at path, creating it if
+// needed. The file is compacted on open, so it only grows by the changes of
+// one run.
+func NewEmbeddedActivityLogRepository(path string) (*EmbeddedActivityLogRepository, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create embedded store directory: %w", err)
+	}
+
+	logs, err := replayJournal(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := compactJournal(path, logs); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded store: %w", err)
+	}
+
+	return &EmbeddedActivityLogRepository{logs: logs, file: file}, nil
+}
+
+func replayJournal(path string) (map[string]*entity.ActivityLog, error) {
+	logs := make(map[string]*entity.ActivityLog)
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return logs, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded store: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16<<20)
+	for line := 1; scanner.Scan(); line++ {
+		var entry journalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to read embedded store line %d: %w", line, err)
+		}
+		switch entry.Op {
+		case opPut:
+			logs[entry.Log.ID.String()] = entry.Log
+		case opDelete:
+			delete(logs, entry.ID)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read embedded store: %w", err)
+	}
+	return logs, nil
+}
+
+// compactJournal rewrites the file with one put per live log, replacing it
+// only once the new file is complete
+func compactJournal(path string, logs map[string]*entity.ActivityLog) error {
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("failed to compact embedded store: %w", err)
+	}
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, activityLog := range logs {
+		if err := encoder.Encode(journalEntry{Op: opPut, Log: activityLog}); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to compact embedded store: %w", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to compact embedded store: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to compact embedded store: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to compact embedded store: %w", err)
+	}
+	return nil
+}
+
+// Close releases the store's file
+func (r *EmbeddedActivityLogRepository) Close() error {
+	return r.file.Close()
+}
+
+// append persists entries; callers hold the write lock and only apply the
+// change in memory once it is on disk
+func (r *EmbeddedActivityLogRepository) append(entries ...journalEntry) error {
+	var buf strings.Builder
+	encoder := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("failed to encode activity log: %w", err)
+		}
+	}
+	if _, err := r.file.WriteString(buf.String()); err != nil {
+		return fmt.Errorf("failed to write embedded store: %w", err)
+	}
+	return nil
+}
+
+// conflict reports why activityLog cannot be inserted next to the stored logs
+func (r *EmbeddedActivityLogRepository) conflict(activityLog *entity.ActivityLog) error {
+	if _, ok := r.logs[activityLog.ID.String()]; ok {
+		return entity.ErrActivityLogExists
+	}
+	if activityLog.ExternalID != "" && r.findByExternalID(activityLog.CompanyID, activityLog.ExternalID) != nil {
+		return errDuplicateExternalID
+	}
+	return nil
+}
+
+func (r *EmbeddedActivityLogRepository) findByExternalID(companyID, externalID string) *entity.ActivityLog {
+	for _, activityLog := range r.logs {
+		if activityLog.CompanyID == companyID && activityLog.ExternalID == externalID {
+			return activityLog
+		}
+	}
+	return nil
+}
+
+// put stores copies of the logs, so callers cannot change them behind the
+// store's back
+func (r *EmbeddedActivityLogRepository) put(activityLogs ...*entity.ActivityLog) error {
+	entries := make([]journalEntry, len(activityLogs))
+	for i, activityLog := range activityLogs {
+		stored := *activityLog
+		entries[i] = journalEntry{Op: opPut, Log: &stored}
+	}
+	if err := r.append(entries...); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		r.logs[entry.Log.ID.String()] = entry.Log
+	}
+	return nil
+}
+
+func (r *EmbeddedActivityLogRepository) Create(ctx context.Context, activityLog *entity.ActivityLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.conflict(activityLog); err != nil {
+		return fmt.Errorf("failed to create activity log: %w", err)
+	}
+	if err := r.put(activityLog); err != nil {
+		return fmt.Errorf("failed to create activity log: %w", err)
+	}
+	return nil
+}
+
+// createEach stores the logs one by one and returns the per-log errors in
+// input order
+func (r *EmbeddedActivityLogRepository) createEach(activityLogs []*entity.ActivityLog) ([]error, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	errs := make([]error, len(activityLogs))
+	for i, activityLog := range activityLogs {
+		if err := r.conflict(activityLog); err != nil {
+			errs[i] = err
+			continue
+		}
+		if err := r.put(activityLog); err != nil {
+			return nil, fmt.Errorf("failed to create activity logs: %w", err)
+		}
+	}
+	return errs, nil
+}
+
+func (r *EmbeddedActivityLogRepository) CreateBatch(ctx context.Context, activityLogs []*entity.ActivityLog) ([]error, error) {
+	return r.createEach(activityLogs)
+}
+
+func (r *EmbeddedActivityLogRepository) CreateMany(ctx context.Context, activityLogs []*entity.ActivityLog) error {
+	errs, err := r.createEach(activityLogs)
+	if err != nil {
+		return err
+	}
+	for _, err := range errs {
+		if err != nil {
+			return &repository.CreateManyError{Errs: errs}
+		}
+	}
+	return nil
+}
+
+// CreateBatchAtomic checks every log before storing any, so either all of
+// them are stored or none
+func (r *EmbeddedActivityLogRepository) CreateBatchAtomic(ctx context.Context, activityLogs []*entity.ActivityLog) ([]error, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	errs := make([]error, len(activityLogs))
+	ids := make(map[string]bool, len(activityLogs))
+	externalIDs := make(map[[2]string]bool)
+	rejected := false
+	for i, activityLog := range activityLogs {
+		err := r.conflict(activityLog)
+		externalKey := [2]string{activityLog.CompanyID, activityLog.ExternalID}
+		switch {
+		case err != nil:
+		case ids[activityLog.ID.String()]:
+			err = entity.ErrActivityLogExists
+		case activityLog.ExternalID != "" && externalIDs[externalKey]:
+			err = errDuplicateExternalID
+		}
+		ids[activityLog.ID.String()] = true
+		if activityLog.ExternalID != "" {
+			externalIDs[externalKey] = true
+		}
+		if err != nil {
+			errs[i] = err
+			rejected = true
+		}
+	}
+	if rejected {
+		return errs, nil
+	}
+
+	if err := r.put(activityLogs...); err != nil {
+		return nil, fmt.Errorf("failed to create activity logs: %w", err)
+	}
+	return errs, nil
+}
+
+func (r *EmbeddedActivityLogRepository) CreateByExternalID(ctx context.Context, activityLog *entity.ActivityLog, update bool) (*entity.ActivityLog, repository.WriteOutcome, error) {
+	if activityLog.ExternalID == "" {
+		return nil, "", fmt.Errorf("external id is required: %w", entity.ErrInvalidExternalID)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing := r.findByExternalID(activityLog.CompanyID, activityLog.ExternalID)
+	if existing == nil {
+		if err := r.conflict(activityLog); err != nil {
+			return nil, "", fmt.Errorf("failed to create activity log: %w", err)
+		}
+		if err := r.put(activityLog); err != nil {
+			return nil, "", fmt.Errorf("failed to create activity log: %w", err)
+		}
+		return activityLog, repository.WriteCreated, nil
+	}
+
+	if !update {
+		stored := *existing
+		return &stored, repository.WriteSkipped, nil
+	}
+
+	replacement := *activityLog
+	replacement.ID = existing.ID
+	replacement.CreatedAt = existing.CreatedAt
+	if err := r.put(&replacement); err != nil {
+		return nil, "", fmt.Errorf("failed to update activity log by external id: %w", err)
+	}
+	return &replacement, repository.WriteUpdated, nil
+}
+
+func (r *EmbeddedActivityLogRepository) GetByID(ctx context.Context, id valueobject.ActivityLogID) (*entity.ActivityLog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	activityLog, ok := r.logs[id.String()]
+	if !ok {
+		return nil, entity.ErrActivityLogNotFound
+	}
+	stored := *activityLog
+	return &stored, nil
+}
+
+func (r *EmbeddedActivityLogRepository) GetByIDs(ctx context.Context, ids []valueobject.ActivityLogID) ([]*entity.ActivityLog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	found := make(map[valueobject.ActivityLogID]*entity.ActivityLog, len(ids))
+	for _, id := range ids {
+		if activityLog, ok := r.logs[id.String()]; ok {
+			stored := *activityLog
+			found[id] = &stored
+		}
+	}
+	return inIDOrder(ids, found), nil
+}
+
+func (r *EmbeddedActivityLogRepository) GetByCompanyID(ctx context.Context, companyID string, page, limit int) ([]*entity.ActivityLog, int, error) {
+	matching := r.find(repository.ActivityLogFilter{CompanyID: companyID})
+	return paginate(matching, (page-1)*limit, limit), len(matching), nil
+}
+
+func (r *EmbeddedActivityLogRepository) Update(ctx context.Context, activityLog *entity.ActivityLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.logs[activityLog.ID.String()]; !ok {
+		return entity.ErrActivityLogNotFound
+	}
+	if err := r.put(activityLog); err != nil {
+		return fmt.Errorf("failed to update activity log: %w", err)
+	}
+	return nil
+}
+
+func (r *EmbeddedActivityLogRepository) Delete(ctx context.Context, id valueobject.ActivityLogID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.logs[id.String()]; !ok {
+		return entity.ErrActivityLogNotFound
+	}
+	if err := r.remove(id.String()); err != nil {
+		return fmt.Errorf("failed to delete activity log: %w", err)
+	}
+	return nil
+}
+
+func (r *EmbeddedActivityLogRepository) remove(ids ...string) error {
+	entries := make([]journalEntry, len(ids))
+	for i, id := range ids {
+		entries[i] = journalEntry{Op: opDelete, ID: id}
+	}
+	if err := r.append(entries...); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		delete(r.logs, id)
+	}
+	return nil
+}
+
+func (r *EmbeddedActivityLogRepository) DeleteOlderThan(ctx context.Context, companyID string, cutoff time.Time, limit int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var ids []string
+	for id, activityLog := range r.logs {
+		if limit > 0 && len(ids) == limit {
+			break
+		}
+		if activityLog.CompanyID == companyID && activityLog.CreatedAt.Before(cutoff) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if err := r.remove(ids...); err != nil {
+		return 0, fmt.Errorf("failed to delete activity logs older than %s: %w", cutoff.Format(time.RFC3339), err)
+	}
+	return len(ids), nil
+}
+
+func (r *EmbeddedActivityLogRepository) CompaniesWithLogsBefore(ctx context.Context, cutoff time.Time) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var companies []string
+	for _, activityLog := range r.logs {
+		if activityLog.CreatedAt.Before(cutoff) && !seen[activityLog.CompanyID] {
+			seen[activityLog.CompanyID] = true
+			companies = append(companies, activityLog.CompanyID)
+		}
+	}
+	slices.Sort(companies)
+	return companies, nil
+}
+
+func (r *EmbeddedActivityLogRepository) CountByCompanyID(ctx context.Context, companyID string) (int, error) {
+	return len(r.find(repository.ActivityLogFilter{CompanyID: companyID})), nil
+}
+
+func (r *EmbeddedActivityLogRepository) CountSince(ctx context.Context, since time.Time) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	total := 0
+	for _, activityLog := range r.logs {
+		if !activityLog.CreatedAt.Before(since) {
+			total++
+		}
+	}
+	return total, nil
+}
+
+func (r *EmbeddedActivityLogRepository) CountByCountryCode(ctx context.Context, companyID string) (map[string]int, error) {
+	counts := make(map[string]int)
+	for _, activityLog := range r.find(repository.ActivityLogFilter{CompanyID: companyID}) {
+		if activityLog.CountryCode != "" {
+			counts[activityLog.CountryCode]++
+		}
+	}
+	return counts, nil
+}
+
+func (r *EmbeddedActivityLogRepository) GetActiveCompanies(ctx context.Context, since time.Time) ([]*entity.CompanyActivity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	latest := make(map[string]time.Time)
+	for _, activityLog := range r.logs {
+		if !activityLog.CreatedAt.Before(since) && activityLog.CreatedAt.After(latest[activityLog.CompanyID]) {
+			latest[activityLog.CompanyID] = activityLog.CreatedAt
+		}
+	}
+
+	companies := make([]*entity.CompanyActivity, 0, len(latest))
+	for companyID, lastActivityAt := range latest {
+		companies = append(companies, &entity.CompanyActivity{CompanyID: companyID, LastActivityAt: lastActivityAt})
+	}
+	slices.SortFunc(companies, func(a, b *entity.CompanyActivity) int {
+		return b.LastActivityAt.Compare(a.LastActivityAt)
+	})
+	return companies, nil
+}
+
+// find returns copies of the logs matching filter in created_at DESC, id DESC
+// order, the order every list query uses
+func (r *EmbeddedActivityLogRepository) find(filter repository.ActivityLogFilter) []*entity.ActivityLog {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matching []*entity.ActivityLog
+	for _, activityLog := range r.logs {
+		if matches(activityLog, filter) {
+			stored := *activityLog
+			matching = append(matching, &stored)
+		}
+	}
+	slices.SortFunc(matching, func(a, b *entity.ActivityLog) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.ID, a.ID)
+	})
+	return matching
+}
+
+// matches mirrors buildFilterConditions
+func matches(activityLog *entity.ActivityLog, filter repository.ActivityLogFilter) bool {
+	switch {
+	case activityLog.CompanyID != filter.CompanyID,
+		filter.ObjectID != "" && activityLog.ObjectID != filter.ObjectID,
+		filter.ActivityName != "" && activityLog.ActivityName != filter.ActivityName,
+		filter.ActorID != "" && activityLog.ActorID != filter.ActorID,
+		filter.DeviceID != "" && activityLog.DeviceID != filter.DeviceID,
+		filter.IPAddress != "" && activityLog.IPAddress != filter.IPAddress,
+		filter.UserAgent != "" && activityLog.UserAgent != filter.UserAgent,
+		filter.CountryCode != "" && activityLog.CountryCode != filter.CountryCode,
+		!filter.StartDate.IsZero() && activityLog.CreatedAt.Before(filter.StartDate),
+		!filter.EndDate.IsZero() && activityLog.CreatedAt.After(filter.EndDate),
+		filter.Text != "" && !strings.Contains(strings.ToLower(activityLog.FormattedMessage), strings.ToLower(filter.Text)):
+		return false
+	}
+	for _, tag := range filter.Tags {
+		if !slices.Contains(activityLog.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func paginate(activityLogs []*entity.ActivityLog, offset, limit int) []*entity.ActivityLog {
+	if offset >= len(activityLogs) {
+		return nil
+	}
+	return activityLogs[offset:min(offset+limit, len(activityLogs))]
+}
+
+type sliceIterator struct {
+	activityLogs []*entity.ActivityLog
+}
+
+func (it *sliceIterator) HasMore() bool {
+	return len(it.activityLogs) > 0
+}
+
+func (it *sliceIterator) Next(ctx context.Context) (*entity.ActivityLog, error) {
+	if len(it.activityLogs) == 0 {
+		return nil, errors.New("no more activity logs")
+	}
+	activityLog := it.activityLogs[0]
+	it.activityLogs = it.activityLogs[1:]
+	return activityLog, nil
+}
+
+func (it *sliceIterator) Close() error {
+	return nil
+}
+
+// OpenCursor iterates over a snapshot of the matching logs taken when it is
+// opened
+func (r *EmbeddedActivityLogRepository) OpenCursor(ctx context.Context, filter repository.ActivityLogFilter) (repository.ActivityLogIterator, error) {
+	return &sliceIterator{activityLogs: r.find(filter)}, nil
+}
+
+func (r *EmbeddedActivityLogRepository) Iterate(ctx context.Context, filter repository.ActivityLogFilter, fn func(*entity.ActivityLog) error) error {
+	for _, activityLog := range r.find(filter) {
+		if err := fn(activityLog); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *EmbeddedActivityLogRepository) Search(ctx context.Context, filter repository.ActivityLogFilter, page repository.SearchPage) (*repository.SearchResult, error) {
+	matching := r.find(filter)
+
+	var (
+		activityLogs []*entity.ActivityLog
+		more         bool
+		result       = &repository.SearchResult{}
+	)
+	if page.Number > 0 {
+		offset := (page.Number - 1) * page.Limit
+		activityLogs = paginate(matching, offset, page.Limit)
+		result.Total = len(matching)
+		more = len(activityLogs) > 0 && offset+len(activityLogs) < result.Total
+	} else {
+		start := 0
+		if after := page.After; after != nil {
+			start = slices.IndexFunc(matching, func(activityLog *entity.ActivityLog) bool {
+				c := activityLog.CreatedAt.Compare(after.CreatedAt)
+				return c < 0 || c == 0 && activityLog.ID.String() < after.ID
+			})
+			if start < 0 {
+				start = len(matching)
+			}
+		}
+		activityLogs = paginate(matching, start, page.Limit)
+		more = start+len(activityLogs) < len(matching)
+	}
+
+	result.ActivityLogs = activityLogs
+	if more {
+		last := activityLogs[len(activityLogs)-1]
+		result.Next = &repository.SearchCursor{CreatedAt: last.CreatedAt, ID: last.ID.String()}
+	}
+	return result, nil
+}
+
+// CountGrouped counts in the same order as the ArangoDB repository: days
+// chronologically, other groupings by descending count
+func (r *EmbeddedActivityLogRepository) CountGrouped(ctx context.Context, filter repository.ActivityLogFilter, groupBy repository.GroupBy, limit int) ([]repository.GroupCount, error) {
+	var key func(*entity.ActivityLog) string
+	switch groupBy {
+	case repository.GroupByActivityName:
+		key = func(activityLog *entity.ActivityLog) string { return activityLog.ActivityName }
+	case repository.GroupByActor:
+		key = func(activityLog *entity.ActivityLog) string { return activityLog.ActorID }
+	case repository.GroupByDay:
+		key = func(activityLog *entity.ActivityLog) string { return activityLog.CreatedAt.UTC().Format(time.DateOnly) }
+	default:
+		return nil, fmt.Errorf("unsupported grouping %q", groupBy)
+	}
+
+	totals := make(map[string]int)
+	for _, activityLog := range r.find(filter) {
+		totals[key(activityLog)]++
+	}
+
+	return mergeGroupCounts(totals, groupBy, limit), nil
+}
+
+func (r *EmbeddedActivityLogRepository) CountByActivityName(ctx context.Context, companyID string, start, end time.Time) ([]repository.GroupCount, error) {
+	return r.CountGrouped(ctx, rangeFilter(companyID, start, end), repository.GroupByActivityName, 0)
+}
+
+func (r *EmbeddedActivityLogRepository) CountByActor(ctx context.Context, companyID string, start, end time.Time) ([]repository.GroupCount, error) {
+	return r.CountGrouped(ctx, rangeFilter(companyID, start, end), repository.GroupByActor, 0)
+}
+
+func (r *EmbeddedActivityLogRepository) CountPerDay(ctx context.Context, companyID string, start, end time.Time) ([]repository.GroupCount, error) {
+	return r.CountGrouped(ctx, rangeFilter(companyID, start, end), repository.GroupByDay, 0)
+}
+
+func (r *EmbeddedActivityLogRepository) DistinctActivityNames(ctx context.Context, companyID string) ([]string, error) {
+	seen := make(map[string]bool)
+	names := []string{}
+	for _, activityLog := range r.find(repository.ActivityLogFilter{CompanyID: companyID}) {
+		if !seen[activityLog.ActivityName] {
+			seen[activityLog.ActivityName] = true
+			names = append(names, activityLog.ActivityName)
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+func (r *EmbeddedActivityLogRepository) DistinctActors(ctx context.Context, companyID string) ([]repository.Actor, error) {
+	var actors []repository.Actor
+	for _, activityLog := range r.find(repository.ActivityLogFilter{CompanyID: companyID}) {
+		actors = append(actors, repository.Actor{
+			ID:         activityLog.ActorID,
+			Name:       activityLog.ActorName,
+			Email:      activityLog.ActorEmail,
+			LastSeenAt: activityLog.CreatedAt,
+		})
+	}
+	return mergeActors(actors), nil
+}
+
+// Reindex has nothing to do: every query scans all logs
+func (r *EmbeddedActivityLogRepository) Reindex(ctx context.Context) ([]repository.IndexStatus, error) {
+	return nil, nil
+}
+
+var (
+	_ repository.ActivityLogRepository = (*EmbeddedActivityLogRepository)(nil)
+	_ repository.IndexManager          = (*EmbeddedActivityLogRepository)(nil)
+)
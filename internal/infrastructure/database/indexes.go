@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/arangodb/go-driver"
+
+	"activity-log-service/internal/domain/repository"
+)
+
+// managedIndex is a persistent index the repository creates and keeps in
+// shape itself rather than leaving to the migrations
+type managedIndex struct {
+	name   string
+	fields []string
+	unique bool
+	sparse bool
+}
+
+// managedIndexes is the versioned definition of every index on the activity
+// log collection. Changing an entry here changes the index everywhere: new
+// deployments create it on start and Reindex rebuilds it on existing ones.
+// Names match the ones migration 002 used, so databases it set up are picked
+// up rather than indexed twice.
+var managedIndexes = []managedIndex{
+	// Company listings and date ranges, newest first
+	{name: "idx_company_created_at", fields: []string{"company_id", "created_at"}},
+	// Activity of a single object, actor or activity type within a company
+	{name: "idx_company_object", fields: []string{"company_id", "object_id"}},
+	{name: "idx_company_actor", fields: []string{"company_id", "actor_id"}},
+	{name: "idx_company_activity", fields: []string{"company_id", "activity_name"}},
+	// Makes external IDs unique per company. Logs without one are left out
+	// of the sparse index. Conditional creates rely on it.
+	{name: "idx_company_external_id", fields: []string{"company_id", "external_id"}, unique: true, sparse: true},
+	// Looks logs up by ID and makes IDs unique, so a redelivered log
+	// conflicts instead of being stored twice. A cluster only allows unique
+	// indexes that cover the shard key, company_id.
+	{name: "idx_id_company", fields: []string{"id", "company_id"}, unique: true},
+	// Service-wide counts of recent logs, e.g. for the public status page
+	{name: "idx_created_at", fields: []string{"created_at"}},
+}
+
+// persistentTypes are the index types RocksDB implements as persistent
+// indexes; hash and skiplist are legacy aliases older migrations still use
+var persistentTypes = []driver.IndexType{driver.PersistentIndex, driver.HashIndex, driver.SkipListIndex}
+
+func (m managedIndex) matches(index driver.Index) bool {
+	return slices.Contains(persistentTypes, index.Type()) &&
+		slices.Equal(index.Fields(), m.fields) &&
+		index.Unique() == m.unique &&
+		index.Sparse() == m.sparse
+}
+
+func (m managedIndex) ensure(ctx context.Context, collection driver.Collection) error {
+	_, _, err := collection.EnsurePersistentIndex(ctx, m.fields, &driver.EnsurePersistentIndexOptions{
+		Name:   m.name,
+		Unique: m.unique,
+		Sparse: m.sparse,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to ensure index %s: %w", m.name, err)
+	}
+	return nil
+}
+
+// EnsureIndexes creates any managed index that does not exist yet. It never
+// touches existing ones; use Reindex to rebuild those that drifted.
+func (r *ArangoActivityLogRepository) EnsureIndexes(ctx context.Context) error {
+	return ensureIndexes(ctx, r.collection)
+}
+
+func ensureIndexes(ctx context.Context, collection driver.Collection) error {
+	for _, index := range managedIndexes {
+		if err := index.ensure(ctx, collection); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexPlan is what Reindex has to do to one managed index. existing is
+// the index to drop first when it has drifted.
+type indexPlan struct {
+	index    managedIndex
+	existing driver.Index
+	action   repository.IndexAction
+}
+
+func planIndexes(ctx context.Context, collection driver.Collection) ([]indexPlan, error) {
+	indexes, err := collection.Indexes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexes: %w", err)
+	}
+	existing := make(map[string]driver.Index, len(indexes))
+	for _, index := range indexes {
+		existing[index.UserName()] = index
+	}
+
+	plans := make([]indexPlan, len(managedIndexes))
+	for i, managed := range managedIndexes {
+		plans[i] = indexPlan{index: managed, action: repository.IndexCreated}
+		if index, ok := existing[managed.name]; ok {
+			plans[i].existing = index
+			plans[i].action = repository.IndexRebuilt
+			if managed.matches(index) {
+				plans[i].action = repository.IndexUnchanged
+			}
+		}
+	}
+	return plans, nil
+}
+
+// DiffIndexes reports what Reindex would do to each managed index of
+// collection without changing anything: created for a missing index, rebuilt
+// for one whose definition drifted
+func DiffIndexes(ctx context.Context, collection driver.Collection) ([]repository.IndexStatus, error) {
+	plans, err := planIndexes(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]repository.IndexStatus, len(plans))
+	for i, plan := range plans {
+		statuses[i] = repository.IndexStatus{Name: plan.index.name, Action: plan.action}
+	}
+	return statuses, nil
+}
+
+// Reindex creates missing managed indexes and rebuilds those whose
+// definition no longer matches the one in code
+func (r *ArangoActivityLogRepository) Reindex(ctx context.Context) ([]repository.IndexStatus, error) {
+	plans, err := planIndexes(ctx, r.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]repository.IndexStatus, 0, len(plans))
+	for _, plan := range plans {
+		switch plan.action {
+		case repository.IndexUnchanged:
+			statuses = append(statuses, repository.IndexStatus{Name: plan.index.name, Action: plan.action})
+			continue
+		case repository.IndexRebuilt:
+			if err := plan.existing.Remove(ctx); err != nil {
+				return statuses, fmt.Errorf("failed to drop index %s: %w", plan.index.name, err)
+			}
+		}
+
+		if err := plan.index.ensure(ctx, r.collection); err != nil {
+			return statuses, err
+		}
+		statuses = append(statuses, repository.IndexStatus{Name: plan.index.name, Action: plan.action})
+	}
+
+	return statuses, nil
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	"github.com/sirupsen/logrus"
+
+	"activity-log-service/internal/infrastructure/querytag"
+)
+
+// QueryOptions control how AQL queries are sent and observed
+type QueryOptions struct {
+	// Annotate prefixes each query with a comment naming the route, tenant
+	// hash and request ID
+	Annotate bool
+	// SlowThreshold logs every query that runs longer; zero turns the slow
+	// query log off
+	SlowThreshold time.Duration
+	// Timeout has the server kill queries running longer, unless the caller's
+	// deadline is earlier; zero leaves them unbounded
+	Timeout time.Duration
+	// MaxResultWindow bounds offset+limit of numbered pages; zero allows any
+	// depth
+	MaxResultWindow int
+}
+
+// wrap layers the query deadline, annotations and slow query log over db
+func (o QueryOptions) wrap(db driver.Database, logger *logrus.Logger) driver.Database {
+	var database driver.Database = deadlineDatabase{Database: db, timeout: o.Timeout}
+	if o.Annotate {
+		database = annotatedDatabase{database}
+	}
+	if o.SlowThreshold > 0 {
+		database = slowQueryDatabase{Database: database, threshold: o.SlowThreshold, logger: logger}
+	}
+	return database
+}
+
+// slowQueryDatabase logs queries that take longer than threshold to return
+// their first batch, which for all but streaming queries is the whole run.
+// Bind vars are logged sanitized, as they carry tenant and user data.
+type slowQueryDatabase struct {
+	driver.Database
+	threshold time.Duration
+	logger    *logrus.Logger
+}
+
+func (d slowQueryDatabase) Query(ctx context.Context, query string, bindVars map[string]interface{}) (driver.Cursor, error) {
+	start := time.Now()
+	cursor, err := d.Database.Query(ctx, query, bindVars)
+	elapsed := time.Since(start)
+	if elapsed < d.threshold {
+		return cursor, err
+	}
+
+	tag, _ := querytag.FromContext(ctx)
+	entry := d.logger.WithFields(logrus.Fields{
+		"duration_ms": elapsed.Milliseconds(),
+		"query":       query,
+		"bind_vars":   sanitizeBindVars(bindVars),
+		"route":       tag.Route,
+		"request_id":  tag.RequestID,
+	})
+	if err != nil {
+		entry = entry.WithError(err)
+	}
+	entry.Warn("Slow AQL query")
+	return cursor, err
+}
+
+// sanitizeBindVars keeps what explains a query plan (collections, numbers,
+// dates, flags) and hides values that identify tenants or people. Company
+// IDs become the tenant hash the query annotations use, so slow queries can
+// still be grouped by tenant.
+func sanitizeBindVars(bindVars map[string]interface{}) map[string]interface{} {
+	sanitized := make(map[string]interface{}, len(bindVars))
+	for key, value := range bindVars {
+		switch v := value.(type) {
+		case string:
+			switch {
+			case strings.HasPrefix(key, "@"):
+				sanitized[key] = v
+			case key == "companyID" || key == "companyId":
+				sanitized[key] = "tenant:" + tenantHash(bindVars)
+			default:
+				sanitized[key] = fmt.Sprintf("<redacted %d chars>", len(v))
+			}
+		case []string:
+			sanitized[key] = fmt.Sprintf("<redacted %d values>", len(v))
+		case bool, int, int32, int64, float64, time.Time, time.Duration:
+			sanitized[key] = v
+		default:
+			sanitized[key] = fmt.Sprintf("<redacted %T>", v)
+		}
+	}
+	return sanitized
+}
@@ -0,0 +1,348 @@
+// Package doctor compares a live deployment with what this version of the
+// service expects: Arango collections, indexes and migrations, the JetStream
+// stream and consumer, and Redis. It only reads; every discrepancy comes with
+// the command or setting that fixes it.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	"github.com/nats-io/nats.go"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+
+	"activity-log-service/internal/domain/repository"
+	"activity-log-service/internal/infrastructure/certs"
+	"activity-log-service/internal/infrastructure/config"
+	"activity-log-service/internal/infrastructure/database"
+	"activity-log-service/internal/infrastructure/messaging"
+	"activity-log-service/internal/infrastructure/migration"
+)
+
+// Status grades a finding
+type Status string
+
+const (
+	StatusOK   Status = "ok"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+// Finding is the result of one check. Fix is empty for passing checks.
+type Finding struct {
+	Component string
+	Check     string
+	Status    Status
+	Detail    string
+	Fix       string
+}
+
+// Failed reports whether any finding failed
+func Failed(findings []Finding) bool {
+	return slices.ContainsFunc(findings, func(f Finding) bool { return f.Status == StatusFail })
+}
+
+// Doctor runs the checks against the deployment described by cfg
+type Doctor struct {
+	cfg            *config.Config
+	migrationsPath string
+	logger         *logrus.Logger
+}
+
+// New builds a doctor. migrationsPath is the directory of the migrations
+// shipped with this version; pending ones are reported.
+func New(cfg *config.Config, migrationsPath string, logger *logrus.Logger) *Doctor {
+	return &Doctor{cfg: cfg, migrationsPath: migrationsPath, logger: logger}
+}
+
+// Run runs every check. A component that cannot be reached yields one
+// failing finding and its remaining checks are skipped.
+func (d *Doctor) Run(ctx context.Context) []Finding {
+	var findings []Finding
+	findings = append(findings, d.checkArango(ctx)...)
+	findings = append(findings, d.checkNATS()...)
+	findings = append(findings, d.checkRedis(ctx)...)
+	return findings
+}
+
+func ok(component, check, detail string) Finding {
+	return Finding{Component: component, Check: check, Status: StatusOK, Detail: detail}
+}
+
+func warn(component, check, detail, fix string) Finding {
+	return Finding{Component: component, Check: check, Status: StatusWarn, Detail: detail, Fix: fix}
+}
+
+func fail(component, check, detail, fix string) Finding {
+	return Finding{Component: component, Check: check, Status: StatusFail, Detail: detail, Fix: fix}
+}
+
+const componentArango = "arango"
+
+func (d *Doctor) checkArango(ctx context.Context) []Finding {
+	db, err := d.openDatabase(ctx)
+	if err != nil {
+		return []Finding{fail(componentArango, "database "+d.cfg.Arango.Database, err.Error(),
+			"check arango.url, arango.database and the credentials, or start a service once to create the database")}
+	}
+
+	findings := []Finding{ok(componentArango, "database "+d.cfg.Arango.Database, "reachable")}
+
+	logs, finding := d.checkCollection(ctx, db, d.cfg.Arango.Collection, "activity logs", "start any server, or run: migrate -command up")
+	findings = append(findings, finding)
+	if logs != nil {
+		findings = append(findings, checkIndexes(ctx, logs)...)
+	}
+
+	if d.cfg.Rollup.Enabled {
+		_, finding := d.checkCollection(ctx, db, d.cfg.Rollup.Collection, "rollups", "start the cron server, which creates it")
+		findings = append(findings, finding)
+	}
+	if d.cfg.AccessLog.Enabled {
+		_, finding := d.checkCollection(ctx, db, d.cfg.AccessLog.Collection, "access log", "start any server, which creates it")
+		findings = append(findings, finding)
+	}
+	if d.cfg.ExportKeys.Enabled {
+		_, finding := d.checkCollection(ctx, db, d.cfg.ExportKeys.Collection, "export keys", "start any server, which creates it")
+		findings = append(findings, finding)
+	}
+
+	return append(findings, d.checkMigrations(ctx, db))
+}
+
+func (d *Doctor) openDatabase(ctx context.Context) (driver.Database, error) {
+	// A single attempt: an unreachable server is a finding, not something
+	// to wait out
+	client, err := database.NewClient(ctx, database.ConnectOptions{
+		Endpoints:   d.cfg.Arango.Endpoints(),
+		Username:    d.cfg.Arango.Username,
+		Password:    d.cfg.Arango.Password,
+		MaxAttempts: 1,
+	}, d.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := client.Database(ctx, d.cfg.Arango.Database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return db, nil
+}
+
+func (d *Doctor) checkCollection(ctx context.Context, db driver.Database, name, purpose, fix string) (driver.Collection, Finding) {
+	check := "collection " + name
+	collection, err := db.Collection(ctx, name)
+	if driver.IsNotFound(err) {
+		return nil, fail(componentArango, check, purpose+" collection is missing", fix)
+	}
+	if err != nil {
+		return nil, fail(componentArango, check, err.Error(), "check the Arango user can read the database")
+	}
+	return collection, ok(componentArango, check, purpose)
+}
+
+func checkIndexes(ctx context.Context, collection driver.Collection) []Finding {
+	statuses, err := database.DiffIndexes(ctx, collection)
+	if err != nil {
+		return []Finding{fail(componentArango, "indexes", err.Error(), "check the Arango user can read the collection")}
+	}
+
+	findings := make([]Finding, len(statuses))
+	for i, index := range statuses {
+		check := "index " + index.Name
+		switch index.Action {
+		case repository.IndexCreated:
+			findings[i] = fail(componentArango, check, "missing; queries on it scan the collection", "run AdminService/Reindex or restart a server")
+		case repository.IndexRebuilt:
+			findings[i] = fail(componentArango, check, "definition differs from the expected one", "run AdminService/Reindex")
+		default:
+			findings[i] = ok(componentArango, check, "matches")
+		}
+	}
+	return findings
+}
+
+func (d *Doctor) checkMigrations(ctx context.Context, db driver.Database) Finding {
+	const check = "migrations"
+
+	migrator := migration.NewMigrator(db, d.logger)
+	shipped, err := migrator.LoadMigrations(d.migrationsPath)
+	if err != nil {
+		return warn(componentArango, check, err.Error(), "pass -migrations with the directory shipped with this version")
+	}
+
+	exists, err := db.CollectionExists(ctx, "migrations")
+	if err != nil {
+		return fail(componentArango, check, err.Error(), "check the Arango user can read the database")
+	}
+	if !exists {
+		return fail(componentArango, check, "no migrations have been applied", "run: migrate -command up")
+	}
+
+	applied, err := migrator.GetAppliedMigrations(ctx)
+	if err != nil {
+		return fail(componentArango, check, err.Error(), "check the Arango user can read the migrations collection")
+	}
+
+	var pending []int
+	for _, m := range shipped {
+		if !slices.Contains(applied, m.Version) {
+			pending = append(pending, m.Version)
+		}
+	}
+	if len(pending) > 0 {
+		return fail(componentArango, check, fmt.Sprintf("%d pending: %v", len(pending), pending), "run: migrate -command up")
+	}
+	return ok(componentArango, check, fmt.Sprintf("%d applied", len(applied)))
+}
+
+const componentNATS = "nats"
+
+func (d *Doctor) checkNATS() []Finding {
+	conn, err := messaging.Connect(d.cfg.NATS, nats.Timeout(5*time.Second))
+	if err != nil {
+		return []Finding{fail(componentNATS, "connection", err.Error(),
+			"check nats.url, nats.credentials_file, nats.token, nats.username, nats.password and nats.tls")}
+	}
+	defer conn.Close()
+
+	js, err := conn.JetStream()
+	if err != nil {
+		return []Finding{fail(componentNATS, "connection", err.Error(), "enable JetStream on the NATS server")}
+	}
+
+	findings := []Finding{ok(componentNATS, "connection", "connected")}
+	findings = append(findings, d.checkStream(js)...)
+	return append(findings, d.checkConsumer(js)...)
+}
+
+func (d *Doctor) checkStream(js nats.JetStreamContext) []Finding {
+	check := "stream " + d.cfg.NATS.Stream
+	info, err := js.StreamInfo(d.cfg.NATS.Stream)
+	if err == nats.ErrStreamNotFound {
+		return []Finding{fail(componentNATS, check, "missing; queued creates and events are lost",
+			"start the consumer, which creates it, or check nats.stream")}
+	}
+	if err != nil {
+		return []Finding{fail(componentNATS, check, err.Error(), "check the NATS account can read stream info")}
+	}
+
+	subjects := messaging.EventSubjects{
+		Created: d.cfg.NATS.Subject,
+		Updated: d.cfg.NATS.UpdatedSubject,
+		Deleted: d.cfg.NATS.DeletedSubject,
+	}.All()
+	expected := messaging.StreamConfig(d.cfg.NATS.Stream, subjects...)
+	actual := info.Config
+	var findings []Finding
+
+	for _, subject := range subjects {
+		if !slices.Contains(actual.Subjects, subject) {
+			findings = append(findings, fail(componentNATS, check+" subjects",
+				fmt.Sprintf("%v does not include %q; published events are dropped", actual.Subjects, subject),
+				fmt.Sprintf("nats stream edit %s --subjects %s", actual.Name, subject)))
+		}
+	}
+	if actual.Storage != expected.Storage {
+		findings = append(findings, warn(componentNATS, check+" storage",
+			fmt.Sprintf("%s, expected %s; messages are lost on restart", actual.Storage, expected.Storage),
+			"recreate the stream with file storage"))
+	}
+	if actual.Retention != expected.Retention {
+		findings = append(findings, warn(componentNATS, check+" retention",
+			fmt.Sprintf("%s, expected %s", actual.Retention, expected.Retention),
+			"recreate the stream with limits retention"))
+	}
+	if actual.MaxAge != expected.MaxAge {
+		findings = append(findings, warn(componentNATS, check+" max age",
+			fmt.Sprintf("%s, expected %s", actual.MaxAge, expected.MaxAge),
+			fmt.Sprintf("nats stream edit %s --max-age %s", actual.Name, expected.MaxAge)))
+	}
+	if actual.MaxMsgs != expected.MaxMsgs {
+		findings = append(findings, warn(componentNATS, check+" max messages",
+			fmt.Sprintf("%d, expected %d", actual.MaxMsgs, expected.MaxMsgs),
+			fmt.Sprintf("nats stream edit %s --max-msgs %d", actual.Name, expected.MaxMsgs)))
+	}
+
+	if len(findings) == 0 {
+		findings = append(findings, ok(componentNATS, check, fmt.Sprintf("%d messages", info.State.Msgs)))
+	}
+	return findings
+}
+
+func (d *Doctor) checkConsumer(js nats.JetStreamContext) []Finding {
+	check := "consumer " + d.cfg.NATS.Durable
+	info, err := js.ConsumerInfo(d.cfg.NATS.Stream, d.cfg.NATS.Durable)
+	if err == nats.ErrConsumerNotFound {
+		return []Finding{fail(componentNATS, check, "missing; nothing writes queued creates",
+			"start the consumer, or check nats.durable")}
+	}
+	if err != nil {
+		return []Finding{fail(componentNATS, check, err.Error(), "check the stream exists and nats.durable")}
+	}
+
+	actual := info.Config
+	var findings []Finding
+
+	if actual.FilterSubject != "" && actual.FilterSubject != d.cfg.NATS.Subject {
+		findings = append(findings, fail(componentNATS, check+" filter",
+			fmt.Sprintf("filters %q, expected %q", actual.FilterSubject, d.cfg.NATS.Subject),
+			fmt.Sprintf("nats consumer rm %s %s, then restart the consumer", d.cfg.NATS.Stream, d.cfg.NATS.Durable)))
+	}
+	if actual.AckPolicy != nats.AckExplicitPolicy {
+		findings = append(findings, fail(componentNATS, check+" ack policy",
+			fmt.Sprintf("%s, expected explicit; failed writes are not redelivered", actual.AckPolicy),
+			fmt.Sprintf("nats consumer rm %s %s, then restart the consumer", d.cfg.NATS.Stream, d.cfg.NATS.Durable)))
+	}
+	if actual.AckWait != d.cfg.NATS.AckWait {
+		findings = append(findings, warn(componentNATS, check+" ack wait",
+			fmt.Sprintf("%s, nats.ack_wait is %s", actual.AckWait, d.cfg.NATS.AckWait),
+			"recreate the consumer or change nats.ack_wait to match"))
+	}
+	if actual.MaxDeliver != d.cfg.NATS.MaxDeliver {
+		findings = append(findings, warn(componentNATS, check+" max deliver",
+			fmt.Sprintf("%d, nats.max_deliver is %d", actual.MaxDeliver, d.cfg.NATS.MaxDeliver),
+			"recreate the consumer or change nats.max_deliver to match"))
+	}
+
+	if len(findings) == 0 {
+		findings = append(findings, ok(componentNATS, check, fmt.Sprintf("%d pending, %d awaiting ack", info.NumPending, info.NumAckPending)))
+	}
+	return findings
+}
+
+const componentRedis = "redis"
+
+func (d *Doctor) checkRedis(ctx context.Context) []Finding {
+	if d.cfg.Redis.Address == "" {
+		return []Finding{ok(componentRedis, "connection", "not configured; reads go straight to Arango")}
+	}
+
+	opts := &redis.Options{
+		Addr:     d.cfg.Redis.Address,
+		Username: d.cfg.Redis.Username,
+		Password: d.cfg.Redis.Password,
+		DB:       d.cfg.Redis.DB,
+	}
+	if tlsCfg := d.cfg.Redis.TLS; tlsCfg.Enabled {
+		tlsConfig, err := certs.ClientConfig(tlsCfg.CAFile, tlsCfg.CertFile, tlsCfg.KeyFile, tlsCfg.InsecureSkipVerify)
+		if err != nil {
+			return []Finding{fail(componentRedis, "connection", err.Error(),
+				"fix the files under redis.tls")}
+		}
+		opts.TLSConfig = tlsConfig
+	}
+	client := redis.NewClient(opts)
+	defer client.Close()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return []Finding{warn(componentRedis, "connection", err.Error(),
+			"check redis.address, redis.username, redis.password and redis.tls; servers fall back to uncached reads meanwhile")}
+	}
+	return []Finding{ok(componentRedis, "connection", "reachable")}
+}
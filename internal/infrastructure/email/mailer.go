@@ -4,30 +4,51 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"html/template"
+	"io"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"gopkg.in/gomail.v2"
 
 	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/infrastructure/metrics"
 )
 
 type Mailer struct {
-	dialer    *gomail.Dialer
-	from      string
-	logger    *logrus.Logger
-	templates map[string]*template.Template
+	dialer         *gomail.Dialer
+	from           string
+	logger         *logrus.Logger
+	trackCompanies bool
+	templatesDir   string
+	reloadInterval time.Duration
+
+	mu        sync.RWMutex
+	templates *templateSet
+	checkedAt time.Time
 }
 
+// EmailConfig configures the SMTP connection. TrackCompanies records the last
+// successful notification per company, one metric series each. Templates in
+// TemplatesDir replace the embedded ones and are reloaded when they changed,
+// checked at most once per ReloadInterval; zero turns reloading off.
 type EmailConfig struct {
-	Host     string
-	Port     int
-	Username string
-	Password string
-	From     string
+	Host           string
+	Port           int
+	Username       string
+	Password       string
+	From           string
+	TrackCompanies bool
+	TemplatesDir   string
+	ReloadInterval time.Duration
 }
 
+// Labels used in the notification delivery metrics
+const (
+	metricsChannel  = "email"
+	metricsProvider = "smtp"
+)
+
 type ActivityLogEmailData struct {
 	ActivityLog    *entity.ActivityLog
 	CompanyName    string
@@ -37,7 +58,35 @@ type ActivityLogEmailData struct {
 	UnsubscribeURL string
 }
 
-func NewMailer(config EmailConfig, logger *logrus.Logger) *Mailer {
+// AlertEmailData is what the alert template is rendered with
+type AlertEmailData struct {
+	Kind      string
+	CompanyID string
+	Subject   string
+	Message   string
+	Details   map[string]interface{}
+	At        time.Time
+}
+
+// EncryptedDailySummaryData is what the encrypted daily summary template is
+// rendered with. The summary itself is only in the attachment, sealed to the
+// company's export key KeyID.
+type EncryptedDailySummaryData struct {
+	CompanyID  string
+	Date       string
+	KeyID      string
+	Attachment string
+}
+
+// attachment is a file sent along with an email
+type attachment struct {
+	name string
+	data []byte
+}
+
+// NewMailer fails when the templates directory cannot be read or holds a
+// template that does not parse
+func NewMailer(config EmailConfig, logger *logrus.Logger) (*Mailer, error) {
 	dialer := gomail.NewDialer(config.Host, config.Port, config.Username, config.Password)
 
 	// For MailHog, we don't need authentication
@@ -45,155 +94,49 @@ func NewMailer(config EmailConfig, logger *logrus.Logger) *Mailer {
 		dialer.Auth = nil
 	}
 
-	mailer := &Mailer{
-		dialer:    dialer,
-		from:      config.From,
-		logger:    logger,
-		templates: make(map[string]*template.Template),
+	templates, err := loadTemplates(config.TemplatesDir)
+	if err != nil {
+		return nil, err
 	}
 
-	// Load email templates
-	mailer.loadTemplates()
-
-	return mailer
+	return &Mailer{
+		dialer:         dialer,
+		from:           config.From,
+		logger:         logger,
+		trackCompanies: config.TrackCompanies,
+		templatesDir:   config.TemplatesDir,
+		reloadInterval: config.ReloadInterval,
+		templates:      templates,
+		checkedAt:      time.Now(),
+	}, nil
 }
 
-func (m *Mailer) loadTemplates() {
-	// Activity log notification template
-	activityLogTemplate := `
-<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="UTF-8">
-    <title>Activity Log Notification</title>
-    <style>
-        body { font-family: Arial, sans-serif; margin: 0; padding: 20px; background-color: #f5f5f5; }
-        .container { max-width: 600px; margin: 0 auto; background-color: white; padding: 20px; border-radius: 5px; box-shadow: 0 2px 5px rgba(0,0,0,0.1); }
-        .header { background-color: #007bff; color: white; padding: 15px; text-align: center; border-radius: 5px 5px 0 0; margin: -20px -20px 20px -20px; }
-        .activity-details { background-color: #f8f9fa; padding: 15px; border-radius: 5px; margin: 15px 0; }
-        .detail-row { margin: 8px 0; }
-        .label { font-weight: bold; color: #495057; }
-        .value { color: #212529; }
-        .changes { background-color: #e7f3ff; padding: 10px; border-left: 4px solid #007bff; margin: 10px 0; }
-        .footer { margin-top: 30px; padding-top: 20px; border-top: 1px solid #dee2e6; font-size: 12px; color: #6c757d; text-align: center; }
-        .btn { display: inline-block; padding: 10px 20px; background-color: #007bff; color: white; text-decoration: none; border-radius: 5px; margin: 10px 0; }
-    </style>
-</head>
-<body>
-    <div class="container">
-        <div class="header">
-            <h1>Activity Log Notification</h1>
-            <p>{{.CompanyName}}</p>
-        </div>
-        
-        <p>A new activity has been logged in your system:</p>
-        
-        <div class="activity-details">
-            <div class="detail-row">
-                <span class="label">Activity:</span>
-                <span class="value">{{.ActivityLog.FormattedMessage}}</span>
-            </div>
-            <div class="detail-row">
-                <span class="label">Type:</span>
-                <span class="value">{{.ActivityLog.ActivityName}}</span>
-            </div>
-            <div class="detail-row">
-                <span class="label">Object:</span>
-                <span class="value">{{.ActivityLog.ObjectName}} ({{.ActivityLog.ObjectID}})</span>
-            </div>
-            <div class="detail-row">
-                <span class="label">Performed by:</span>
-                <span class="value">{{.ActivityLog.Actor.Name}} ({{.ActivityLog.Actor.Email}})</span>
-            </div>
-            <div class="detail-row">
-                <span class="label">Time:</span>
-                <span class="value">{{.ActivityLog.CreatedAt.Format "2006-01-02 15:04:05 UTC"}}</span>
-            </div>
-            
-            {{if .ActivityLog.Changes}}
-            <div class="changes">
-                <strong>Changes:</strong><br>
-                <pre>{{.ActivityLog.Changes}}</pre>
-            </div>
-            {{end}}
-        </div>
-        
-        {{if .WebURL}}
-        <div style="text-align: center;">
-            <a href="{{.WebURL}}/activity-logs/{{.ActivityLog.ID}}" class="btn">View in Dashboard</a>
-        </div>
-        {{end}}
-        
-        <div class="footer">
-            <p>This is an automated notification from Activity Log Service.</p>
-            {{if .UnsubscribeURL}}
-            <p><a href="{{.UnsubscribeURL}}">Unsubscribe</a> from these notifications.</p>
-            {{end}}
-        </div>
-    </div>
-</body>
-</html>`
-
-	tmpl, err := template.New("activity_log").Parse(activityLogTemplate)
-	if err != nil {
-		m.logger.WithError(err).Error("Failed to parse activity log email template")
-	} else {
-		m.templates["activity_log"] = tmpl
+// render executes the named HTML template and its plain text alternative, if
+// it has one, with data, using the company's own templates where it has
+// them
+func (m *Mailer) render(companyID, name string, data interface{}) (html, text string, err error) {
+	m.reloadIfChanged()
+	m.mu.RLock()
+	tmpl, textTmpl := m.templates.lookup(companyID, name)
+	m.mu.RUnlock()
+	if tmpl == nil {
+		return "", "", fmt.Errorf("email template %q not found", name)
 	}
 
-	// Summary email template
-	summaryTemplate := `
-<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="UTF-8">
-    <title>Daily Activity Summary</title>
-    <style>
-        body { font-family: Arial, sans-serif; margin: 0; padding: 20px; background-color: #f5f5f5; }
-        .container { max-width: 600px; margin: 0 auto; background-color: white; padding: 20px; border-radius: 5px; box-shadow: 0 2px 5px rgba(0,0,0,0.1); }
-        .header { background-color: #28a745; color: white; padding: 15px; text-align: center; border-radius: 5px 5px 0 0; margin: -20px -20px 20px -20px; }
-        .summary-stats { display: flex; justify-content: space-around; margin: 20px 0; }
-        .stat { text-align: center; }
-        .stat-number { font-size: 2em; font-weight: bold; color: #007bff; }
-        .stat-label { color: #6c757d; }
-        .footer { margin-top: 30px; padding-top: 20px; border-top: 1px solid #dee2e6; font-size: 12px; color: #6c757d; text-align: center; }
-    </style>
-</head>
-<body>
-    <div class="container">
-        <div class="header">
-            <h1>Daily Activity Summary</h1>
-            <p>{{.Date}}</p>
-        </div>
-        
-        <div class="summary-stats">
-            <div class="stat">
-                <div class="stat-number">{{.TotalActivities}}</div>
-                <div class="stat-label">Total Activities</div>
-            </div>
-            <div class="stat">
-                <div class="stat-number">{{.UniqueUsers}}</div>
-                <div class="stat-label">Active Users</div>
-            </div>
-            <div class="stat">
-                <div class="stat-number">{{.TopActivity}}</div>
-                <div class="stat-label">Most Common Activity</div>
-            </div>
-        </div>
-        
-        <div class="footer">
-            <p>This is your daily activity summary from Activity Log Service.</p>
-        </div>
-    </div>
-</body>
-</html>`
-
-	summaryTmpl, err := template.New("daily_summary").Parse(summaryTemplate)
-	if err != nil {
-		m.logger.WithError(err).Error("Failed to parse summary email template")
-	} else {
-		m.templates["daily_summary"] = summaryTmpl
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, data); err != nil {
+		return "", "", fmt.Errorf("failed to execute email template: %w", err)
+	}
+	html = body.String()
+
+	if textTmpl != nil {
+		body.Reset()
+		if err := textTmpl.Execute(&body, data); err != nil {
+			return "", "", fmt.Errorf("failed to execute plain text email template: %w", err)
+		}
+		text = body.String()
 	}
+	return html, text, nil
 }
 
 func (m *Mailer) SendActivityLogNotification(ctx context.Context, data ActivityLogEmailData) error {
@@ -201,14 +144,13 @@ func (m *Mailer) SendActivityLogNotification(ctx context.Context, data ActivityL
 		return fmt.Errorf("no recipients specified")
 	}
 
-	template, exists := m.templates["activity_log"]
-	if !exists {
-		return fmt.Errorf("activity log email template not found")
+	var companyID string
+	if data.ActivityLog != nil {
+		companyID = data.ActivityLog.CompanyID
 	}
-
-	var body bytes.Buffer
-	if err := template.Execute(&body, data); err != nil {
-		return fmt.Errorf("failed to execute email template: %w", err)
+	html, text, err := m.render(companyID, "activity_log", data)
+	if err != nil {
+		return err
 	}
 
 	subject := data.Subject
@@ -216,46 +158,102 @@ func (m *Mailer) SendActivityLogNotification(ctx context.Context, data ActivityL
 		subject = fmt.Sprintf("Activity Log: %s", data.ActivityLog.FormattedMessage)
 	}
 
-	return m.sendEmail(ctx, data.Recipients, subject, body.String())
+	if err := m.sendEmail(ctx, "activity_log", data.Recipients, subject, html, text); err != nil {
+		return err
+	}
+	if m.trackCompanies && data.ActivityLog != nil {
+		metrics.RecordNotificationSuccess(metricsChannel, data.ActivityLog.CompanyID)
+	}
+	return nil
+}
+
+func (m *Mailer) SendDailySummary(ctx context.Context, recipients []string, summary DailySummaryData) error {
+	if len(recipients) == 0 {
+		return fmt.Errorf("no recipients specified")
+	}
+
+	html, text, err := m.render(summary.CompanyID, "daily_summary", summary)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("Daily Activity Summary - %s - %s", summary.CompanyID, summary.Date)
+	return m.sendEmail(ctx, "daily_summary", recipients, subject, html, text)
 }
 
-func (m *Mailer) SendDailySummary(ctx context.Context, recipients []string, summaryData map[string]interface{}) error {
+// SendEncryptedDailySummary sends a company's daily summary as the sealed
+// attachment only; the email itself names the key it is encrypted to but
+// holds none of the figures.
+func (m *Mailer) SendEncryptedDailySummary(ctx context.Context, recipients []string, companyID, date, keyID string, sealed []byte) error {
 	if len(recipients) == 0 {
 		return fmt.Errorf("no recipients specified")
 	}
 
-	template, exists := m.templates["daily_summary"]
-	if !exists {
-		return fmt.Errorf("daily summary email template not found")
+	data := EncryptedDailySummaryData{
+		CompanyID:  companyID,
+		Date:       date,
+		KeyID:      keyID,
+		Attachment: fmt.Sprintf("daily-summary-%s.json.enc", date),
+	}
+	html, text, err := m.render(companyID, "daily_summary_encrypted", data)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("Daily Activity Summary - %s - %s", companyID, date)
+	return m.sendEmail(ctx, "daily_summary", recipients, subject, html, text, attachment{name: data.Attachment, data: sealed})
+}
+
+func (m *Mailer) SendAlert(ctx context.Context, recipients []string, alert AlertEmailData) error {
+	if len(recipients) == 0 {
+		return fmt.Errorf("no recipients specified")
 	}
 
-	var body bytes.Buffer
-	if err := template.Execute(&body, summaryData); err != nil {
-		return fmt.Errorf("failed to execute email template: %w", err)
+	// Alerts go to operators, so companies' templates do not apply
+	html, text, err := m.render("", "alert", alert)
+	if err != nil {
+		return err
 	}
 
-	subject := fmt.Sprintf("Daily Activity Summary - %s", time.Now().Format("2006-01-02"))
-	return m.sendEmail(ctx, recipients, subject, body.String())
+	return m.sendEmail(ctx, alert.Kind, recipients, alert.Subject, html, text)
 }
 
-func (m *Mailer) sendEmail(ctx context.Context, recipients []string, subject, body string) error {
+// sendEmail delivers one message; kind labels it in the delivery metrics.
+// With a plain text body the message is multipart/alternative, text first so
+// that clients able to show HTML prefer it.
+func (m *Mailer) sendEmail(ctx context.Context, kind string, recipients []string, subject, html, text string, attachments ...attachment) error {
 	msg := gomail.NewMessage()
 	msg.SetHeader("From", m.from)
 	msg.SetHeader("To", recipients...)
 	msg.SetHeader("Subject", subject)
-	msg.SetBody("text/html", body)
+	if text != "" {
+		msg.SetBody("text/plain", text)
+		msg.AddAlternative("text/html", html)
+	} else {
+		msg.SetBody("text/html", html)
+	}
+	for _, file := range attachments {
+		data := file.data
+		msg.Attach(file.name, gomail.SetCopyFunc(func(w io.Writer) error {
+			_, err := w.Write(data)
+			return err
+		}))
+	}
 
 	// Add message ID and date headers
 	msg.SetHeader("Message-ID", fmt.Sprintf("<%d@activity-log-service>", time.Now().UnixNano()))
 	msg.SetHeader("Date", time.Now().Format(time.RFC1123Z))
 
+	start := time.Now()
 	if err := m.dialer.DialAndSend(msg); err != nil {
+		metrics.RecordNotificationDelivery(metricsChannel, metricsProvider, kind, "failure", time.Since(start))
 		m.logger.WithError(err).WithFields(logrus.Fields{
 			"recipients": recipients,
 			"subject":    subject,
 		}).Error("Failed to send email")
 		return fmt.Errorf("failed to send email: %w", err)
 	}
+	metrics.RecordNotificationDelivery(metricsChannel, metricsProvider, kind, "success", time.Since(start))
 
 	m.logger.WithFields(logrus.Fields{
 		"recipients": recipients,
@@ -0,0 +1,287 @@
+package email
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"reflect"
+	"strings"
+	texttemplate "text/template"
+	"text/template/parse"
+
+	"activity-log-service/internal/domain/entity"
+)
+
+// Template kinds accepted by ValidateTemplate
+const (
+	TemplateKindActivityLog  = "activity_log"
+	TemplateKindDailySummary = "daily_summary"
+	TemplateKindMessage      = "message"
+)
+
+// DailySummaryData is what the daily summary template is rendered with: one
+// company's activity over the last 24 hours. Encrypted summaries carry its
+// JSON encoding.
+type DailySummaryData struct {
+	CompanyID       string `json:"company_id"`
+	Date            string `json:"date"`
+	TotalActivities int    `json:"total_activities"`
+	UniqueUsers     int    `json:"unique_users"`
+	TopActivity     string `json:"top_activity"`
+}
+
+type TemplateValidationResult struct {
+	Valid    bool     `json:"valid"`
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings"`
+	Preview  string   `json:"preview,omitempty"`
+}
+
+// hashableFields may hold HMAC digests instead of raw values depending on the
+// privacy settings, which is rarely what a template author expects to print
+var hashableFields = map[string]bool{
+	"IPAddress": true,
+	"UserAgent": true,
+	"DeviceID":  true,
+}
+
+// ValidateTemplate parses source as a template of the given kind, checks every
+// field it references against the data the service renders that kind with,
+// and renders it against sample data.
+func ValidateTemplate(kind, source string) *TemplateValidationResult {
+	result := &TemplateValidationResult{
+		Errors:   []string{},
+		Warnings: []string{},
+	}
+
+	var (
+		tree    *parse.Tree
+		execute func(*bytes.Buffer, interface{}) error
+		sample  interface{}
+	)
+
+	switch kind {
+	case TemplateKindActivityLog, TemplateKindDailySummary:
+		tmpl, err := htmltemplate.New(kind).Parse(source)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			return result
+		}
+		tree = tmpl.Tree
+		execute = func(buf *bytes.Buffer, data interface{}) error { return tmpl.Execute(buf, data) }
+	case TemplateKindMessage:
+		tmpl, err := texttemplate.New(kind).Parse(source)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			return result
+		}
+		tree = tmpl.Tree
+		execute = func(buf *bytes.Buffer, data interface{}) error { return tmpl.Execute(buf, data) }
+	default:
+		result.Errors = append(result.Errors, fmt.Sprintf("unknown template type %q", kind))
+		return result
+	}
+
+	switch kind {
+	case TemplateKindActivityLog:
+		sample = ActivityLogEmailData{
+			ActivityLog:    sampleActivityLog(),
+			CompanyName:    "Example Company",
+			Recipients:     []string{"admin@example.com"},
+			Subject:        "Activity Log: User Jane Doe was created",
+			WebURL:         "https://example.com",
+			UnsubscribeURL: "https://example.com/unsubscribe",
+		}
+	case TemplateKindDailySummary:
+		sample = DailySummaryData{
+			CompanyID:       "company1",
+			Date:            "2024-01-01",
+			TotalActivities: 42,
+			UniqueUsers:     7,
+			TopActivity:     "user_created",
+		}
+	case TemplateKindMessage:
+		sample = sampleActivityLog()
+	}
+
+	if tree == nil || tree.Root == nil || !hasActions(tree.Root) {
+		result.Warnings = append(result.Warnings, "template does not reference any fields")
+	} else {
+		checker := &fieldChecker{root: reflect.TypeOf(sample), result: result}
+		checker.walk(tree.Root, checker.root)
+	}
+
+	var rendered bytes.Buffer
+	if err := execute(&rendered, sample); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("failed to render sample: %v", err))
+	} else {
+		result.Preview = rendered.String()
+		if strings.TrimSpace(result.Preview) == "" {
+			result.Warnings = append(result.Warnings, "template renders to an empty string")
+		}
+	}
+
+	result.Valid = len(result.Errors) == 0
+	return result
+}
+
+func sampleActivityLog() *entity.ActivityLog {
+	activityLog := entity.NewActivityLog(
+		"user_created",
+		"company_123",
+		"user",
+		"user_456",
+		json.RawMessage(`{"name": "Jane Doe"}`),
+		"User Jane Doe was created",
+		"actor_789",
+		"System Administrator",
+		"admin@example.com",
+	)
+	activityLog.CountryCode = "DE"
+	activityLog.City = "Berlin"
+	activityLog.Tags = []string{"users"}
+	return activityLog
+}
+
+func hasActions(node parse.Node) bool {
+	list, ok := node.(*parse.ListNode)
+	if !ok {
+		return true
+	}
+	for _, n := range list.Nodes {
+		if n.Type() != parse.NodeText {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldChecker walks a parsed template and reports field references that do
+// not exist on the type dot has at that point. A nil dot means the type could
+// not be determined statically and checks are skipped.
+type fieldChecker struct {
+	root   reflect.Type
+	result *TemplateValidationResult
+}
+
+func (c *fieldChecker) walk(node parse.Node, dot reflect.Type) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			c.walk(child, dot)
+		}
+	case *parse.ActionNode:
+		c.pipeType(n.Pipe, dot)
+	case *parse.IfNode:
+		c.pipeType(n.Pipe, dot)
+		c.walk(n.List, dot)
+		c.walk(n.ElseList, dot)
+	case *parse.WithNode:
+		inner := c.pipeType(n.Pipe, dot)
+		c.walk(n.List, inner)
+		c.walk(n.ElseList, dot)
+	case *parse.RangeNode:
+		inner := elemType(c.pipeType(n.Pipe, dot))
+		c.walk(n.List, inner)
+		c.walk(n.ElseList, dot)
+	case *parse.TemplateNode:
+		c.pipeType(n.Pipe, dot)
+	}
+}
+
+// pipeType checks every field in the pipeline and returns the type of its
+// result when it is a single field reference.
+func (c *fieldChecker) pipeType(pipe *parse.PipeNode, dot reflect.Type) reflect.Type {
+	if pipe == nil {
+		return nil
+	}
+
+	var last reflect.Type
+	for _, cmd := range pipe.Cmds {
+		last = nil
+		for i, arg := range cmd.Args {
+			var t reflect.Type
+			switch a := arg.(type) {
+			case *parse.FieldNode:
+				t = c.resolve(dot, a.Ident, a.String())
+			case *parse.VariableNode:
+				if len(a.Ident) > 1 && a.Ident[0] == "$" {
+					t = c.resolve(c.root, a.Ident[1:], a.String())
+				}
+			case *parse.PipeNode:
+				t = c.pipeType(a, dot)
+			case *parse.DotNode:
+				t = dot
+			}
+			if i == 0 && len(cmd.Args) == 1 {
+				last = t
+			}
+		}
+	}
+
+	return last
+}
+
+func (c *fieldChecker) resolve(t reflect.Type, fields []string, ref string) reflect.Type {
+	for _, field := range fields {
+		if t == nil {
+			return nil
+		}
+
+		if method, ok := t.MethodByName(field); ok {
+			return methodResult(method.Type)
+		}
+		if t.Kind() != reflect.Ptr {
+			if method, ok := reflect.PtrTo(t).MethodByName(field); ok {
+				return methodResult(method.Type)
+			}
+		}
+
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Interface || t.Kind() == reflect.Map {
+			return nil
+		}
+		if t.Kind() != reflect.Struct {
+			c.result.Errors = append(c.result.Errors, fmt.Sprintf("%s: %s has no field %s", ref, t, field))
+			return nil
+		}
+
+		structField, ok := t.FieldByName(field)
+		if !ok || !structField.IsExported() {
+			c.result.Errors = append(c.result.Errors, fmt.Sprintf("%s: unknown field %s on %s", ref, field, t.Name()))
+			return nil
+		}
+		if t == reflect.TypeOf(entity.ActivityLog{}) && hashableFields[field] {
+			c.result.Warnings = append(c.result.Warnings, fmt.Sprintf("%s may contain a hashed value when privacy hashing is enabled", ref))
+		}
+		t = structField.Type
+	}
+	return t
+}
+
+func methodResult(t reflect.Type) reflect.Type {
+	if t.NumOut() == 0 {
+		return nil
+	}
+	return t.Out(0)
+}
+
+func elemType(t reflect.Type) reflect.Type {
+	if t == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return t.Elem()
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package email
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	texttemplate "text/template"
+	"time"
+)
+
+// defaultTemplates are the templates used where the templates directory has
+// none
+//
+//go:embed templates/*.html templates/*.txt
+var defaultTemplates embed.FS
+
+// templateNames are the emails the mailer renders. Each is a <name>.html
+// template and, optionally, a <name>.txt plain text alternative.
+var templateNames = []string{"activity_log", "daily_summary", "daily_summary_encrypted", "alert"}
+
+// companiesDir holds the per-company overrides inside the templates
+// directory, one subdirectory per company ID
+const companiesDir = "companies"
+
+// templateSet is one load of the templates. companies holds only the
+// templates a company overrides.
+type templateSet struct {
+	html      map[string]*template.Template
+	text      map[string]*texttemplate.Template
+	companies map[string]*templateSet
+	// modTimes are the files of the templates directory the set was loaded
+	// from, to tell when it changed
+	modTimes map[string]time.Time
+}
+
+func newTemplateSet() *templateSet {
+	return &templateSet{
+		html: make(map[string]*template.Template),
+		text: make(map[string]*texttemplate.Template),
+	}
+}
+
+// loadTemplates parses the embedded templates and lays those found in dir
+// over them. An empty dir uses the embedded templates only.
+func loadTemplates(dir string) (*templateSet, error) {
+	set := newTemplateSet()
+	if err := set.parse(defaultTemplates, "templates"); err != nil {
+		return nil, err
+	}
+	for _, name := range templateNames {
+		if set.html[name] == nil {
+			return nil, fmt.Errorf("embedded %s.html template is missing", name)
+		}
+	}
+	if dir == "" {
+		return set, nil
+	}
+
+	modTimes, err := statTemplates(dir)
+	if err != nil {
+		return nil, err
+	}
+	set.modTimes = modTimes
+
+	root := os.DirFS(dir)
+	if err := set.parse(root, "."); err != nil {
+		return nil, err
+	}
+
+	entries, err := fs.ReadDir(root, companiesDir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list company email templates: %w", err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		company := newTemplateSet()
+		if err := company.parse(root, filepath.Join(companiesDir, entry.Name())); err != nil {
+			return nil, err
+		}
+		if set.companies == nil {
+			set.companies = make(map[string]*templateSet)
+		}
+		set.companies[entry.Name()] = company
+	}
+	return set, nil
+}
+
+// parse adds the templates found in dir of fsys, replacing those of the same
+// name; missing files are skipped
+func (s *templateSet) parse(fsys fs.FS, dir string) error {
+	for _, name := range templateNames {
+		source, err := readTemplate(fsys, dir, name+".html")
+		if err != nil {
+			return err
+		}
+		if source != "" {
+			tmpl, err := template.New(name).Parse(source)
+			if err != nil {
+				return fmt.Errorf("failed to parse %s: %w", filepath.Join(dir, name+".html"), err)
+			}
+			s.html[name] = tmpl
+		}
+
+		source, err = readTemplate(fsys, dir, name+".txt")
+		if err != nil {
+			return err
+		}
+		if source != "" {
+			tmpl, err := texttemplate.New(name).Parse(source)
+			if err != nil {
+				return fmt.Errorf("failed to parse %s: %w", filepath.Join(dir, name+".txt"), err)
+			}
+			s.text[name] = tmpl
+		}
+	}
+	return nil
+}
+
+// readTemplate returns the file's content, or "" when it does not exist
+func readTemplate(fsys fs.FS, dir, file string) (string, error) {
+	data, err := fs.ReadFile(fsys, filepath.ToSlash(filepath.Join(dir, file)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read email template %s: %w", file, err)
+	}
+	return string(data), nil
+}
+
+// lookup returns the named templates for the company, falling back to the
+// shared ones for each template the company does not override. The plain
+// text template is nil when there is none.
+func (s *templateSet) lookup(companyID, name string) (*template.Template, *texttemplate.Template) {
+	html, text := s.html[name], s.text[name]
+	if company := s.companies[companyID]; company != nil {
+		if tmpl := company.html[name]; tmpl != nil {
+			html = tmpl
+		}
+		if tmpl := company.text[name]; tmpl != nil {
+			text = tmpl
+		}
+	}
+	return html, text
+}
+
+// statTemplates returns the modification time of every file under dir
+func statTemplates(dir string) (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		modTimes[path] = info.ModTime()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read email templates directory: %w", err)
+	}
+	return modTimes, nil
+}
+
+// sameModTimes reports whether both stats list the same files, unchanged
+func sameModTimes(a, b map[string]time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, modTime := range a {
+		if other, ok := b[path]; !ok || !other.Equal(modTime) {
+			return false
+		}
+	}
+	return true
+}
+
+// reloadIfChanged reloads the templates when a file in the templates
+// directory was added, changed or removed since they were loaded, checking
+// at most once per reload interval. A failed reload keeps the previous
+// templates.
+func (m *Mailer) reloadIfChanged() {
+	if m.templatesDir == "" || m.reloadInterval <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+	due := time.Since(m.checkedAt) >= m.reloadInterval
+	if due {
+		m.checkedAt = time.Now()
+	}
+	current := m.templates
+	m.mu.Unlock()
+	if !due {
+		return
+	}
+
+	modTimes, err := statTemplates(m.templatesDir)
+	if err != nil {
+		m.logger.WithError(err).WithField("dir", m.templatesDir).Warn("Failed to check email templates for changes")
+		return
+	}
+	if sameModTimes(modTimes, current.modTimes) {
+		return
+	}
+
+	set, err := loadTemplates(m.templatesDir)
+	if err != nil {
+		m.logger.WithError(err).WithField("dir", m.templatesDir).Error("Failed to reload email templates, keeping previous ones")
+		return
+	}
+
+	m.mu.Lock()
+	m.templates = set
+	m.mu.Unlock()
+	m.logger.WithField("dir", m.templatesDir).Info("Reloaded email templates")
+}
@@ -0,0 +1,300 @@
+// Package envelope encrypts data to a recipient's public key so that only the
+// holder of the matching private key can read it. Each message gets a fresh
+// AES-256-GCM key; that key is wrapped with RSA-OAEP or derived through an
+// X25519 exchange with a one-off ephemeral key, depending on the recipient.
+//
+// A sealed message is laid out as
+//
+//	version (1) | algorithm (1) | wrapped key length (2) | wrapped key | nonce (12) | ciphertext
+//
+// For X25519 the wrapped key is the ephemeral public key.
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+const version = 1
+
+// Algorithm names how the message key is wrapped
+type Algorithm string
+
+const (
+	AlgorithmRSAOAEP Algorithm = "rsa-oaep-sha256"
+	AlgorithmX25519  Algorithm = "x25519"
+)
+
+var algorithmIDs = map[Algorithm]byte{
+	AlgorithmRSAOAEP: 1,
+	AlgorithmX25519:  2,
+}
+
+// minRSABits rejects keys too weak to protect audit data
+const minRSABits = 2048
+
+// ErrInvalidKey is wrapped by ParsePublicKey for keys it cannot use
+var ErrInvalidKey = errors.New("invalid public key")
+
+// ErrInvalidPrivateKey is wrapped by ParsePrivateKey for keys it cannot use
+var ErrInvalidPrivateKey = errors.New("invalid private key")
+
+// PublicKey is a parsed recipient key
+type PublicKey struct {
+	algorithm Algorithm
+	id        string
+	rsa       *rsa.PublicKey
+	x25519    *ecdh.PublicKey
+}
+
+// Algorithm reports how messages sealed to the key wrap their message key
+func (k *PublicKey) Algorithm() Algorithm { return k.algorithm }
+
+// ID fingerprints the key, so recipients can tell which of their private keys
+// opens a message
+func (k *PublicKey) ID() string { return k.id }
+
+// ParsePublicKey reads a PEM "PUBLIC KEY" block holding an RSA key of at least
+// 2048 bits or an X25519 key
+func ParsePublicKey(pemData []byte) (*PublicKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("%w: expected a PEM PUBLIC KEY block", ErrInvalidKey)
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+
+	fingerprint := sha256.Sum256(block.Bytes)
+	key := &PublicKey{id: hex.EncodeToString(fingerprint[:8])}
+
+	switch pub := parsed.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("%w: RSA keys must have at least %d bits", ErrInvalidKey, minRSABits)
+		}
+		key.algorithm = AlgorithmRSAOAEP
+		key.rsa = pub
+	case *ecdh.PublicKey:
+		if pub.Curve() != ecdh.X25519() {
+			return nil, fmt.Errorf("%w: only X25519 ECDH keys are supported", ErrInvalidKey)
+		}
+		key.algorithm = AlgorithmX25519
+		key.x25519 = pub
+	default:
+		return nil, fmt.Errorf("%w: unsupported key type %T", ErrInvalidKey, parsed)
+	}
+
+	return key, nil
+}
+
+// ParsePrivateKey reads a PEM "PRIVATE KEY" block holding an RSA or X25519
+// key, or a PEM "RSA PRIVATE KEY" block, for UnwrapKey and Open
+func ParsePrivateKey(pemData []byte) (any, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, fmt.Errorf("%w: expected a PEM PRIVATE KEY block", ErrInvalidPrivateKey)
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPrivateKey, err)
+		}
+		return parsed, nil
+	case "PRIVATE KEY":
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPrivateKey, err)
+		}
+		switch priv := parsed.(type) {
+		case *rsa.PrivateKey:
+			return priv, nil
+		case *ecdh.PrivateKey:
+			if priv.Curve() == ecdh.X25519() {
+				return priv, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: unsupported key type %T", ErrInvalidPrivateKey, parsed)
+	default:
+		return nil, fmt.Errorf("%w: expected a PEM PRIVATE KEY block", ErrInvalidPrivateKey)
+	}
+}
+
+// Seal encrypts plaintext to key
+func Seal(key *PublicKey, plaintext []byte) ([]byte, error) {
+	messageKey, wrapped, err := key.wrap()
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(messageKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	header := make([]byte, 4, 4+len(wrapped)+len(nonce))
+	header[0] = version
+	header[1] = algorithmIDs[key.algorithm]
+	binary.BigEndian.PutUint16(header[2:], uint16(len(wrapped)))
+	header = append(header, wrapped...)
+	header = append(header, nonce...)
+
+	// The header is authenticated too, so swapping the wrapped key or the
+	// algorithm makes decryption fail rather than misbehave
+	return gcm.Seal(header, nonce, plaintext, header), nil
+}
+
+// wrap returns a fresh message key and the bytes the recipient needs to
+// recover it
+func (k *PublicKey) wrap() (messageKey, wrapped []byte, err error) {
+	switch k.algorithm {
+	case AlgorithmRSAOAEP:
+		messageKey = make([]byte, 32)
+		if _, err := rand.Read(messageKey); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate message key: %w", err)
+		}
+		wrapped, err = rsa.EncryptOAEP(sha256.New(), rand.Reader, k.rsa, messageKey, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to wrap message key: %w", err)
+		}
+		return messageKey, wrapped, nil
+	case AlgorithmX25519:
+		ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate ephemeral key: %w", err)
+		}
+		shared, err := ephemeral.ECDH(k.x25519)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to derive shared secret: %w", err)
+		}
+		wrapped = ephemeral.PublicKey().Bytes()
+		return deriveX25519Key(shared, wrapped, k.x25519.Bytes()), wrapped, nil
+	}
+	return nil, nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidKey, k.algorithm)
+}
+
+// deriveX25519Key binds the message key to both public keys as well as the
+// shared secret
+func deriveX25519Key(shared, ephemeralPub, recipientPub []byte) []byte {
+	h := sha256.New()
+	h.Write(shared)
+	h.Write(ephemeralPub)
+	h.Write(recipientPub)
+	return h.Sum(nil)
+}
+
+// ErrInvalidMessageKey is returned by OpenWithKey for a key that does not open
+// the message
+var ErrInvalidMessageKey = errors.New("invalid message key")
+
+// Header returns the leading part of a sealed message that holds its wrapped
+// message key and nonce. It is all a recipient needs to recover the message
+// key with UnwrapKey; the ciphertext is not part of it.
+func Header(sealed []byte) ([]byte, error) {
+	if len(sealed) < 4 || sealed[0] != version {
+		return nil, errors.New("unsupported envelope version")
+	}
+	wrappedLen := int(binary.BigEndian.Uint16(sealed[2:4]))
+	headerLen := 4 + wrappedLen + 12
+	if len(sealed) < headerLen {
+		return nil, errors.New("truncated envelope")
+	}
+	return sealed[:headerLen], nil
+}
+
+// UnwrapKey recovers the message key from the header of a message sealed to
+// the public half of priv, which must be an *rsa.PrivateKey or an X25519
+// *ecdh.PrivateKey. It is what a recipient's tooling runs; the service never
+// sees a private key and opens a message with OpenWithKey instead.
+func UnwrapKey(priv any, header []byte) ([]byte, error) {
+	header, err := Header(header)
+	if err != nil {
+		return nil, err
+	}
+	wrapped := header[4 : len(header)-12]
+
+	switch key := priv.(type) {
+	case *rsa.PrivateKey:
+		if header[1] != algorithmIDs[AlgorithmRSAOAEP] {
+			return nil, errors.New("envelope was not sealed to an RSA key")
+		}
+		messageKey, err := rsa.DecryptOAEP(sha256.New(), nil, key, wrapped, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unwrap message key: %w", err)
+		}
+		return messageKey, nil
+	case *ecdh.PrivateKey:
+		if header[1] != algorithmIDs[AlgorithmX25519] {
+			return nil, errors.New("envelope was not sealed to an X25519 key")
+		}
+		ephemeral, err := ecdh.X25519().NewPublicKey(wrapped)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ephemeral key: %w", err)
+		}
+		shared, err := key.ECDH(ephemeral)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive shared secret: %w", err)
+		}
+		return deriveX25519Key(shared, wrapped, key.PublicKey().Bytes()), nil
+	}
+	return nil, fmt.Errorf("unsupported private key type %T", priv)
+}
+
+// Open decrypts a message sealed to the public half of priv, see UnwrapKey
+func Open(priv any, sealed []byte) ([]byte, error) {
+	messageKey, err := UnwrapKey(priv, sealed)
+	if err != nil {
+		return nil, err
+	}
+	return OpenWithKey(messageKey, sealed)
+}
+
+// OpenWithKey decrypts a sealed message with the message key its recipient
+// unwrapped. The key opens that one message only.
+func OpenWithKey(messageKey, sealed []byte) ([]byte, error) {
+	header, err := Header(sealed)
+	if err != nil {
+		return nil, err
+	}
+	if len(messageKey) != 32 {
+		return nil, fmt.Errorf("%w: it must be 32 bytes", ErrInvalidMessageKey)
+	}
+
+	block, err := aes.NewCipher(messageKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	plaintext, err := gcm.Open(nil, header[len(header)-12:], sealed[len(header):], header)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMessageKey, err)
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,64 @@
+package geoip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// APIResolver resolves addresses through an external HTTP lookup service.
+// The configured URL must contain a single %s placeholder for the address and
+// return a JSON body with country_code, country_name and city fields.
+type APIResolver struct {
+	urlTemplate string
+	client      *http.Client
+}
+
+type apiResponse struct {
+	CountryCode string `json:"country_code"`
+	CountryName string `json:"country_name"`
+	City        string `json:"city"`
+}
+
+func NewAPIResolver(urlTemplate string) *APIResolver {
+	return &APIResolver{
+		urlTemplate: urlTemplate,
+		client:      &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (r *APIResolver) Lookup(ctx context.Context, ip string) (*Location, error) {
+	if !strings.Contains(r.urlTemplate, "%s") {
+		return nil, fmt.Errorf("geoip api url must contain a %%s placeholder")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(r.urlTemplate, url.PathEscape(ip)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build geoip request: %w", err)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call geoip api: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("geoip api returned status %d", resp.StatusCode)
+	}
+
+	var body apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode geoip response: %w", err)
+	}
+
+	return &Location{
+		CountryCode: body.CountryCode,
+		Country:     body.CountryName,
+		City:        body.City,
+	}, nil
+}
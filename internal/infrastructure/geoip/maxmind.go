@@ -0,0 +1,200 @@
+package geoip
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oschwald/geoip2-golang"
+	"github.com/sirupsen/logrus"
+)
+
+// MaxMindResolver reads a local GeoLite2/GeoIP2 City database. The file is
+// replaced out-of-band by the cron refresh job, so the resolver re-opens it
+// whenever its modification time changes.
+type MaxMindResolver struct {
+	path      string
+	logger    *logrus.Logger
+	mu        sync.RWMutex
+	reader    *geoip2.Reader
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+const reloadCheckInterval = time.Minute
+
+func NewMaxMindResolver(path string, logger *logrus.Logger) (*MaxMindResolver, error) {
+	r := &MaxMindResolver{
+		path:   path,
+		logger: logger,
+	}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *MaxMindResolver) Lookup(ctx context.Context, ip string) (*Location, error) {
+	r.reloadIfChanged()
+
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return nil, fmt.Errorf("invalid ip address: %s", ip)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	record, err := r.reader.City(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup ip address: %w", err)
+	}
+
+	return &Location{
+		CountryCode: record.Country.IsoCode,
+		Country:     record.Country.Names["en"],
+		City:        record.City.Names["en"],
+	}, nil
+}
+
+func (r *MaxMindResolver) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reader.Close()
+}
+
+func (r *MaxMindResolver) reloadIfChanged() {
+	r.mu.RLock()
+	due := time.Since(r.checkedAt) >= reloadCheckInterval
+	r.mu.RUnlock()
+	if !due {
+		return
+	}
+
+	info, err := os.Stat(r.path)
+	if err != nil {
+		r.logger.WithError(err).WithField("path", r.path).Warn("Failed to stat geoip database")
+		return
+	}
+
+	r.mu.Lock()
+	r.checkedAt = time.Now()
+	changed := info.ModTime().After(r.modTime)
+	r.mu.Unlock()
+
+	if changed {
+		if err := r.reload(); err != nil {
+			r.logger.WithError(err).WithField("path", r.path).Error("Failed to reload geoip database")
+		}
+	}
+}
+
+func (r *MaxMindResolver) reload() error {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat geoip database: %w", err)
+	}
+
+	reader, err := geoip2.Open(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to open geoip database: %w", err)
+	}
+
+	r.mu.Lock()
+	old := r.reader
+	r.reader = reader
+	r.modTime = info.ModTime()
+	r.checkedAt = time.Now()
+	r.mu.Unlock()
+
+	if old != nil {
+		old.Close()
+	}
+
+	r.logger.WithField("path", r.path).Info("GeoIP database loaded")
+	return nil
+}
+
+// DownloadDatabase fetches a MaxMind database from url and atomically replaces
+// the file at path. Plain .mmdb, gzip and tar.gz (MaxMind's download format)
+// payloads are supported.
+func DownloadDatabase(ctx context.Context, url, path string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build download request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download geoip database: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("geoip database download returned status %d", resp.StatusCode)
+	}
+
+	var body io.Reader = resp.Body
+	isTar := strings.HasSuffix(url, ".tar.gz") || strings.Contains(url, "suffix=tar.gz")
+	if isTar || strings.HasSuffix(url, ".gz") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to open gzip stream: %w", err)
+		}
+		defer gz.Close()
+		body = gz
+	}
+
+	if isTar {
+		tr := tar.NewReader(body)
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				return fmt.Errorf("no .mmdb file found in archive")
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read archive: %w", err)
+			}
+			if strings.HasSuffix(header.Name, ".mmdb") {
+				body = tr
+				break
+			}
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".geoip-*.mmdb")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write geoip database: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write geoip database: %w", err)
+	}
+
+	// Make sure what we downloaded is actually readable before swapping it in
+	reader, err := geoip2.Open(tmp.Name())
+	if err != nil {
+		return fmt.Errorf("downloaded geoip database is invalid: %w", err)
+	}
+	reader.Close()
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace geoip database: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package geoip
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"activity-log-service/internal/infrastructure/cache"
+)
+
+type Location struct {
+	CountryCode string `json:"country_code"`
+	Country     string `json:"country"`
+	City        string `json:"city"`
+}
+
+type Resolver interface {
+	Lookup(ctx context.Context, ip string) (*Location, error)
+}
+
+type Config struct {
+	Provider     string
+	DatabasePath string
+	APIURL       string
+	CacheTTL     time.Duration
+	// HashIP turns an address into its cache key, so raw addresses are never
+	// written to the cache
+	HashIP func(ip string) string
+}
+
+// NewResolver builds the configured resolver and, when a cache is available,
+// wraps it so repeated lookups for the same address skip the provider.
+func NewResolver(config Config, redisCache cache.Store, logger *logrus.Logger) (Resolver, error) {
+	var resolver Resolver
+	switch config.Provider {
+	case "maxmind":
+		mm, err := NewMaxMindResolver(config.DatabasePath, logger)
+		if err != nil {
+			return nil, err
+		}
+		resolver = mm
+	case "api":
+		resolver = NewAPIResolver(config.APIURL)
+	default:
+		return nil, fmt.Errorf("unknown geoip provider: %s", config.Provider)
+	}
+
+	if redisCache != nil {
+		resolver = NewCachedResolver(resolver, redisCache, config.CacheTTL, config.HashIP, logger)
+	}
+
+	return resolver, nil
+}
+
+type CachedResolver struct {
+	resolver Resolver
+	cache    cache.Store
+	ttl      time.Duration
+	hashIP   func(ip string) string
+	logger   *logrus.Logger
+}
+
+func NewCachedResolver(resolver Resolver, redisCache cache.Store, ttl time.Duration, hashIP func(ip string) string, logger *logrus.Logger) *CachedResolver {
+	return &CachedResolver{
+		resolver: resolver,
+		cache:    redisCache,
+		ttl:      ttl,
+		hashIP:   hashIP,
+		logger:   logger,
+	}
+}
+
+func (r *CachedResolver) Lookup(ctx context.Context, ip string) (*Location, error) {
+	cacheKey := cache.BuildGeoIPCacheKey(r.hashIP(ip))
+	var location Location
+	if err := r.cache.Get(ctx, cacheKey, &location); err == nil {
+		return &location, nil
+	}
+
+	result, err := r.resolver.Lookup(ctx, ip)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.cache.Set(ctx, cacheKey, result, r.ttl); err != nil {
+		r.logger.WithError(err).Warn("Failed to cache geoip lookup")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,81 @@
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Check verifies that a single dependency is reachable
+type Check func(ctx context.Context) error
+
+// Checker runs a named set of dependency checks concurrently
+type Checker struct {
+	checks  map[string]Check
+	timeout time.Duration
+}
+
+type Result struct {
+	Name    string        `json:"name"`
+	Healthy bool          `json:"healthy"`
+	Error   string        `json:"error,omitempty"`
+	Latency time.Duration `json:"latency"`
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		checks:  make(map[string]Check),
+		timeout: timeout,
+	}
+}
+
+// Register adds a check; registering the same name twice replaces it
+func (c *Checker) Register(name string, check Check) {
+	c.checks[name] = check
+}
+
+// Run executes every check and returns the results sorted by name
+func (c *Checker) Run(ctx context.Context) []Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make([]Result, 0, len(c.checks))
+	)
+	for name, check := range c.checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+			start := time.Now()
+			err := check(ctx)
+			result := Result{
+				Name:    name,
+				Healthy: err == nil,
+				Latency: time.Since(start),
+			}
+			if err != nil {
+				result.Error = err.Error()
+			}
+			mu.Lock()
+			results = append(results, result)
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+	return results
+}
+
+// Healthy reports whether every result passed
+func Healthy(results []Result) bool {
+	for _, result := range results {
+		if !result.Healthy {
+			return false
+		}
+	}
+	return true
+}
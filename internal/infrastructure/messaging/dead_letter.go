@@ -0,0 +1,176 @@
+package messaging
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"activity-log-service/internal/infrastructure/config"
+	pb "activity-log-service/pkg/proto"
+)
+
+// Headers a dead-lettered message carries next to the original ones
+const (
+	HeaderDeadLetterError      = "dlq-error"
+	HeaderDeadLetterSubject    = "dlq-original-subject"
+	HeaderDeadLetterSequence   = "dlq-original-sequence"
+	HeaderDeadLetterDeliveries = "dlq-deliveries"
+	HeaderDeadLetterFailedAt   = "dlq-failed-at"
+)
+
+const deadLetterHeaderPrefix = "dlq-"
+
+// errUndecodable marks messages no redelivery will fix; they are dead-lettered
+// on their first failure
+var errUndecodable = errors.New("undecodable message")
+
+// deadLetterMsg copies msg to subject with the reason it failed
+func deadLetterMsg(msg *nats.Msg, subject string, cause error, deliveries uint64, streamSequence uint64) *nats.Msg {
+	header := make(nats.Header, len(msg.Header)+5)
+	for key, values := range msg.Header {
+		header[key] = append([]string(nil), values...)
+	}
+	header.Set(HeaderDeadLetterError, cause.Error())
+	header.Set(HeaderDeadLetterSubject, msg.Subject)
+	header.Set(HeaderDeadLetterSequence, strconv.FormatUint(streamSequence, 10))
+	header.Set(HeaderDeadLetterDeliveries, strconv.FormatUint(deliveries, 10))
+	header.Set(HeaderDeadLetterFailedAt, time.Now().UTC().Format(time.RFC3339))
+
+	return &nats.Msg{Subject: subject, Header: header, Data: msg.Data}
+}
+
+// DeadLetter is a message the consumer gave up on
+type DeadLetter struct {
+	// Sequence is the message's position in the dead-letter stream
+	Sequence uint64
+	// Subject and OriginalSequence locate the message it was published as
+	Subject          string
+	OriginalSequence uint64
+	Error            string
+	Deliveries       int
+	FailedAt         time.Time
+	ContentType      string
+	Data             []byte
+}
+
+// Payload returns Data for printing, with protobuf events rendered as JSON
+func (l *DeadLetter) Payload() []byte {
+	if l.ContentType != contentTypeProtobuf {
+		return l.Data
+	}
+	var envelope pb.EventEnvelope
+	if err := proto.Unmarshal(l.Data, &envelope); err != nil {
+		return l.Data
+	}
+	data, err := protojson.Marshal(&envelope)
+	if err != nil {
+		return l.Data
+	}
+	return data
+}
+
+// DeadLetterQueue inspects the dead-letter stream and replays its messages
+// onto the subjects they came from
+type DeadLetterQueue struct {
+	conn   *nats.Conn
+	js     nats.JetStreamContext
+	stream string
+	logger *logrus.Logger
+}
+
+func NewDeadLetterQueue(cfg config.NATSConfig, logger *logrus.Logger) (*DeadLetterQueue, error) {
+	conn, err := Connect(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	return &DeadLetterQueue{conn: conn, js: js, stream: cfg.DeadLetterStream, logger: logger}, nil
+}
+
+// List returns up to limit dead letters, oldest first
+func (q *DeadLetterQueue) List(limit int) ([]DeadLetter, error) {
+	info, err := q.js.StreamInfo(q.stream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dead-letter stream info: %w", err)
+	}
+
+	var letters []DeadLetter
+	for seq := info.State.FirstSeq; seq <= info.State.LastSeq && len(letters) < limit; seq++ {
+		letter, err := q.Get(seq)
+		if errors.Is(err, nats.ErrMsgNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		letters = append(letters, *letter)
+	}
+	return letters, nil
+}
+
+// Get returns the dead letter at seq
+func (q *DeadLetterQueue) Get(seq uint64) (*DeadLetter, error) {
+	raw, err := q.js.GetMsg(q.stream, seq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dead letter %d: %w", seq, err)
+	}
+
+	letter := &DeadLetter{
+		Sequence:    raw.Sequence,
+		Subject:     raw.Header.Get(HeaderDeadLetterSubject),
+		Error:       raw.Header.Get(HeaderDeadLetterError),
+		ContentType: raw.Header.Get(contentTypeHeader),
+		Data:        raw.Data,
+	}
+	letter.OriginalSequence, _ = strconv.ParseUint(raw.Header.Get(HeaderDeadLetterSequence), 10, 64)
+	letter.Deliveries, _ = strconv.Atoi(raw.Header.Get(HeaderDeadLetterDeliveries))
+	letter.FailedAt, _ = time.Parse(time.RFC3339, raw.Header.Get(HeaderDeadLetterFailedAt))
+	return letter, nil
+}
+
+// Replay publishes the dead letter at seq to its original subject with its
+// original headers, then removes it from the dead-letter stream. The consumer
+// processes it like a new message.
+func (q *DeadLetterQueue) Replay(seq uint64) error {
+	raw, err := q.js.GetMsg(q.stream, seq)
+	if err != nil {
+		return fmt.Errorf("failed to get dead letter %d: %w", seq, err)
+	}
+	subject := raw.Header.Get(HeaderDeadLetterSubject)
+	if subject == "" {
+		return fmt.Errorf("dead letter %d has no original subject", seq)
+	}
+
+	header := make(nats.Header, len(raw.Header))
+	for key, values := range raw.Header {
+		if !strings.HasPrefix(strings.ToLower(key), deadLetterHeaderPrefix) {
+			header[key] = values
+		}
+	}
+	if _, err := q.js.PublishMsg(&nats.Msg{Subject: subject, Header: header, Data: raw.Data}); err != nil {
+		return fmt.Errorf("failed to replay dead letter %d: %w", seq, err)
+	}
+
+	if err := q.js.DeleteMsg(q.stream, seq); err != nil {
+		return fmt.Errorf("replayed dead letter %d but failed to remove it: %w", seq, err)
+	}
+	q.logger.WithFields(logrus.Fields{"sequence": seq, "subject": subject}).Info("Dead letter replayed")
+	return nil
+}
+
+func (q *DeadLetterQueue) Close() {
+	q.conn.Close()
+}
@@ -0,0 +1,219 @@
+package messaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/event"
+	"activity-log-service/internal/domain/valueobject"
+	pb "activity-log-service/pkg/proto"
+)
+
+// EventEncoding is how the publisher encodes events. Version 1 events are
+// JSON; version 2 events are a protobuf EventEnvelope; CloudEvents wraps the
+// version 1 JSON in a CloudEvents 1.0 structured-mode envelope. The consumer
+// decodes all of them, by the content-type header.
+type EventEncoding string
+
+const (
+	EncodingJSON        EventEncoding = "json"
+	EncodingProtobuf    EventEncoding = "protobuf"
+	EncodingCloudEvents EventEncoding = "cloudevents"
+)
+
+// ParseEventEncoding validates a configured encoding; empty means protobuf
+func ParseEventEncoding(encoding string) (EventEncoding, error) {
+	switch EventEncoding(encoding) {
+	case "", EncodingProtobuf:
+		return EncodingProtobuf, nil
+	case EncodingJSON, EncodingCloudEvents:
+		return EventEncoding(encoding), nil
+	}
+	return "", fmt.Errorf("unknown event encoding %q", encoding)
+}
+
+const (
+	contentTypeHeader      = "content-type"
+	contentTypeJSON        = "application/json"
+	contentTypeProtobuf    = "application/x-protobuf"
+	contentTypeCloudEvents = "application/cloudevents+json"
+
+	// protobufEventVersion is the version of events encoded as EventEnvelope
+	protobufEventVersion = 2
+)
+
+// cloudEvent is a CloudEvents 1.0 event in structured mode. Subject is the
+// activity log ID, and Data the version 1 JSON event.
+type cloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// cloudEventTypePrefix namespaces event types, e.g.
+// com.activitylog.activity_log_created
+const cloudEventTypePrefix = "com.activitylog."
+
+// encodeEvent returns the message body of e and its content type. source
+// is only used by CloudEvents.
+func encodeEvent(e event.Event, encoding EventEncoding, source string) ([]byte, string, error) {
+	switch encoding {
+	case EncodingJSON:
+		data, err := e.ToJSON()
+		return data, contentTypeJSON, err
+	case EncodingCloudEvents:
+		data, err := e.ToJSON()
+		if err != nil {
+			return nil, "", err
+		}
+		data, err = json.Marshal(cloudEvent{
+			SpecVersion:     "1.0",
+			ID:              e.GetEventID(),
+			Source:          source,
+			Type:            cloudEventTypePrefix + e.GetEventType(),
+			Subject:         e.GetAggregateID(),
+			Time:            e.GetTimestamp(),
+			DataContentType: contentTypeJSON,
+			Data:            data,
+		})
+		return data, contentTypeCloudEvents, err
+	}
+
+	envelope := &pb.EventEnvelope{
+		EventId:     e.GetEventID(),
+		EventType:   e.GetEventType(),
+		AggregateId: e.GetAggregateID(),
+		Timestamp:   timestamppb.New(e.GetTimestamp()),
+		Version:     protobufEventVersion,
+	}
+	switch e := e.(type) {
+	case *event.ActivityLogCreated:
+		envelope.Payload = &pb.EventEnvelope_Created{Created: &pb.ActivityLogCreatedEvent{
+			ActivityLog: activityLogToProto(e.ActivityLog),
+			Trimmed:     e.Trimmed,
+			Queued:      e.Queued,
+		}}
+	case *event.ActivityLogUpdated:
+		envelope.Payload = &pb.EventEnvelope_Updated{Updated: &pb.ActivityLogUpdatedEvent{
+			ActivityLog: activityLogToProto(e.ActivityLog),
+		}}
+	case *event.ActivityLogDeleted:
+		envelope.Payload = &pb.EventEnvelope_Deleted{Deleted: &pb.ActivityLogDeletedEvent{
+			CompanyId: e.CompanyID,
+		}}
+	default:
+		return nil, "", fmt.Errorf("no protobuf encoding for %s events", e.GetEventType())
+	}
+
+	data, err := proto.Marshal(envelope)
+	return data, contentTypeProtobuf, err
+}
+
+// decodeEvent decodes a created event in any encoding. Messages without a
+// content type predate it and are JSON.
+func decodeEvent(header nats.Header, data []byte) (*event.ActivityLogCreated, error) {
+	switch header.Get(contentTypeHeader) {
+	case contentTypeCloudEvents:
+		var envelope cloudEvent
+		if err := json.Unmarshal(data, &envelope); err != nil {
+			return nil, fmt.Errorf("%w: failed to unmarshal cloud event: %v", errUndecodable, err)
+		}
+		data = envelope.Data
+	case contentTypeProtobuf:
+		return decodeProtobufEvent(data)
+	}
+
+	var created event.ActivityLogCreated
+	if err := json.Unmarshal(data, &created); err != nil {
+		return nil, fmt.Errorf("%w: failed to unmarshal event: %v", errUndecodable, err)
+	}
+	return &created, nil
+}
+
+func decodeProtobufEvent(data []byte) (*event.ActivityLogCreated, error) {
+	var envelope pb.EventEnvelope
+	if err := proto.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("%w: failed to unmarshal event: %v", errUndecodable, err)
+	}
+	payload := envelope.GetCreated()
+	if payload == nil {
+		return nil, fmt.Errorf("%w: %s event has no created payload", errUndecodable, envelope.GetEventType())
+	}
+	return &event.ActivityLogCreated{
+		EventID:     envelope.GetEventId(),
+		EventType:   envelope.GetEventType(),
+		AggregateID: envelope.GetAggregateId(),
+		ActivityLog: activityLogFromProto(payload.GetActivityLog()),
+		Timestamp:   envelope.GetTimestamp().AsTime(),
+		Version:     int(envelope.GetVersion()),
+		Trimmed:     payload.GetTrimmed(),
+		Queued:      payload.GetQueued(),
+	}, nil
+}
+
+func activityLogToProto(activityLog *entity.ActivityLog) *pb.ActivityLog {
+	if activityLog == nil {
+		return nil
+	}
+	return &pb.ActivityLog{
+		Id:               activityLog.ID.String(),
+		ActivityName:     activityLog.ActivityName,
+		CompanyId:        activityLog.CompanyID,
+		ObjectName:       activityLog.ObjectName,
+		ObjectId:         activityLog.ObjectID,
+		Changes:          string(activityLog.Changes),
+		FormattedMessage: activityLog.FormattedMessage,
+		ActorId:          activityLog.ActorID,
+		ActorName:        activityLog.ActorName,
+		ActorEmail:       activityLog.ActorEmail,
+		CreatedAt:        timestamppb.New(activityLog.CreatedAt),
+		UserAgent:        activityLog.UserAgent,
+		IpAddress:        activityLog.IPAddress,
+		DeviceId:         activityLog.DeviceID,
+		CountryCode:      activityLog.CountryCode,
+		City:             activityLog.City,
+		Tags:             activityLog.Tags,
+		ExternalId:       activityLog.ExternalID,
+	}
+}
+
+func activityLogFromProto(activityLog *pb.ActivityLog) *entity.ActivityLog {
+	if activityLog == nil {
+		return nil
+	}
+	var changes json.RawMessage
+	if activityLog.GetChanges() != "" {
+		changes = json.RawMessage(activityLog.GetChanges())
+	}
+	return &entity.ActivityLog{
+		ID:               valueobject.ActivityLogID(activityLog.GetId()),
+		ActivityName:     activityLog.GetActivityName(),
+		CompanyID:        activityLog.GetCompanyId(),
+		ObjectName:       activityLog.GetObjectName(),
+		ObjectID:         activityLog.GetObjectId(),
+		Changes:          changes,
+		FormattedMessage: activityLog.GetFormattedMessage(),
+		ActorID:          activityLog.GetActorId(),
+		ActorName:        activityLog.GetActorName(),
+		ActorEmail:       activityLog.GetActorEmail(),
+		UserAgent:        activityLog.GetUserAgent(),
+		IPAddress:        activityLog.GetIpAddress(),
+		DeviceID:         activityLog.GetDeviceId(),
+		CountryCode:      activityLog.GetCountryCode(),
+		City:             activityLog.GetCity(),
+		Tags:             activityLog.GetTags(),
+		ExternalID:       activityLog.GetExternalId(),
+		CreatedAt:        activityLog.GetCreatedAt().AsTime(),
+	}
+}
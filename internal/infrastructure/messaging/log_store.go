@@ -0,0 +1,84 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/event"
+	"activity-log-service/internal/domain/repository"
+	"activity-log-service/internal/domain/valueobject"
+)
+
+// logStore writes the logs of created events and indexes them; the NATS
+// consumer and the in-process bus share it
+type logStore struct {
+	arangoRepo repository.ActivityLogRepository
+	indexer    Indexer
+	logger     *logrus.Logger
+}
+
+// storeEvent writes the log of one event and indexes it
+func (s *logStore) storeEvent(ctx context.Context, event *event.ActivityLogCreated) error {
+	s.logger.WithFields(logrus.Fields{
+		"event_type":   event.GetEventType(),
+		"aggregate_id": event.GetAggregateID(),
+	}).Info("Processing activity log event")
+
+	// A trimmed event is only published once its log has been stored, so
+	// there is nothing to write; hydrating confirms the log is readable and
+	// a failure is retried like any other
+	if event.Trimmed {
+		activityLog, err := s.hydrate(ctx, event)
+		if err != nil {
+			return err
+		}
+		return s.index(ctx, activityLog)
+	}
+
+	// A log with an external ID may already be stored under it; writing it
+	// again would violate the unique index and be redelivered forever
+	var err error
+	if event.ActivityLog != nil && event.ActivityLog.ExternalID != "" {
+		_, _, err = s.arangoRepo.CreateByExternalID(ctx, event.ActivityLog, false)
+	} else {
+		err = storedOnce(s.arangoRepo.Create(ctx, event.ActivityLog))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save to ArangoDB: %w", err)
+	}
+
+	return s.index(ctx, event.ActivityLog)
+}
+
+// storedOnce treats a log already stored under its ID as stored: a
+// redelivered event carries the same log, so the earlier delivery wrote it.
+// It is still indexed again in case that delivery failed after the write.
+func storedOnce(err error) error {
+	if errors.Is(err, entity.ErrActivityLogExists) {
+		return nil
+	}
+	return err
+}
+
+func (s *logStore) index(ctx context.Context, activityLog *entity.ActivityLog) error {
+	if s.indexer == nil {
+		return nil
+	}
+	if err := s.indexer.Index(ctx, activityLog); err != nil {
+		return fmt.Errorf("failed to index activity log: %w", err)
+	}
+	return nil
+}
+
+// hydrate fetches the full activity log a trimmed event refers to
+func (s *logStore) hydrate(ctx context.Context, event *event.ActivityLogCreated) (*entity.ActivityLog, error) {
+	activityLog, err := s.arangoRepo.GetByID(ctx, valueobject.ActivityLogID(event.GetAggregateID()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to hydrate trimmed event: %w", err)
+	}
+	return activityLog, nil
+}
@@ -0,0 +1,145 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"activity-log-service/internal/domain/event"
+	"activity-log-service/internal/domain/repository"
+)
+
+// ErrBusStopped is returned for events published after the bus was stopped
+var ErrBusStopped = errors.New("event bus stopped")
+
+// MemoryBus publishes events to consumers in the same process instead of
+// NATS, so a single binary runs the whole create, consume and store flow in
+// development and integration tests. Created events are stored and indexed
+// by a pool of workers like the NATS consumer does; updated and deleted
+// events have no consumer here and are dropped. Nothing survives a restart,
+// and an event that fails to be stored is logged and not retried.
+type MemoryBus struct {
+	logStore
+	events     chan busEvent
+	jobTimeout time.Duration
+	wg         sync.WaitGroup
+
+	// stopMu keeps Stop from closing events while an event is being queued
+	stopMu  sync.RWMutex
+	stopped bool
+
+	mu       sync.Mutex
+	sequence uint64
+	ackFloor uint64
+	acked    map[uint64]bool
+}
+
+type busEvent struct {
+	sequence uint64
+	event    *event.ActivityLogCreated
+}
+
+var _ event.Publisher = (*MemoryBus)(nil)
+
+// NewMemoryBus starts workers that store created events into arangoRepo.
+// Up to queueSize events wait for a free worker; publishing blocks while
+// the queue is full.
+func NewMemoryBus(arangoRepo repository.ActivityLogRepository, workers, queueSize int, jobTimeout time.Duration, logger *logrus.Logger) *MemoryBus {
+	b := &MemoryBus{
+		logStore:   logStore{arangoRepo: arangoRepo, logger: logger},
+		events:     make(chan busEvent, queueSize),
+		jobTimeout: jobTimeout,
+		acked:      make(map[uint64]bool),
+	}
+	for i := 0; i < workers; i++ {
+		b.wg.Add(1)
+		go b.worker()
+	}
+	return b
+}
+
+// SetIndexer makes the bus pass every stored log to indexer
+func (b *MemoryBus) SetIndexer(indexer Indexer) {
+	b.indexer = indexer
+}
+
+// PublishActivityLogCreated queues the event for the workers and returns its
+// sequence, which ConsumerAckFloor reaches once it is stored
+func (b *MemoryBus) PublishActivityLogCreated(ctx context.Context, event *event.ActivityLogCreated) (uint64, error) {
+	b.stopMu.RLock()
+	defer b.stopMu.RUnlock()
+	if b.stopped {
+		return 0, ErrBusStopped
+	}
+
+	b.mu.Lock()
+	b.sequence++
+	sequence := b.sequence
+	b.mu.Unlock()
+
+	select {
+	case b.events <- busEvent{sequence: sequence, event: event}:
+		return sequence, nil
+	case <-ctx.Done():
+		b.ack(sequence)
+		return 0, ctx.Err()
+	}
+}
+
+func (b *MemoryBus) PublishActivityLogUpdated(ctx context.Context, event *event.ActivityLogUpdated) (uint64, error) {
+	return 0, nil
+}
+
+func (b *MemoryBus) PublishActivityLogDeleted(ctx context.Context, event *event.ActivityLogDeleted) (uint64, error) {
+	return 0, nil
+}
+
+// ConsumerAckFloor returns the sequence up to which every created event has
+// been processed. The bus has a single stream and consumer, so streamName
+// and durable are ignored.
+func (b *MemoryBus) ConsumerAckFloor(streamName, durable string) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.ackFloor, nil
+}
+
+func (b *MemoryBus) worker() {
+	defer b.wg.Done()
+	for e := range b.events {
+		ctx, cancel := context.WithTimeout(context.Background(), b.jobTimeout)
+		if err := b.storeEvent(ctx, e.event); err != nil {
+			b.logger.WithError(err).WithField("aggregate_id", e.event.GetAggregateID()).Error("Failed to process event")
+		}
+		cancel()
+		b.ack(e.sequence)
+	}
+}
+
+// ack marks sequence as processed and moves the ack floor past every
+// sequence processed without a gap
+func (b *MemoryBus) ack(sequence uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.acked[sequence] = true
+	for b.acked[b.ackFloor+1] {
+		delete(b.acked, b.ackFloor+1)
+		b.ackFloor++
+	}
+}
+
+// Stop refuses further events and waits for the queued ones to be stored
+func (b *MemoryBus) Stop() {
+	b.stopMu.Lock()
+	if b.stopped {
+		b.stopMu.Unlock()
+		return
+	}
+	b.stopped = true
+	close(b.events)
+	b.stopMu.Unlock()
+
+	b.wg.Wait()
+}
@@ -0,0 +1,39 @@
+package messaging
+
+import (
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+
+	"activity-log-service/internal/infrastructure/certs"
+	"activity-log-service/internal/infrastructure/config"
+)
+
+// Connect dials cfg.URL with the credentials and TLS settings of cfg, followed
+// by opts. A credentials file carries its own user JWT and nkey seed; token
+// and username/password are for servers configured with plain authorization.
+func Connect(cfg config.NATSConfig, opts ...nats.Option) (*nats.Conn, error) {
+	var auth []nats.Option
+	if cfg.CredentialsFile != "" {
+		auth = append(auth, nats.UserCredentials(cfg.CredentialsFile))
+	}
+	if cfg.Token != "" {
+		auth = append(auth, nats.Token(cfg.Token))
+	}
+	if cfg.Username != "" {
+		auth = append(auth, nats.UserInfo(cfg.Username, cfg.Password))
+	}
+	if cfg.TLS.Enabled {
+		tlsConfig, err := certs.ClientConfig(cfg.TLS.CAFile, cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.InsecureSkipVerify)
+		if err != nil {
+			return nil, fmt.Errorf("invalid NATS TLS config: %w", err)
+		}
+		auth = append(auth, nats.Secure(tlsConfig))
+	}
+
+	conn, err := nats.Connect(cfg.URL, append(auth, opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	return conn, nil
+}
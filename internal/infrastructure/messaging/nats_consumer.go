@@ -2,8 +2,9 @@ package messaging
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand/v2"
 	"sync"
 	"time"
 
@@ -12,32 +13,96 @@ import (
 	"github.com/opentracing/opentracing-go/ext"
 	"github.com/sirupsen/logrus"
 
+	"activity-log-service/internal/domain/entity"
 	"activity-log-service/internal/domain/event"
 	"activity-log-service/internal/domain/repository"
+	"activity-log-service/internal/infrastructure/config"
+	"activity-log-service/internal/infrastructure/metrics"
 )
 
 type NATSConsumer struct {
+	logStore
+	opts         ConsumerOptions
 	conn         *nats.Conn
 	js           nats.JetStreamContext
-	logger       *logrus.Logger
-	arangoRepo   repository.ActivityLogRepository
+	stream       string
 	subscription *nats.Subscription
 	workerPool   *WorkerPool
-	stopCh       chan struct{}
+	stopOnce     sync.Once
 	wg           sync.WaitGroup
-	tracer       opentracing.Tracer
+	// fetchers are the pull loops; their batches run under batchCtx, which
+	// outlives the consumer's context so a stop drains them
+	fetchers      sync.WaitGroup
+	batchCtx      context.Context
+	cancelBatches context.CancelFunc
+	tracer        opentracing.Tracer
+	// deadLetterSubject receives messages that failed maxDeliver times, or
+	// cannot be decoded at all; empty keeps redelivering them instead
+	deadLetterStream  string
+	deadLetterSubject string
+	maxDeliver        int
+}
+
+// Indexer receives every stored log, e.g. to keep a search index in sync.
+// Indexing the same log twice must be harmless: failed messages are
+// redelivered.
+type Indexer interface {
+	Index(ctx context.Context, activityLog *entity.ActivityLog) error
 }
 
 type ActivityLogHandler func(ctx context.Context, event *event.ActivityLogCreated) error
 
+// ConsumerOptions says what the consumer subscribes to and how it works
+// through the messages
+type ConsumerOptions struct {
+	Subject string
+	Durable string
+	// Workers process messages concurrently, each for at most JobTimeout;
+	// up to QueueSize received messages wait for a free worker
+	Workers    int
+	QueueSize  int
+	JobTimeout time.Duration
+	// Pull fetches up to BatchSize messages at a time, waiting at most
+	// FetchWait for them, and stores each batch with one bulk insert.
+	// Workers then counts fetch loops and QueueSize is unused.
+	Pull      bool
+	BatchSize int
+	FetchWait time.Duration
+	// A failed message is redelivered after RetryInitialBackoff, doubling
+	// with each delivery up to RetryMaxBackoff; zero redelivers at once
+	RetryInitialBackoff time.Duration
+	RetryMaxBackoff     time.Duration
+	// MaxAckPending caps the messages the server hands out before they are
+	// acknowledged, so it stops sending instead of the queue filling up.
+	// Zero sizes it to what the consumer can hold: Workers + QueueSize
+	// pushed, or Workers * BatchSize pulled.
+	MaxAckPending int
+	// DrainTimeout bounds how long Stop waits for received messages to be
+	// processed before it cancels them
+	DrainTimeout time.Duration
+	// LagInterval is how often the durable's backlog is exported as metrics;
+	// zero turns it off
+	LagInterval time.Duration
+}
+
+func (o ConsumerOptions) maxAckPending() int {
+	switch {
+	case o.MaxAckPending > 0:
+		return o.MaxAckPending
+	case o.Pull:
+		return o.Workers * o.BatchSize
+	}
+	return o.Workers + o.QueueSize
+}
+
 func NewNATSConsumer(
-	url string,
+	natsCfg config.NATSConfig,
 	logger *logrus.Logger,
 	arangoRepo repository.ActivityLogRepository,
-	workers int,
+	opts ConsumerOptions,
 	tracer opentracing.Tracer,
 ) (*NATSConsumer, error) {
-	conn, err := nats.Connect(url,
+	conn, err := Connect(natsCfg,
 		nats.ReconnectWait(time.Second*2),
 		nats.MaxReconnects(10),
 		nats.DisconnectErrHandler(func(nc *nats.Conn, err error) {
@@ -48,7 +113,7 @@ func NewNATSConsumer(
 		}),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+		return nil, err
 	}
 
 	js, err := conn.JetStream()
@@ -56,30 +121,77 @@ func NewNATSConsumer(
 		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
 	}
 
-	workerPool := NewWorkerPool(workers, logger)
+	workerPool := NewWorkerPool(opts.Workers, opts.QueueSize, opts.JobTimeout, opts.DrainTimeout, logger)
+	batchCtx, cancelBatches := context.WithCancel(context.Background())
 
 	return &NATSConsumer{
-		conn:       conn,
-		js:         js,
-		logger:     logger,
-		arangoRepo: arangoRepo,
-		workerPool: workerPool,
-		stopCh:     make(chan struct{}),
-		tracer:     tracer,
+		opts:          opts,
+		conn:          conn,
+		js:            js,
+		logStore:      logStore{arangoRepo: arangoRepo, logger: logger},
+		workerPool:    workerPool,
+		batchCtx:      batchCtx,
+		cancelBatches: cancelBatches,
+		tracer:        tracer,
 	}, nil
 }
 
+// SetIndexer makes the consumer pass every stored log to indexer. A log is
+// only acknowledged once it has been indexed.
+func (c *NATSConsumer) SetIndexer(indexer Indexer) {
+	c.indexer = indexer
+}
+
+// SetDeadLetter moves messages that failed on their maxDeliver-th delivery,
+// or cannot be decoded, to subject on stream, which Start creates if needed.
+// The count is the consumer's own, so it works whatever the durable's
+// max_deliver is set to.
+func (c *NATSConsumer) SetDeadLetter(stream, subject string, maxDeliver int) {
+	c.deadLetterStream = stream
+	c.deadLetterSubject = subject
+	c.maxDeliver = maxDeliver
+}
+
 func (c *NATSConsumer) Start(ctx context.Context) error {
-	c.workerPool.Start()
+	if c.deadLetterSubject != "" {
+		if err := ensureStream(c.js, c.deadLetterStream, []string{c.deadLetterSubject}, c.logger); err != nil {
+			return fmt.Errorf("failed to ensure dead-letter stream: %w", err)
+		}
+	}
 
-	sub, err := c.js.Subscribe("activity.log.created", c.handleMessage, nats.Durable("activity-log-consumer"))
+	stream, err := c.js.StreamNameBySubject(c.opts.Subject)
 	if err != nil {
-		return fmt.Errorf("failed to subscribe: %w", err)
+		return fmt.Errorf("failed to find stream of %s: %w", c.opts.Subject, err)
+	}
+	c.stream = stream
+
+	if err := c.updateMaxAckPending(); err != nil {
+		return err
+	}
+
+	if c.opts.Pull {
+		if err := c.startPull(ctx); err != nil {
+			return err
+		}
+	} else {
+		c.workerPool.Start()
+
+		sub, err := c.js.Subscribe(c.opts.Subject, c.handleMessage,
+			nats.Durable(c.opts.Durable),
+			nats.MaxAckPending(c.opts.maxAckPending()),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to subscribe: %w", err)
+		}
+		c.subscription = sub
 	}
-	c.subscription = sub
 
 	c.logger.Info("NATS consumer started")
 
+	if c.opts.LagInterval > 0 {
+		go c.monitorLag(ctx)
+	}
+
 	c.wg.Add(1)
 	go func() {
 		defer c.wg.Done()
@@ -90,15 +202,54 @@ func (c *NATSConsumer) Start(ctx context.Context) error {
 	return nil
 }
 
+// updateMaxAckPending applies the cap to an existing durable. Subscribing
+// with a cap the durable does not have fails instead of changing it.
+func (c *NATSConsumer) updateMaxAckPending() error {
+	info, err := c.js.ConsumerInfo(c.stream, c.opts.Durable)
+	if errors.Is(err, nats.ErrConsumerNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get consumer info: %w", err)
+	}
+
+	want := c.opts.maxAckPending()
+	if info.Config.MaxAckPending == want {
+		return nil
+	}
+	config := info.Config
+	config.MaxAckPending = want
+	if _, err := c.js.UpdateConsumer(c.stream, &config); err != nil {
+		return fmt.Errorf("failed to update max ack pending of %s: %w", c.opts.Durable, err)
+	}
+	c.logger.WithFields(logrus.Fields{
+		"durable":         c.opts.Durable,
+		"max_ack_pending": want,
+		"was":             info.Config.MaxAckPending,
+	}).Info("Consumer max ack pending updated")
+	return nil
+}
+
+// Stop stops receiving, drains the messages already received and then
+// closes the connection, so their acks go out. It is safe to call more than
+// once.
 func (c *NATSConsumer) Stop() {
+	c.stopOnce.Do(c.stop)
+}
+
+func (c *NATSConsumer) stop() {
 	c.logger.Info("Stopping NATS consumer")
 
 	if c.subscription != nil {
 		c.subscription.Unsubscribe()
 	}
 
+	c.drainFetchers()
 	c.workerPool.Stop()
-	close(c.stopCh)
+
+	if err := c.conn.FlushTimeout(time.Second); err != nil {
+		c.logger.WithError(err).Warn("Failed to flush acks before closing")
+	}
 	c.conn.Close()
 
 	c.logger.Info("NATS consumer stopped")
@@ -109,48 +260,119 @@ func (c *NATSConsumer) handleMessage(msg *nats.Msg) {
 		ID:   fmt.Sprintf("msg-%d", time.Now().UnixNano()),
 		Data: msg.Data,
 		Handler: func(ctx context.Context, data []byte) error {
-			return c.processActivityLogEvent(ctx, data)
+			return c.processActivityLogEvent(ctx, msg.Header, data)
 		},
 		OnSuccess: func() {
-			msg.Ack()
-			c.logger.Debug("Message acknowledged")
+			c.ack(msg)
 		},
 		OnError: func(err error) {
-			c.logger.WithError(err).Error("Failed to process message")
+			c.fail(msg, err)
+		},
+		// Hand it back at once, for another replica to pick up
+		OnReject: func() {
 			msg.Nak()
 		},
 	}
 
-	c.workerPool.Submit(job)
+	if !c.workerPool.Submit(job) {
+		metrics.RecordNATSMessageProcessed(msg.Subject, "nacked")
+	}
+}
+
+func (c *NATSConsumer) ack(msg *nats.Msg) {
+	msg.Ack()
+	metrics.RecordNATSMessageProcessed(msg.Subject, "acked")
+	c.logger.Debug("Message acknowledged")
+}
+
+// fail dead-letters msg, or hands it back for redelivery after a backoff
+func (c *NATSConsumer) fail(msg *nats.Msg, err error) {
+	c.logger.WithError(err).Error("Failed to process message")
+	if c.deadLetter(msg, err) {
+		metrics.RecordNATSMessageProcessed(msg.Subject, "dead_lettered")
+		return
+	}
+	metrics.RecordNATSMessageProcessed(msg.Subject, "nacked")
+
+	var deliveries uint64 = 1
+	if meta, err := msg.Metadata(); err == nil {
+		deliveries = meta.NumDelivered
+	}
+	if delay := c.retryDelay(deliveries); delay > 0 {
+		msg.NakWithDelay(delay)
+		return
+	}
+	msg.Nak()
+}
+
+// retryDelay doubles with each delivery so far, up to RetryMaxBackoff, so an
+// outage does not turn into a hot retry loop. The delay is jittered between
+// half and all of it, so messages that failed together spread out again.
+func (c *NATSConsumer) retryDelay(deliveries uint64) time.Duration {
+	delay := c.opts.RetryInitialBackoff
+	if delay <= 0 {
+		return 0
+	}
+	for n := uint64(1); n < deliveries && delay < c.opts.RetryMaxBackoff; n++ {
+		delay *= 2
+	}
+	delay = min(delay, c.opts.RetryMaxBackoff)
+	return delay/2 + rand.N(delay/2+1)
 }
 
-func (c *NATSConsumer) processActivityLogEvent(ctx context.Context, data []byte) error {
+// deadLetter moves msg to the dead-letter subject if it has used up its
+// deliveries or can never succeed, and reports whether it did. A message that
+// cannot be dead-lettered is left to redelivery.
+func (c *NATSConsumer) deadLetter(msg *nats.Msg, cause error) bool {
+	if c.deadLetterSubject == "" {
+		return false
+	}
+	meta, err := msg.Metadata()
+	if err != nil {
+		return false
+	}
+	exhausted := c.maxDeliver > 0 && meta.NumDelivered >= uint64(c.maxDeliver)
+	if !exhausted && !errors.Is(cause, errUndecodable) {
+		return false
+	}
+
+	fields := logrus.Fields{
+		"subject":         msg.Subject,
+		"stream_sequence": meta.Sequence.Stream,
+		"deliveries":      meta.NumDelivered,
+	}
+	if _, err := c.js.PublishMsg(deadLetterMsg(msg, c.deadLetterSubject, cause, meta.NumDelivered, meta.Sequence.Stream)); err != nil {
+		c.logger.WithError(err).WithFields(fields).Error("Failed to dead-letter message")
+		return false
+	}
+	if err := msg.Term(); err != nil {
+		c.logger.WithError(err).WithFields(fields).Warn("Failed to terminate dead-lettered message")
+	}
+	c.logger.WithError(cause).WithFields(fields).Warn("Message dead-lettered")
+	return true
+}
+
+func (c *NATSConsumer) processActivityLogEvent(ctx context.Context, header nats.Header, data []byte) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "processActivityLogEvent")
 	defer span.Finish()
 
 	ext.Component.Set(span, "nats-consumer")
 
-	var event event.ActivityLogCreated
-	if err := json.Unmarshal(data, &event); err != nil {
+	event, err := decodeEvent(header, data)
+	if err != nil {
 		ext.Error.Set(span, true)
 		span.SetTag("error.message", err.Error())
-		return fmt.Errorf("failed to unmarshal event: %w", err)
+		return err
 	}
 
 	span.SetTag("event_type", event.GetEventType())
 	span.SetTag("aggregate_id", event.GetAggregateID())
 
-	c.logger.WithFields(logrus.Fields{
-		"event_type":   event.GetEventType(),
-		"aggregate_id": event.GetAggregateID(),
-	}).Info("Processing activity log event")
-
-	if err := c.arangoRepo.Create(ctx, event.ActivityLog); err != nil {
+	if err := c.storeEvent(ctx, event); err != nil {
 		ext.Error.Set(span, true)
 		span.SetTag("error.message", err.Error())
-		return fmt.Errorf("failed to save to ArangoDB: %w", err)
+		return err
 	}
-
 	return nil
 }
 
@@ -158,12 +380,21 @@ func (c *NATSConsumer) Wait() {
 	c.wg.Wait()
 }
 
+// WorkerPool runs jobs on a fixed number of workers. The queue is bounded;
+// the consumer keeps it from filling by capping unacknowledged messages, so
+// Submit only blocks if the server sends more than it was asked to.
 type WorkerPool struct {
-	workers  int
-	jobQueue chan *Job
-	quit     chan struct{}
-	logger   *logrus.Logger
-	wg       sync.WaitGroup
+	workers      int
+	jobTimeout   time.Duration
+	drainTimeout time.Duration
+	jobQueue     chan *Job
+	quit         chan struct{}
+	// jobCtx is the parent of every job's context; it is cancelled when the
+	// drain on Stop runs out of time
+	jobCtx    context.Context
+	cancelJob context.CancelFunc
+	logger    *logrus.Logger
+	wg        sync.WaitGroup
 }
 
 type Job struct {
@@ -172,14 +403,22 @@ type Job struct {
 	Handler   func(ctx context.Context, data []byte) error
 	OnSuccess func()
 	OnError   func(error)
+	// OnReject is called instead of the handler for a job the pool did not
+	// run because it was stopping
+	OnReject func()
 }
 
-func NewWorkerPool(workers int, logger *logrus.Logger) *WorkerPool {
+func NewWorkerPool(workers, queueSize int, jobTimeout, drainTimeout time.Duration, logger *logrus.Logger) *WorkerPool {
+	jobCtx, cancelJob := context.WithCancel(context.Background())
 	return &WorkerPool{
-		workers:  workers,
-		jobQueue: make(chan *Job, 100),
-		quit:     make(chan struct{}),
-		logger:   logger,
+		workers:      workers,
+		jobTimeout:   jobTimeout,
+		drainTimeout: drainTimeout,
+		jobQueue:     make(chan *Job, queueSize),
+		quit:         make(chan struct{}),
+		jobCtx:       jobCtx,
+		cancelJob:    cancelJob,
+		logger:       logger,
 	}
 }
 
@@ -191,17 +430,65 @@ func (wp *WorkerPool) Start() {
 	wp.logger.WithField("workers", wp.workers).Info("Worker pool started")
 }
 
+// Stop stops taking jobs and drains the pool: the workers finish the jobs
+// running and queued. Jobs still running after drainTimeout have their
+// context cancelled; jobs still queued then are rejected.
 func (wp *WorkerPool) Stop() {
 	close(wp.quit)
-	wp.wg.Wait()
+
+	done := make(chan struct{})
+	go func() {
+		wp.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(wp.drainTimeout):
+		wp.logger.WithField("drain_timeout", wp.drainTimeout).Warn("Worker pool drain timed out, cancelling jobs")
+		wp.cancelJob()
+		<-done
+	}
+	wp.cancelJob()
+
+	// A Submit racing with the close may have queued a job after the
+	// workers left
+leftover:
+	for {
+		select {
+		case job := <-wp.jobQueue:
+			wp.reject(job)
+		default:
+			break leftover
+		}
+	}
+	metrics.SetWorkerPoolQueueDepth(0)
 	wp.logger.Info("Worker pool stopped")
 }
 
-func (wp *WorkerPool) Submit(job *Job) {
+// Submit queues job, blocking while the queue is full. It reports false, and
+// rejects the job, once the pool is stopping.
+func (wp *WorkerPool) Submit(job *Job) bool {
+	select {
+	case <-wp.quit:
+		wp.reject(job)
+		return false
+	default:
+	}
+
 	select {
 	case wp.jobQueue <- job:
+		metrics.SetWorkerPoolQueueDepth(len(wp.jobQueue))
+		return true
 	case <-wp.quit:
-		wp.logger.Warn("Worker pool is shutting down, job rejected")
+		wp.reject(job)
+		return false
+	}
+}
+
+func (wp *WorkerPool) reject(job *Job) {
+	wp.logger.WithField("job_id", job.ID).Warn("Worker pool is shutting down, job rejected")
+	if job.OnReject != nil {
+		job.OnReject()
 	}
 }
 
@@ -214,27 +501,55 @@ func (wp *WorkerPool) worker(id int) {
 	for {
 		select {
 		case job := <-wp.jobQueue:
-			logger.WithField("job_id", job.ID).Debug("Processing job")
-
-			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-			err := job.Handler(ctx, job.Data)
-			cancel()
+			wp.run(logger, job)
 
-			if err != nil {
-				logger.WithError(err).WithField("job_id", job.ID).Error("Job failed")
-				if job.OnError != nil {
-					job.OnError(err)
-				}
-			} else {
-				logger.WithField("job_id", job.ID).Debug("Job completed successfully")
-				if job.OnSuccess != nil {
-					job.OnSuccess()
+		case <-wp.quit:
+			// Drain what is queued before stopping
+			for {
+				select {
+				case job := <-wp.jobQueue:
+					wp.run(logger, job)
+				default:
+					logger.Info("Worker stopping")
+					return
 				}
 			}
+		}
+	}
+}
 
-		case <-wp.quit:
-			logger.Info("Worker stopping")
-			return
+func (wp *WorkerPool) run(logger *logrus.Entry, job *Job) {
+	metrics.SetWorkerPoolQueueDepth(len(wp.jobQueue))
+	if wp.jobCtx.Err() != nil {
+		wp.reject(job)
+		return
+	}
+	logger.WithField("job_id", job.ID).Debug("Processing job")
+
+	metrics.AddConsumerInFlight(1)
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(wp.jobCtx, wp.jobTimeout)
+	err := job.Handler(ctx, job.Data)
+	cancel()
+	metrics.RecordConsumerProcessing("message", processingStatus(err), time.Since(start))
+	metrics.AddConsumerInFlight(-1)
+
+	if err != nil {
+		logger.WithError(err).WithField("job_id", job.ID).Error("Job failed")
+		if job.OnError != nil {
+			job.OnError(err)
 		}
+		return
+	}
+	logger.WithField("job_id", job.ID).Debug("Job completed successfully")
+	if job.OnSuccess != nil {
+		job.OnSuccess()
+	}
+}
+
+func processingStatus(err error) string {
+	if err != nil {
+		return "error"
 	}
+	return "success"
 }
@@ -0,0 +1,65 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"activity-log-service/internal/infrastructure/metrics"
+)
+
+// monitorLag exports the durable's backlog every LagInterval until ctx is
+// done or the connection is closed. The durable is shared by every consumer
+// instance, so each of them reports the same numbers.
+func (c *NATSConsumer) monitorLag(ctx context.Context) {
+	ticker := time.NewTicker(c.opts.LagInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.reportLag(); err != nil && !c.conn.IsClosed() {
+			c.logger.WithError(err).Warn("Failed to report consumer lag")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if c.conn.IsClosed() {
+				return
+			}
+		}
+	}
+}
+
+// reportLag exports the durable's pending, ack pending and redelivered
+// counts, and how old the first message past its ack floor is. The stream
+// also holds other subjects, so that message may precede the oldest
+// unprocessed log: the age is an upper bound, which is what an alert on the
+// stream's max age needs.
+func (c *NATSConsumer) reportLag() error {
+	info, err := c.js.ConsumerInfo(c.stream, c.opts.Durable)
+	if err != nil {
+		return fmt.Errorf("failed to get consumer info: %w", err)
+	}
+	stream, err := c.js.StreamInfo(c.stream)
+	if err != nil {
+		return fmt.Errorf("failed to get stream info: %w", err)
+	}
+
+	var oldest time.Duration
+	if info.NumPending > 0 || info.NumAckPending > 0 {
+		msg, err := c.js.GetMsg(c.stream, max(info.AckFloor.Stream+1, stream.State.FirstSeq))
+		switch {
+		case err == nil:
+			oldest = time.Since(msg.Time)
+		case !errors.Is(err, nats.ErrMsgNotFound):
+			return fmt.Errorf("failed to get oldest unacked message: %w", err)
+		}
+	}
+
+	metrics.SetConsumerLag(c.stream, c.opts.Durable, info.NumPending, info.NumAckPending, info.NumRedelivered, oldest)
+	metrics.SetStreamMaxAge(c.stream, stream.Config.MaxAge)
+	return nil
+}
@@ -3,22 +3,50 @@ package messaging
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/nats-io/nats.go"
 	"github.com/sirupsen/logrus"
 
 	"activity-log-service/internal/domain/event"
+	"activity-log-service/internal/infrastructure/config"
 )
 
+var _ event.Publisher = (*NATSPublisher)(nil)
+
 type NATSPublisher struct {
-	conn   *nats.Conn
-	js     nats.JetStreamContext
-	logger *logrus.Logger
+	conn            *nats.Conn
+	js              nats.JetStreamContext
+	subjects        EventSubjects
+	logger          *logrus.Logger
+	payloadPolicy   event.PayloadPolicy
+	maxPayloadBytes int
+	encoding        EventEncoding
+	source          string
+}
+
+// EventSubjects are the subjects each kind of event is published to. Empty
+// Updated or Deleted subjects turn those events off.
+type EventSubjects struct {
+	Created string
+	Updated string
+	Deleted string
 }
 
-func NewNATSPublisher(url string, logger *logrus.Logger) (*NATSPublisher, error) {
-	conn, err := nats.Connect(url,
+// All returns the configured subjects, for the stream to cover
+func (s EventSubjects) All() []string {
+	var subjects []string
+	for _, subject := range []string{s.Created, s.Updated, s.Deleted} {
+		if subject != "" {
+			subjects = append(subjects, subject)
+		}
+	}
+	return subjects
+}
+
+func NewNATSPublisher(cfg config.NATSConfig, subjects EventSubjects, logger *logrus.Logger) (*NATSPublisher, error) {
+	conn, err := Connect(cfg,
 		nats.ReconnectWait(time.Second*2),
 		nats.MaxReconnects(10),
 		nats.DisconnectErrHandler(func(nc *nats.Conn, err error) {
@@ -29,7 +57,7 @@ func NewNATSPublisher(url string, logger *logrus.Logger) (*NATSPublisher, error)
 		}),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+		return nil, err
 	}
 
 	js, err := conn.JetStream()
@@ -38,39 +66,119 @@ func NewNATSPublisher(url string, logger *logrus.Logger) (*NATSPublisher, error)
 	}
 
 	return &NATSPublisher{
-		conn:   conn,
-		js:     js,
-		logger: logger,
+		conn:          conn,
+		js:            js,
+		subjects:      subjects,
+		logger:        logger,
+		payloadPolicy: event.PayloadFull,
+		encoding:      EncodingProtobuf,
 	}, nil
 }
 
-func (p *NATSPublisher) PublishActivityLogCreated(ctx context.Context, event *event.ActivityLogCreated) error {
-	data, err := event.ToJSON()
+// SetEventEncoding switches the publisher to encoding. Publish JSON until
+// every consumer decodes protobuf. source identifies this service in
+// CloudEvents.
+func (p *NATSPublisher) SetEventEncoding(encoding EventEncoding, source string) {
+	p.encoding = encoding
+	p.source = source
+}
+
+// SetPayloadPolicy controls how much of each activity log published events
+// embed. maxBytes is only used by event.PayloadCapped.
+func (p *NATSPublisher) SetPayloadPolicy(policy event.PayloadPolicy, maxBytes int) {
+	p.payloadPolicy = policy
+	p.maxPayloadBytes = maxBytes
+}
+
+// eventPayloadTrimmed is the payload header value of trimmed events, so
+// subscribers can tell without decoding the body
+const eventPayloadTrimmed = "trimmed"
+
+// PublishActivityLogCreated publishes the event and returns the stream
+// sequence it was stored at.
+func (p *NATSPublisher) PublishActivityLogCreated(ctx context.Context, event *event.ActivityLogCreated) (uint64, error) {
+	event = event.WithPayloadPolicy(p.payloadPolicy, p.maxPayloadBytes)
+	return p.publish(p.subjects.Created, event, event.Trimmed)
+}
+
+// PublishActivityLogUpdated publishes the event, unless updated events are
+// turned off, and returns the stream sequence it was stored at.
+func (p *NATSPublisher) PublishActivityLogUpdated(ctx context.Context, event *event.ActivityLogUpdated) (uint64, error) {
+	if p.subjects.Updated == "" {
+		return 0, nil
+	}
+	return p.publish(p.subjects.Updated, event, false)
+}
+
+// PublishActivityLogDeleted publishes the event, unless deleted events are
+// turned off, and returns the stream sequence it was stored at.
+func (p *NATSPublisher) PublishActivityLogDeleted(ctx context.Context, event *event.ActivityLogDeleted) (uint64, error) {
+	if p.subjects.Deleted == "" {
+		return 0, nil
+	}
+	return p.publish(p.subjects.Deleted, event, false)
+}
+
+func (p *NATSPublisher) publish(subject string, event event.Event, trimmed bool) (uint64, error) {
+	data, contentType, err := encodeEvent(event, p.encoding, p.source)
 	if err != nil {
-		return fmt.Errorf("failed to marshal event: %w", err)
+		return 0, fmt.Errorf("failed to marshal event: %w", err)
 	}
 
 	msg := &nats.Msg{
-		Subject: "activity.log.created",
+		Subject: subject,
 		Data:    data,
 		Header:  make(nats.Header),
 	}
 
+	msg.Header.Set(contentTypeHeader, contentType)
 	msg.Header.Set("event-type", event.GetEventType())
 	msg.Header.Set("aggregate-id", event.GetAggregateID())
 	msg.Header.Set("timestamp", event.GetTimestamp().Format(time.RFC3339))
+	if trimmed {
+		msg.Header.Set("payload", string(eventPayloadTrimmed))
+	}
 
-	_, err = p.js.PublishMsg(msg)
+	ack, err := p.js.PublishMsg(msg)
 	if err != nil {
-		return fmt.Errorf("failed to publish event: %w", err)
+		return 0, fmt.Errorf("failed to publish event: %w", err)
 	}
 
 	p.logger.WithFields(logrus.Fields{
 		"event_type":   event.GetEventType(),
 		"aggregate_id": event.GetAggregateID(),
 		"subject":      msg.Subject,
+		"sequence":     ack.Sequence,
+		"trimmed":      trimmed,
 	}).Info("Event published successfully")
 
+	return ack.Sequence, nil
+}
+
+// ConsumerAckFloor returns the stream sequence up to which the durable
+// consumer has acknowledged every message.
+func (p *NATSPublisher) ConsumerAckFloor(streamName, durable string) (uint64, error) {
+	info, err := p.js.ConsumerInfo(streamName, durable)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get consumer info: %w", err)
+	}
+	return info.AckFloor.Stream, nil
+}
+
+// StreamLastSequence returns the sequence of the newest message in the stream
+func (p *NATSPublisher) StreamLastSequence(streamName string) (uint64, error) {
+	info, err := p.js.StreamInfo(streamName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get stream info: %w", err)
+	}
+	return info.State.LastSeq, nil
+}
+
+// Ping reports an error unless the NATS connection is currently established
+func (p *NATSPublisher) Ping(ctx context.Context) error {
+	if !p.conn.IsConnected() {
+		return fmt.Errorf("nats connection is %s", p.conn.Status())
+	}
 	return nil
 }
 
@@ -79,28 +187,60 @@ func (p *NATSPublisher) Close() error {
 	return nil
 }
 
-func (p *NATSPublisher) EnsureStream(streamName, subject string) error {
-	stream, err := p.js.StreamInfo(streamName)
+// StreamConfig is the configuration EnsureStream creates the stream with
+func StreamConfig(streamName string, subjects ...string) *nats.StreamConfig {
+	return &nats.StreamConfig{
+		Name:      streamName,
+		Subjects:  subjects,
+		Retention: nats.LimitsPolicy,
+		MaxAge:    time.Hour * 24 * 30,
+		MaxMsgs:   1000000,
+		Storage:   nats.FileStorage,
+	}
+}
+
+// EnsureStream creates the stream for every subject the publisher publishes
+// to
+func (p *NATSPublisher) EnsureStream(streamName string) error {
+	return ensureStream(p.js, streamName, p.subjects.All(), p.logger)
+}
+
+// ensureStream creates the stream, or adds the subjects an existing stream
+// does not cover yet. Its other settings are left alone.
+func ensureStream(js nats.JetStreamContext, streamName string, subjects []string, logger *logrus.Logger) error {
+	stream, err := js.StreamInfo(streamName)
 	if err != nil {
 		if err == nats.ErrStreamNotFound {
-			_, err = p.js.AddStream(&nats.StreamConfig{
-				Name:      streamName,
-				Subjects:  []string{subject},
-				Retention: nats.LimitsPolicy,
-				MaxAge:    time.Hour * 24 * 30,
-				MaxMsgs:   1000000,
-				Storage:   nats.FileStorage,
-			})
+			_, err = js.AddStream(StreamConfig(streamName, subjects...))
 			if err != nil {
 				return fmt.Errorf("failed to create stream: %w", err)
 			}
-			p.logger.WithField("stream", streamName).Info("Stream created")
+			logger.WithField("stream", streamName).Info("Stream created")
 		} else {
 			return fmt.Errorf("failed to get stream info: %w", err)
 		}
-	} else {
-		p.logger.WithField("stream", stream.Config.Name).Info("Stream already exists")
+		return nil
 	}
 
+	var missing []string
+	for _, subject := range subjects {
+		if !slices.Contains(stream.Config.Subjects, subject) {
+			missing = append(missing, subject)
+		}
+	}
+	if len(missing) == 0 {
+		logger.WithField("stream", stream.Config.Name).Info("Stream already exists")
+		return nil
+	}
+
+	updated := stream.Config
+	updated.Subjects = append(slices.Clone(updated.Subjects), missing...)
+	if _, err := js.UpdateStream(&updated); err != nil {
+		return fmt.Errorf("failed to add subjects %v to stream: %w", missing, err)
+	}
+	logger.WithFields(logrus.Fields{
+		"stream":   stream.Config.Name,
+		"subjects": missing,
+	}).Info("Stream subjects added")
 	return nil
 }
@@ -0,0 +1,150 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/event"
+	"activity-log-service/internal/domain/repository"
+	"activity-log-service/internal/infrastructure/metrics"
+)
+
+// startPull subscribes as a pull consumer and runs one fetch loop per worker.
+// Each loop fetches up to BatchSize messages and stores them with a single
+// CreateMany, instead of one insert per message.
+func (c *NATSConsumer) startPull(ctx context.Context) error {
+	sub, err := c.js.PullSubscribe(c.opts.Subject, c.opts.Durable, nats.MaxAckPending(c.opts.maxAckPending()))
+	if err != nil {
+		return fmt.Errorf("failed to pull subscribe: %w", err)
+	}
+	c.subscription = sub
+
+	for i := 0; i < c.opts.Workers; i++ {
+		c.fetchers.Add(1)
+		go func() {
+			defer c.fetchers.Done()
+			c.fetchLoop(ctx, sub)
+		}()
+	}
+	return nil
+}
+
+// drainFetchers waits for the pull loops to finish their batches, cancelling
+// the batches once DrainTimeout has passed
+func (c *NATSConsumer) drainFetchers() {
+	done := make(chan struct{})
+	go func() {
+		c.fetchers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(c.opts.DrainTimeout):
+		c.logger.WithField("drain_timeout", c.opts.DrainTimeout).Warn("Consumer drain timed out, cancelling batches")
+		c.cancelBatches()
+		<-done
+	}
+	c.cancelBatches()
+}
+
+func (c *NATSConsumer) fetchLoop(ctx context.Context, sub *nats.Subscription) {
+	for ctx.Err() == nil && sub.IsValid() {
+		msgs, err := sub.Fetch(c.opts.BatchSize, nats.MaxWait(c.opts.FetchWait))
+		if err != nil {
+			if !errors.Is(err, nats.ErrTimeout) && ctx.Err() == nil && sub.IsValid() {
+				c.logger.WithError(err).Warn("Failed to fetch messages")
+				time.Sleep(c.opts.FetchWait)
+			}
+			continue
+		}
+
+		metrics.AddConsumerInFlight(len(msgs))
+		start := time.Now()
+		batchCtx, cancel := context.WithTimeout(c.batchCtx, c.opts.JobTimeout)
+		err = c.processBatch(batchCtx, msgs)
+		cancel()
+		metrics.RecordConsumerProcessing("batch", processingStatus(err), time.Since(start))
+		metrics.AddConsumerInFlight(-len(msgs))
+	}
+}
+
+// processBatch stores a fetched batch and acknowledges each message once its
+// log is stored and indexed. Logs rejected by CreateMany fail on their own;
+// an error for the whole insert fails every message of it and is returned.
+func (c *NATSConsumer) processBatch(ctx context.Context, msgs []*nats.Msg) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "processActivityLogBatch")
+	defer span.Finish()
+
+	ext.Component.Set(span, "nats-consumer")
+	span.SetTag("batch_size", len(msgs))
+
+	var (
+		pending []*nats.Msg
+		logs    []*entity.ActivityLog
+	)
+	for _, msg := range msgs {
+		event, err := decodeEvent(msg.Header, msg.Data)
+		if err != nil {
+			c.fail(msg, err)
+			continue
+		}
+		if !bulkInsertable(event) {
+			c.settle(msg, c.storeEvent(ctx, event))
+			continue
+		}
+		pending = append(pending, msg)
+		logs = append(logs, event.ActivityLog)
+	}
+	if len(logs) == 0 {
+		return nil
+	}
+
+	var batchErr error
+	errs := make([]error, len(logs))
+	if err := c.arangoRepo.CreateMany(ctx, logs); err != nil {
+		var createErr *repository.CreateManyError
+		if !errors.As(err, &createErr) {
+			ext.Error.Set(span, true)
+			span.SetTag("error.message", err.Error())
+			batchErr = fmt.Errorf("failed to save to ArangoDB: %w", err)
+			for i := range errs {
+				errs[i] = batchErr
+			}
+		} else {
+			for i, itemErr := range createErr.Errs {
+				errs[i] = storedOnce(itemErr)
+			}
+		}
+	}
+
+	for i, msg := range pending {
+		err := errs[i]
+		if err == nil {
+			err = c.index(ctx, logs[i])
+		}
+		c.settle(msg, err)
+	}
+	return batchErr
+}
+
+// bulkInsertable reports whether event's log can go through CreateMany. A
+// trimmed event has nothing to write, and a log with an external ID must be
+// written by it so a redelivery does not violate the unique index.
+func bulkInsertable(event *event.ActivityLogCreated) bool {
+	return !event.Trimmed && event.ActivityLog != nil && event.ActivityLog.ExternalID == ""
+}
+
+func (c *NATSConsumer) settle(msg *nats.Msg, err error) {
+	if err != nil {
+		c.fail(msg, err)
+		return
+	}
+	c.ack(msg)
+}
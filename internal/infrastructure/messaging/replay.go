@@ -0,0 +1,144 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/sirupsen/logrus"
+
+	"activity-log-service/internal/infrastructure/config"
+)
+
+// HeaderReplayedSequence marks a replayed message with the stream sequence it
+// was copied from
+const HeaderReplayedSequence = "replay-original-sequence"
+
+// ReplayRange selects the messages to replay by stream sequence and by the
+// time they were stored. Zero values leave that side open; both bounds are
+// inclusive, except Until.
+type ReplayRange struct {
+	FromSequence uint64
+	ToSequence   uint64
+	Since        time.Time
+	Until        time.Time
+}
+
+func (r ReplayRange) includes(meta *nats.MsgMetadata) bool {
+	return (r.FromSequence == 0 || meta.Sequence.Stream >= r.FromSequence) &&
+		(r.Since.IsZero() || !meta.Timestamp.Before(r.Since)) &&
+		(r.Until.IsZero() || meta.Timestamp.Before(r.Until))
+}
+
+// StreamReplayer publishes created events already in the stream again, so
+// the consumer processes them a second time, e.g. to backfill logs a
+// consumer bug acknowledged without storing
+type StreamReplayer struct {
+	conn    *nats.Conn
+	js      nats.JetStreamContext
+	stream  string
+	subject string
+	logger  *logrus.Logger
+}
+
+func NewStreamReplayer(cfg config.NATSConfig, logger *logrus.Logger) (*StreamReplayer, error) {
+	conn, err := Connect(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	return &StreamReplayer{conn: conn, js: js, stream: cfg.Stream, subject: cfg.Subject, logger: logger}, nil
+}
+
+// replayIdleTimeout ends a replay when no further message arrives, in case
+// the range ends past the last created event
+const replayIdleTimeout = 5 * time.Second
+
+// Replay publishes every created event in rng to the created subject again,
+// with its original headers, and returns how many it published; with dryRun
+// it only counts them. It reads the stream with an ordered consumer of its
+// own and stops at the last message stored when it began, so the copies it
+// publishes are not replayed again.
+func (r *StreamReplayer) Replay(ctx context.Context, rng ReplayRange, dryRun bool) (int, error) {
+	info, err := r.js.StreamInfo(r.stream)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get stream info: %w", err)
+	}
+	last := info.State.LastSeq
+	if rng.ToSequence != 0 && rng.ToSequence < last {
+		last = rng.ToSequence
+	}
+
+	start := nats.DeliverAll()
+	switch {
+	case rng.FromSequence != 0:
+		start = nats.StartSequence(rng.FromSequence)
+	case !rng.Since.IsZero():
+		start = nats.StartTime(rng.Since)
+	}
+	sub, err := r.js.SubscribeSync(r.subject, nats.OrderedConsumer(), start, nats.BindStream(r.stream))
+	if err != nil {
+		return 0, fmt.Errorf("failed to subscribe to %s: %w", r.subject, err)
+	}
+	defer sub.Unsubscribe()
+
+	replayed := 0
+	for {
+		msgCtx, cancel := context.WithTimeout(ctx, replayIdleTimeout)
+		msg, err := sub.NextMsgWithContext(msgCtx)
+		cancel()
+		switch {
+		case ctx.Err() != nil:
+			return replayed, ctx.Err()
+		case errors.Is(err, context.DeadlineExceeded):
+			return replayed, nil
+		case err != nil:
+			return replayed, fmt.Errorf("failed to read stream: %w", err)
+		}
+
+		meta, err := msg.Metadata()
+		if err != nil {
+			return replayed, fmt.Errorf("failed to read message metadata: %w", err)
+		}
+		if meta.Sequence.Stream > last || (!rng.Until.IsZero() && !meta.Timestamp.Before(rng.Until)) {
+			return replayed, nil
+		}
+		if rng.includes(meta) {
+			if !dryRun {
+				if err := r.republish(msg, meta.Sequence.Stream); err != nil {
+					return replayed, err
+				}
+			}
+			replayed++
+		}
+
+		if meta.NumPending == 0 {
+			return replayed, nil
+		}
+	}
+}
+
+func (r *StreamReplayer) republish(msg *nats.Msg, seq uint64) error {
+	header := make(nats.Header, len(msg.Header)+1)
+	for key, values := range msg.Header {
+		header[key] = values
+	}
+	header.Set(HeaderReplayedSequence, strconv.FormatUint(seq, 10))
+	if _, err := r.js.PublishMsg(&nats.Msg{Subject: r.subject, Header: header, Data: msg.Data}); err != nil {
+		return fmt.Errorf("failed to replay message %d: %w", seq, err)
+	}
+	return nil
+}
+
+func (r *StreamReplayer) Close() {
+	r.conn.Close()
+}
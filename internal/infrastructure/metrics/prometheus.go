@@ -32,11 +32,74 @@ var (
 	NATSMessageProcessedTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "nats_message_processed_total",
-			Help: "Total number of NATS messages processed",
+			Help: "Total number of NATS messages processed, per subject and status (acked, nacked, dead_lettered)",
 		},
 		[]string{"subject", "status"},
 	)
 
+	ConsumerProcessingDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "consumer_processing_duration_seconds",
+			Help:    "Time the consumer spent storing a pushed message or a pulled batch",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"unit", "status"},
+	)
+
+	WorkerPoolQueueDepth = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "consumer_worker_pool_queue_depth",
+			Help: "Number of received messages waiting for a free worker",
+		},
+	)
+
+	ConsumerInFlight = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "consumer_in_flight_messages",
+			Help: "Number of messages the consumer is currently storing",
+		},
+	)
+
+	ConsumerPending = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "consumer_pending_messages",
+			Help: "Number of messages in the stream not yet delivered to the durable",
+		},
+		[]string{"stream", "durable"},
+	)
+
+	ConsumerAckPending = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "consumer_ack_pending_messages",
+			Help: "Number of messages delivered to the durable but not yet acknowledged",
+		},
+		[]string{"stream", "durable"},
+	)
+
+	ConsumerRedelivered = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "consumer_redelivered_messages",
+			Help: "Number of unacknowledged messages that were delivered more than once",
+		},
+		[]string{"stream", "durable"},
+	)
+
+	ConsumerOldestUnackedAge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "consumer_oldest_unacked_age_seconds",
+			Help: "Age of the first stream message past the durable's ack floor, 0 when it has caught up",
+		},
+		[]string{"stream", "durable"},
+	)
+
+	StreamMaxAge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "stream_max_age_seconds",
+			Help: "Age at which the stream discards messages, 0 when unlimited",
+		},
+		[]string{"stream"},
+	)
+
 	ArangoDBOperationDuration = promauto.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "arango_db_operation_duration_seconds",
@@ -71,6 +134,197 @@ var (
 		},
 		[]string{"method", "status"},
 	)
+
+	CanaryStageDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "canary_stage_duration_seconds",
+			Help:    "Time from a canary write until each stage observed it",
+			Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		},
+		[]string{"stage", "status"},
+	)
+
+	CanaryLastSuccess = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "canary_last_success_timestamp_seconds",
+			Help: "Unix time of the last canary probe that passed each stage",
+		},
+		[]string{"stage"},
+	)
+
+	LoadShedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "load_shed_total",
+			Help: "Total number of requests rejected by overload protection",
+		},
+		[]string{"transport", "class"},
+	)
+
+	InFlightRequests = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "in_flight_requests",
+			Help: "Number of requests currently being served",
+		},
+	)
+
+	NotificationDeliveryDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "notification_delivery_duration_seconds",
+			Help:    "Time spent delivering a notification, per channel, provider, kind and outcome",
+			Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"channel", "provider", "kind", "outcome"},
+	)
+
+	NotificationDeliveryAttempts = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "notification_delivery_attempts_total",
+			Help: "Total number of notification delivery attempts",
+		},
+		[]string{"channel", "provider", "kind", "outcome"},
+	)
+
+	NotificationLastSuccess = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "notification_last_success_timestamp_seconds",
+			Help: "Unix time of the last notification delivered per channel and company",
+		},
+		[]string{"channel", "company_id"},
+	)
+
+	CacheHitsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_hits_total",
+			Help: "Total number of cache reads answered from Redis, per key class",
+		},
+		[]string{"class"},
+	)
+
+	CacheMissesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_misses_total",
+			Help: "Total number of cache reads that fell through to the source, failed reads included, per key class",
+		},
+		[]string{"class"},
+	)
+
+	CacheSetsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_sets_total",
+			Help: "Total number of values written to the cache, per key class",
+		},
+		[]string{"class"},
+	)
+
+	CacheInvalidationsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_invalidations_total",
+			Help: "Total number of cache deletes by key, pattern or flush, per key class",
+		},
+		[]string{"class"},
+	)
+
+	RetentionDeletedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "retention_deleted_logs_total",
+			Help: "Total number of activity logs deleted by log rotation for being past their retention",
+		},
+	)
+
+	RetentionDroppedPartitionsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "retention_dropped_partitions_total",
+			Help: "Total number of monthly partitions dropped by log rotation",
+		},
+	)
+
+	CronJobLockTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cron_job_lock_total",
+			Help: "Cron job lock attempts per job and outcome (acquired, held, error, lost)",
+		},
+		[]string{"job", "outcome"},
+	)
+
+	CronJobFailuresTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cron_job_failures_total",
+			Help: "Failed cron job runs per job and reason (error, timeout, panic)",
+		},
+		[]string{"job", "reason"},
+	)
+
+	CronJobRunsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cron_job_runs_total",
+			Help: "Cron job runs per job and status (succeeded, failed)",
+		},
+		[]string{"job", "status"},
+	)
+
+	CronJobDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "cron_job_duration_seconds",
+			Help:    "Cron job run duration per job and status",
+			Buckets: []float64{1, 5, 15, 60, 300, 900, 1800, 3600, 10800, 21600},
+		},
+		[]string{"job", "status"},
+	)
+
+	CronJobLastRun = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cron_job_last_run_timestamp_seconds",
+			Help: "When each cron job's last run finished",
+		},
+		[]string{"job"},
+	)
+
+	CronJobLastSuccess = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cron_job_last_success_timestamp_seconds",
+			Help: "When each cron job's last successful run finished",
+		},
+		[]string{"job"},
+	)
+
+	CronJobItems = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cron_job_items_processed",
+			Help: "Items each cron job's last run processed",
+		},
+		[]string{"job"},
+	)
+
+	ArangoCollectionDocuments = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "arango_db_collection_documents",
+			Help: "Documents per collection, as of the last database maintenance",
+		},
+		[]string{"collection"},
+	)
+
+	ArangoCollectionSizeBytes = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "arango_db_collection_size_bytes",
+			Help: "Estimated size per collection and part (documents, indexes), as of the last database maintenance",
+		},
+		[]string{"collection", "part"},
+	)
+
+	ArangoIndexMemoryBytes = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "arango_db_index_memory_bytes",
+			Help: "Memory per index, as of the last database maintenance",
+		},
+		[]string{"collection", "index"},
+	)
+
+	Overloaded = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "overloaded",
+			Help: "1 while low priority reads are being shed because of high latency",
+		},
+	)
 )
 
 func StartMetricsServer(port int, logger *logrus.Logger) {
@@ -103,6 +357,32 @@ func RecordNATSMessageProcessed(subject, status string) {
 	NATSMessageProcessedTotal.WithLabelValues(subject, status).Inc()
 }
 
+// RecordConsumerProcessing records how long the consumer took for unit, a
+// message or a batch; status is success or error
+func RecordConsumerProcessing(unit, status string, duration time.Duration) {
+	ConsumerProcessingDuration.WithLabelValues(unit, status).Observe(duration.Seconds())
+}
+
+func SetWorkerPoolQueueDepth(n int) {
+	WorkerPoolQueueDepth.Set(float64(n))
+}
+
+func AddConsumerInFlight(n int) {
+	ConsumerInFlight.Add(float64(n))
+}
+
+// SetConsumerLag records the durable's backlog as JetStream reports it
+func SetConsumerLag(stream, durable string, pending uint64, ackPending, redelivered int, oldestUnacked time.Duration) {
+	ConsumerPending.WithLabelValues(stream, durable).Set(float64(pending))
+	ConsumerAckPending.WithLabelValues(stream, durable).Set(float64(ackPending))
+	ConsumerRedelivered.WithLabelValues(stream, durable).Set(float64(redelivered))
+	ConsumerOldestUnackedAge.WithLabelValues(stream, durable).Set(oldestUnacked.Seconds())
+}
+
+func SetStreamMaxAge(stream string, maxAge time.Duration) {
+	StreamMaxAge.WithLabelValues(stream).Set(maxAge.Seconds())
+}
+
 func RecordArangoDBOperationDuration(operation, status string, duration time.Duration) {
 	ArangoDBOperationDuration.WithLabelValues(operation, status).Observe(duration.Seconds())
 }
@@ -115,3 +395,107 @@ func RecordGRPCRequest(method, status string, duration time.Duration) {
 	GRPCRequestsTotal.WithLabelValues(method, status).Inc()
 	GRPCRequestDuration.WithLabelValues(method, status).Observe(duration.Seconds())
 }
+
+func RecordCanaryStage(stage, status string, duration time.Duration) {
+	CanaryStageDuration.WithLabelValues(stage, status).Observe(duration.Seconds())
+	if status == "success" {
+		CanaryLastSuccess.WithLabelValues(stage).SetToCurrentTime()
+	}
+}
+
+// RecordNotificationDelivery records one delivery attempt. outcome is
+// success or failure.
+func RecordNotificationDelivery(channel, provider, kind, outcome string, duration time.Duration) {
+	NotificationDeliveryAttempts.WithLabelValues(channel, provider, kind, outcome).Inc()
+	NotificationDeliveryDuration.WithLabelValues(channel, provider, kind, outcome).Observe(duration.Seconds())
+}
+
+// RecordNotificationSuccess marks the last successful delivery for a company.
+// It adds a series per company, so callers only use it when enabled.
+func RecordNotificationSuccess(channel, companyID string) {
+	NotificationLastSuccess.WithLabelValues(channel, companyID).SetToCurrentTime()
+}
+
+func RecordCacheHit(class string) {
+	CacheHitsTotal.WithLabelValues(class).Inc()
+}
+
+func RecordCacheMiss(class string) {
+	CacheMissesTotal.WithLabelValues(class).Inc()
+}
+
+func RecordCacheSet(class string) {
+	CacheSetsTotal.WithLabelValues(class).Inc()
+}
+
+func RecordCacheInvalidation(class string) {
+	CacheInvalidationsTotal.WithLabelValues(class).Inc()
+}
+
+func RecordLoadShed(transport, class string) {
+	LoadShedTotal.WithLabelValues(transport, class).Inc()
+}
+
+func SetInFlightRequests(n int64) {
+	InFlightRequests.Set(float64(n))
+}
+
+func SetOverloaded(overloaded bool) {
+	if overloaded {
+		Overloaded.Set(1)
+		return
+	}
+	Overloaded.Set(0)
+}
+
+func RecordRetentionDeleted(n int) {
+	RetentionDeletedTotal.Add(float64(n))
+}
+
+func RecordRetentionDroppedPartitions(n int) {
+	RetentionDroppedPartitionsTotal.Add(float64(n))
+}
+
+func RecordCronJobLock(job, outcome string) {
+	CronJobLockTotal.WithLabelValues(job, outcome).Inc()
+}
+
+func RecordCronJobFailure(job, reason string) {
+	CronJobFailuresTotal.WithLabelValues(job, reason).Inc()
+}
+
+// InitCronJob exports zero runs for a scheduled job, so that alerts on its
+// run counters work before it first runs
+func InitCronJob(job string) {
+	CronJobRunsTotal.WithLabelValues(job, "succeeded")
+	CronJobRunsTotal.WithLabelValues(job, "failed")
+}
+
+// RecordCronJobRun records a finished run. status is succeeded or failed.
+func RecordCronJobRun(job, status string, duration time.Duration, items int) {
+	CronJobRunsTotal.WithLabelValues(job, status).Inc()
+	CronJobDuration.WithLabelValues(job, status).Observe(duration.Seconds())
+	CronJobLastRun.WithLabelValues(job).SetToCurrentTime()
+	CronJobItems.WithLabelValues(job).Set(float64(items))
+	if status == "succeeded" {
+		CronJobLastSuccess.WithLabelValues(job).SetToCurrentTime()
+	}
+}
+
+// ResetCollectionStats drops the collection and index series, so that
+// collections dropped since the last report disappear
+func ResetCollectionStats() {
+	ArangoCollectionDocuments.Reset()
+	ArangoCollectionSizeBytes.Reset()
+	ArangoIndexMemoryBytes.Reset()
+}
+
+func SetCollectionStats(collection string, documents, documentBytes, indexBytes int64) {
+	ArangoCollectionDocuments.WithLabelValues(collection).Set(float64(documents))
+	ArangoCollectionSizeBytes.WithLabelValues(collection, "documents").Set(float64(documentBytes))
+	ArangoCollectionSizeBytes.WithLabelValues(collection, "indexes").Set(float64(indexBytes))
+}
+
+func SetIndexMemory(collection, index string, bytes int64) {
+	ArangoIndexMemoryBytes.WithLabelValues(collection, index).Set(float64(bytes))
+}
@@ -0,0 +1,51 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// DirStore keeps objects as files below a directory. It is meant for local
+// development and tests of the archive flow.
+type DirStore struct {
+	root string
+}
+
+func NewDirStore(root string) *DirStore {
+	return &DirStore{root: root}
+}
+
+func (s *DirStore) path(key string) string {
+	return filepath.Join(s.root, filepath.FromSlash(key))
+}
+
+// Put writes to a temporary file first so a crash never leaves half an
+// object behind
+func (s *DirStore) Put(ctx context.Context, key string, body []byte) error {
+	path := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to store object %s: %w", key, err)
+	}
+	if err := os.WriteFile(path+".tmp", body, 0o644); err != nil {
+		return fmt.Errorf("failed to store object %s: %w", key, err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to store object %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *DirStore) Get(ctx context.Context, key string) ([]byte, error) {
+	body, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %s: %w", key, err)
+	}
+	return body, nil
+}
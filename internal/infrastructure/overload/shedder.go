@@ -0,0 +1,153 @@
+package overload
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"activity-log-service/internal/infrastructure/metrics"
+)
+
+// Class is the priority of a request. Only ClassRead is ever shed.
+type Class string
+
+const (
+	ClassCritical Class = "critical"
+	ClassWrite    Class = "write"
+	ClassRead     Class = "read"
+)
+
+// PriorityHeader lets callers mark a read as critical so it is never shed
+const PriorityHeader = "X-Priority"
+
+// sampleSize bounds the latency window; evalInterval bounds how often the
+// p99 is recomputed from it
+const (
+	sampleSize   = 1024
+	evalInterval = 250 * time.Millisecond
+)
+
+type Config struct {
+	// P99Threshold sheds reads once the p99 latency of recent requests
+	// exceeds it; zero disables the latency signal
+	P99Threshold time.Duration
+	// MaxInFlight sheds reads once this many requests are being served;
+	// zero disables the queue depth signal
+	MaxInFlight int
+	// Window is how far back latency samples count towards the p99
+	Window     time.Duration
+	RetryAfter time.Duration
+}
+
+type sample struct {
+	at       time.Time
+	duration time.Duration
+}
+
+// Shedder decides whether to admit a request based on recent latency and
+// the number of requests in flight. Writes and critical requests are always
+// admitted; reads are rejected while either signal is over its threshold.
+type Shedder struct {
+	config   Config
+	inFlight int64
+
+	mu         sync.Mutex
+	samples    []sample
+	next       int
+	p99        time.Duration
+	evaluated  time.Time
+	overloaded bool
+}
+
+func NewShedder(config Config) *Shedder {
+	return &Shedder{
+		config:  config,
+		samples: make([]sample, 0, sampleSize),
+	}
+}
+
+// ParseClass maps a priority header value onto a class, falling back to def
+func ParseClass(value string, def Class) Class {
+	if Class(value) == ClassCritical {
+		return ClassCritical
+	}
+	return def
+}
+
+// Admit reports whether a request of the given class may proceed. When it
+// may, the returned func must be called once the request has finished.
+func (s *Shedder) Admit(class Class, transport string) (func(), bool) {
+	if class == ClassRead && s.Overloaded() {
+		metrics.RecordLoadShed(transport, string(class))
+		return nil, false
+	}
+
+	start := time.Now()
+	metrics.SetInFlightRequests(atomic.AddInt64(&s.inFlight, 1))
+	return func() {
+		metrics.SetInFlightRequests(atomic.AddInt64(&s.inFlight, -1))
+		s.observe(start, time.Since(start))
+	}, true
+}
+
+// RetryAfter is the back-off suggested to shed clients
+func (s *Shedder) RetryAfter() time.Duration {
+	return s.config.RetryAfter
+}
+
+// Overloaded reports whether reads are currently being shed
+func (s *Shedder) Overloaded() bool {
+	if s.config.MaxInFlight > 0 && atomic.LoadInt64(&s.inFlight) >= int64(s.config.MaxInFlight) {
+		return true
+	}
+	if s.config.P99Threshold <= 0 {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.evaluated) >= evalInterval {
+		s.p99 = s.percentile(now, 0.99)
+		s.evaluated = now
+
+		overloaded := s.p99 > s.config.P99Threshold
+		if overloaded != s.overloaded {
+			s.overloaded = overloaded
+			metrics.SetOverloaded(overloaded)
+		}
+	}
+	return s.overloaded
+}
+
+func (s *Shedder) observe(at time.Time, duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.samples) < sampleSize {
+		s.samples = append(s.samples, sample{at: at, duration: duration})
+		return
+	}
+	s.samples[s.next] = sample{at: at, duration: duration}
+	s.next = (s.next + 1) % sampleSize
+}
+
+// percentile computes the q-th latency percentile over samples inside the
+// window; callers must hold mu
+func (s *Shedder) percentile(now time.Time, q float64) time.Duration {
+	cutoff := now.Add(-s.config.Window)
+	durations := make([]time.Duration, 0, len(s.samples))
+	for _, sm := range s.samples {
+		if sm.at.After(cutoff) {
+			durations = append(durations, sm.duration)
+		}
+	}
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	return durations[int(float64(len(durations)-1)*q)]
+}
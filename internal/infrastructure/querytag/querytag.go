@@ -0,0 +1,25 @@
+// Package querytag carries the API call a request came in on down to the
+// database layer, so the queries it runs can be traced back to it.
+package querytag
+
+import "context"
+
+// Tag identifies the originating call
+type Tag struct {
+	// Route is the HTTP route pattern or the full gRPC method name
+	Route     string
+	RequestID string
+}
+
+type tagKey struct{}
+
+// WithTag attaches tag to ctx
+func WithTag(ctx context.Context, tag Tag) context.Context {
+	return context.WithValue(ctx, tagKey{}, tag)
+}
+
+// FromContext returns the tag attached to ctx, if any
+func FromContext(ctx context.Context) (Tag, bool) {
+	tag, ok := ctx.Value(tagKey{}).(Tag)
+	return tag, ok
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+	"activity-log-service/internal/domain/valueobject"
+)
+
+// AppendOnlyActivityLogRepository rejects changes to stored logs with
+// entity.ErrAppendOnly. Deletes marked with repository.WithLegalErasure go
+// through, as do the age-based deletes of retention and archiving, which
+// follow configured policy rather than a caller's request.
+type AppendOnlyActivityLogRepository struct {
+	repository.ActivityLogRepository
+}
+
+func NewAppendOnlyActivityLogRepository(repo repository.ActivityLogRepository) *AppendOnlyActivityLogRepository {
+	return &AppendOnlyActivityLogRepository{ActivityLogRepository: repo}
+}
+
+// CreateByExternalID only rejects updates; creating or skipping leaves stored
+// logs untouched
+func (r *AppendOnlyActivityLogRepository) CreateByExternalID(ctx context.Context, activityLog *entity.ActivityLog, update bool) (*entity.ActivityLog, repository.WriteOutcome, error) {
+	if update {
+		return nil, "", entity.ErrAppendOnly
+	}
+	return r.ActivityLogRepository.CreateByExternalID(ctx, activityLog, false)
+}
+
+func (r *AppendOnlyActivityLogRepository) Update(ctx context.Context, activityLog *entity.ActivityLog) error {
+	return entity.ErrAppendOnly
+}
+
+func (r *AppendOnlyActivityLogRepository) Delete(ctx context.Context, id valueobject.ActivityLogID) error {
+	if !repository.IsLegalErasure(ctx) {
+		return entity.ErrAppendOnly
+	}
+	return r.ActivityLogRepository.Delete(ctx, id)
+}
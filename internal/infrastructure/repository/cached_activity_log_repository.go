@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,24 +12,79 @@ import (
 	"activity-log-service/internal/domain/repository"
 	"activity-log-service/internal/domain/valueobject"
 	"activity-log-service/internal/infrastructure/cache"
+	"activity-log-service/internal/infrastructure/metrics"
+)
+
+// CachePolicy says whether one kind of read is cached and for how long. A
+// policy without a positive TTL caches nothing.
+type CachePolicy struct {
+	Enabled bool
+	TTL     time.Duration
+}
+
+func (p CachePolicy) caches() bool {
+	return p.Enabled && p.TTL > 0
+}
+
+// CacheOptions holds the policy of each kind of cached read
+type CacheOptions struct {
+	// ActivityLog covers single logs, read by ID or listed on a page
+	ActivityLog CachePolicy
+	// CompanyPage covers a company's listing pages
+	CompanyPage CachePolicy
+	// CompanyCount covers a company's log count
+	CompanyCount CachePolicy
+	// Query covers a company's searches, counts and facets
+	Query CachePolicy
+	// Local keeps up to LocalMaxEntries of the most read single logs in
+	// process, in front of Redis
+	Local           CachePolicy
+	LocalMaxEntries int
+	// ActiveCompaniesWindow is how far back the active companies index
+	// reaches once trimmed; zero when it is never trimmed
+	ActiveCompaniesWindow time.Duration
+}
+
+// localInvalidationChannel carries the IDs of changed logs to every
+// instance's in-process cache; localPurgeAll empties them instead
+const (
+	localInvalidationChannel = "activity_log_cache_invalidation"
+	localPurgeAll            = "*"
 )
 
 type CachedActivityLogRepository struct {
 	repo   repository.ActivityLogRepository
-	cache  *cache.RedisCache
+	cache  cache.Store
+	opts   CacheOptions
 	logger *logrus.Logger
+	// local is nil unless the in-process tier is enabled
+	local *cache.LRU[entity.ActivityLog]
 }
 
+// NewCachedActivityLogRepository subscribes the in-process tier, when
+// enabled, to the changes other instances make. Without that subscription it
+// could serve stale logs, so it stays off if subscribing fails.
 func NewCachedActivityLogRepository(
 	repo repository.ActivityLogRepository,
-	cache *cache.RedisCache,
+	redisCache cache.Store,
+	opts CacheOptions,
 	logger *logrus.Logger,
 ) *CachedActivityLogRepository {
-	return &CachedActivityLogRepository{
+	r := &CachedActivityLogRepository{
 		repo:   repo,
-		cache:  cache,
+		cache:  redisCache,
+		opts:   opts,
 		logger: logger,
 	}
+
+	if opts.Local.caches() && opts.LocalMaxEntries > 0 {
+		r.local = cache.NewLRU[entity.ActivityLog](opts.LocalMaxEntries, opts.Local.TTL)
+		if err := redisCache.Subscribe(context.Background(), localInvalidationChannel, r.evictLocal); err != nil {
+			logger.WithError(err).Warn("Failed to subscribe to cache invalidations, in-process cache disabled")
+			r.local = nil
+		}
+	}
+	return r
 }
 
 func (r *CachedActivityLogRepository) Create(ctx context.Context, activityLog *entity.ActivityLog) error {
@@ -38,8 +94,7 @@ func (r *CachedActivityLogRepository) Create(ctx context.Context, activityLog *e
 	}
 
 	// Cache the created activity log
-	cacheKey := cache.BuildActivityLogCacheKey(string(activityLog.ID))
-	if err := r.cache.Set(ctx, cacheKey, activityLog, 1*time.Hour); err != nil {
+	if err := r.cacheLog(ctx, activityLog); err != nil {
 		r.logger.WithError(err).WithField("activity_log_id", activityLog.ID).
 			Warn("Failed to cache activity log after creation")
 	}
@@ -50,10 +105,141 @@ func (r *CachedActivityLogRepository) Create(ctx context.Context, activityLog *e
 			Warn("Failed to invalidate company cache after creation")
 	}
 
+	// Track the company as recently active
+	activeKey := cache.BuildActiveCompaniesCacheKey()
+	if err := r.cache.AddToSortedSet(ctx, activeKey, activityLog.CompanyID, float64(activityLog.CreatedAt.Unix())); err != nil {
+		r.logger.WithError(err).WithField("company_id", activityLog.CompanyID).
+			Warn("Failed to record company activity after creation")
+	}
+
 	return nil
 }
 
+func (r *CachedActivityLogRepository) CreateBatch(ctx context.Context, activityLogs []*entity.ActivityLog) ([]error, error) {
+	errs, err := r.repo.CreateBatch(ctx, activityLogs)
+	if err != nil {
+		return nil, err
+	}
+
+	r.afterBatchCreate(ctx, activityLogs, errs)
+	return errs, nil
+}
+
+func (r *CachedActivityLogRepository) CreateMany(ctx context.Context, activityLogs []*entity.ActivityLog) error {
+	err := r.repo.CreateMany(ctx, activityLogs)
+	var createErr *repository.CreateManyError
+	switch {
+	case err == nil:
+		r.afterBatchCreate(ctx, activityLogs, nil)
+	case errors.As(err, &createErr):
+		r.afterBatchCreate(ctx, activityLogs, createErr.Errs)
+	}
+	return err
+}
+
+func (r *CachedActivityLogRepository) CreateBatchAtomic(ctx context.Context, activityLogs []*entity.ActivityLog) ([]error, error) {
+	errs, err := r.repo.CreateBatchAtomic(ctx, activityLogs)
+	if err != nil {
+		return nil, err
+	}
+
+	// A rejected document rolls back the whole batch
+	for _, createErr := range errs {
+		if createErr != nil {
+			return errs, nil
+		}
+	}
+
+	r.afterBatchCreate(ctx, activityLogs, errs)
+	return errs, nil
+}
+
+func (r *CachedActivityLogRepository) CreateByExternalID(ctx context.Context, activityLog *entity.ActivityLog, update bool) (*entity.ActivityLog, repository.WriteOutcome, error) {
+	stored, outcome, err := r.repo.CreateByExternalID(ctx, activityLog, update)
+	if err != nil {
+		return nil, "", err
+	}
+	if outcome == repository.WriteSkipped {
+		return stored, outcome, nil
+	}
+
+	if err := r.cacheLog(ctx, stored); err != nil {
+		r.logger.WithError(err).WithField("activity_log_id", stored.ID).
+			Warn("Failed to cache activity log after conditional create")
+	}
+	if outcome == repository.WriteUpdated {
+		r.invalidateLocal(ctx, string(stored.ID))
+	}
+
+	if err := r.invalidateCompanyCache(ctx, stored.CompanyID); err != nil {
+		r.logger.WithError(err).WithField("company_id", stored.CompanyID).
+			Warn("Failed to invalidate company cache after conditional create")
+	}
+
+	if outcome == repository.WriteCreated {
+		activeKey := cache.BuildActiveCompaniesCacheKey()
+		if err := r.cache.AddToSortedSet(ctx, activeKey, stored.CompanyID, float64(stored.CreatedAt.Unix())); err != nil {
+			r.logger.WithError(err).WithField("company_id", stored.CompanyID).
+				Warn("Failed to record company activity after conditional create")
+		}
+	}
+
+	return stored, outcome, nil
+}
+
+// afterBatchCreate refreshes the caches of companies that got new logs
+func (r *CachedActivityLogRepository) afterBatchCreate(ctx context.Context, activityLogs []*entity.ActivityLog, errs []error) {
+	// Only touch the caches of companies that actually got new logs
+	activeKey := cache.BuildActiveCompaniesCacheKey()
+	lastActivity := make(map[string]time.Time)
+	for i, activityLog := range activityLogs {
+		if i < len(errs) && errs[i] != nil {
+			continue
+		}
+		if activityLog.CreatedAt.After(lastActivity[activityLog.CompanyID]) {
+			lastActivity[activityLog.CompanyID] = activityLog.CreatedAt
+		}
+	}
+
+	members := make([]cache.SortedSetMember, 0, len(lastActivity))
+	for companyID, createdAt := range lastActivity {
+		if err := r.invalidateCompanyCache(ctx, companyID); err != nil {
+			r.logger.WithError(err).WithField("company_id", companyID).
+				Warn("Failed to invalidate company cache after batch creation")
+		}
+		members = append(members, cache.SortedSetMember{Member: companyID, Score: float64(createdAt.Unix())})
+	}
+	if err := r.cache.AddManyToSortedSet(ctx, activeKey, members); err != nil {
+		r.logger.WithError(err).WithField("companies_count", len(members)).
+			Warn("Failed to record company activity after batch creation")
+	}
+}
+
 func (r *CachedActivityLogRepository) GetByID(ctx context.Context, id valueobject.ActivityLogID) (*entity.ActivityLog, error) {
+	if r.local == nil {
+		return r.getShared(ctx, id)
+	}
+
+	if activityLog, ok := r.local.Get(string(id)); ok {
+		metrics.RecordCacheHit(localCacheClass)
+		return &activityLog, nil
+	}
+	metrics.RecordCacheMiss(localCacheClass)
+
+	activityLog, err := r.getShared(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.local.Set(string(id), *activityLog)
+	return activityLog, nil
+}
+
+// getShared reads a log through Redis, when logs are cached there
+func (r *CachedActivityLogRepository) getShared(ctx context.Context, id valueobject.ActivityLogID) (*entity.ActivityLog, error) {
+	if !r.opts.ActivityLog.caches() {
+		return r.repo.GetByID(ctx, id)
+	}
+
 	// Try to get from cache first
 	cacheKey := cache.BuildActivityLogCacheKey(string(id))
 	var activityLog entity.ActivityLog
@@ -69,7 +255,7 @@ func (r *CachedActivityLogRepository) GetByID(ctx context.Context, id valueobjec
 	}
 
 	// Cache the result
-	if err := r.cache.Set(ctx, cacheKey, activityLog2, 1*time.Hour); err != nil {
+	if err := r.cache.Set(ctx, cacheKey, activityLog2, r.opts.ActivityLog.TTL); err != nil {
 		r.logger.WithError(err).WithField("activity_log_id", id).
 			Warn("Failed to cache activity log after retrieval")
 	}
@@ -77,9 +263,112 @@ func (r *CachedActivityLogRepository) GetByID(ctx context.Context, id valueobjec
 	return activityLog2, nil
 }
 
+// GetByIDs looks in each tier for the logs the one before it lacked: the
+// in-process one, then Redis in a single MGET, then the repository, whose
+// logs are cached in one pipeline
+func (r *CachedActivityLogRepository) GetByIDs(ctx context.Context, ids []valueobject.ActivityLogID) ([]*entity.ActivityLog, error) {
+	found := make(map[valueobject.ActivityLogID]*entity.ActivityLog, len(ids))
+	missing := ids
+	if r.local != nil {
+		missing = nil
+		for _, id := range ids {
+			if activityLog, ok := r.local.Get(string(id)); ok {
+				metrics.RecordCacheHit(localCacheClass)
+				found[id] = &activityLog
+				continue
+			}
+			metrics.RecordCacheMiss(localCacheClass)
+			missing = append(missing, id)
+		}
+	}
+
+	shared, err := r.getManyShared(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, activityLog := range shared {
+		found[activityLog.ID] = activityLog
+		if r.local != nil {
+			r.local.Set(string(activityLog.ID), *activityLog)
+		}
+	}
+
+	activityLogs := make([]*entity.ActivityLog, 0, len(found))
+	for _, id := range ids {
+		if activityLog, ok := found[id]; ok {
+			activityLogs = append(activityLogs, activityLog)
+			delete(found, id)
+		}
+	}
+	return activityLogs, nil
+}
+
+// getManyShared reads logs through Redis, when logs are cached there
+func (r *CachedActivityLogRepository) getManyShared(ctx context.Context, ids []valueobject.ActivityLogID) ([]*entity.ActivityLog, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	if !r.opts.ActivityLog.caches() {
+		return r.repo.GetByIDs(ctx, ids)
+	}
+
+	keys := make([]string, len(ids))
+	cached := make([]entity.ActivityLog, len(ids))
+	dests := make([]interface{}, len(ids))
+	for i, id := range ids {
+		keys[i] = cache.BuildActivityLogCacheKey(string(id))
+		dests[i] = &cached[i]
+	}
+	hits, err := r.cache.GetMany(ctx, keys, dests)
+	if err != nil {
+		r.logger.WithError(err).WithField("ids_count", len(ids)).Warn("Failed to get activity logs from cache")
+		hits = make([]bool, len(ids))
+	}
+
+	var activityLogs []*entity.ActivityLog
+	var missing []valueobject.ActivityLogID
+	for i, id := range ids {
+		if hits[i] {
+			activityLogs = append(activityLogs, &cached[i])
+			continue
+		}
+		missing = append(missing, id)
+	}
+	if len(missing) == 0 {
+		return activityLogs, nil
+	}
+
+	loaded, err := r.repo.GetByIDs(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]cache.Entry, len(loaded))
+	for i, activityLog := range loaded {
+		entries[i] = cache.Entry{Key: cache.BuildActivityLogCacheKey(string(activityLog.ID)), Value: activityLog, Expiration: r.opts.ActivityLog.TTL}
+	}
+	if err := r.cache.SetMany(ctx, entries); err != nil {
+		r.logger.WithError(err).WithField("ids_count", len(loaded)).
+			Warn("Failed to cache activity logs after retrieval")
+	}
+
+	return append(activityLogs, loaded...), nil
+}
+
 func (r *CachedActivityLogRepository) GetByCompanyID(ctx context.Context, companyID string, page, limit int) ([]*entity.ActivityLog, int, error) {
-	// Check cache for activity logs
-	cacheKey := cache.BuildCompanyActivityLogsCacheKey(companyID, page, limit)
+	if !r.opts.CompanyPage.caches() {
+		return r.repo.GetByCompanyID(ctx, companyID, page, limit)
+	}
+
+	version, err := r.cache.Version(ctx, cache.BuildCompanyCacheVersionKey(companyID))
+	if err != nil {
+		r.logger.WithError(err).WithField("company_id", companyID).
+			Warn("Failed to get company cache version, reading through")
+		return r.repo.GetByCompanyID(ctx, companyID, page, limit)
+	}
+
+	// Check cache for activity logs. A page read while a write bumps the
+	// version is stored under the old one, where nothing looks for it.
+	cacheKey := cache.BuildCompanyActivityLogsCacheKey(companyID, version, page, limit)
 	var cachedResult struct {
 		ActivityLogs []*entity.ActivityLog `json:"activity_logs"`
 		Total        int                   `json:"total"`
@@ -100,7 +389,6 @@ func (r *CachedActivityLogRepository) GetByCompanyID(ctx context.Context, compan
 		return nil, 0, err
 	}
 
-	// Cache the result
 	result := struct {
 		ActivityLogs []*entity.ActivityLog `json:"activity_logs"`
 		Total        int                   `json:"total"`
@@ -109,7 +397,15 @@ func (r *CachedActivityLogRepository) GetByCompanyID(ctx context.Context, compan
 		Total:        total,
 	}
 
-	if err := r.cache.Set(ctx, cacheKey, result, 30*time.Minute); err != nil {
+	// Cache the page together with the individual logs in one round trip
+	entries := make([]cache.Entry, 0, len(activityLogs)+1)
+	entries = append(entries, cache.Entry{Key: cacheKey, Value: result, Expiration: r.opts.CompanyPage.TTL})
+	if r.opts.ActivityLog.caches() {
+		for _, log := range activityLogs {
+			entries = append(entries, cache.Entry{Key: cache.BuildActivityLogCacheKey(string(log.ID)), Value: log, Expiration: r.opts.ActivityLog.TTL})
+		}
+	}
+	if err := r.cache.SetMany(ctx, entries); err != nil {
 		r.logger.WithError(err).WithFields(logrus.Fields{
 			"company_id": companyID,
 			"page":       page,
@@ -117,15 +413,6 @@ func (r *CachedActivityLogRepository) GetByCompanyID(ctx context.Context, compan
 		}).Warn("Failed to cache company activity logs")
 	}
 
-	// Also cache individual activity logs
-	for _, log := range activityLogs {
-		individualCacheKey := cache.BuildActivityLogCacheKey(string(log.ID))
-		if err := r.cache.Set(ctx, individualCacheKey, log, 1*time.Hour); err != nil {
-			r.logger.WithError(err).WithField("activity_log_id", log.ID).
-				Warn("Failed to cache individual activity log")
-		}
-	}
-
 	return activityLogs, total, nil
 }
 
@@ -136,11 +423,11 @@ func (r *CachedActivityLogRepository) Update(ctx context.Context, activityLog *e
 	}
 
 	// Update the cache
-	cacheKey := cache.BuildActivityLogCacheKey(string(activityLog.ID))
-	if err := r.cache.Set(ctx, cacheKey, activityLog, 1*time.Hour); err != nil {
+	if err := r.cacheLog(ctx, activityLog); err != nil {
 		r.logger.WithError(err).WithField("activity_log_id", activityLog.ID).
 			Warn("Failed to update cache after activity log update")
 	}
+	r.invalidateLocal(ctx, string(activityLog.ID))
 
 	// Invalidate company activity logs cache
 	if err := r.invalidateCompanyCache(ctx, activityLog.CompanyID); err != nil {
@@ -170,6 +457,7 @@ func (r *CachedActivityLogRepository) Delete(ctx context.Context, id valueobject
 		r.logger.WithError(err).WithField("activity_log_id", id).
 			Warn("Failed to delete activity log from cache")
 	}
+	r.invalidateLocal(ctx, string(id))
 
 	// Invalidate company activity logs cache if we have the company ID
 	if activityLog != nil {
@@ -182,31 +470,32 @@ func (r *CachedActivityLogRepository) Delete(ctx context.Context, id valueobject
 	return nil
 }
 
-func (r *CachedActivityLogRepository) GetByObjectID(ctx context.Context, companyID, objectID string, page, limit int) ([]*entity.ActivityLog, int, error) {
-	// For now, we'll not cache this method to keep it simple
-	// In a production system, you might want to cache this as well
-	return r.repo.GetByObjectID(ctx, companyID, objectID, page, limit)
-}
-
-func (r *CachedActivityLogRepository) GetByActivityName(ctx context.Context, companyID, activityName string, page, limit int) ([]*entity.ActivityLog, int, error) {
-	// For now, we'll not cache this method to keep it simple
-	// In a production system, you might want to cache this as well
-	return r.repo.GetByActivityName(ctx, companyID, activityName, page, limit)
-}
+// DeleteOlderThan drops the company's cached pages and count. Individually
+// cached logs are left to expire on their own TTL.
+func (r *CachedActivityLogRepository) DeleteOlderThan(ctx context.Context, companyID string, cutoff time.Time, limit int) (int, error) {
+	removed, err := r.repo.DeleteOlderThan(ctx, companyID, cutoff, limit)
+	if err != nil {
+		return 0, err
+	}
 
-func (r *CachedActivityLogRepository) GetByDateRange(ctx context.Context, companyID string, startDate, endDate time.Time, page, limit int) ([]*entity.ActivityLog, int, error) {
-	// For now, we'll not cache this method to keep it simple
-	// In a production system, you might want to cache this as well
-	return r.repo.GetByDateRange(ctx, companyID, startDate, endDate, page, limit)
+	if removed > 0 {
+		if err := r.invalidateCompanyCache(ctx, companyID); err != nil {
+			r.logger.WithError(err).WithField("company_id", companyID).
+				Warn("Failed to invalidate company cache after retention cleanup")
+		}
+	}
+	return removed, nil
 }
 
-func (r *CachedActivityLogRepository) GetByActor(ctx context.Context, companyID, actorID string, page, limit int) ([]*entity.ActivityLog, int, error) {
-	// For now, we'll not cache this method to keep it simple
-	// In a production system, you might want to cache this as well
-	return r.repo.GetByActor(ctx, companyID, actorID, page, limit)
+func (r *CachedActivityLogRepository) CompaniesWithLogsBefore(ctx context.Context, cutoff time.Time) ([]string, error) {
+	return r.repo.CompaniesWithLogsBefore(ctx, cutoff)
 }
 
 func (r *CachedActivityLogRepository) CountByCompanyID(ctx context.Context, companyID string) (int, error) {
+	if !r.opts.CompanyCount.caches() {
+		return r.repo.CountByCompanyID(ctx, companyID)
+	}
+
 	// Check cache for count
 	cacheKey := cache.BuildActivityLogCountCacheKey(companyID)
 	var count int
@@ -221,8 +510,8 @@ func (r *CachedActivityLogRepository) CountByCompanyID(ctx context.Context, comp
 		return 0, err
 	}
 
-	// Cache the result for 5 minutes
-	if err := r.cache.Set(ctx, cacheKey, count, 5*time.Minute); err != nil {
+	// Cache the result
+	if err := r.cache.Set(ctx, cacheKey, count, r.opts.CompanyCount.TTL); err != nil {
 		r.logger.WithError(err).WithField("company_id", companyID).
 			Warn("Failed to cache activity log count")
 	}
@@ -230,12 +519,79 @@ func (r *CachedActivityLogRepository) CountByCompanyID(ctx context.Context, comp
 	return count, nil
 }
 
+func (r *CachedActivityLogRepository) CountSince(ctx context.Context, since time.Time) (int, error) {
+	// Counts across companies have no cache version to be invalidated by
+	return r.repo.CountSince(ctx, since)
+}
+
+func (r *CachedActivityLogRepository) GetActiveCompanies(ctx context.Context, since time.Time) ([]*entity.CompanyActivity, error) {
+	activeKey := cache.BuildActiveCompaniesCacheKey()
+
+	// Companies older than the window have been trimmed from the index
+	window := r.opts.ActiveCompaniesWindow
+	if window > 0 && since.Before(time.Now().Add(-window)) {
+		return r.repo.GetActiveCompanies(ctx, since)
+	}
+
+	// The index is only populated by writes through this repository, so fall
+	// back to the database (and backfill) when Redis has nothing yet
+	exists, err := r.cache.Exists(ctx, activeKey)
+	if err == nil && exists {
+		members, err := r.cache.GetSortedSetByMinScore(ctx, activeKey, float64(since.Unix()))
+		if err == nil {
+			companies := make([]*entity.CompanyActivity, 0, len(members))
+			for _, member := range members {
+				companies = append(companies, &entity.CompanyActivity{
+					CompanyID:      member.Member,
+					LastActivityAt: time.Unix(int64(member.Score), 0).UTC(),
+				})
+			}
+			r.logger.WithField("since", since).Debug("Active companies retrieved from cache")
+			return companies, nil
+		}
+		r.logger.WithError(err).Warn("Failed to read active companies from cache")
+	}
+
+	companies, err := r.repo.GetActiveCompanies(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, company := range companies {
+		if err := r.cache.AddToSortedSet(ctx, activeKey, company.CompanyID, float64(company.LastActivityAt.Unix())); err != nil {
+			r.logger.WithError(err).WithField("company_id", company.CompanyID).
+				Warn("Failed to backfill active company")
+			break
+		}
+	}
+
+	return companies, nil
+}
+
+func (r *CachedActivityLogRepository) OpenCursor(ctx context.Context, filter repository.ActivityLogFilter) (repository.ActivityLogIterator, error) {
+	// Cursors stream straight from the database and are never cached
+	return r.repo.OpenCursor(ctx, filter)
+}
+
+func (r *CachedActivityLogRepository) Iterate(ctx context.Context, filter repository.ActivityLogFilter, fn func(*entity.ActivityLog) error) error {
+	return r.repo.Iterate(ctx, filter, fn)
+}
+
+// cacheLog stores the current version of a log, unless logs are not cached
+func (r *CachedActivityLogRepository) cacheLog(ctx context.Context, activityLog *entity.ActivityLog) error {
+	if !r.opts.ActivityLog.caches() {
+		return nil
+	}
+	cacheKey := cache.BuildActivityLogCacheKey(string(activityLog.ID))
+	return r.cache.Set(ctx, cacheKey, activityLog, r.opts.ActivityLog.TTL)
+}
+
 // invalidateCompanyCache invalidates all cached data for a company
 func (r *CachedActivityLogRepository) invalidateCompanyCache(ctx context.Context, companyID string) error {
-	// Delete company activity logs cache patterns
-	pattern := fmt.Sprintf("company_activity_logs:%s:*", companyID)
-	if err := r.cache.DeleteByPattern(ctx, pattern); err != nil {
-		return fmt.Errorf("failed to delete company activity logs cache: %w", err)
+	// Move the company's pages to a new version; the old ones expire
+	versionKey := cache.BuildCompanyCacheVersionKey(companyID)
+	if err := r.cache.BumpVersion(ctx, versionKey); err != nil {
+		return fmt.Errorf("failed to invalidate company activity logs cache: %w", err)
 	}
 
 	// Delete company count cache
@@ -249,10 +605,85 @@ func (r *CachedActivityLogRepository) invalidateCompanyCache(ctx context.Context
 
 // ClearCache clears all cached data
 func (r *CachedActivityLogRepository) ClearCache(ctx context.Context) error {
+	r.invalidateLocal(ctx, localPurgeAll)
 	return r.cache.FlushAll(ctx)
 }
 
-// ClearCacheForCompany clears all cached data for a specific company
+// ClearCacheForCompany clears all cached data for a specific company. The
+// in-process tier does not know which company its logs belong to, so it is
+// emptied entirely.
 func (r *CachedActivityLogRepository) ClearCacheForCompany(ctx context.Context, companyID string) error {
+	r.invalidateLocal(ctx, localPurgeAll)
 	return r.invalidateCompanyCache(ctx, companyID)
 }
+
+// localCacheClass labels the in-process tier in the cache metrics
+const localCacheClass = "local_activity_log"
+
+// invalidateLocal evicts a log, or everything for localPurgeAll, from the
+// in-process tier of this and every other instance. Instances that miss the
+// message serve the stale log until the local TTL expires.
+func (r *CachedActivityLogRepository) invalidateLocal(ctx context.Context, id string) {
+	if r.local == nil {
+		return
+	}
+	r.evictLocal(id)
+	if err := r.cache.Publish(ctx, localInvalidationChannel, id); err != nil {
+		r.logger.WithError(err).WithField("activity_log_id", id).
+			Warn("Failed to broadcast in-process cache invalidation")
+	}
+	metrics.RecordCacheInvalidation(localCacheClass)
+}
+
+func (r *CachedActivityLogRepository) evictLocal(id string) {
+	if id == localPurgeAll {
+		r.local.Purge()
+		return
+	}
+	r.local.Delete(id)
+}
+
+// purgeKeyBatch bounds the cache keys deleted per request after a purge
+const purgeKeyBatch = 1000
+
+// CachedCompanyPurger also drops a purged company's logs from the cache.
+// Their IDs are listed before the purge, since nothing maps the company to
+// its cached logs afterwards.
+type CachedCompanyPurger struct {
+	purger repository.CompanyPurger
+	logs   repository.ActivityLogRepository
+	cache  cache.Store
+}
+
+// NewCachedCompanyPurger lists the company's logs through logs, which should
+// read the database rather than the cache
+func NewCachedCompanyPurger(purger repository.CompanyPurger, logs repository.ActivityLogRepository, store cache.Store) *CachedCompanyPurger {
+	return &CachedCompanyPurger{
+		purger: purger,
+		logs:   logs,
+		cache:  store,
+	}
+}
+
+func (p *CachedCompanyPurger) PurgeCompany(ctx context.Context, companyID string) error {
+	var keys []string
+	err := p.logs.Iterate(ctx, repository.ActivityLogFilter{CompanyID: companyID}, func(activityLog *entity.ActivityLog) error {
+		keys = append(keys, cache.BuildActivityLogCacheKey(string(activityLog.ID)))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the logs to purge: %w", err)
+	}
+
+	if err := p.purger.PurgeCompany(ctx, companyID); err != nil {
+		return err
+	}
+
+	for start := 0; start < len(keys); start += purgeKeyBatch {
+		end := min(start+purgeKeyBatch, len(keys))
+		if err := p.cache.DeleteMany(ctx, keys[start:end]); err != nil {
+			return fmt.Errorf("failed to drop purged logs from the cache: %w", err)
+		}
+	}
+	return nil
+}
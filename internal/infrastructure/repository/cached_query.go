@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"activity-log-service/internal/domain/repository"
+	"activity-log-service/internal/infrastructure/cache"
+)
+
+// cachedQuery serves a company-scoped read from the cache under a key hashed
+// from op and its arguments, or loads and caches it. Keys carry the company's
+// cache version, so every write to the company invalidates them along with
+// its pages. Reads not scoped to a company have no version to be invalidated
+// by and always go to the repository.
+func cachedQuery[T any](
+	ctx context.Context,
+	r *CachedActivityLogRepository,
+	companyID, op string,
+	args any,
+	load func() (T, error),
+) (T, error) {
+	if !r.opts.Query.caches() || companyID == "" {
+		return load()
+	}
+
+	fields := logrus.Fields{"company_id": companyID, "query": op}
+	hash, err := queryHash(args)
+	if err != nil {
+		r.logger.WithError(err).WithFields(fields).Warn("Failed to hash query, reading through")
+		return load()
+	}
+	version, err := r.cache.Version(ctx, cache.BuildCompanyCacheVersionKey(companyID))
+	if err != nil {
+		r.logger.WithError(err).WithFields(fields).Warn("Failed to get company cache version, reading through")
+		return load()
+	}
+
+	cacheKey := cache.BuildCompanyQueryCacheKey(companyID, version, op, hash)
+	var result T
+	if err := r.cache.Get(ctx, cacheKey, &result); err == nil {
+		r.logger.WithFields(fields).Debug("Query result retrieved from cache")
+		return result, nil
+	}
+
+	result, err = load()
+	if err != nil {
+		return result, err
+	}
+
+	if err := r.cache.Set(ctx, cacheKey, result, r.opts.Query.TTL); err != nil {
+		r.logger.WithError(err).WithFields(fields).Warn("Failed to cache query result")
+	}
+	return result, nil
+}
+
+// queryHash identifies a query by its arguments. Filters are plain structs,
+// so their JSON encoding is stable.
+func queryHash(args any) (string, error) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16]), nil
+}
+
+func (r *CachedActivityLogRepository) Search(ctx context.Context, filter repository.ActivityLogFilter, page repository.SearchPage) (*repository.SearchResult, error) {
+	args := struct {
+		Filter repository.ActivityLogFilter
+		Page   repository.SearchPage
+	}{filter, page}
+	return cachedQuery(ctx, r, filter.CompanyID, "search", args, func() (*repository.SearchResult, error) {
+		return r.repo.Search(ctx, filter, page)
+	})
+}
+
+func (r *CachedActivityLogRepository) CountGrouped(ctx context.Context, filter repository.ActivityLogFilter, groupBy repository.GroupBy, limit int) ([]repository.GroupCount, error) {
+	args := struct {
+		Filter  repository.ActivityLogFilter
+		GroupBy repository.GroupBy
+		Limit   int
+	}{filter, groupBy, limit}
+	return cachedQuery(ctx, r, filter.CompanyID, "count_grouped", args, func() ([]repository.GroupCount, error) {
+		return r.repo.CountGrouped(ctx, filter, groupBy, limit)
+	})
+}
+
+func (r *CachedActivityLogRepository) CountByActivityName(ctx context.Context, companyID string, start, end time.Time) ([]repository.GroupCount, error) {
+	return cachedQuery(ctx, r, companyID, "count_by_activity_name", []time.Time{start, end}, func() ([]repository.GroupCount, error) {
+		return r.repo.CountByActivityName(ctx, companyID, start, end)
+	})
+}
+
+func (r *CachedActivityLogRepository) CountByActor(ctx context.Context, companyID string, start, end time.Time) ([]repository.GroupCount, error) {
+	return cachedQuery(ctx, r, companyID, "count_by_actor", []time.Time{start, end}, func() ([]repository.GroupCount, error) {
+		return r.repo.CountByActor(ctx, companyID, start, end)
+	})
+}
+
+func (r *CachedActivityLogRepository) CountPerDay(ctx context.Context, companyID string, start, end time.Time) ([]repository.GroupCount, error) {
+	return cachedQuery(ctx, r, companyID, "count_per_day", []time.Time{start, end}, func() ([]repository.GroupCount, error) {
+		return r.repo.CountPerDay(ctx, companyID, start, end)
+	})
+}
+
+func (r *CachedActivityLogRepository) CountByCountryCode(ctx context.Context, companyID string) (map[string]int, error) {
+	return cachedQuery(ctx, r, companyID, "count_by_country_code", nil, func() (map[string]int, error) {
+		return r.repo.CountByCountryCode(ctx, companyID)
+	})
+}
+
+func (r *CachedActivityLogRepository) DistinctActivityNames(ctx context.Context, companyID string) ([]string, error) {
+	return cachedQuery(ctx, r, companyID, "distinct_activity_names", nil, func() ([]string, error) {
+		return r.repo.DistinctActivityNames(ctx, companyID)
+	})
+}
+
+func (r *CachedActivityLogRepository) DistinctActors(ctx context.Context, companyID string) ([]repository.Actor, error) {
+	return cachedQuery(ctx, r, companyID, "distinct_actors", nil, func() ([]repository.Actor, error) {
+		return r.repo.DistinctActors(ctx, companyID)
+	})
+}
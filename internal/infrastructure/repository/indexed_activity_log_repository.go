@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+	"activity-log-service/internal/domain/valueobject"
+)
+
+// SearchIndex answers the queries a secondary search index is better at than
+// the database
+type SearchIndex interface {
+	Search(ctx context.Context, filter repository.ActivityLogFilter, page repository.SearchPage) (*repository.SearchResult, error)
+	CountGrouped(ctx context.Context, filter repository.ActivityLogFilter, groupBy repository.GroupBy, limit int) ([]repository.GroupCount, error)
+	Index(ctx context.Context, activityLog *entity.ActivityLog) error
+	IndexMany(ctx context.Context, activityLogs []*entity.ActivityLog) error
+	Delete(ctx context.Context, id string) error
+	DeleteOlderThan(ctx context.Context, companyID string, cutoff time.Time) error
+	DeleteCompany(ctx context.Context, companyID string) error
+	DeleteBefore(ctx context.Context, cutoff time.Time, keep []string) error
+}
+
+// IndexedActivityLogRepository routes text searches and aggregations to a
+// search index and everything else to the wrapped repository, which stays the
+// source of truth. When the index fails the query falls back to the wrapped
+// repository, so the index is never required for correctness.
+//
+// New logs reach the index through the created-log events. Writes that raise
+// no such event, i.e. updates, upserts, restores and deletes, are applied to
+// the index here; a failure only leaves the index stale and is logged.
+type IndexedActivityLogRepository struct {
+	repository.ActivityLogRepository
+	index  SearchIndex
+	logger *logrus.Logger
+}
+
+func NewIndexedActivityLogRepository(
+	repo repository.ActivityLogRepository,
+	index SearchIndex,
+	logger *logrus.Logger,
+) *IndexedActivityLogRepository {
+	return &IndexedActivityLogRepository{
+		ActivityLogRepository: repo,
+		index:                 index,
+		logger:                logger,
+	}
+}
+
+// Search uses the index for filters with text; exact-match filters are
+// served by the database indexes just as well and are never stale there
+func (r *IndexedActivityLogRepository) Search(ctx context.Context, filter repository.ActivityLogFilter, page repository.SearchPage) (*repository.SearchResult, error) {
+	if filter.Text == "" || !indexable(filter) {
+		return r.ActivityLogRepository.Search(ctx, filter, page)
+	}
+
+	result, err := r.index.Search(ctx, filter, page)
+	if err != nil {
+		r.logger.WithError(err).WithField("company_id", filter.CompanyID).
+			Warn("Search index failed, searching the database instead")
+		return r.ActivityLogRepository.Search(ctx, filter, page)
+	}
+	return result, nil
+}
+
+func (r *IndexedActivityLogRepository) CountGrouped(ctx context.Context, filter repository.ActivityLogFilter, groupBy repository.GroupBy, limit int) ([]repository.GroupCount, error) {
+	if !indexable(filter) {
+		return r.ActivityLogRepository.CountGrouped(ctx, filter, groupBy, limit)
+	}
+	counts, err := r.index.CountGrouped(ctx, filter, groupBy, limit)
+	if err != nil {
+		r.logger.WithError(err).WithField("company_id", filter.CompanyID).
+			Warn("Search index failed, aggregating in the database instead")
+		return r.ActivityLogRepository.CountGrouped(ctx, filter, groupBy, limit)
+	}
+	return counts, nil
+}
+
+// CreateMany also indexes the stored logs; it restores archived logs, which
+// raise no created-log events
+func (r *IndexedActivityLogRepository) CreateMany(ctx context.Context, activityLogs []*entity.ActivityLog) error {
+	err := r.ActivityLogRepository.CreateMany(ctx, activityLogs)
+	stored := activityLogs
+	var rejected *repository.CreateManyError
+	switch {
+	case errors.As(err, &rejected):
+		stored = make([]*entity.ActivityLog, 0, len(activityLogs))
+		for i, activityLog := range activityLogs {
+			if i < len(rejected.Errs) && rejected.Errs[i] == nil {
+				stored = append(stored, activityLog)
+			}
+		}
+	case err != nil:
+		return err
+	}
+
+	if indexErr := r.index.IndexMany(ctx, stored); indexErr != nil {
+		r.logger.WithError(indexErr).WithField("count", len(stored)).
+			Warn("Failed to add stored activity logs to the search index")
+	}
+	return err
+}
+
+// CreateByExternalID also indexes a log whose content the upsert replaced
+func (r *IndexedActivityLogRepository) CreateByExternalID(ctx context.Context, activityLog *entity.ActivityLog, update bool) (*entity.ActivityLog, repository.WriteOutcome, error) {
+	stored, outcome, err := r.ActivityLogRepository.CreateByExternalID(ctx, activityLog, update)
+	if err != nil || outcome != repository.WriteUpdated {
+		return stored, outcome, err
+	}
+	r.reindex(ctx, stored)
+	return stored, outcome, nil
+}
+
+// Update also replaces the log in the index
+func (r *IndexedActivityLogRepository) Update(ctx context.Context, activityLog *entity.ActivityLog) error {
+	if err := r.ActivityLogRepository.Update(ctx, activityLog); err != nil {
+		return err
+	}
+	r.reindex(ctx, activityLog)
+	return nil
+}
+
+func (r *IndexedActivityLogRepository) reindex(ctx context.Context, activityLog *entity.ActivityLog) {
+	if err := r.index.Index(ctx, activityLog); err != nil {
+		r.logger.WithError(err).WithField("activity_log_id", activityLog.ID).
+			Warn("Failed to update activity log in the search index")
+	}
+}
+
+// Delete also removes the log from the index so that an erased log cannot be
+// found there
+func (r *IndexedActivityLogRepository) Delete(ctx context.Context, id valueobject.ActivityLogID) error {
+	if err := r.ActivityLogRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	if err := r.index.Delete(ctx, id.String()); err != nil {
+		r.logger.WithError(err).WithField("activity_log_id", id).
+			Warn("Failed to remove deleted activity log from the search index")
+	}
+	return nil
+}
+
+// DeleteOlderThan also removes the logs from the index, once a call leaves
+// none before cutoff. Retention and archiving both delete through it.
+func (r *IndexedActivityLogRepository) DeleteOlderThan(ctx context.Context, companyID string, cutoff time.Time, limit int) (int, error) {
+	deleted, err := r.ActivityLogRepository.DeleteOlderThan(ctx, companyID, cutoff, limit)
+	if err != nil || (limit > 0 && deleted >= limit) {
+		return deleted, err
+	}
+	if err := r.index.DeleteOlderThan(ctx, companyID, cutoff); err != nil {
+		r.logger.WithError(err).WithField("company_id", companyID).
+			Warn("Failed to remove expired activity logs from the search index")
+	}
+	return deleted, nil
+}
+
+// IndexedCompanyPurger also removes a purged company's logs from the index
+type IndexedCompanyPurger struct {
+	purger repository.CompanyPurger
+	index  SearchIndex
+	logger *logrus.Logger
+}
+
+func NewIndexedCompanyPurger(purger repository.CompanyPurger, index SearchIndex, logger *logrus.Logger) *IndexedCompanyPurger {
+	return &IndexedCompanyPurger{
+		purger: purger,
+		index:  index,
+		logger: logger,
+	}
+}
+
+// IndexedPartitionManager also removes the logs of dropped partitions from
+// the index
+type IndexedPartitionManager struct {
+	partitions repository.PartitionManager
+	index      SearchIndex
+	logger     *logrus.Logger
+}
+
+func NewIndexedPartitionManager(partitions repository.PartitionManager, index SearchIndex, logger *logrus.Logger) *IndexedPartitionManager {
+	return &IndexedPartitionManager{
+		partitions: partitions,
+		index:      index,
+		logger:     logger,
+	}
+}
+
+// DropPartitionsBefore removes every log before cutoff but the keep
+// companies' from the index once a partition was dropped. Logs that old are
+// past every retention, so those still in kept partitions are deleted by
+// the retention job anyway.
+func (m *IndexedPartitionManager) DropPartitionsBefore(ctx context.Context, cutoff time.Time, keep []string) ([]string, error) {
+	dropped, err := m.partitions.DropPartitionsBefore(ctx, cutoff, keep)
+	if len(dropped) == 0 {
+		return dropped, err
+	}
+	if indexErr := m.index.DeleteBefore(ctx, cutoff, keep); indexErr != nil {
+		m.logger.WithError(indexErr).WithField("partitions", dropped).
+			Warn("Failed to remove dropped partitions from the search index")
+	}
+	return dropped, err
+}
+
+func (p *IndexedCompanyPurger) PurgeCompany(ctx context.Context, companyID string) error {
+	if err := p.purger.PurgeCompany(ctx, companyID); err != nil {
+		return err
+	}
+	if err := p.index.DeleteCompany(ctx, companyID); err != nil {
+		p.logger.WithError(err).WithField("company_id", companyID).
+			Warn("Failed to remove purged company from the search index")
+	}
+	return nil
+}
+
+func (r *IndexedActivityLogRepository) CountByActivityName(ctx context.Context, companyID string, start, end time.Time) ([]repository.GroupCount, error) {
+	return r.CountGrouped(ctx, rangeFilter(companyID, start, end), repository.GroupByActivityName, 0)
+}
+
+func (r *IndexedActivityLogRepository) CountByActor(ctx context.Context, companyID string, start, end time.Time) ([]repository.GroupCount, error) {
+	return r.CountGrouped(ctx, rangeFilter(companyID, start, end), repository.GroupByActor, 0)
+}
+
+func (r *IndexedActivityLogRepository) CountPerDay(ctx context.Context, companyID string, start, end time.Time) ([]repository.GroupCount, error) {
+	return r.CountGrouped(ctx, rangeFilter(companyID, start, end), repository.GroupByDay, 0)
+}
+
+// indexable reports whether the index can apply every field of filter; it
+// does not map IP addresses and user agents
+func indexable(filter repository.ActivityLogFilter) bool {
+	return filter.IPAddress == "" && filter.UserAgent == ""
+}
+
+func rangeFilter(companyID string, start, end time.Time) repository.ActivityLogFilter {
+	return repository.ActivityLogFilter{CompanyID: companyID, StartDate: start, EndDate: end}
+}
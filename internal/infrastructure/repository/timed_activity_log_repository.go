@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+	"activity-log-service/internal/domain/valueobject"
+)
+
+// RecordDuration observes how long one repository operation took, with
+// status success or error
+type RecordDuration func(operation, status string, duration time.Duration)
+
+// TimedActivityLogRepository times every operation of the wrapped store.
+// OpenCursor is timed up to the first batch; Iterate includes the time spent
+// in its callback.
+type TimedActivityLogRepository struct {
+	repo   repository.ActivityLogRepository
+	record RecordDuration
+}
+
+func NewTimedActivityLogRepository(repo repository.ActivityLogRepository, record RecordDuration) *TimedActivityLogRepository {
+	return &TimedActivityLogRepository{repo: repo, record: record}
+}
+
+func (r *TimedActivityLogRepository) observe(operation string, start time.Time, err error) {
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	r.record(operation, status, time.Since(start))
+}
+
+func (r *TimedActivityLogRepository) Create(ctx context.Context, activityLog *entity.ActivityLog) error {
+	start := time.Now()
+	err := r.repo.Create(ctx, activityLog)
+	r.observe("create", start, err)
+	return err
+}
+
+func (r *TimedActivityLogRepository) CreateBatch(ctx context.Context, activityLogs []*entity.ActivityLog) ([]error, error) {
+	start := time.Now()
+	result, err := r.repo.CreateBatch(ctx, activityLogs)
+	r.observe("create_batch", start, err)
+	return result, err
+}
+
+func (r *TimedActivityLogRepository) CreateBatchAtomic(ctx context.Context, activityLogs []*entity.ActivityLog) ([]error, error) {
+	start := time.Now()
+	result, err := r.repo.CreateBatchAtomic(ctx, activityLogs)
+	r.observe("create_batch_atomic", start, err)
+	return result, err
+}
+
+func (r *TimedActivityLogRepository) CreateMany(ctx context.Context, activityLogs []*entity.ActivityLog) error {
+	start := time.Now()
+	err := r.repo.CreateMany(ctx, activityLogs)
+	r.observe("create_many", start, err)
+	return err
+}
+
+func (r *TimedActivityLogRepository) CreateByExternalID(ctx context.Context, activityLog *entity.ActivityLog, update bool) (*entity.ActivityLog, repository.WriteOutcome, error) {
+	start := time.Now()
+	stored, outcome, err := r.repo.CreateByExternalID(ctx, activityLog, update)
+	r.observe("create_by_external_id", start, err)
+	return stored, outcome, err
+}
+
+func (r *TimedActivityLogRepository) GetByID(ctx context.Context, id valueobject.ActivityLogID) (*entity.ActivityLog, error) {
+	start := time.Now()
+	result, err := r.repo.GetByID(ctx, id)
+	r.observe("get_by_id", start, err)
+	return result, err
+}
+
+func (r *TimedActivityLogRepository) GetByIDs(ctx context.Context, ids []valueobject.ActivityLogID) ([]*entity.ActivityLog, error) {
+	start := time.Now()
+	activityLogs, err := r.repo.GetByIDs(ctx, ids)
+	r.observe("get_by_ids", start, err)
+	return activityLogs, err
+}
+
+func (r *TimedActivityLogRepository) GetByCompanyID(ctx context.Context, companyID string, page, limit int) ([]*entity.ActivityLog, int, error) {
+	start := time.Now()
+	activityLogs, total, err := r.repo.GetByCompanyID(ctx, companyID, page, limit)
+	r.observe("get_by_company_id", start, err)
+	return activityLogs, total, err
+}
+
+func (r *TimedActivityLogRepository) Update(ctx context.Context, activityLog *entity.ActivityLog) error {
+	start := time.Now()
+	err := r.repo.Update(ctx, activityLog)
+	r.observe("update", start, err)
+	return err
+}
+
+func (r *TimedActivityLogRepository) Delete(ctx context.Context, id valueobject.ActivityLogID) error {
+	start := time.Now()
+	err := r.repo.Delete(ctx, id)
+	r.observe("delete", start, err)
+	return err
+}
+
+func (r *TimedActivityLogRepository) DeleteOlderThan(ctx context.Context, companyID string, cutoff time.Time, limit int) (int, error) {
+	start := time.Now()
+	result, err := r.repo.DeleteOlderThan(ctx, companyID, cutoff, limit)
+	r.observe("delete_older_than", start, err)
+	return result, err
+}
+
+func (r *TimedActivityLogRepository) CompaniesWithLogsBefore(ctx context.Context, cutoff time.Time) ([]string, error) {
+	start := time.Now()
+	result, err := r.repo.CompaniesWithLogsBefore(ctx, cutoff)
+	r.observe("companies_with_logs_before", start, err)
+	return result, err
+}
+
+func (r *TimedActivityLogRepository) CountByCompanyID(ctx context.Context, companyID string) (int, error) {
+	start := time.Now()
+	result, err := r.repo.CountByCompanyID(ctx, companyID)
+	r.observe("count_by_company_id", start, err)
+	return result, err
+}
+
+func (r *TimedActivityLogRepository) CountSince(ctx context.Context, since time.Time) (int, error) {
+	start := time.Now()
+	result, err := r.repo.CountSince(ctx, since)
+	r.observe("count_since", start, err)
+	return result, err
+}
+
+func (r *TimedActivityLogRepository) CountByCountryCode(ctx context.Context, companyID string) (map[string]int, error) {
+	start := time.Now()
+	result, err := r.repo.CountByCountryCode(ctx, companyID)
+	r.observe("count_by_country_code", start, err)
+	return result, err
+}
+
+func (r *TimedActivityLogRepository) GetActiveCompanies(ctx context.Context, since time.Time) ([]*entity.CompanyActivity, error) {
+	start := time.Now()
+	result, err := r.repo.GetActiveCompanies(ctx, since)
+	r.observe("get_active_companies", start, err)
+	return result, err
+}
+
+func (r *TimedActivityLogRepository) OpenCursor(ctx context.Context, filter repository.ActivityLogFilter) (repository.ActivityLogIterator, error) {
+	start := time.Now()
+	result, err := r.repo.OpenCursor(ctx, filter)
+	r.observe("open_cursor", start, err)
+	return result, err
+}
+
+func (r *TimedActivityLogRepository) Iterate(ctx context.Context, filter repository.ActivityLogFilter, fn func(*entity.ActivityLog) error) error {
+	start := time.Now()
+	err := r.repo.Iterate(ctx, filter, fn)
+	r.observe("iterate", start, err)
+	return err
+}
+
+func (r *TimedActivityLogRepository) Search(ctx context.Context, filter repository.ActivityLogFilter, page repository.SearchPage) (*repository.SearchResult, error) {
+	start := time.Now()
+	result, err := r.repo.Search(ctx, filter, page)
+	r.observe("search", start, err)
+	return result, err
+}
+
+func (r *TimedActivityLogRepository) CountGrouped(ctx context.Context, filter repository.ActivityLogFilter, groupBy repository.GroupBy, limit int) ([]repository.GroupCount, error) {
+	start := time.Now()
+	result, err := r.repo.CountGrouped(ctx, filter, groupBy, limit)
+	r.observe("count_grouped", start, err)
+	return result, err
+}
+
+func (r *TimedActivityLogRepository) CountByActivityName(ctx context.Context, companyID string, from, to time.Time) ([]repository.GroupCount, error) {
+	start := time.Now()
+	result, err := r.repo.CountByActivityName(ctx, companyID, from, to)
+	r.observe("count_by_activity_name", start, err)
+	return result, err
+}
+
+func (r *TimedActivityLogRepository) CountByActor(ctx context.Context, companyID string, from, to time.Time) ([]repository.GroupCount, error) {
+	start := time.Now()
+	result, err := r.repo.CountByActor(ctx, companyID, from, to)
+	r.observe("count_by_actor", start, err)
+	return result, err
+}
+
+func (r *TimedActivityLogRepository) CountPerDay(ctx context.Context, companyID string, from, to time.Time) ([]repository.GroupCount, error) {
+	start := time.Now()
+	result, err := r.repo.CountPerDay(ctx, companyID, from, to)
+	r.observe("count_per_day", start, err)
+	return result, err
+}
+
+func (r *TimedActivityLogRepository) DistinctActivityNames(ctx context.Context, companyID string) ([]string, error) {
+	start := time.Now()
+	names, err := r.repo.DistinctActivityNames(ctx, companyID)
+	r.observe("distinct_activity_names", start, err)
+	return names, err
+}
+
+func (r *TimedActivityLogRepository) DistinctActors(ctx context.Context, companyID string) ([]repository.Actor, error) {
+	start := time.Now()
+	actors, err := r.repo.DistinctActors(ctx, companyID)
+	r.observe("distinct_actors", start, err)
+	return actors, err
+}
@@ -0,0 +1,92 @@
+// Package sampling makes keep-or-drop decisions that every replica agrees on.
+// Decisions depend only on the item and a seed shared through config, never
+// on local state such as counters or random numbers, so the same log is kept
+// or dropped no matter which instance sees it.
+package sampling
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+)
+
+// Key identifies the item a decision is about
+type Key struct {
+	CompanyID    string
+	ActivityName string
+	ObjectID     string
+}
+
+// Sampler decides whether an item is kept. Implementations must return the
+// same answer for the same key on every replica.
+type Sampler interface {
+	Sample(key Key) bool
+}
+
+// Always keeps everything
+type Always struct{}
+
+func (Always) Sample(Key) bool { return true }
+
+// Hash maps key onto [0, 2^64) using seed. The fields are length-prefixed so
+// ("ab", "c") and ("a", "bc") hash differently.
+func Hash(seed string, key Key) uint64 {
+	h := fnv.New64a()
+	for _, part := range []string{seed, key.CompanyID, key.ActivityName, key.ObjectID} {
+		var length [4]byte
+		binary.BigEndian.PutUint32(length[:], uint32(len(part)))
+		h.Write(length[:])
+		h.Write([]byte(part))
+	}
+	return h.Sum64()
+}
+
+// HashSampler keeps a fixed fraction of keys, chosen by their seeded hash.
+// Changing the seed reshuffles which keys are kept without changing how many.
+type HashSampler struct {
+	seed      string
+	threshold uint64
+	all       bool
+}
+
+// NewHashSampler keeps rate of all keys; rate is clamped to [0, 1]
+func NewHashSampler(seed string, rate float64) *HashSampler {
+	s := &HashSampler{seed: seed}
+	switch {
+	case rate >= 1:
+		s.all = true
+	case rate > 0:
+		s.threshold = uint64(rate * math.MaxUint64)
+	}
+	return s
+}
+
+func (s *HashSampler) Sample(key Key) bool {
+	return s.all || Hash(s.seed, key) < s.threshold
+}
+
+// excluding drops every key of the given companies and defers to next for
+// the rest
+type excluding struct {
+	next      Sampler
+	companies map[string]bool
+}
+
+// Exclude wraps next so keys of the given companies are never kept, e.g. the
+// canary's reserved company. Empty IDs are ignored.
+func Exclude(next Sampler, companyIDs ...string) Sampler {
+	companies := make(map[string]bool, len(companyIDs))
+	for _, id := range companyIDs {
+		if id != "" {
+			companies[id] = true
+		}
+	}
+	if len(companies) == 0 {
+		return next
+	}
+	return &excluding{next: next, companies: companies}
+}
+
+func (s *excluding) Sample(key Key) bool {
+	return !s.companies[key.CompanyID] && s.next.Sample(key)
+}
@@ -0,0 +1,437 @@
+// Package search keeps a secondary Elasticsearch (or OpenSearch) index of the
+// activity logs for full-text search and aggregations. ArangoDB stays the
+// source of truth: the index is fed from the created-log events and may lag
+// behind it.
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/repository"
+)
+
+// maxGroups caps the buckets of an unlimited aggregation; it is the default
+// search.max_buckets of Elasticsearch
+const maxGroups = 65535
+
+// mapping indexes the fields logs are filtered, searched and grouped by.
+// Everything else is kept in _source only.
+const mapping = `{
+  "mappings": {
+    "dynamic": false,
+    "properties": {
+      "id":                {"type": "keyword"},
+      "company_id":        {"type": "keyword"},
+      "object_id":         {"type": "keyword"},
+      "activity_name":     {"type": "keyword"},
+      "actor_id":          {"type": "keyword"},
+      "device_id":         {"type": "keyword"},
+      "country_code":      {"type": "keyword"},
+      "tags":              {"type": "keyword"},
+      "external_id":       {"type": "keyword"},
+      "formatted_message": {"type": "text"},
+      "created_at":        {"type": "date_nanos"}
+    }
+  }
+}`
+
+// groupFields maps each grouping onto the field it aggregates
+var groupFields = map[repository.GroupBy]string{
+	repository.GroupByActivityName: "activity_name",
+	repository.GroupByActor:        "actor_id",
+	repository.GroupByDay:          "created_at",
+}
+
+type ElasticConfig struct {
+	URL      string
+	Username string
+	Password string
+	Index    string
+	Timeout  time.Duration
+}
+
+// ElasticIndex talks to the REST API directly; only endpoints Elasticsearch
+// and OpenSearch share are used
+type ElasticIndex struct {
+	baseURL  string
+	username string
+	password string
+	index    string
+	client   *http.Client
+}
+
+func NewElasticIndex(cfg ElasticConfig) *ElasticIndex {
+	return &ElasticIndex{
+		baseURL:  strings.TrimRight(cfg.URL, "/"),
+		username: cfg.Username,
+		password: cfg.Password,
+		index:    cfg.Index,
+		client:   &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// do sends a request and decodes a successful JSON response into out, which
+// may be nil. Statuses listed in allowed are not treated as errors.
+func (e *ElasticIndex) do(ctx context.Context, method, path string, contentType string, body io.Reader, out interface{}, allowed ...int) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, e.baseURL+path, body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build elasticsearch request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if e.username != "" {
+		req.SetBasicAuth(e.username, e.password)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to call elasticsearch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	for _, status := range allowed {
+		if resp.StatusCode == status {
+			return resp.StatusCode, nil
+		}
+	}
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return resp.StatusCode, fmt.Errorf("elasticsearch returned status %d: %s", resp.StatusCode, detail)
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to decode elasticsearch response: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// EnsureIndex creates the index with its mapping unless it exists
+func (e *ElasticIndex) EnsureIndex(ctx context.Context) error {
+	path := "/" + url.PathEscape(e.index)
+	status, err := e.do(ctx, http.MethodHead, path, "", nil, nil, http.StatusNotFound)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusNotFound {
+		return nil
+	}
+
+	// Another replica may win the race; that is as good as creating it
+	status, err = e.do(ctx, http.MethodPut, path, "application/json", strings.NewReader(mapping), nil, http.StatusBadRequest)
+	if err != nil {
+		return fmt.Errorf("failed to create search index: %w", err)
+	}
+	if status == http.StatusBadRequest {
+		if _, err := e.do(ctx, http.MethodHead, path, "", nil, nil); err != nil {
+			return fmt.Errorf("failed to create search index: %w", err)
+		}
+	}
+	return nil
+}
+
+// Index stores activityLog under its ID, so indexing a log again replaces it
+func (e *ElasticIndex) Index(ctx context.Context, activityLog *entity.ActivityLog) error {
+	body, err := json.Marshal(activityLog)
+	if err != nil {
+		return fmt.Errorf("failed to encode activity log: %w", err)
+	}
+
+	path := "/" + url.PathEscape(e.index) + "/_doc/" + url.PathEscape(activityLog.ID.String())
+	if _, err := e.do(ctx, http.MethodPut, path, "application/json", bytes.NewReader(body), nil); err != nil {
+		return fmt.Errorf("failed to index activity log: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a log from the index; a log that was never indexed is not
+// an error
+func (e *ElasticIndex) Delete(ctx context.Context, id string) error {
+	path := "/" + url.PathEscape(e.index) + "/_doc/" + url.PathEscape(id)
+	if _, err := e.do(ctx, http.MethodDelete, path, "", nil, nil, http.StatusNotFound); err != nil {
+		return fmt.Errorf("failed to remove activity log from index: %w", err)
+	}
+	return nil
+}
+
+// bulkSize bounds the logs sent per _bulk request
+const bulkSize = 1000
+
+// IndexMany stores activityLogs like Index, bulkSize logs per request
+func (e *ElasticIndex) IndexMany(ctx context.Context, activityLogs []*entity.ActivityLog) error {
+	path := "/" + url.PathEscape(e.index) + "/_bulk"
+	for start := 0; start < len(activityLogs); start += bulkSize {
+		end := min(start+bulkSize, len(activityLogs))
+
+		var body bytes.Buffer
+		encoder := json.NewEncoder(&body)
+		for _, activityLog := range activityLogs[start:end] {
+			action := map[string]interface{}{"index": map[string]interface{}{"_id": activityLog.ID.String()}}
+			if err := encoder.Encode(action); err != nil {
+				return fmt.Errorf("failed to encode bulk action: %w", err)
+			}
+			if err := encoder.Encode(activityLog); err != nil {
+				return fmt.Errorf("failed to encode activity log: %w", err)
+			}
+		}
+
+		var response struct {
+			Errors bool `json:"errors"`
+		}
+		if _, err := e.do(ctx, http.MethodPost, path, "application/x-ndjson", &body, &response); err != nil {
+			return fmt.Errorf("failed to index activity logs: %w", err)
+		}
+		if response.Errors {
+			return fmt.Errorf("failed to index some of %d activity logs", end-start)
+		}
+	}
+	return nil
+}
+
+// DeleteOlderThan removes a company's logs created before cutoff from the
+// index
+func (e *ElasticIndex) DeleteOlderThan(ctx context.Context, companyID string, cutoff time.Time) error {
+	createdAt := map[string]interface{}{"lt": cutoff.UTC().Format(time.RFC3339Nano)}
+	return e.deleteByQuery(ctx, map[string]interface{}{
+		"bool": map[string]interface{}{"filter": []interface{}{
+			term("company_id", companyID),
+			map[string]interface{}{"range": map[string]interface{}{"created_at": createdAt}},
+		}},
+	})
+}
+
+// DeleteCompany removes every log of a company from the index
+func (e *ElasticIndex) DeleteCompany(ctx context.Context, companyID string) error {
+	return e.deleteByQuery(ctx, map[string]interface{}{
+		"bool": map[string]interface{}{"filter": []interface{}{term("company_id", companyID)}},
+	})
+}
+
+// DeleteBefore removes the logs created before cutoff from the index, except
+// those of the keep companies
+func (e *ElasticIndex) DeleteBefore(ctx context.Context, cutoff time.Time, keep []string) error {
+	createdAt := map[string]interface{}{"lt": cutoff.UTC().Format(time.RFC3339Nano)}
+	boolQuery := map[string]interface{}{"filter": []interface{}{
+		map[string]interface{}{"range": map[string]interface{}{"created_at": createdAt}},
+	}}
+	if len(keep) > 0 {
+		boolQuery["must_not"] = map[string]interface{}{"terms": map[string]interface{}{"company_id": keep}}
+	}
+	return e.deleteByQuery(ctx, map[string]interface{}{"bool": boolQuery})
+}
+
+// deleteByQuery removes the logs matching q. Logs changed while it runs are
+// skipped rather than failing the request.
+func (e *ElasticIndex) deleteByQuery(ctx context.Context, q map[string]interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"query": q})
+	if err != nil {
+		return fmt.Errorf("failed to encode delete query: %w", err)
+	}
+
+	path := "/" + url.PathEscape(e.index) + "/_delete_by_query?conflicts=proceed"
+	if _, err := e.do(ctx, http.MethodPost, path, "application/json", bytes.NewReader(body), nil); err != nil {
+		return fmt.Errorf("failed to remove activity logs from index: %w", err)
+	}
+	return nil
+}
+
+// Ping reports an error unless the cluster answers
+func (e *ElasticIndex) Ping(ctx context.Context) error {
+	_, err := e.do(ctx, http.MethodGet, "/", "", nil, nil)
+	return err
+}
+
+// query translates filter into a bool query of exact filters. Text is the
+// only scored clause: it matches all of its words rather than a substring.
+func query(filter repository.ActivityLogFilter) map[string]interface{} {
+	must := []interface{}{term("company_id", filter.CompanyID)}
+	for field, value := range map[string]string{
+		"object_id":     filter.ObjectID,
+		"activity_name": filter.ActivityName,
+		"actor_id":      filter.ActorID,
+		"device_id":     filter.DeviceID,
+		"country_code":  filter.CountryCode,
+	} {
+		if value != "" {
+			must = append(must, term(field, value))
+		}
+	}
+	for _, tag := range filter.Tags {
+		must = append(must, term("tags", tag))
+	}
+
+	createdAt := map[string]interface{}{}
+	if !filter.StartDate.IsZero() {
+		createdAt["gte"] = filter.StartDate.UTC().Format(time.RFC3339Nano)
+	}
+	if !filter.EndDate.IsZero() {
+		createdAt["lte"] = filter.EndDate.UTC().Format(time.RFC3339Nano)
+	}
+	if len(createdAt) > 0 {
+		must = append(must, map[string]interface{}{"range": map[string]interface{}{"created_at": createdAt}})
+	}
+
+	boolQuery := map[string]interface{}{"filter": must}
+	if filter.Text != "" {
+		boolQuery["must"] = map[string]interface{}{
+			"match": map[string]interface{}{
+				"formatted_message": map[string]interface{}{"query": filter.Text, "operator": "and"},
+			},
+		}
+	}
+	return map[string]interface{}{"bool": boolQuery}
+}
+
+func term(field, value string) map[string]interface{} {
+	return map[string]interface{}{"term": map[string]interface{}{field: value}}
+}
+
+type searchResponse struct {
+	Hits struct {
+		Total struct {
+			Value int `json:"value"`
+		} `json:"total"`
+		Hits []struct {
+			Source entity.ActivityLog `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+	Aggregations map[string]struct {
+		Buckets []struct {
+			Key         interface{} `json:"key"`
+			KeyAsString string      `json:"key_as_string"`
+			DocCount    int         `json:"doc_count"`
+		} `json:"buckets"`
+	} `json:"aggregations"`
+}
+
+func (e *ElasticIndex) search(ctx context.Context, body map[string]interface{}) (*searchResponse, error) {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode search: %w", err)
+	}
+
+	var response searchResponse
+	path := "/" + url.PathEscape(e.index) + "/_search"
+	if _, err := e.do(ctx, http.MethodPost, path, "application/json", bytes.NewReader(encoded), &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Search mirrors ActivityLogRepository.Search, newest first. Cursor pages use
+// search_after on (created_at, id); numbered pages use from and are capped by
+// the index's max_result_window.
+func (e *ElasticIndex) Search(ctx context.Context, filter repository.ActivityLogFilter, page repository.SearchPage) (*repository.SearchResult, error) {
+	body := map[string]interface{}{
+		"query": query(filter),
+		"sort": []interface{}{
+			map[string]interface{}{"created_at": "desc"},
+			map[string]interface{}{"id": "desc"},
+		},
+	}
+
+	numbered := page.Number > 0
+	if numbered {
+		body["from"] = (page.Number - 1) * page.Limit
+		body["size"] = page.Limit
+		body["track_total_hits"] = true
+	} else {
+		// Fetch one extra document to know whether another page follows
+		body["size"] = page.Limit + 1
+		body["track_total_hits"] = false
+		if page.After != nil {
+			body["search_after"] = []interface{}{page.After.CreatedAt.UnixNano(), page.After.ID}
+		}
+	}
+
+	response, err := e.search(ctx, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search activity logs: %w", err)
+	}
+
+	activityLogs := make([]*entity.ActivityLog, len(response.Hits.Hits))
+	for i := range response.Hits.Hits {
+		activityLogs[i] = &response.Hits.Hits[i].Source
+	}
+
+	result := &repository.SearchResult{ActivityLogs: activityLogs}
+	more := len(activityLogs) > page.Limit
+	if numbered {
+		result.Total = response.Hits.Total.Value
+		more = len(activityLogs) > 0 && (page.Number-1)*page.Limit+len(activityLogs) < result.Total
+	} else if more {
+		result.ActivityLogs = activityLogs[:page.Limit]
+	}
+	if more {
+		last := result.ActivityLogs[len(result.ActivityLogs)-1]
+		result.Next = &repository.SearchCursor{CreatedAt: last.CreatedAt, ID: last.ID.String()}
+	}
+	return result, nil
+}
+
+// CountGrouped mirrors ActivityLogRepository.CountGrouped with a terms or,
+// for days, a date histogram aggregation
+func (e *ElasticIndex) CountGrouped(ctx context.Context, filter repository.ActivityLogFilter, groupBy repository.GroupBy, limit int) ([]repository.GroupCount, error) {
+	field, ok := groupFields[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported grouping %q", groupBy)
+	}
+	size := limit
+	if size <= 0 || size > maxGroups {
+		size = maxGroups
+	}
+
+	var aggregation map[string]interface{}
+	if groupBy == repository.GroupByDay {
+		aggregation = map[string]interface{}{"date_histogram": map[string]interface{}{
+			"field":             field,
+			"calendar_interval": "day",
+			"format":            "yyyy-MM-dd",
+			"time_zone":         "UTC",
+			"min_doc_count":     1,
+		}}
+	} else {
+		aggregation = map[string]interface{}{"terms": map[string]interface{}{
+			"field": field,
+			"size":  size,
+			"order": []interface{}{
+				map[string]interface{}{"_count": "desc"},
+				map[string]interface{}{"_key": "asc"},
+			},
+		}}
+	}
+
+	response, err := e.search(ctx, map[string]interface{}{
+		"query":            query(filter),
+		"size":             0,
+		"track_total_hits": false,
+		"aggs":             map[string]interface{}{"groups": aggregation},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count activity logs by %s: %w", groupBy, err)
+	}
+
+	buckets := response.Aggregations["groups"].Buckets
+	counts := make([]repository.GroupCount, 0, len(buckets))
+	for _, bucket := range buckets {
+		key := bucket.KeyAsString
+		if key == "" {
+			key = fmt.Sprint(bucket.Key)
+		}
+		counts = append(counts, repository.GroupCount{Key: key, Count: bucket.DocCount})
+	}
+	if limit > 0 && len(counts) > limit {
+		counts = counts[:limit]
+	}
+	return counts, nil
+}
@@ -0,0 +1,832 @@
+package initialization
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/wire"
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+
+	"activity-log-service/internal/application/usecase"
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/domain/event"
+	"activity-log-service/internal/domain/repository"
+	"activity-log-service/internal/infrastructure/alerting"
+	"activity-log-service/internal/infrastructure/cache"
+	"activity-log-service/internal/infrastructure/canary"
+	"activity-log-service/internal/infrastructure/certs"
+	"activity-log-service/internal/infrastructure/config"
+	"activity-log-service/internal/infrastructure/database"
+	"activity-log-service/internal/infrastructure/email"
+	"activity-log-service/internal/infrastructure/geoip"
+	"activity-log-service/internal/infrastructure/health"
+	"activity-log-service/internal/infrastructure/messaging"
+	"activity-log-service/internal/infrastructure/metrics"
+	"activity-log-service/internal/infrastructure/objectstore"
+	"activity-log-service/internal/infrastructure/overload"
+	infraRepo "activity-log-service/internal/infrastructure/repository"
+	"activity-log-service/internal/infrastructure/sampling"
+	"activity-log-service/internal/infrastructure/search"
+	"activity-log-service/internal/infrastructure/tracing"
+)
+
+// ConfigPath is the location of the base YAML configuration file
+type ConfigPath string
+
+// ConfigProfile names the environment overlay merged over the base file
+type ConfigProfile string
+
+// CoreSet provides what every binary needs: configuration, logging, tracing
+// and the (optionally cached) repository.
+var CoreSet = wire.NewSet(
+	ProvideConfig,
+	ProvideLogger,
+	ProvideTracer,
+	ProvideArangoRepository,
+	ProvideEmbeddedRepository,
+	ProvidePartitionedRepository,
+	ProvideCompanyRepository,
+	ProvideCache,
+	ProvideSearchIndex,
+	ProvideRepository,
+	ProvideHealthChecker,
+	ProvideShedder,
+	ProvideStatsRepository,
+	ProvideAccessLogRepository,
+	ProvideExportKeyRepository,
+	ProvideJobRunRepository,
+	ProvideIndexManager,
+	ProvidePartitionManager,
+	ProvideStorageMaintainer,
+	ProvideCompanyPurger,
+)
+
+// UseCaseSet provides the activity log use case together with its optional
+// collaborators. Each optional provider returns nil when its component is
+// disabled, so binaries compose the same set regardless of configuration.
+var UseCaseSet = wire.NewSet(
+	ProvidePublisher,
+	ProvideMemoryBus,
+	ProvideEventPublisher,
+	ProvideMailer,
+	ProvideGeoIP,
+	ProvidePrivacyOptions,
+	ProvideConsistencyOptions,
+	ProvideStatsOptions,
+	ProvideSchemaOptions,
+	ProvideAccessLogOptions,
+	ProvideNotificationSampler,
+	ProvideCanary,
+	ProvideArchiveUseCase,
+	ProvideDailyExportUseCase,
+	ProvideAlerter,
+	ProvideAnomalyDetectionUseCase,
+	usecase.NewActivityLogUseCase,
+)
+
+// DependenciesSet fills Dependencies from the providers above
+var DependenciesSet = wire.NewSet(
+	CoreSet,
+	UseCaseSet,
+	wire.Struct(new(Dependencies), "Config", "Logger", "Tracer", "Repository", "Cache", "Publisher", "Mailer", "GeoIP", "UseCase", "Health", "Canary", "Shedder", "Rollups", "Indexes", "Storage", "Search", "Partitions", "Purger", "Archive", "JobRuns", "DailyExport", "Alerter", "Anomalies", "ExportKeys"),
+)
+
+// Per-binary provider sets. They differ only in which optional components
+// are mandatory for that binary.
+var (
+	HTTPSet = wire.NewSet(DependenciesSet, wire.Value(InitializationOptions{
+		RequireNATS:       true,
+		MetricsPortOffset: 1,
+	}))
+	GRPCSet = wire.NewSet(DependenciesSet, wire.Value(InitializationOptions{
+		MetricsPortOffset: 0,
+	}))
+	ConsumerSet = wire.NewSet(DependenciesSet, wire.Value(InitializationOptions{
+		MetricsPortOffset: 2,
+	}))
+	CronSet = wire.NewSet(DependenciesSet, wire.Value(InitializationOptions{
+		RequireCache:      true,
+		MetricsPortOffset: 3,
+	}))
+)
+
+func ProvideConfig(path ConfigPath, profile ConfigProfile) (*config.Config, error) {
+	if path == "" {
+		path = "configs/config.yaml"
+	}
+
+	cfg, err := config.LoadProfile(string(path), string(profile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return cfg, nil
+}
+
+func ProvideLogger(cfg *config.Config) *logrus.Logger {
+	logger := logrus.New()
+	logger.SetLevel(getLogLevel(cfg.Logger.Level))
+	if cfg.Logger.Format == "json" {
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	}
+	return logger
+}
+
+func ProvideTracer(cfg *config.Config, logger *logrus.Logger) (opentracing.Tracer, func(), error) {
+	tracer, closer, err := tracing.InitJaeger(&cfg.Jaeger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize Jaeger tracer: %w", err)
+	}
+
+	cleanup := func() {
+		if err := closer.Close(); err != nil {
+			logger.WithError(err).Error("Failed to close tracer")
+		}
+	}
+	return tracer, cleanup, nil
+}
+
+// ProvideArangoRepository returns nil when logs are kept in the embedded store
+func ProvideArangoRepository(cfg *config.Config, logger *logrus.Logger) (*database.ArangoActivityLogRepository, error) {
+	if cfg.Storage.Driver == config.StorageEmbedded {
+		return nil, nil
+	}
+
+	arangoRepo, err := database.NewArangoActivityLogRepository(
+		connectOptions(cfg),
+		cfg.Arango.Database,
+		cfg.Arango.Collection,
+		clusterOptions(cfg),
+		database.QueryOptions{
+			Annotate:        cfg.Arango.QueryAnnotations,
+			SlowThreshold:   cfg.Arango.SlowQueryThreshold,
+			Timeout:         cfg.Arango.QueryTimeout,
+			MaxResultWindow: cfg.Arango.MaxResultWindow,
+		},
+		logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ArangoDB repository: %w", err)
+	}
+	return arangoRepo, nil
+}
+
+// ProvideEmbeddedRepository opens the embedded store when it is the
+// configured driver and returns nil otherwise
+func ProvideEmbeddedRepository(cfg *config.Config, logger *logrus.Logger) (*database.EmbeddedActivityLogRepository, func(), error) {
+	switch cfg.Storage.Driver {
+	case config.StorageArango:
+		return nil, func() {}, nil
+	case config.StorageEmbedded:
+	default:
+		return nil, nil, fmt.Errorf("unknown storage driver %q, expected %s or %s", cfg.Storage.Driver, config.StorageArango, config.StorageEmbedded)
+	}
+
+	embeddedRepo, err := database.NewEmbeddedActivityLogRepository(cfg.Storage.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open embedded store: %w", err)
+	}
+
+	logger.WithField("path", cfg.Storage.Path).Warn("Using the embedded store; it is meant for local development only")
+	cleanup := func() {
+		if err := embeddedRepo.Close(); err != nil {
+			logger.WithError(err).Error("Failed to close embedded store")
+		}
+	}
+	return embeddedRepo, cleanup, nil
+}
+
+// ProvidePartitionedRepository returns nil unless logs are partitioned by
+// month
+func ProvidePartitionedRepository(cfg *config.Config, arangoRepo *database.ArangoActivityLogRepository) *database.PartitionedActivityLogRepository {
+	if arangoRepo == nil || !cfg.Arango.PartitionByMonth {
+		return nil
+	}
+	return database.NewPartitionedActivityLogRepository(arangoRepo, clusterOptions(cfg))
+}
+
+// ProvideCompanyRepository returns nil unless each company has its own
+// collection
+func ProvideCompanyRepository(cfg *config.Config, arangoRepo *database.ArangoActivityLogRepository) (*database.CompanyActivityLogRepository, error) {
+	if arangoRepo == nil || !cfg.Arango.CollectionPerCompany {
+		return nil, nil
+	}
+	if cfg.Arango.PartitionByMonth {
+		return nil, fmt.Errorf("arango.collection_per_company cannot be combined with arango.partition_by_month")
+	}
+	return database.NewCompanyActivityLogRepository(arangoRepo, clusterOptions(cfg)), nil
+}
+
+// requireArango fails for components that keep data in their own ArangoDB
+// collection when logs are kept in the embedded store
+func requireArango(arangoRepo *database.ArangoActivityLogRepository, component string) error {
+	if arangoRepo == nil {
+		return fmt.Errorf("%s requires storage driver %s", component, config.StorageArango)
+	}
+	return nil
+}
+
+func connectOptions(cfg *config.Config) database.ConnectOptions {
+	return database.ConnectOptions{
+		Endpoints:      cfg.Arango.Endpoints(),
+		Username:       cfg.Arango.Username,
+		Password:       cfg.Arango.Password,
+		MaxAttempts:    cfg.Arango.Connect.MaxAttempts,
+		InitialBackoff: cfg.Arango.Connect.InitialBackoff,
+		MaxBackoff:     cfg.Arango.Connect.MaxBackoff,
+		ReadEndpoints:  cfg.Arango.ReadURLs,
+	}
+}
+
+func clusterOptions(cfg *config.Config) database.ClusterOptions {
+	return database.ClusterOptions{
+		NumberOfShards:    cfg.Arango.Cluster.NumberOfShards,
+		ReplicationFactor: cfg.Arango.Cluster.ReplicationFactor,
+		WriteConcern:      cfg.Arango.Cluster.WriteConcern,
+		ShardKeys:         cfg.Arango.Cluster.ShardKeys,
+	}
+}
+
+// ProvideCache connects to Redis when it is configured. It returns a nil cache
+// when Redis is not configured or unreachable, unless the binary requires it.
+func ProvideCache(cfg *config.Config, logger *logrus.Logger, opts InitializationOptions) (cache.Store, func(), error) {
+	noop := func() {}
+
+	if cfg.Redis.Address == "" {
+		if opts.RequireCache {
+			return nil, nil, fmt.Errorf("Redis configuration is required but not provided")
+		}
+		return nil, noop, nil
+	}
+
+	cacheConfig, err := redisCacheConfig(cfg.Redis, cfg.Cache.Compression)
+	if err != nil {
+		return nil, nil, err
+	}
+	redisCache, err := cache.NewRedisCache(cacheConfig, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid cache config: %w", err)
+	}
+
+	if err := redisCache.Ping(context.Background()); err != nil {
+		if closeErr := redisCache.Close(); closeErr != nil {
+			logger.WithError(closeErr).Error("Failed to close Redis cache")
+		}
+		if opts.RequireCache {
+			return nil, nil, fmt.Errorf("failed to connect to Redis cache: %w", err)
+		}
+		logger.WithError(err).Warn("Failed to connect to Redis cache, using direct repository")
+		return nil, noop, nil
+	}
+
+	logger.Info("Redis cache enabled")
+	cleanup := func() {
+		if err := redisCache.Close(); err != nil {
+			logger.WithError(err).Error("Failed to close Redis cache")
+		}
+	}
+	return redisCache, cleanup, nil
+}
+
+// redisCacheConfig fails on unreadable TLS files rather than falling back to
+// uncached reads, since those point at a broken deployment
+func redisCacheConfig(cfg config.RedisConfig, compression config.CacheCompressionConfig) (cache.CacheConfig, error) {
+	cacheConfig := cache.CacheConfig{
+		Address:         cfg.Address,
+		Username:        cfg.Username,
+		Password:        cfg.Password,
+		DB:              cfg.DB,
+		Compression:     compression.Codec,
+		CompressMinSize: compression.MinSize,
+	}
+	if cfg.TLS.Enabled {
+		tlsConfig, err := certs.ClientConfig(cfg.TLS.CAFile, cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.InsecureSkipVerify)
+		if err != nil {
+			return cache.CacheConfig{}, fmt.Errorf("invalid Redis TLS config: %w", err)
+		}
+		cacheConfig.TLS = tlsConfig
+	}
+	return cacheConfig, nil
+}
+
+// ProvideSearchIndex returns nil when the search index is disabled
+func ProvideSearchIndex(cfg *config.Config) *search.ElasticIndex {
+	if !cfg.Search.Enabled {
+		return nil
+	}
+	return search.NewElasticIndex(search.ElasticConfig{
+		URL:      cfg.Search.URL,
+		Username: cfg.Search.Username,
+		Password: cfg.Search.Password,
+		Index:    cfg.Search.Index,
+		Timeout:  cfg.Search.Timeout,
+	})
+}
+
+// ProvideRepository times the configured store's operations and layers the
+// optional append-only guard, search index and cache over it. The cache is
+// outermost so cached reads skip the index too.
+func ProvideRepository(
+	cfg *config.Config,
+	arangoRepo *database.ArangoActivityLogRepository,
+	embeddedRepo *database.EmbeddedActivityLogRepository,
+	partitionedRepo *database.PartitionedActivityLogRepository,
+	companyRepo *database.CompanyActivityLogRepository,
+	redisCache cache.Store,
+	searchIndex *search.ElasticIndex,
+	logger *logrus.Logger,
+) repository.ActivityLogRepository {
+	var repo repository.ActivityLogRepository = arangoRepo
+	record := metrics.RecordArangoDBOperationDuration
+	switch {
+	case embeddedRepo != nil:
+		repo = embeddedRepo
+		record = metrics.RecordJSONFileOperationDuration
+	case partitionedRepo != nil:
+		repo = partitionedRepo
+	case companyRepo != nil:
+		repo = companyRepo
+	}
+	repo = infraRepo.NewTimedActivityLogRepository(repo, record)
+	if cfg.Storage.AppendOnly {
+		repo = infraRepo.NewAppendOnlyActivityLogRepository(repo)
+	}
+	if searchIndex != nil {
+		repo = infraRepo.NewIndexedActivityLogRepository(repo, searchIndex, logger)
+	}
+	if redisCache == nil {
+		return repo
+	}
+	return infraRepo.NewCachedActivityLogRepository(repo, redisCache, cacheOptions(cfg), logger)
+}
+
+func cacheOptions(cfg *config.Config) infraRepo.CacheOptions {
+	policy := func(p config.CachePolicyConfig) infraRepo.CachePolicy {
+		return infraRepo.CachePolicy{Enabled: p.Enabled, TTL: p.TTL}
+	}
+	return infraRepo.CacheOptions{
+		ActivityLog:  policy(cfg.Cache.ActivityLog),
+		CompanyPage:  policy(cfg.Cache.CompanyPage),
+		CompanyCount: policy(cfg.Cache.CompanyCount),
+		Query:        policy(cfg.Cache.Query),
+		Local: infraRepo.CachePolicy{
+			Enabled: cfg.Cache.Local.Enabled,
+			TTL:     cfg.Cache.Local.TTL,
+		},
+		LocalMaxEntries:       cfg.Cache.Local.MaxEntries,
+		ActiveCompaniesWindow: cfg.Cache.ActiveCompaniesWindow,
+	}
+}
+
+// ProvideStatsRepository returns nil when stats rollups are disabled
+func ProvideStatsRepository(cfg *config.Config, arangoRepo *database.ArangoActivityLogRepository) (repository.ActivityStatsRepository, error) {
+	if !cfg.Rollup.Enabled {
+		return nil, nil
+	}
+	if err := requireArango(arangoRepo, "rollup"); err != nil {
+		return nil, err
+	}
+
+	statsRepo, err := database.NewArangoActivityStatsRepository(arangoRepo, cfg.Rollup.Collection, clusterOptions(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stats repository: %w", err)
+	}
+	return statsRepo, nil
+}
+
+func ProvideIndexManager(
+	arangoRepo *database.ArangoActivityLogRepository,
+	embeddedRepo *database.EmbeddedActivityLogRepository,
+	partitionedRepo *database.PartitionedActivityLogRepository,
+	companyRepo *database.CompanyActivityLogRepository,
+) repository.IndexManager {
+	switch {
+	case embeddedRepo != nil:
+		return embeddedRepo
+	case partitionedRepo != nil:
+		return partitionedRepo
+	case companyRepo != nil:
+		return companyRepo
+	}
+	return arangoRepo
+}
+
+// ProvideStorageMaintainer returns nil for the embedded store
+func ProvideStorageMaintainer(arangoRepo *database.ArangoActivityLogRepository) repository.StorageMaintainer {
+	if arangoRepo == nil {
+		return nil
+	}
+	return arangoRepo
+}
+
+// ProvideCompanyPurger returns nil unless each company has its own collection
+func ProvideCompanyPurger(companyRepo *database.CompanyActivityLogRepository, redisCache cache.Store, searchIndex *search.ElasticIndex, logger *logrus.Logger) repository.CompanyPurger {
+	if companyRepo == nil {
+		return nil
+	}
+	var purger repository.CompanyPurger = companyRepo
+	if searchIndex != nil {
+		purger = infraRepo.NewIndexedCompanyPurger(purger, searchIndex, logger)
+	}
+	if redisCache != nil {
+		purger = infraRepo.NewCachedCompanyPurger(purger, companyRepo, redisCache)
+	}
+	return purger
+}
+
+// ProvidePartitionManager returns nil unless logs are partitioned by month
+func ProvidePartitionManager(partitionedRepo *database.PartitionedActivityLogRepository, searchIndex *search.ElasticIndex, logger *logrus.Logger) repository.PartitionManager {
+	if partitionedRepo == nil {
+		return nil
+	}
+	if searchIndex != nil {
+		return infraRepo.NewIndexedPartitionManager(partitionedRepo, searchIndex, logger)
+	}
+	return partitionedRepo
+}
+
+// ProvideAccessLogRepository returns nil when the access log is disabled
+func ProvideAccessLogRepository(cfg *config.Config, arangoRepo *database.ArangoActivityLogRepository) (repository.AccessLogRepository, error) {
+	if !cfg.AccessLog.Enabled {
+		return nil, nil
+	}
+	if err := requireArango(arangoRepo, "access_log"); err != nil {
+		return nil, err
+	}
+
+	accessLogRepo, err := database.NewArangoAccessLogRepository(arangoRepo, cfg.AccessLog.Collection, cfg.AccessLog.Retention, clusterOptions(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create access log repository: %w", err)
+	}
+	return accessLogRepo, nil
+}
+
+// ProvideJobRunRepository returns nil when the job history is disabled
+func ProvideJobRunRepository(cfg *config.Config, arangoRepo *database.ArangoActivityLogRepository) (repository.JobRunRepository, error) {
+	if !cfg.JobHistory.Enabled {
+		return nil, nil
+	}
+	if err := requireArango(arangoRepo, "job_history"); err != nil {
+		return nil, err
+	}
+
+	jobRunRepo, err := database.NewArangoJobRunRepository(arangoRepo, cfg.JobHistory.Collection, cfg.JobHistory.Retention, clusterOptions(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job run repository: %w", err)
+	}
+	return jobRunRepo, nil
+}
+
+// ProvideExportKeyRepository returns nil when export encryption is disabled
+func ProvideExportKeyRepository(cfg *config.Config, arangoRepo *database.ArangoActivityLogRepository) (repository.ExportKeyRepository, error) {
+	if !cfg.ExportKeys.Enabled {
+		return nil, nil
+	}
+	if err := requireArango(arangoRepo, "export_keys"); err != nil {
+		return nil, err
+	}
+
+	exportKeyRepo, err := database.NewArangoExportKeyRepository(arangoRepo, cfg.ExportKeys.Collection, clusterOptions(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create export key repository: %w", err)
+	}
+	return exportKeyRepo, nil
+}
+
+// ProvideArchiveUseCase returns nil when archiving is disabled
+func ProvideArchiveUseCase(cfg *config.Config, arangoRepo *database.ArangoActivityLogRepository, repo repository.ActivityLogRepository, exportKeys repository.ExportKeyRepository) (*usecase.ArchiveUseCase, error) {
+	if !cfg.Archive.Enabled {
+		return nil, nil
+	}
+	if err := requireArango(arangoRepo, "archive"); err != nil {
+		return nil, err
+	}
+
+	store, err := objectStore(cfg.Archive.Store, cfg.Archive.Path, cfg.Archive.S3)
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive store: %w", err)
+	}
+
+	manifests, err := database.NewArangoArchiveRepository(arangoRepo, cfg.Archive.Collection, clusterOptions(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive repository: %w", err)
+	}
+
+	return usecase.NewArchiveUseCase(repo, manifests, exportKeys, store, usecase.ArchiveOptions{
+		After:            cfg.Archive.After,
+		MaxLogsPerObject: cfg.Archive.MaxLogsPerObject,
+		Prefix:           cfg.Archive.Prefix,
+	}), nil
+}
+
+// ProvideDailyExportUseCase returns nil when the daily export is disabled
+func ProvideDailyExportUseCase(cfg *config.Config, arangoRepo *database.ArangoActivityLogRepository, repo repository.ActivityLogRepository, exportKeys repository.ExportKeyRepository) (*usecase.DailyExportUseCase, error) {
+	if !cfg.DailyExport.Enabled {
+		return nil, nil
+	}
+	if err := requireArango(arangoRepo, "daily_export"); err != nil {
+		return nil, err
+	}
+
+	store, err := objectStore(cfg.DailyExport.Store, cfg.DailyExport.Path, cfg.DailyExport.S3)
+	if err != nil {
+		return nil, fmt.Errorf("invalid daily export store: %w", err)
+	}
+
+	manifests, err := database.NewArangoDailyExportRepository(arangoRepo, cfg.DailyExport.Collection, clusterOptions(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create daily export repository: %w", err)
+	}
+
+	return usecase.NewDailyExportUseCase(repo, manifests, exportKeys, store, usecase.DailyExportOptions{
+		MaxLogsPerObject: cfg.DailyExport.MaxLogsPerObject,
+		MaxAttempts:      cfg.DailyExport.MaxAttempts,
+		Prefix:           cfg.DailyExport.Prefix,
+	}), nil
+}
+
+// ProvideAlerter returns nil when no alert channel is configured
+func ProvideAlerter(cfg *config.Config, mailer *email.Mailer, logger *logrus.Logger) (*alerting.Alerter, error) {
+	if !cfg.Alerting.Configured() {
+		return nil, nil
+	}
+	if len(cfg.Alerting.EmailRecipients) > 0 && mailer == nil {
+		return nil, fmt.Errorf("alerting.email_recipients requires email.enabled")
+	}
+
+	return alerting.NewAlerter(alerting.Config{
+		EmailRecipients: cfg.Alerting.EmailRecipients,
+		WebhookURL:      cfg.Alerting.WebhookURL,
+		SlackWebhookURL: cfg.Alerting.SlackWebhookURL,
+		Timeout:         cfg.Alerting.Timeout,
+	}, mailer, logger), nil
+}
+
+// ProvideAnomalyDetectionUseCase returns nil when anomaly detection is
+// disabled
+func ProvideAnomalyDetectionUseCase(cfg *config.Config, repo repository.ActivityLogRepository) (*usecase.AnomalyDetectionUseCase, error) {
+	if !cfg.Anomaly.Enabled {
+		return nil, nil
+	}
+	if !cfg.Alerting.Configured() {
+		return nil, fmt.Errorf("anomaly_detection requires an alerting channel")
+	}
+	if cfg.Anomaly.Baseline < time.Hour {
+		return nil, fmt.Errorf("anomaly_detection.baseline must be at least an hour")
+	}
+
+	return usecase.NewAnomalyDetectionUseCase(repo, usecase.AnomalyOptions{
+		Baseline:    cfg.Anomaly.Baseline,
+		SpikeFactor: cfg.Anomaly.SpikeFactor,
+		MinSpike:    cfg.Anomaly.MinSpike,
+		MinBaseline: cfg.Anomaly.MinBaseline,
+	}), nil
+}
+
+// objectStore opens the S3 bucket or the local directory an archive or
+// export writes to
+func objectStore(kind, path string, s3 config.ArchiveS3Config) (usecase.ObjectStore, error) {
+	switch kind {
+	case config.ArchiveStoreS3:
+		return objectstore.NewS3Store(objectstore.S3Config{
+			Endpoint:        s3.Endpoint,
+			Region:          s3.Region,
+			Bucket:          s3.Bucket,
+			AccessKeyID:     s3.AccessKeyID,
+			SecretAccessKey: s3.SecretAccessKey,
+			Timeout:         s3.Timeout,
+		}), nil
+	case config.ArchiveStoreDir:
+		return objectstore.NewDirStore(path), nil
+	}
+	return nil, fmt.Errorf("unknown store %q, expected %s or %s", kind, config.ArchiveStoreS3, config.ArchiveStoreDir)
+}
+
+// ProvideHealthChecker registers a check for every external dependency the
+// binary was wired with; disabled optional components are skipped.
+func ProvideHealthChecker(
+	cfg *config.Config,
+	arangoRepo *database.ArangoActivityLogRepository,
+	redisCache cache.Store,
+	publisher *messaging.NATSPublisher,
+) *health.Checker {
+	checker := health.NewChecker(cfg.Server.HealthCheckTimeout)
+	if arangoRepo != nil {
+		checker.Register("arangodb", arangoRepo.Ping)
+	}
+	if redisCache != nil {
+		checker.Register("redis", redisCache.Ping)
+	}
+	if publisher != nil {
+		checker.Register("nats", publisher.Ping)
+	}
+	return checker
+}
+
+// ProvideShedder returns nil when overload protection is disabled
+func ProvideShedder(cfg *config.Config) *overload.Shedder {
+	if !cfg.Overload.Enabled {
+		return nil
+	}
+
+	return overload.NewShedder(overload.Config{
+		P99Threshold: cfg.Overload.P99Threshold,
+		MaxInFlight:  cfg.Overload.MaxInFlight,
+		Window:       cfg.Overload.Window,
+		RetryAfter:   cfg.Overload.RetryAfter,
+	})
+}
+
+func ProvidePublisher(cfg *config.Config, logger *logrus.Logger, opts InitializationOptions) (*messaging.NATSPublisher, func(), error) {
+	noop := func() {}
+
+	if cfg.NATS.Driver == config.BusMemory {
+		return nil, noop, nil
+	}
+	if cfg.NATS.URL == "" {
+		// The embedded store is for running a binary on its own
+		if opts.RequireNATS && cfg.Storage.Driver != config.StorageEmbedded {
+			return nil, nil, fmt.Errorf("NATS configuration is required but not provided")
+		}
+		return nil, noop, nil
+	}
+
+	payloadPolicy, err := event.ParsePayloadPolicy(cfg.NATS.PayloadPolicy)
+	if err != nil {
+		return nil, nil, err
+	}
+	encoding, err := messaging.ParseEventEncoding(cfg.NATS.EventEncoding)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	publisher, err := messaging.NewNATSPublisher(cfg.NATS, messaging.EventSubjects{
+		Created: cfg.NATS.Subject,
+		Updated: cfg.NATS.UpdatedSubject,
+		Deleted: cfg.NATS.DeletedSubject,
+	}, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create NATS publisher: %w", err)
+	}
+	publisher.SetPayloadPolicy(payloadPolicy, cfg.NATS.MaxPayloadBytes)
+	publisher.SetEventEncoding(encoding, cfg.NATS.EventSource)
+
+	// Ensure NATS stream exists
+	if err := publisher.EnsureStream(cfg.NATS.Stream); err != nil {
+		publisher.Close()
+		return nil, nil, fmt.Errorf("failed to ensure NATS stream: %w", err)
+	}
+
+	cleanup := func() {
+		if err := publisher.Close(); err != nil {
+			logger.WithError(err).Error("Failed to close NATS publisher")
+		}
+	}
+	return publisher, cleanup, nil
+}
+
+func ProvideMailer(cfg *config.Config, logger *logrus.Logger, opts InitializationOptions) (*email.Mailer, error) {
+	if !cfg.Email.Enabled {
+		if opts.RequireEmail {
+			return nil, fmt.Errorf("email service is required but not enabled in config")
+		}
+		return nil, nil
+	}
+
+	mailer, err := email.NewMailer(email.EmailConfig{
+		Host:           cfg.Email.Host,
+		Port:           cfg.Email.Port,
+		Username:       cfg.Email.Username,
+		Password:       cfg.Email.Password,
+		From:           cfg.Email.From,
+		TrackCompanies: cfg.Metrics.NotificationCompanyGauge,
+		TemplatesDir:   cfg.Email.TemplatesDir,
+		ReloadInterval: cfg.Email.TemplatesReloadInterval,
+	}, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load email templates: %w", err)
+	}
+	logger.Info("Email service enabled")
+	return mailer, nil
+}
+
+// ProvideMemoryBus returns nil unless nats.driver is memory. The bus stores
+// the logs of created events into repo, like the consumer would.
+func ProvideMemoryBus(cfg *config.Config, repo repository.ActivityLogRepository, searchIndex *search.ElasticIndex, logger *logrus.Logger) (*messaging.MemoryBus, func()) {
+	if cfg.NATS.Driver != config.BusMemory {
+		return nil, func() {}
+	}
+
+	bus := messaging.NewMemoryBus(repo, cfg.Consumer.Workers, cfg.Consumer.QueueSize, cfg.Consumer.JobTimeout, logger)
+	if searchIndex != nil {
+		bus.SetIndexer(searchIndex)
+	}
+	logger.Info("Publishing events to the in-process bus")
+	return bus, bus.Stop
+}
+
+// ProvideEventPublisher hands the use case the in-process bus or the NATS
+// publisher, or no publisher at all when neither is configured. A nil
+// pointer must not reach it wrapped in a non-nil interface.
+func ProvideEventPublisher(publisher *messaging.NATSPublisher, bus *messaging.MemoryBus) event.Publisher {
+	if bus != nil {
+		return bus
+	}
+	if publisher == nil {
+		return nil
+	}
+	return publisher
+}
+
+// ProvideGeoIP returns a nil resolver when enrichment is disabled or fails to
+// initialize; enrichment is best effort and never blocks startup.
+func ProvideGeoIP(cfg *config.Config, redisCache cache.Store, privacy usecase.PrivacyOptions, logger *logrus.Logger) geoip.Resolver {
+	if !cfg.GeoIP.Enabled {
+		return nil
+	}
+
+	resolver, err := geoip.NewResolver(geoip.Config{
+		Provider:     cfg.GeoIP.Provider,
+		DatabasePath: cfg.GeoIP.DatabasePath,
+		APIURL:       cfg.GeoIP.APIURL,
+		CacheTTL:     cfg.GeoIP.CacheTTL,
+		HashIP:       privacy.Hash,
+	}, redisCache, logger)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to initialize GeoIP resolver, enrichment disabled")
+		return nil
+	}
+
+	logger.WithField("provider", cfg.GeoIP.Provider).Info("GeoIP enrichment enabled")
+	return resolver
+}
+
+func ProvidePrivacyOptions(cfg *config.Config) usecase.PrivacyOptions {
+	return usecase.PrivacyOptions{
+		HashIPAddress: cfg.Privacy.HashIPAddress,
+		HashUserAgent: cfg.Privacy.HashUserAgent,
+		HashDeviceID:  cfg.Privacy.HashDeviceID,
+		HashSalt:      cfg.Privacy.HashSalt,
+	}
+}
+
+func ProvideConsistencyOptions(cfg *config.Config) usecase.ConsistencyOptions {
+	return usecase.ConsistencyOptions{
+		QueueOnDBFailure:   cfg.NATS.QueueOnDBFailure,
+		Stream:             cfg.NATS.Stream,
+		Durable:            cfg.NATS.Durable,
+		SessionWaitTimeout: cfg.NATS.SessionWaitTimeout,
+	}
+}
+
+// ProvideSchemaOptions fails on a withheld field that is not registered, so a
+// typo cannot silently leave a field enabled
+func ProvideSchemaOptions(cfg *config.Config) (usecase.SchemaOptions, error) {
+	withheld, err := entity.ParseWithheldFields(cfg.Schema.WithheldFields)
+	if err != nil {
+		return usecase.SchemaOptions{}, fmt.Errorf("invalid schema config: %w", err)
+	}
+	return usecase.SchemaOptions{Withheld: withheld}, nil
+}
+
+func ProvideAccessLogOptions(cfg *config.Config) usecase.AccessLogOptions {
+	readers := make(map[string][]string, len(cfg.AccessLog.Readers))
+	for _, reader := range cfg.AccessLog.Readers {
+		readers[reader.Principal] = append(readers[reader.Principal], reader.Companies...)
+	}
+	return usecase.AccessLogOptions{Readers: readers}
+}
+
+// ProvideNotificationSampler picks the logs that send an email notification.
+// Canary traffic never does.
+func ProvideNotificationSampler(cfg *config.Config) sampling.Sampler {
+	var sampler sampling.Sampler = sampling.NewHashSampler(cfg.Sampling.Seed, cfg.Sampling.NotificationRate)
+	if cfg.Canary.Enabled {
+		sampler = sampling.Exclude(sampler, cfg.Canary.CompanyID)
+	}
+	return sampler
+}
+
+func ProvideStatsOptions(cfg *config.Config) usecase.StatsOptions {
+	return usecase.StatsOptions{
+		RawWindow: cfg.Rollup.RawWindow,
+	}
+}
+
+// ProvideCanary returns nil unless the synthetic canary is enabled. The
+// consumer stage is only checked when a NATS publisher is available.
+func ProvideCanary(cfg *config.Config, publisher *messaging.NATSPublisher, logger *logrus.Logger) *canary.Canary {
+	if !cfg.Canary.Enabled {
+		return nil
+	}
+
+	return canary.NewCanary(canary.Config{
+		BaseURL:     cfg.Canary.BaseURL,
+		CompanyID:   cfg.Canary.CompanyID,
+		ReadSLO:     cfg.Canary.ReadSLO,
+		ConsumerSLO: cfg.Canary.ConsumerSLO,
+		Stream:      cfg.NATS.Stream,
+		Durable:     cfg.NATS.Durable,
+	}, publisher, logger)
+}
@@ -0,0 +1,27 @@
+//go:build wireinject
+
+package initialization
+
+import (
+	"github.com/google/wire"
+)
+
+func initializeHTTP(path ConfigPath, profile ConfigProfile) (*Dependencies, func(), error) {
+	wire.Build(HTTPSet)
+	return nil, nil, nil
+}
+
+func initializeGRPC(path ConfigPath, profile ConfigProfile) (*Dependencies, func(), error) {
+	wire.Build(GRPCSet)
+	return nil, nil, nil
+}
+
+func initializeConsumer(path ConfigPath, profile ConfigProfile) (*Dependencies, func(), error) {
+	wire.Build(ConsumerSet)
+	return nil, nil, nil
+}
+
+func initializeCron(path ConfigPath, profile ConfigProfile) (*Dependencies, func(), error) {
+	wire.Build(CronSet)
+	return nil, nil, nil
+}
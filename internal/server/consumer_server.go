@@ -10,45 +10,81 @@ import (
 	"activity-log-service/internal/domain/repository"
 	"activity-log-service/internal/infrastructure/config"
 	"activity-log-service/internal/infrastructure/messaging"
+	"activity-log-service/internal/infrastructure/search"
 )
 
 type ConsumerServer struct {
-	consumer   *messaging.NATSConsumer
-	arangoRepo repository.ActivityLogRepository
-	config     *config.Config
-	logger     *logrus.Logger
-	tracer     opentracing.Tracer
+	consumer    *messaging.NATSConsumer
+	arangoRepo  repository.ActivityLogRepository
+	searchIndex *search.ElasticIndex
+	config      *config.Config
+	logger      *logrus.Logger
+	tracer      opentracing.Tracer
 }
 
+// NewConsumerServer builds the consumer. searchIndex is nil unless the search
+// index is enabled; when set, every stored log is indexed before it is acked.
 func NewConsumerServer(
 	arangoRepo repository.ActivityLogRepository,
+	searchIndex *search.ElasticIndex,
 	config *config.Config,
 	logger *logrus.Logger,
 	tracer opentracing.Tracer,
 ) (*ConsumerServer, error) {
+	if config.NATS.Driver == "memory" {
+		return nil, fmt.Errorf("nats.driver is memory: events are consumed in the process that publishes them")
+	}
+
 	consumer, err := messaging.NewNATSConsumer(
-		config.NATS.URL,
+		config.NATS,
 		logger,
 		arangoRepo,
-		4, // Number of workers
+		messaging.ConsumerOptions{
+			Subject:             config.NATS.Subject,
+			Durable:             config.NATS.Durable,
+			Workers:             config.Consumer.Workers,
+			QueueSize:           config.Consumer.QueueSize,
+			JobTimeout:          config.Consumer.JobTimeout,
+			Pull:                config.Consumer.Mode == "pull",
+			BatchSize:           config.Consumer.BatchSize,
+			FetchWait:           config.Consumer.FetchWait,
+			RetryInitialBackoff: config.Consumer.Retry.InitialBackoff,
+			RetryMaxBackoff:     config.Consumer.Retry.MaxBackoff,
+			MaxAckPending:       config.Consumer.MaxAckPending,
+			DrainTimeout:        config.Consumer.DrainTimeout,
+			LagInterval:         config.Consumer.LagInterval,
+		},
 		tracer,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create NATS consumer: %w", err)
 	}
+	if searchIndex != nil {
+		consumer.SetIndexer(searchIndex)
+	}
+	if config.NATS.DeadLetterSubject != "" {
+		consumer.SetDeadLetter(config.NATS.DeadLetterStream, config.NATS.DeadLetterSubject, config.NATS.MaxDeliver)
+	}
 
 	return &ConsumerServer{
-		consumer:   consumer,
-		arangoRepo: arangoRepo,
-		config:     config,
-		logger:     logger,
-		tracer:     tracer,
+		consumer:    consumer,
+		arangoRepo:  arangoRepo,
+		searchIndex: searchIndex,
+		config:      config,
+		logger:      logger,
+		tracer:      tracer,
 	}, nil
 }
 
 func (s *ConsumerServer) Start(ctx context.Context) error {
 	s.logger.WithField("url", s.config.NATS.URL).Info("Starting NATS consumer")
 
+	if s.searchIndex != nil {
+		if err := s.searchIndex.EnsureIndex(ctx); err != nil {
+			return fmt.Errorf("failed to prepare search index: %w", err)
+		}
+	}
+
 	if err := s.consumer.Start(ctx); err != nil {
 		return fmt.Errorf("failed to start NATS consumer: %w", err)
 	}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	deliveryHTTP "activity-log-service/internal/delivery/http"
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/infrastructure/cache"
+	"activity-log-service/internal/infrastructure/metrics"
+)
+
+// lockTimeout bounds each Redis call taking or renewing a job's lock
+const lockTimeout = 5 * time.Second
+
+// runLocked runs job while holding its lock in the cache, so that of several
+// cron replicas only one runs each scheduled job, and returns the run. With
+// retry, failed runs are retried under the same lock as the job's retry
+// policy allows and the last run is returned. It fails only when the job did
+// not run; how the run went is in the returned JobRun. The lock is renewed
+// every third of cron.lock_ttl while the job runs and is kept for
+// cron.lock_grace after it ends, so a replica whose clock lags by less than
+// that does not run the job a second time. Without a cache the job runs
+// unlocked. Runs without retry end once ctx does.
+func (s *CronServer) runLocked(ctx context.Context, job cronJob, retry bool) (*entity.JobRun, error) {
+	run := func() *entity.JobRun {
+		if retry {
+			return s.executeWithRetry(job)
+		}
+		jobRun, _ := s.execute(ctx, job)
+		return jobRun
+	}
+
+	ttl := s.config.Cron.LockTTL
+	if s.cacheRepo == nil || ttl <= 0 {
+		return run(), nil
+	}
+
+	name := job.name
+	key := cache.BuildLockKey("cron:" + name)
+	lockCtx, cancel := context.WithTimeout(ctx, lockTimeout)
+	token, err := s.cacheRepo.Lock(lockCtx, key, ttl)
+	cancel()
+	switch {
+	case errors.Is(err, cache.ErrLockHeld):
+		metrics.RecordCronJobLock(name, "held")
+		return nil, fmt.Errorf("%w: %s", deliveryHTTP.ErrJobLocked, name)
+	case err != nil:
+		metrics.RecordCronJobLock(name, "error")
+		return nil, fmt.Errorf("failed to lock %s job: %w", name, err)
+	}
+	metrics.RecordCronJobLock(name, "acquired")
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		s.renewLock(name, key, token, ttl, done)
+	}()
+
+	jobRun := run()
+	// A renewal still in flight would undo the release
+	close(done)
+	<-stopped
+	s.releaseLock(name, key, token)
+	return jobRun, nil
+}
+
+// releaseLock lets the job's lock expire after cron.lock_grace, or drops it
+// right away without a grace period. A lock that is not released expires
+// after lock_ttl.
+func (s *CronServer) releaseLock(name, key, token string) {
+	ctx, cancel := context.WithTimeout(context.Background(), lockTimeout)
+	defer cancel()
+
+	var err error
+	if grace := s.config.Cron.LockGrace; grace > 0 {
+		err = s.cacheRepo.ExtendLock(ctx, key, token, grace)
+	} else {
+		err = s.cacheRepo.Unlock(ctx, key, token)
+	}
+	if err != nil && !errors.Is(err, cache.ErrLockLost) {
+		s.logger.WithError(err).WithField("job", name).Warn("Failed to release cron job lock")
+	}
+}
+
+// renewLock extends the job's lock until done is closed or the lock is lost
+func (s *CronServer) renewLock(name, key, token string, ttl time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), lockTimeout)
+		err := s.cacheRepo.ExtendLock(ctx, key, token, ttl)
+		cancel()
+		switch {
+		case errors.Is(err, cache.ErrLockLost):
+			metrics.RecordCronJobLock(name, "lost")
+			s.logger.WithField("job", name).Warn("Cron job lock expired while the job was running; another replica may run it too")
+			return
+		case err != nil:
+			s.logger.WithError(err).WithField("job", name).Warn("Failed to renew cron job lock")
+		}
+	}
+}
+
+// scheduled wraps job for the scheduler, skipping runs that another replica
+// already holds the lock for and retrying failed ones
+func (s *CronServer) scheduled(job cronJob) func() {
+	return func() {
+		_, err := s.runLocked(context.Background(), job, true)
+		switch {
+		case errors.Is(err, deliveryHTTP.ErrJobLocked):
+			s.logger.WithField("job", job.name).Debug("Skipping cron job, another replica is running it")
+		case err != nil:
+			s.logger.WithError(err).WithField("job", job.name).Error("Skipping cron job")
+		}
+	}
+}
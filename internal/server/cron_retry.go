@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/infrastructure/alerting"
+	"activity-log-service/internal/infrastructure/config"
+)
+
+// notifyTimeout bounds sending the alert for a job that failed for good
+const notifyTimeout = 30 * time.Second
+
+// retryPolicy returns cron.retry with the job's own non-zero retry settings
+// applied over it
+func (s *CronServer) retryPolicy(name string) config.RetryConfig {
+	policy := s.config.Cron.Retry
+	override := s.config.Cron.Jobs[name].Retry
+	if override.Attempts > 0 {
+		policy.Attempts = override.Attempts
+	}
+	if override.Backoff > 0 {
+		policy.Backoff = override.Backoff
+	}
+	if override.MaxBackoff > 0 {
+		policy.MaxBackoff = override.MaxBackoff
+	}
+	if override.Jitter > 0 {
+		policy.Jitter = override.Jitter
+	}
+	return policy
+}
+
+// retryDelay is the wait after the given failed attempt, counting from 1
+func retryDelay(policy config.RetryConfig, attempt int) time.Duration {
+	delay := policy.Backoff
+	for i := 1; i < attempt && (policy.MaxBackoff <= 0 || delay < policy.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if policy.MaxBackoff > 0 && delay > policy.MaxBackoff {
+		delay = policy.MaxBackoff
+	}
+	if policy.Jitter > 0 {
+		delay += time.Duration((rand.Float64()*2 - 1) * policy.Jitter * float64(delay))
+	}
+	return delay
+}
+
+// executeWithRetry runs job until it succeeds, panics or has used up the
+// attempts of its retry policy, and returns the last run. Waiting for a
+// retry ends early when the cron server stops. A job that still failed is
+// reported through the alerter when cron.notify_failures is set.
+func (s *CronServer) executeWithRetry(job cronJob) *entity.JobRun {
+	policy := s.retryPolicy(job.name)
+
+	for attempt := 1; ; attempt++ {
+		run, err := s.execute(context.Background(), job)
+		if err == nil {
+			return run
+		}
+		if attempt >= policy.Attempts || errors.Is(err, errJobPanicked) {
+			s.notifyFailure(run, attempt)
+			return run
+		}
+
+		delay := retryDelay(policy, attempt)
+		s.logger.WithFields(logrus.Fields{
+			"job":     job.name,
+			"attempt": attempt,
+			"delay":   delay,
+		}).Warn("Retrying cron job")
+
+		select {
+		case <-time.After(delay):
+		case <-s.stopping:
+			s.logger.WithField("job", job.name).Info("Cron server stopping, not retrying cron job")
+			return run
+		}
+	}
+}
+
+// notifyFailure alerts that run was the last attempt of its job and failed
+func (s *CronServer) notifyFailure(run *entity.JobRun, attempts int) {
+	if s.alerter == nil || !s.config.Cron.NotifyFailures {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+	err := s.alerter.Send(ctx, alerting.Alert{
+		Kind:    "job_failed",
+		Subject: fmt.Sprintf("Cron job %s failed", run.Job),
+		Message: fmt.Sprintf("The %s job failed: %s", run.Job, run.Error),
+		Details: map[string]interface{}{
+			"job":        run.Job,
+			"attempts":   attempts,
+			"started_at": run.StartedAt,
+			"error":      run.Error,
+		},
+	})
+	if err != nil {
+		s.logger.WithError(err).WithField("job", run.Job).Error("Failed to alert on cron job failure")
+	}
+}
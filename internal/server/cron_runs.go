@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"activity-log-service/internal/domain/entity"
+	"activity-log-service/internal/infrastructure/metrics"
+)
+
+// recordTimeout bounds storing a job run in the history
+const recordTimeout = 5 * time.Second
+
+// errJobPanicked marks a run that ended in a panic
+var errJobPanicked = errors.New("job panicked")
+
+// execute runs job with its timeout, bounded by ctx as well, logs its start
+// and end, exports the run's metrics, keeps it for the status page and
+// records the run in the job history when it is enabled. A panic fails the
+// run instead of the cron process. Jobs stop at their next context check once
+// the timeout expired or ctx ended. A run that cannot be recorded is logged;
+// the job's outcome does not change. The error is the one the job failed
+// with.
+func (s *CronServer) execute(ctx context.Context, job cronJob) (*entity.JobRun, error) {
+	fields := logrus.Fields{"job": job.name, "timeout": job.timeout}
+	s.logger.WithFields(fields).Info("Cron job started")
+
+	run := &entity.JobRun{Job: job.name, StartedAt: time.Now().UTC(), Status: entity.JobRunSucceeded}
+
+	jobCtx, cancel := context.WithTimeout(ctx, job.timeout)
+	items, err := s.runSafely(jobCtx, job)
+	timedOut := errors.Is(jobCtx.Err(), context.DeadlineExceeded)
+	cancel()
+
+	run.FinishedAt = time.Now().UTC()
+	run.Duration = run.FinishedAt.Sub(run.StartedAt)
+	run.Items = items
+	fields["duration"] = run.Duration
+	fields["items"] = items
+
+	if err != nil {
+		run.Status = entity.JobRunFailed
+		run.Error = err.Error()
+
+		reason := "error"
+		switch {
+		case errors.Is(err, errJobPanicked):
+			reason = "panic"
+		case timedOut:
+			reason = "timeout"
+		}
+		metrics.RecordCronJobFailure(job.name, reason)
+		fields["reason"] = reason
+		s.logger.WithError(err).WithFields(fields).Error("Cron job failed")
+	} else {
+		s.logger.WithFields(fields).Info("Cron job finished")
+	}
+	metrics.RecordCronJobRun(job.name, string(run.Status), run.Duration, items)
+	s.rememberRun(run)
+
+	if s.jobRuns != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), recordTimeout)
+		defer cancel()
+		if err := s.jobRuns.Record(ctx, run); err != nil {
+			s.logger.WithError(err).WithFields(logrus.Fields{
+				"job":    job.name,
+				"status": run.Status,
+			}).Error("Failed to record cron job run")
+		}
+	}
+	return run, err
+}
+
+// runSafely runs job, turning a panic into an error wrapping errJobPanicked
+func (s *CronServer) runSafely(ctx context.Context, job cronJob) (items int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.WithFields(logrus.Fields{
+				"job":   job.name,
+				"stack": string(debug.Stack()),
+			}).Error("Cron job panicked")
+			err = fmt.Errorf("%w: %v", errJobPanicked, r)
+		}
+	}()
+	return job.run(ctx)
+}
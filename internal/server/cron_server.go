@@ -2,96 +2,308 @@ package server
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"net/http"
+	"sync"
 	"time"
 
+	"github.com/labstack/echo/v4"
 	"github.com/opentracing/opentracing-go"
 	"github.com/robfig/cron/v3"
 	"github.com/sirupsen/logrus"
 
+	"activity-log-service/internal/application/usecase"
+	deliveryGRPC "activity-log-service/internal/delivery/grpc"
+	deliveryHTTP "activity-log-service/internal/delivery/http"
+	"activity-log-service/internal/domain/entity"
 	"activity-log-service/internal/domain/repository"
+	"activity-log-service/internal/infrastructure/alerting"
 	"activity-log-service/internal/infrastructure/cache"
+	"activity-log-service/internal/infrastructure/canary"
 	"activity-log-service/internal/infrastructure/config"
 	"activity-log-service/internal/infrastructure/email"
+	"activity-log-service/internal/infrastructure/geoip"
+	"activity-log-service/internal/infrastructure/metrics"
 )
 
+// cronParser parses job schedules, which have a leading seconds field
+var cronParser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
 type CronServer struct {
 	cron       *cron.Cron
 	arangoRepo repository.ActivityLogRepository
-	cacheRepo  *cache.RedisCache
+	cacheRepo  cache.Store
 	mailer     *email.Mailer
+	canary     *canary.Canary
+	rollups    repository.ActivityStatsRepository
+	partitions repository.PartitionManager
+	indexes    repository.IndexManager
+	storage    repository.StorageMaintainer
+	archive    *usecase.ArchiveUseCase
+	export     *usecase.DailyExportUseCase
+	anomalies  *usecase.AnomalyDetectionUseCase
+	alerter    *alerting.Alerter
+	jobRuns    repository.JobRunRepository
+	exportKeys repository.ExportKeyRepository
 	config     *config.Config
 	logger     *logrus.Logger
 	tracer     opentracing.Tracer
+
+	// stopping is closed by Stop to cut short the waits between retries
+	stopping chan struct{}
+	stopOnce sync.Once
+
+	// statusMu guards the scheduler entries and last runs the status page
+	// shows
+	statusMu sync.Mutex
+	entries  map[string]cron.EntryID
+	lastRuns map[string]*entity.JobRun
 }
 
 func NewCronServer(
 	arangoRepo repository.ActivityLogRepository,
-	cacheRepo *cache.RedisCache,
+	cacheRepo cache.Store,
 	mailer *email.Mailer,
+	canary *canary.Canary,
+	rollups repository.ActivityStatsRepository,
+	partitions repository.PartitionManager,
+	indexes repository.IndexManager,
+	storage repository.StorageMaintainer,
+	archive *usecase.ArchiveUseCase,
+	export *usecase.DailyExportUseCase,
+	anomalies *usecase.AnomalyDetectionUseCase,
+	alerter *alerting.Alerter,
+	jobRuns repository.JobRunRepository,
+	exportKeys repository.ExportKeyRepository,
 	config *config.Config,
 	logger *logrus.Logger,
 	tracer opentracing.Tracer,
 ) *CronServer {
-	c := cron.New(cron.WithSeconds())
+	c := cron.New(cron.WithParser(cronParser))
 
 	return &CronServer{
 		cron:       c,
 		arangoRepo: arangoRepo,
 		cacheRepo:  cacheRepo,
 		mailer:     mailer,
+		canary:     canary,
+		rollups:    rollups,
+		partitions: partitions,
+		indexes:    indexes,
+		storage:    storage,
+		archive:    archive,
+		export:     export,
+		anomalies:  anomalies,
+		alerter:    alerter,
+		jobRuns:    jobRuns,
+		exportKeys: exportKeys,
 		config:     config,
 		logger:     logger,
 		tracer:     tracer,
+		stopping:   make(chan struct{}),
+		entries:    make(map[string]cron.EntryID),
+		lastRuns:   make(map[string]*entity.JobRun),
 	}
 }
 
-func (s *CronServer) Start(ctx context.Context) error {
-	s.logger.Info("Starting cron server")
+// cronJob is a named job and the schedule it runs on. run returns how many
+// items the job processed; its context expires after timeout. available is
+// false when the job's dependencies are not configured.
+type cronJob struct {
+	name      string
+	schedule  string
+	timeout   time.Duration
+	run       func(ctx context.Context) (int, error)
+	available bool
+}
 
-	// Schedule cache cleanup every 5 minutes
-	_, err := s.cron.AddFunc("0 */5 * * * *", s.cleanupExpiredCache)
-	if err != nil {
-		return fmt.Errorf("failed to schedule cache cleanup job: %w", err)
+// allJobs returns every job with the schedule it has before cron.jobs
+// overrides, whether or not it is available
+func (s *CronServer) allJobs() []cronJob {
+	// Daily summary email based on config
+	// Format: "08:00" -> "0 0 8 * * *"
+	var summarySchedule string
+	var hour, minute int
+	if _, err := fmt.Sscanf(s.config.Cron.DailySummaryTime, "%d:%d", &hour, &minute); err == nil {
+		summarySchedule = fmt.Sprintf("0 %d %d * * *", minute, hour)
 	}
 
-	// Schedule metrics collection every hour
-	_, err = s.cron.AddFunc("0 0 * * * *", s.collectMetrics)
-	if err != nil {
-		return fmt.Errorf("failed to schedule metrics collection job: %w", err)
+	geoIP := s.config.GeoIP
+
+	return []cronJob{
+		{name: "metrics_collection", timeout: 5 * time.Minute, run: s.collectMetrics, available: true},
+		{name: "database_maintenance", timeout: time.Hour, run: s.performDatabaseMaintenance, available: true},
+		{name: "log_rotation", timeout: time.Hour, run: s.rotateOldLogs, available: true},
+		{name: "cache_cleanup", timeout: 5 * time.Minute, run: s.cleanupExpiredCache, available: s.cacheRepo != nil},
+		{name: "daily_summary", schedule: summarySchedule, timeout: 10 * time.Minute, run: s.sendDailySummary, available: s.mailer != nil},
+		{name: "geoip_refresh", schedule: geoIP.RefreshSchedule, timeout: 10 * time.Minute, run: s.refreshGeoIPDatabase,
+			available: geoIP.Enabled && geoIP.Provider == "maxmind" && geoIP.DatabaseURL != ""},
+		{name: "canary", schedule: s.config.Canary.Schedule, timeout: s.config.Canary.ReadSLO + s.config.Canary.ConsumerSLO + 30*time.Second,
+			run: s.runCanary, available: s.canary != nil},
+		{name: "rollup_hourly", schedule: s.config.Rollup.HourlySchedule, timeout: 30 * time.Minute,
+			run: func(ctx context.Context) (int, error) { return s.rollUpStats(ctx, repository.GranularityHour) }, available: s.rollups != nil},
+		{name: "rollup_daily", schedule: s.config.Rollup.DailySchedule, timeout: 30 * time.Minute,
+			run: func(ctx context.Context) (int, error) { return s.rollUpStats(ctx, repository.GranularityDay) }, available: s.rollups != nil},
+		{name: "archive", schedule: s.config.Archive.Schedule, timeout: 6 * time.Hour, run: s.archiveOldLogs, available: s.archive != nil},
+		{name: "daily_export", schedule: s.config.DailyExport.Schedule, timeout: 6 * time.Hour, run: s.exportPreviousDay, available: s.export != nil},
+		{name: "cache_warming", schedule: s.config.CacheWarming.Schedule, timeout: 15 * time.Minute, run: s.warmCache,
+			available: s.config.CacheWarming.Enabled && s.cacheRepo != nil && s.rollups != nil},
+		{name: "anomaly_detection", schedule: s.config.Anomaly.Schedule, timeout: 30 * time.Minute, run: s.detectAnomalies,
+			available: s.anomalies != nil && s.alerter != nil},
 	}
+}
 
-	// Schedule database maintenance every day at 2 AM
-	_, err = s.cron.AddFunc("0 0 2 * * *", s.performDatabaseMaintenance)
-	if err != nil {
-		return fmt.Errorf("failed to schedule database maintenance job: %w", err)
+// jobs returns the available jobs not disabled under cron.jobs, with the
+// schedules and timeouts configured there
+func (s *CronServer) jobs() []cronJob {
+	var jobs []cronJob
+	for _, job := range s.allJobs() {
+		override, ok := s.config.Cron.Jobs[job.name]
+		if ok && override.Schedule != "" {
+			job.schedule = override.Schedule
+		}
+		if ok && override.Timeout > 0 {
+			job.timeout = override.Timeout
+		}
+		if !job.available || (ok && !override.Enabled) || job.schedule == "" {
+			continue
+		}
+		jobs = append(jobs, job)
 	}
+	return jobs
+}
 
-	// Schedule log rotation every day at 3 AM
-	_, err = s.cron.AddFunc("0 0 3 * * *", s.rotateOldLogs)
-	if err != nil {
-		return fmt.Errorf("failed to schedule log rotation job: %w", err)
+// validateJobs checks that every job under cron.jobs exists and that every
+// enabled job has a valid schedule running less often than cron.lock_grace,
+// which would otherwise skip runs
+func (s *CronServer) validateJobs() error {
+	known := make(map[string]bool)
+	for _, job := range s.allJobs() {
+		known[job.name] = true
 	}
 
-	// Schedule daily summary email based on config
-	if s.mailer != nil && s.config.Cron.DailySummaryTime != "" {
-		// Parse the time and create cron expression
-		// Format: "08:00" -> "0 0 8 * * *"
-		var hour, minute int
-		if _, err := fmt.Sscanf(s.config.Cron.DailySummaryTime, "%d:%d", &hour, &minute); err == nil {
-			cronExpr := fmt.Sprintf("0 %d %d * * *", minute, hour)
-			_, err = s.cron.AddFunc(cronExpr, s.sendDailySummary)
-			if err != nil {
-				return fmt.Errorf("failed to schedule daily summary job: %w", err)
+	var errs []error
+	for name := range s.config.Cron.Jobs {
+		if !known[name] {
+			errs = append(errs, fmt.Errorf("%w: %s", deliveryGRPC.ErrUnknownJob, name))
+		}
+	}
+	for _, job := range s.jobs() {
+		schedule, err := cronParser.Parse(job.schedule)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid schedule %q for %s job: %w", job.schedule, job.name, err))
+			continue
+		}
+		if grace := s.config.Cron.LockGrace; grace > 0 && s.cacheRepo != nil {
+			if interval := shortestInterval(schedule); interval <= grace {
+				errs = append(errs, fmt.Errorf("cron.lock_grace %s must be shorter than the %s job's interval %s", grace, job.name, interval))
 			}
 		}
 	}
+	return errors.Join(errs...)
+}
+
+// shortestInterval is the shortest gap between the schedule's next few runs
+func shortestInterval(schedule cron.Schedule) time.Duration {
+	const runs = 16
+	next := schedule.Next(time.Now())
+	shortest := time.Duration(0)
+	for i := 0; i < runs; i++ {
+		after := schedule.Next(next)
+		if gap := after.Sub(next); shortest == 0 || gap < shortest {
+			shortest = gap
+		}
+		next = after
+	}
+	return shortest
+}
+
+// JobNames lists the jobs enabled by the current configuration
+func (s *CronServer) JobNames() []string {
+	jobs := s.jobs()
+	names := make([]string, len(jobs))
+	for i, job := range jobs {
+		names[i] = job.name
+	}
+	return names
+}
+
+// TriggerJob runs the named job once, synchronously and outside its
+// schedule and without retries, and returns the run. The run ends at the
+// job's timeout or once ctx does, whichever comes first. Jobs disabled by
+// configuration are unknown.
+// It fails without running the job while a replica holds the job's lock.
+func (s *CronServer) TriggerJob(ctx context.Context, name string) (*entity.JobRun, error) {
+	for _, job := range s.jobs() {
+		if job.name == name {
+			s.logger.WithField("job", name).Info("Running cron job on demand")
+			return s.runLocked(ctx, job, false)
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", deliveryGRPC.ErrUnknownJob, name)
+}
+
+// RunJob is TriggerJob for the admin gRPC service; it also fails when the job
+// fails
+func (s *CronServer) RunJob(ctx context.Context, name string) error {
+	run, err := s.TriggerJob(ctx, name)
+	if err != nil {
+		return err
+	}
+	if run.Status == entity.JobRunFailed {
+		return fmt.Errorf("%s job failed: %s", name, run.Error)
+	}
+	return nil
+}
+
+func (s *CronServer) Start(ctx context.Context) error {
+	s.logger.Info("Starting cron server")
+
+	if err := s.validateJobs(); err != nil {
+		return fmt.Errorf("invalid cron job config: %w", err)
+	}
+
+	for _, job := range s.jobs() {
+		id, err := s.cron.AddFunc(job.schedule, s.scheduled(job))
+		if err != nil {
+			return fmt.Errorf("failed to schedule %s job: %w", job.name, err)
+		}
+		s.statusMu.Lock()
+		s.entries[job.name] = id
+		s.statusMu.Unlock()
+		metrics.InitCronJob(job.name)
+	}
+
+	var token string
+	if s.config.Admin.Enabled {
+		if s.config.Admin.Token == "" {
+			return fmt.Errorf("admin endpoint is enabled without an admin token")
+		}
+		token = s.config.Admin.Token
+	}
+	var statuses deliveryHTTP.JobStatusLister
+	if s.config.Cron.StatusPage {
+		statuses = s
+	}
+	var admin *echo.Echo
+	if token != "" || statuses != nil {
+		admin = deliveryHTTP.NewJobAdminServer(s, token, statuses)
+		go s.serveAdmin(admin)
+	}
 
 	s.cron.Start()
 
 	go func() {
 		<-ctx.Done()
 		s.logger.Info("Shutting down cron server")
+		if admin != nil {
+			if err := admin.Close(); err != nil {
+				s.logger.WithError(err).Error("Failed to close cron admin endpoint")
+			}
+		}
 		s.Stop()
 	}()
 
@@ -102,38 +314,59 @@ func (s *CronServer) Start(ctx context.Context) error {
 	}
 }
 
+// serveAdmin serves the job admin endpoint and status page on
+// cron.admin_port until it is closed
+func (s *CronServer) serveAdmin(admin *echo.Echo) {
+	port := s.config.Cron.AdminPort
+	s.logger.WithField("port", port).Info("Starting cron admin endpoint")
+	if err := admin.Start(fmt.Sprintf(":%d", port)); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		s.logger.WithError(err).Error("Cron admin endpoint failed")
+	}
+}
+
 func (s *CronServer) Stop() {
 	s.logger.Info("Stopping cron server")
+	s.stopOnce.Do(func() { close(s.stopping) })
 	cronCtx := s.cron.Stop()
 	<-cronCtx.Done()
 }
 
-func (s *CronServer) cleanupExpiredCache() {
+func (s *CronServer) cleanupExpiredCache(ctx context.Context) (int, error) {
 	span := s.tracer.StartSpan("cleanupExpiredCache")
 	defer span.Finish()
 
-	s.logger.Info("Running cache cleanup job")
-
-	ctx, cancel := context.WithTimeout(opentracing.ContextWithSpan(context.Background(), span), 5*time.Minute)
-	defer cancel()
+	ctx = opentracing.ContextWithSpan(ctx, span)
 
 	// Check Redis connection
 	if err := s.cacheRepo.Ping(ctx); err != nil {
 		s.logger.WithError(err).Error("Failed to ping Redis during cache cleanup")
 		span.SetTag("error", true)
 		span.SetTag("error.message", err.Error())
-		return
+		return 0, err
 	}
 
-	s.logger.Info("Cache cleanup completed successfully")
+	// Trim the companies that fell out of the active companies window
+	var trimmed int
+	if window := s.config.Cache.ActiveCompaniesWindow; window > 0 {
+		cutoff := time.Now().Add(-window)
+		removed, err := s.cacheRepo.RemoveFromSortedSetBelowScore(ctx, cache.BuildActiveCompaniesCacheKey(), float64(cutoff.Unix()))
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to trim active companies during cache cleanup")
+			span.SetTag("error", true)
+			span.SetTag("error.message", err.Error())
+			return 0, err
+		}
+		trimmed = removed
+	}
+
+	s.logger.WithField("trimmed_companies", trimmed).Info("Cache cleanup completed successfully")
+	return trimmed, nil
 }
 
-func (s *CronServer) collectMetrics() {
+func (s *CronServer) collectMetrics(ctx context.Context) (int, error) {
 	span := s.tracer.StartSpan("collectMetrics")
 	defer span.Finish()
 
-	s.logger.Info("Running metrics collection job")
-
 	// Example: Collect database statistics
 	// This could be expanded to collect various metrics about the system
 
@@ -142,83 +375,597 @@ func (s *CronServer) collectMetrics() {
 		"timestamp": time.Now(),
 		"job":       "metrics_collection",
 	}).Info("Metrics collection completed")
+	return 0, nil
 }
 
-func (s *CronServer) performDatabaseMaintenance() {
+// performDatabaseMaintenance logs and exports the size of every collection
+// and index, then reindexes and compacts when configured to. It returns how
+// many collections it reported on.
+func (s *CronServer) performDatabaseMaintenance(ctx context.Context) (int, error) {
 	span := s.tracer.StartSpan("performDatabaseMaintenance")
 	defer span.Finish()
 
-	s.logger.Info("Running database maintenance job")
+	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	// Example maintenance tasks:
-	// 1. Analyze collection statistics
-	// 2. Optimize indexes
-	// 3. Clean up old data (if retention policies exist)
+	var errs []error
+	fail := func(err error, msg string) {
+		s.logger.WithError(err).Error(msg)
+		span.SetTag("error", true)
+		errs = append(errs, err)
+	}
+
+	var stats []repository.CollectionStats
+	if s.storage != nil {
+		var err error
+		stats, err = s.storage.CollectionStats(ctx)
+		if err != nil {
+			fail(err, "Failed to collect database statistics")
+		} else {
+			s.reportCollectionStats(stats)
+		}
+	}
+
+	if s.config.Maintenance.Reindex && s.indexes != nil {
+		statuses, err := s.indexes.Reindex(ctx)
+		for _, status := range statuses {
+			if status.Action != repository.IndexUnchanged {
+				s.logger.WithFields(logrus.Fields{"index": status.Name, "action": status.Action}).Info("Reindexed activity logs")
+			}
+		}
+		if err != nil {
+			fail(err, "Failed to reindex activity logs")
+		}
+	}
+
+	if s.config.Maintenance.Compact {
+		for _, collection := range stats {
+			if err := s.storage.Compact(ctx, collection.Name); err != nil {
+				fail(err, "Failed to compact collection")
+			}
+		}
+	}
 
-	// For now, just log that maintenance ran
 	s.logger.WithFields(logrus.Fields{
-		"timestamp": time.Now(),
-		"job":       "database_maintenance",
+		"timestamp":   time.Now(),
+		"job":         "database_maintenance",
+		"collections": len(stats),
+		"reindexed":   s.config.Maintenance.Reindex,
+		"compacted":   s.config.Maintenance.Compact,
 	}).Info("Database maintenance completed")
+	return len(stats), errors.Join(errs...)
 }
 
-func (s *CronServer) rotateOldLogs() {
+// reportCollectionStats logs each collection's figures and replaces the
+// collection and index metrics with them
+func (s *CronServer) reportCollectionStats(stats []repository.CollectionStats) {
+	metrics.ResetCollectionStats()
+	for _, collection := range stats {
+		metrics.SetCollectionStats(collection.Name, collection.Documents, collection.DocumentBytes, collection.IndexBytes)
+		for _, index := range collection.Indexes {
+			metrics.SetIndexMemory(collection.Name, index.Name, index.MemoryBytes)
+		}
+		s.logger.WithFields(logrus.Fields{
+			"collection":     collection.Name,
+			"documents":      collection.Documents,
+			"document_bytes": collection.DocumentBytes,
+			"index_bytes":    collection.IndexBytes,
+			"indexes":        len(collection.Indexes),
+		}).Info("Collection statistics")
+	}
+}
+
+func (s *CronServer) rotateOldLogs(ctx context.Context) (int, error) {
 	span := s.tracer.StartSpan("rotateOldLogs")
 	defer span.Finish()
 
-	s.logger.Info("Running log rotation job")
+	policy := s.config.Retention
+	shortest := policy.Shortest()
+	if shortest <= 0 {
+		s.logger.Info("No retention configured, keeping all activity logs")
+		return 0, nil
+	}
+
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	now := time.Now().UTC()
+
+	// Whole months past every company's retention are dropped at once; the
+	// deletes below trim the months that are only partly expired. Months
+	// holding logs of a company under a legal hold are kept, and none are
+	// dropped while the holds cannot be read.
+	var dropped []string
+	var errs []error
+	if longest, finite := policy.Longest(); finite && s.partitions != nil {
+		var held []string
+		var err error
+		if s.archive != nil {
+			held, err = s.archive.HeldCompanies(ctx)
+		}
+		if err == nil {
+			dropped, err = s.partitions.DropPartitionsBefore(ctx, now.Add(-longest), held)
+		}
+		if err != nil {
+			s.logger.WithError(err).WithField("dropped", dropped).Error("Failed to drop expired partitions")
+			span.SetTag("error", true)
+			errs = append(errs, fmt.Errorf("failed to drop expired partitions: %w", err))
+		}
+		metrics.RecordRetentionDroppedPartitions(len(dropped))
+	}
+
+	// Only companies with logs past the shortest retention can have anything
+	// to delete
+	companies, err := s.arangoRepo.CompaniesWithLogsBefore(ctx, now.Add(-shortest))
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list companies for retention cleanup")
+		span.SetTag("error", true)
+		return 0, errors.Join(append(errs, fmt.Errorf("failed to list companies: %w", err))...)
+	}
+
+	var total, failed int
+	for _, companyID := range companies {
+		retention := policy.For(companyID)
+		if retention <= 0 {
+			continue
+		}
+		if s.archive != nil {
+			held, err := s.archive.OnHold(ctx, companyID)
+			if err != nil {
+				s.logger.WithError(err).WithField("company_id", companyID).Error("Failed to check legal holds")
+				span.SetTag("error", true)
+				failed++
+				continue
+			}
+			if held {
+				continue
+			}
+		}
 
-	// Example: Archive old activity logs based on retention policy
-	// This could involve:
-	// 1. Moving old logs to archive storage
-	// 2. Compressing old data
-	// 3. Updating indexes
+		removed, err := s.deleteExpired(ctx, companyID, now.Add(-retention))
+		total += removed
+		if err != nil {
+			s.logger.WithError(err).WithFields(logrus.Fields{
+				"company_id": companyID,
+				"deleted":    removed,
+			}).Error("Failed to delete expired activity logs")
+			span.SetTag("error", true)
+			failed++
+		}
+	}
 
-	// For now, just log that rotation ran
 	s.logger.WithFields(logrus.Fields{
 		"timestamp": time.Now(),
 		"job":       "log_rotation",
+		"companies": len(companies),
+		"deleted":   total,
+		"dropped":   len(dropped),
 	}).Info("Log rotation completed")
+
+	if failed > 0 {
+		errs = append(errs, fmt.Errorf("failed to delete the expired logs of %d companies", failed))
+	}
+	return total, errors.Join(errs...)
 }
 
-func (s *CronServer) sendDailySummary() {
-	span := s.tracer.StartSpan("sendDailySummary")
+// deleteExpired deletes the company's logs created before cutoff in batches
+// of retention.batch_size, logging its progress after each, and returns how
+// many it deleted
+func (s *CronServer) deleteExpired(ctx context.Context, companyID string, cutoff time.Time) (int, error) {
+	batchSize := s.config.Retention.BatchSize
+	var total int
+	for {
+		removed, err := s.arangoRepo.DeleteOlderThan(ctx, companyID, cutoff, batchSize)
+		total += removed
+		metrics.RecordRetentionDeleted(removed)
+		if err != nil {
+			return total, err
+		}
+		if batchSize <= 0 || removed < batchSize {
+			return total, nil
+		}
+		s.logger.WithFields(logrus.Fields{
+			"company_id": companyID,
+			"cutoff":     cutoff,
+			"deleted":    total,
+		}).Info("Deleting expired activity logs")
+	}
+}
+
+func (s *CronServer) archiveOldLogs(ctx context.Context) (int, error) {
+	span := s.tracer.StartSpan("archiveOldLogs")
 	defer span.Finish()
 
-	s.logger.Info("Running daily summary email job")
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	run, err := s.archive.Archive(ctx, time.Now().UTC())
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to archive some activity logs")
+		span.SetTag("error", true)
+		span.SetTag("error.message", err.Error())
+	}
+	if run == nil {
+		return 0, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"timestamp": time.Now(),
+		"job":       "archive",
+		"companies": run.Companies,
+		"held":      run.Held,
+		"objects":   run.Objects,
+		"archived":  run.Logs,
+	}).Info("Archive completed")
+	return run.Logs, err
+}
 
-	ctx, cancel := context.WithTimeout(opentracing.ContextWithSpan(context.Background(), span), 10*time.Minute)
-	defer cancel()
+// exportPreviousDay copies every active company's logs of the previous UTC
+// day to the object store and retries the days that failed before
+func (s *CronServer) exportPreviousDay(ctx context.Context) (int, error) {
+	span := s.tracer.StartSpan("exportPreviousDay")
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	run, err := s.export.Export(ctx, time.Now())
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to export some activity logs")
+		span.SetTag("error", true)
+		span.SetTag("error.message", err.Error())
+	}
+	if run == nil {
+		return 0, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"job":       "daily_export",
+		"day":       run.Day.Format("2006-01-02"),
+		"companies": run.Companies,
+		"retried":   run.Retried,
+		"failed":    run.Failed,
+		"objects":   run.Objects,
+		"exported":  run.Logs,
+	}).Info("Daily export completed")
+	return run.Logs, err
+}
+
+// warmCache reads the first listing pages and the log count of the busiest
+// companies through the cache, so the dashboards loading them next find them
+// there. Companies are ranked by their daily rollups over the lookback; a
+// company that fails is logged and the others are still warmed.
+func (s *CronServer) warmCache(ctx context.Context) (int, error) {
+	span := s.tracer.StartSpan("warmCache")
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	cfg := s.config.CacheWarming
+	if cfg.Companies <= 0 {
+		return 0, nil
+	}
+	to := repository.GranularityDay.Truncate(time.Now())
+	from := to.Add(-cfg.Lookback)
+
+	top, err := s.rollups.TopCompanies(ctx, repository.GranularityDay, from, to, cfg.Companies)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to rank companies for cache warming")
+		span.SetTag("error", true)
+		return 0, fmt.Errorf("failed to rank companies: %w", err)
+	}
+
+	var warmed, failed int
+	for _, company := range top {
+		if err := s.warmCompany(ctx, company.Key, cfg.PageSizes); err != nil {
+			s.logger.WithError(err).WithField("company_id", company.Key).Error("Failed to warm company cache")
+			span.SetTag("error", true)
+			failed++
+			continue
+		}
+		warmed++
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"job":       "cache_warming",
+		"companies": len(top),
+		"warmed":    warmed,
+		"failed":    failed,
+	}).Info("Cache warming completed")
+
+	if failed > 0 {
+		return warmed, fmt.Errorf("failed to warm the cache of %d companies", failed)
+	}
+	return warmed, nil
+}
+
+// warmCompany reads the company's first page in each size and its log count
+// through the cached repository, which stores whatever it does not hold yet
+func (s *CronServer) warmCompany(ctx context.Context, companyID string, pageSizes []int) error {
+	for _, limit := range pageSizes {
+		if _, _, err := s.arangoRepo.GetByCompanyID(ctx, companyID, 1, limit); err != nil {
+			return fmt.Errorf("failed to read first page of %d: %w", limit, err)
+		}
+	}
+	if _, err := s.arangoRepo.CountByCompanyID(ctx, companyID); err != nil {
+		return fmt.Errorf("failed to count logs: %w", err)
+	}
+	return nil
+}
+
+// detectAnomalies alerts on every company whose log volume in the last
+// complete hour was a spike or a drop, and returns how many alerts it sent
+func (s *CronServer) detectAnomalies(ctx context.Context) (int, error) {
+	span := s.tracer.StartSpan("detectAnomalies")
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	anomalies, err := s.anomalies.Detect(ctx, time.Now())
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to check some companies for volume anomalies")
+		span.SetTag("error", true)
+		span.SetTag("error.message", err.Error())
+	}
+
+	var sent, failed int
+	for _, anomaly := range anomalies {
+		fields := logrus.Fields{
+			"company_id": anomaly.CompanyID,
+			"kind":       anomaly.Kind,
+			"count":      anomaly.Count,
+			"baseline":   anomaly.Baseline,
+		}
+		s.logger.WithFields(fields).Warn("Activity volume anomaly")
+
+		alerted, alertErr := s.alertAnomaly(ctx, anomaly)
+		if alertErr != nil {
+			s.logger.WithError(alertErr).WithFields(fields).Error("Failed to alert on activity volume anomaly")
+			span.SetTag("error", true)
+			failed++
+			continue
+		}
+		if alerted {
+			sent++
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"job":       "anomaly_detection",
+		"anomalies": len(anomalies),
+		"alerted":   sent,
+		"failed":    failed,
+	}).Info("Anomaly detection completed")
+
+	if failed > 0 {
+		err = errors.Join(err, fmt.Errorf("failed to send %d anomaly alerts", failed))
+	}
+	return sent, err
+}
+
+// alertAnomaly sends the anomaly's alert unless one of the same kind was
+// sent for the company within the cooldown, which it tracks with a lock
+// released only when sending fails. Without a cache every anomaly is
+// alerted.
+func (s *CronServer) alertAnomaly(ctx context.Context, anomaly entity.VolumeAnomaly) (bool, error) {
+	var key, token string
+	if cooldown := s.config.Anomaly.Cooldown; s.cacheRepo != nil && cooldown > 0 {
+		key = cache.BuildLockKey(fmt.Sprintf("anomaly:%s:%s", anomaly.CompanyID, anomaly.Kind))
+		var err error
+		token, err = s.cacheRepo.Lock(ctx, key, cooldown)
+		switch {
+		case errors.Is(err, cache.ErrLockHeld):
+			return false, nil
+		case err != nil:
+			s.logger.WithError(err).WithField("company_id", anomaly.CompanyID).Warn("Failed to check anomaly alert cooldown, alerting anyway")
+		}
+	}
+
+	subject := fmt.Sprintf("Activity spike for %s", anomaly.CompanyID)
+	if anomaly.Kind == entity.VolumeDrop {
+		subject = fmt.Sprintf("No activity from %s", anomaly.CompanyID)
+	}
+	err := s.alerter.Send(ctx, alerting.Alert{
+		Kind:      "volume_" + string(anomaly.Kind),
+		CompanyID: anomaly.CompanyID,
+		Subject:   subject,
+		Message:   anomaly.Summary(),
+		Details: map[string]interface{}{
+			"hour_start": anomaly.HourStart,
+			"count":      anomaly.Count,
+			"baseline":   math.Round(anomaly.Baseline*10) / 10,
+		},
+	})
+	if err != nil && token != "" {
+		// Let the next run alert again
+		if unlockErr := s.cacheRepo.Unlock(ctx, key, token); unlockErr != nil {
+			s.logger.WithError(unlockErr).WithField("company_id", anomaly.CompanyID).Warn("Failed to reset anomaly alert cooldown")
+		}
+	}
+	return err == nil, err
+}
+
+// summarize aggregates the company's logs created between start and end
+func (s *CronServer) summarize(ctx context.Context, companyID string, start, end time.Time) (*email.DailySummaryData, error) {
+	summary := &email.DailySummaryData{CompanyID: companyID, TopActivity: "N/A"}
+
+	byName, err := s.arangoRepo.CountByActivityName(ctx, companyID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	top := 0
+	for _, group := range byName {
+		summary.TotalActivities += group.Count
+		if group.Count > top || (group.Count == top && group.Key < summary.TopActivity) {
+			summary.TopActivity, top = group.Key, group.Count
+		}
+	}
+
+	byActor, err := s.arangoRepo.CountByActor(ctx, companyID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	summary.UniqueUsers = len(byActor)
+	return summary, nil
+}
+
+// sendDailySummary emails each company that was active in the last 24 hours
+// and has recipients configured its own summary. A company with an export key
+// gets it only as an attachment encrypted to the key. A company that fails is
+// logged and the others are still sent.
+func (s *CronServer) sendDailySummary(ctx context.Context) (int, error) {
+	span := s.tracer.StartSpan("sendDailySummary")
+	defer span.Finish()
+
+	ctx = opentracing.ContextWithSpan(ctx, span)
 
 	if s.mailer == nil {
 		s.logger.Warn("Mailer not configured, skipping daily summary")
-		return
+		return 0, nil
+	}
+	if len(s.config.Cron.DailySummaryRecipients) == 0 {
+		s.logger.Info("No daily summary recipients configured, skipping daily summary")
+		return 0, nil
+	}
+
+	end := time.Now().UTC()
+	start := end.Add(-24 * time.Hour)
+
+	activeCompanies, err := s.arangoRepo.GetActiveCompanies(ctx, start)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get active companies for daily summary")
+		span.SetTag("error", true)
+		return 0, fmt.Errorf("failed to get active companies: %w", err)
+	}
+
+	var sent, failed int
+	for _, company := range activeCompanies {
+		recipients := s.config.Cron.SummaryRecipients(company.CompanyID)
+		if len(recipients) == 0 {
+			continue
+		}
+		fields := logrus.Fields{"company_id": company.CompanyID}
+
+		summary, err := s.summarize(ctx, company.CompanyID, start, end)
+		if err != nil {
+			s.logger.WithError(err).WithFields(fields).Error("Failed to aggregate activity for daily summary")
+			span.SetTag("error", true)
+			failed++
+			continue
+		}
+		summary.Date = end.Format("2006-01-02")
+
+		if err := s.mailSummary(ctx, recipients, summary); err != nil {
+			s.logger.WithError(err).WithFields(fields).Error("Failed to send daily summary email")
+			span.SetTag("error", true)
+			failed++
+			continue
+		}
+		sent++
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"timestamp": time.Now(),
+		"job":       "daily_summary",
+		"active":    len(activeCompanies),
+		"sent":      sent,
+		"failed":    failed,
+	}).Info("Daily summary emails sent")
+
+	if failed > 0 {
+		return sent, fmt.Errorf("failed to send the daily summary of %d companies", failed)
+	}
+	return sent, nil
+}
+
+// mailSummary sends summary in plaintext, or sealed to the company's export
+// key when it has one
+func (s *CronServer) mailSummary(ctx context.Context, recipients []string, summary *email.DailySummaryData) error {
+	report, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("failed to encode daily summary: %w", err)
+	}
+	sealed, keyID, err := usecase.SealObject(ctx, s.exportKeys, summary.CompanyID, report)
+	if err != nil {
+		return err
 	}
+	if keyID == "" {
+		return s.mailer.SendDailySummary(ctx, recipients, *summary)
+	}
+	return s.mailer.SendEncryptedDailySummary(ctx, recipients, summary.CompanyID, summary.Date, keyID, sealed)
+}
+
+func (s *CronServer) refreshGeoIPDatabase(ctx context.Context) (int, error) {
+	span := s.tracer.StartSpan("refreshGeoIPDatabase")
+	defer span.Finish()
 
-	// For now, send a basic summary
-	// In a real implementation, you would:
-	// 1. Query activity log statistics for the past day
-	// 2. Generate summary data
-	// 3. Send email to configured recipients
+	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	summaryData := map[string]interface{}{
-		"Date":            time.Now().Format("2006-01-02"),
-		"TotalActivities": 0,
-		"UniqueUsers":     0,
-		"TopActivity":     "N/A",
+	if err := geoip.DownloadDatabase(ctx, s.config.GeoIP.DatabaseURL, s.config.GeoIP.DatabasePath); err != nil {
+		s.logger.WithError(err).Error("Failed to refresh GeoIP database")
+		span.SetTag("error", true)
+		span.SetTag("error.message", err.Error())
+		return 0, err
 	}
 
-	// Example recipients (in real implementation, get from config)
-	recipients := []string{"admin@example.com"}
+	s.logger.WithFields(logrus.Fields{
+		"timestamp": time.Now(),
+		"job":       "geoip_refresh",
+		"path":      s.config.GeoIP.DatabasePath,
+	}).Info("GeoIP database refreshed")
+	return 0, nil
+}
+
+func (s *CronServer) runCanary(ctx context.Context) (int, error) {
+	span := s.tracer.StartSpan("runCanary")
+	defer span.Finish()
+
+	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	if err := s.mailer.SendDailySummary(ctx, recipients, summaryData); err != nil {
-		s.logger.WithError(err).Error("Failed to send daily summary email")
-		return
+	if err := s.canary.Run(ctx); err != nil {
+		s.logger.WithError(err).Error("Canary probe failed")
+		span.SetTag("error", true)
+		span.SetTag("error.message", err.Error())
+		return 0, err
+	}
+	return 0, nil
+}
+
+// rollUpStats rolls up every bucket of the given granularity that has aged
+// past the raw window within the catch-up window and is not complete yet
+func (s *CronServer) rollUpStats(ctx context.Context, granularity repository.Granularity) (int, error) {
+	span := s.tracer.StartSpan("rollUpStats")
+	defer span.Finish()
+	span.SetTag("granularity", string(granularity))
+
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	to := granularity.Truncate(time.Now().Add(-s.config.Rollup.RawWindow))
+	from := granularity.Truncate(to.Add(-s.config.Rollup.CatchUpWindow))
+
+	done, err := s.rollups.RolledUpBuckets(ctx, granularity, from, to)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list rolled up stats buckets")
+		span.SetTag("error", true)
+		span.SetTag("error.message", err.Error())
+		return 0, err
+	}
+	complete := make(map[time.Time]bool, len(done))
+	for _, bucket := range done {
+		complete[bucket.UTC()] = true
+	}
+
+	rolledUp := 0
+	for bucket := from; bucket.Before(to); bucket = bucket.Add(granularity.Duration()) {
+		if complete[bucket] {
+			continue
+		}
+		if err := s.rollups.Rollup(ctx, granularity, bucket); err != nil {
+			s.logger.WithError(err).WithField("bucket", bucket).Error("Failed to roll up stats")
+			span.SetTag("error", true)
+			span.SetTag("error.message", err.Error())
+			return rolledUp, err
+		}
+		rolledUp++
 	}
 
+	span.SetTag("rolled_up", rolledUp)
 	s.logger.WithFields(logrus.Fields{
-		"timestamp":  time.Now(),
-		"job":        "daily_summary",
-		"recipients": recipients,
-	}).Info("Daily summary email sent successfully")
+		"granularity": granularity,
+		"rolled_up":   rolledUp,
+	}).Info("Stats rollup completed")
+	return rolledUp, nil
 }
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+
+	"github.com/robfig/cron/v3"
+
+	deliveryHTTP "activity-log-service/internal/delivery/http"
+	"activity-log-service/internal/domain/entity"
+)
+
+// rememberRun keeps run as its job's last run for the status page
+func (s *CronServer) rememberRun(run *entity.JobRun) {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+	s.lastRuns[run.Job] = run
+}
+
+// JobStatuses lists the scheduled jobs with their next run and last run.
+// With the job history enabled the last run is the newest of any replica;
+// otherwise, or when the history cannot be read, it is the last run this
+// replica saw.
+func (s *CronServer) JobStatuses(ctx context.Context) []deliveryHTTP.JobStatus {
+	s.statusMu.Lock()
+	entries := make(map[string]cron.EntryID, len(s.entries))
+	for name, id := range s.entries {
+		entries[name] = id
+	}
+	lastRuns := make(map[string]*entity.JobRun, len(s.lastRuns))
+	for name, run := range s.lastRuns {
+		lastRuns[name] = run
+	}
+	s.statusMu.Unlock()
+
+	var statuses []deliveryHTTP.JobStatus
+	for _, job := range s.jobs() {
+		id, ok := entries[job.name]
+		if !ok {
+			continue
+		}
+		status := deliveryHTTP.JobStatus{
+			Name:     job.name,
+			Schedule: job.schedule,
+			Timeout:  job.timeout,
+			NextRun:  s.cron.Entry(id).Next,
+			LastRun:  lastRuns[job.name],
+		}
+		if s.jobRuns != nil {
+			runs, err := s.jobRuns.List(ctx, job.name, 1)
+			switch {
+			case err != nil:
+				s.logger.WithError(err).WithField("job", job.name).Warn("Failed to read cron job history for the status page")
+			case len(runs) > 0 && (status.LastRun == nil || runs[0].StartedAt.After(status.LastRun.StartedAt)):
+				status.LastRun = runs[0]
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
@@ -4,60 +4,151 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	// Registers the gzip compressor; responses are compressed for clients
+	// that send grpc-encoding: gzip
+	_ "google.golang.org/grpc/encoding/gzip"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 
 	"activity-log-service/internal/application/usecase"
 	deliveryGRPC "activity-log-service/internal/delivery/grpc"
+	"activity-log-service/internal/infrastructure/certs"
 	"activity-log-service/internal/infrastructure/config"
+	"activity-log-service/internal/infrastructure/health"
+	"activity-log-service/internal/infrastructure/overload"
 	pb "activity-log-service/pkg/proto"
 )
 
 type GRPCServer struct {
-	server   *grpc.Server
-	listener net.Listener
-	useCase  *usecase.ActivityLogUseCase
-	config   *config.Config
-	logger   *logrus.Logger
-	tracer   opentracing.Tracer
+	server        *grpc.Server
+	listener      net.Listener
+	useCase       *usecase.ActivityLogUseCase
+	healthChecker *health.Checker
+	healthServer  *grpchealth.Server
+	certReloader  *certs.Reloader
+	config        *config.Config
+	logger        *logrus.Logger
+	tracer        opentracing.Tracer
 }
 
+// NewGRPCServer serves the activity log API. admin, when set, is registered
+// alongside it and requires config.Admin.Token.
 func NewGRPCServer(
 	useCase *usecase.ActivityLogUseCase,
+	admin *deliveryGRPC.AdminServiceServer,
+	healthChecker *health.Checker,
+	shedder *overload.Shedder,
 	config *config.Config,
 	logger *logrus.Logger,
 	tracer opentracing.Tracer,
 ) (*GRPCServer, error) {
+	if admin != nil && config.Admin.Token == "" {
+		return nil, fmt.Errorf("admin service is enabled without an admin token")
+	}
+
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", config.Server.GRPCPort))
 	if err != nil {
 		return nil, fmt.Errorf("failed to listen on gRPC port: %w", err)
 	}
 
-	server := grpc.NewServer()
+	trustedProxies, err := config.Server.TrustedProxyPrefixes()
+	if err != nil {
+		lis.Close()
+		return nil, err
+	}
+	unary := deliveryGRPC.UnaryInterceptors(tracer, shedder, logger, config.Server.Timeout, trustedProxies)
+	stream := deliveryGRPC.StreamInterceptors(tracer, shedder, logger, trustedProxies)
+	if admin != nil {
+		// Check the admin token before anything else, so unauthenticated
+		// admin calls never reach load shedding, logging or the handlers
+		unary = append([]grpc.UnaryServerInterceptor{deliveryGRPC.UnaryAdminAuth(config.Admin.Token)}, unary...)
+		stream = append([]grpc.StreamServerInterceptor{deliveryGRPC.StreamAdminAuth(config.Admin.Token)}, stream...)
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+		// Recycle connections so clients rebalance across instances; in-flight
+		// calls get the request timeout to finish before the connection closes
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle:     config.Server.MaxConnectionIdle,
+			MaxConnectionAge:      config.Server.MaxConnectionAge,
+			MaxConnectionAgeGrace: config.Server.Timeout,
+		}),
+		grpc.MaxRecvMsgSize(config.Server.GRPCMaxRecvMsgSize),
+		grpc.MaxSendMsgSize(config.Server.GRPCMaxSendMsgSize),
+	}
+
+	var reloader *certs.Reloader
+	if tlsCfg := config.Server.GRPCTLS; tlsCfg.Enabled {
+		clientAuth, err := certs.ParseClientAuth(tlsCfg.ClientAuth, tlsCfg.ClientCAFile != "")
+		if err != nil {
+			lis.Close()
+			return nil, fmt.Errorf("invalid gRPC TLS config: %w", err)
+		}
+		reloader, err = certs.NewReloader(tlsCfg.CertFile, tlsCfg.KeyFile, tlsCfg.ClientCAFile, logger)
+		if err != nil {
+			lis.Close()
+			return nil, fmt.Errorf("failed to load gRPC TLS certificates: %w", err)
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(reloader.ServerConfig(clientAuth))))
+	}
+
+	server := grpc.NewServer(opts...)
 	activityLogService := deliveryGRPC.NewActivityLogServiceServer(useCase, tracer)
 
 	pb.RegisterActivityLogServiceServer(server, activityLogService)
+	if admin != nil {
+		pb.RegisterAdminServiceServer(server, admin)
+	}
+
+	// Report NOT_SERVING until the first dependency check has run
+	healthServer := grpchealth.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthServer.SetServingStatus(pb.ActivityLogService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
 	reflection.Register(server)
 
 	return &GRPCServer{
-		server:   server,
-		listener: lis,
-		useCase:  useCase,
-		config:   config,
-		logger:   logger,
-		tracer:   tracer,
+		server:        server,
+		listener:      lis,
+		useCase:       useCase,
+		healthChecker: healthChecker,
+		healthServer:  healthServer,
+		certReloader:  reloader,
+		config:        config,
+		logger:        logger,
+		tracer:        tracer,
 	}, nil
 }
 
 func (s *GRPCServer) Start(ctx context.Context) error {
-	s.logger.WithField("port", s.config.Server.GRPCPort).Info("Starting gRPC server")
+	s.logger.WithFields(logrus.Fields{
+		"port":              s.config.Server.GRPCPort,
+		"tls":               s.certReloader != nil,
+		"max_recv_msg_size": s.config.Server.GRPCMaxRecvMsgSize,
+		"max_send_msg_size": s.config.Server.GRPCMaxSendMsgSize,
+	}).Info("Starting gRPC server")
+
+	if s.certReloader != nil {
+		go s.certReloader.Watch(ctx, s.config.Server.GRPCTLS.ReloadInterval)
+	}
+
+	go s.watchHealth(ctx)
 
 	go func() {
 		<-ctx.Done()
 		s.logger.Info("Shutting down gRPC server")
+		s.healthServer.Shutdown()
 		s.server.GracefulStop()
 	}()
 
@@ -68,7 +159,57 @@ func (s *GRPCServer) Start(ctx context.Context) error {
 	return nil
 }
 
+// defaultHealthCheckInterval replaces a server.health_check_interval that is
+// not positive
+const defaultHealthCheckInterval = 10 * time.Second
+
+// watchHealth periodically runs the dependency checks and publishes the
+// result through the standard grpc.health.v1 service
+func (s *GRPCServer) watchHealth(ctx context.Context) {
+	interval := s.config.Server.HealthCheckInterval
+	if interval <= 0 {
+		s.logger.WithFields(logrus.Fields{
+			"interval": interval,
+			"default":  defaultHealthCheckInterval,
+		}).Warn("Invalid server.health_check_interval, using the default")
+		interval = defaultHealthCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.updateHealth(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *GRPCServer) updateHealth(ctx context.Context) {
+	results := s.healthChecker.Run(ctx)
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if !health.Healthy(results) {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+		for _, result := range results {
+			if !result.Healthy {
+				s.logger.WithFields(logrus.Fields{
+					"dependency": result.Name,
+					"error":      result.Error,
+				}).Warn("Dependency health check failed")
+			}
+		}
+	}
+
+	s.healthServer.SetServingStatus("", status)
+	s.healthServer.SetServingStatus(pb.ActivityLogService_ServiceDesc.ServiceName, status)
+}
+
 func (s *GRPCServer) Stop() {
 	s.logger.Info("Stopping gRPC server")
+	s.healthServer.Shutdown()
 	s.server.GracefulStop()
 }
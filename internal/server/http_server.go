@@ -10,6 +10,8 @@ import (
 	"activity-log-service/internal/application/usecase"
 	"activity-log-service/internal/delivery/http"
 	"activity-log-service/internal/infrastructure/config"
+	"activity-log-service/internal/infrastructure/health"
+	"activity-log-service/internal/infrastructure/overload"
 )
 
 type HTTPServer struct {
@@ -22,11 +24,37 @@ type HTTPServer struct {
 
 func NewHTTPServer(
 	useCase *usecase.ActivityLogUseCase,
+	healthChecker *health.Checker,
+	shedder *overload.Shedder,
 	config *config.Config,
 	logger *logrus.Logger,
 	tracer opentracing.Tracer,
-) *HTTPServer {
-	echoServer := http.NewEchoServer(useCase, tracer)
+) (*HTTPServer, error) {
+	trustedProxies, err := config.Server.TrustedProxyPrefixes()
+	if err != nil {
+		return nil, err
+	}
+	echoServer := http.NewEchoServer(useCase, shedder, tracer, trustedProxies)
+	echoServer.SetTimeouts(
+		config.Server.ReadTimeout,
+		config.Server.WriteTimeout,
+		config.Server.MaxConnectionIdle,
+		config.Server.Timeout,
+	)
+	echoServer.EnableReadiness(healthChecker)
+	if config.Admin.Enabled {
+		if config.Admin.Token == "" {
+			return nil, fmt.Errorf("admin routes are enabled without an admin token")
+		}
+		echoServer.EnableAdmin(config.Admin.Token)
+	}
+	if config.StatusPage.Enabled {
+		echoServer.EnableStatusPage(healthChecker, http.StatusPageConfig{
+			CacheTTL:  config.StatusPage.CacheTTL,
+			RateLimit: config.StatusPage.RateLimit,
+			RateBurst: config.StatusPage.RateBurst,
+		})
+	}
 
 	return &HTTPServer{
 		echoServer: echoServer,
@@ -34,7 +62,7 @@ func NewHTTPServer(
 		config:     config,
 		logger:     logger,
 		tracer:     tracer,
-	}
+	}, nil
 }
 
 func (s *HTTPServer) Start(ctx context.Context) error {
@@ -0,0 +1,200 @@
+// Package errcode is the catalog of error codes the activity log service
+// returns. Codes are stable: the same failure carries the same code in HTTP
+// problem responses and in gRPC ErrorInfo details, and clients can match on
+// them with errors.Is.
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain identifies this service in gRPC ErrorInfo details
+const Domain = "activity-log-service"
+
+// Code is a stable, documented error identifier such as ALS-1001. The
+// thousands digit groups codes: 1xxx invalid input, 2xxx missing resources,
+// 3xxx limits, 4xxx operations the deployment forbids, 5xxx server side
+// failures.
+type Code string
+
+const (
+	Validation          Code = "ALS-1001"
+	InvalidPageToken    Code = "ALS-1002"
+	InvalidSessionToken Code = "ALS-1003"
+	BatchAborted        Code = "ALS-1004"
+	NotFound            Code = "ALS-2001"
+	QuotaExceeded       Code = "ALS-3001"
+	Overloaded          Code = "ALS-3002"
+	ResultWindow        Code = "ALS-3003"
+	AppendOnly          Code = "ALS-4001"
+	Forbidden           Code = "ALS-4002"
+	Internal            Code = "ALS-5001"
+	DatabaseUnavailable Code = "ALS-5002"
+)
+
+// Definition describes how a code is surfaced on each transport
+type Definition struct {
+	Code       Code       `json:"code"`
+	Reason     string     `json:"reason"`
+	Title      string     `json:"title"`
+	HTTPStatus int        `json:"http_status"`
+	GRPCCode   codes.Code `json:"grpc_code"`
+}
+
+var catalog = map[Code]Definition{
+	Validation: {
+		Code: Validation, Reason: "VALIDATION_FAILED", Title: "Validation failed",
+		HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument,
+	},
+	InvalidPageToken: {
+		Code: InvalidPageToken, Reason: "INVALID_PAGE_TOKEN", Title: "Invalid page token or cursor",
+		HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument,
+	},
+	InvalidSessionToken: {
+		Code: InvalidSessionToken, Reason: "INVALID_SESSION_TOKEN", Title: "Invalid session token",
+		HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument,
+	},
+	BatchAborted: {
+		Code: BatchAborted, Reason: "BATCH_ABORTED", Title: "Atomic batch aborted",
+		HTTPStatus: http.StatusConflict, GRPCCode: codes.Aborted,
+	},
+	NotFound: {
+		Code: NotFound, Reason: "NOT_FOUND", Title: "Activity log not found",
+		HTTPStatus: http.StatusNotFound, GRPCCode: codes.NotFound,
+	},
+	QuotaExceeded: {
+		Code: QuotaExceeded, Reason: "QUOTA_EXCEEDED", Title: "Quota exceeded",
+		HTTPStatus: http.StatusTooManyRequests, GRPCCode: codes.ResourceExhausted,
+	},
+	Overloaded: {
+		Code: Overloaded, Reason: "OVERLOADED", Title: "Service overloaded",
+		HTTPStatus: http.StatusTooManyRequests, GRPCCode: codes.ResourceExhausted,
+	},
+	ResultWindow: {
+		Code: ResultWindow, Reason: "RESULT_WINDOW_EXCEEDED", Title: "Page beyond the result window",
+		HTTPStatus: http.StatusBadRequest, GRPCCode: codes.OutOfRange,
+	},
+	AppendOnly: {
+		Code: AppendOnly, Reason: "APPEND_ONLY", Title: "Activity logs are append-only",
+		HTTPStatus: http.StatusMethodNotAllowed, GRPCCode: codes.PermissionDenied,
+	},
+	Forbidden: {
+		Code: Forbidden, Reason: "FORBIDDEN", Title: "Access denied",
+		HTTPStatus: http.StatusForbidden, GRPCCode: codes.PermissionDenied,
+	},
+	Internal: {
+		Code: Internal, Reason: "INTERNAL", Title: "Internal error",
+		HTTPStatus: http.StatusInternalServerError, GRPCCode: codes.Internal,
+	},
+	DatabaseUnavailable: {
+		Code: DatabaseUnavailable, Reason: "DATABASE_UNAVAILABLE", Title: "Database unavailable",
+		HTTPStatus: http.StatusServiceUnavailable, GRPCCode: codes.Unavailable,
+	},
+}
+
+// Lookup returns the definition of code. Unknown codes resolve to Internal.
+func Lookup(code Code) Definition {
+	if def, ok := catalog[code]; ok {
+		return def
+	}
+	return catalog[Internal]
+}
+
+// All returns every registered definition ordered by code, for documentation
+func All() []Definition {
+	defs := make([]Definition, 0, len(catalog))
+	for _, def := range catalog {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Code < defs[j].Code })
+	return defs
+}
+
+// TypeURI is the RFC 7807 problem type for code
+func (c Code) TypeURI() string {
+	return "urn:" + Domain + ":error:" + string(c)
+}
+
+// Error is a typed error carrying a catalog code
+type Error struct {
+	Code    Code
+	Message string
+}
+
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func Newf(code Code, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// Is matches any *Error with the same code, so errors.Is(err,
+// errcode.New(errcode.NotFound, "")) works regardless of message.
+func (e *Error) Is(target error) bool {
+	var other *Error
+	if !errors.As(target, &other) {
+		return false
+	}
+	return other.Code == e.Code
+}
+
+// GRPCStatus lets grpc return *Error directly; the code travels in an
+// ErrorInfo detail.
+func (e *Error) GRPCStatus() *status.Status {
+	def := Lookup(e.Code)
+	st := status.New(def.GRPCCode, e.Message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   def.Reason,
+		Domain:   Domain,
+		Metadata: map[string]string{"code": string(def.Code)},
+	})
+	if err != nil {
+		return st
+	}
+	return detailed
+}
+
+// FromGRPC extracts the typed error from a gRPC status error. It returns
+// false when err carries no ErrorInfo from this service.
+func FromGRPC(err error) (*Error, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil, false
+	}
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.GetDomain() != Domain {
+			continue
+		}
+		if code := info.GetMetadata()["code"]; code != "" {
+			return New(Code(code), st.Message()), true
+		}
+	}
+	return nil, false
+}
+
+// Problem is the RFC 7807 body HTTP endpoints return on failure
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Code   Code   `json:"error_code"`
+}
+
+// AsError converts a decoded problem response back into a typed error
+func (p *Problem) AsError() *Error {
+	return New(p.Code, p.Detail)
+}
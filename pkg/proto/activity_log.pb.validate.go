@@ -0,0 +1,8299 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: pkg/proto/activity_log.proto
+
+package proto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on ActivityLog with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ActivityLog) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ActivityLog with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ActivityLogMultiError, or
+// nil if none found.
+func (m *ActivityLog) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ActivityLog) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for ActivityName
+
+	// no validation rules for CompanyId
+
+	// no validation rules for ObjectName
+
+	// no validation rules for ObjectId
+
+	// no validation rules for Changes
+
+	// no validation rules for FormattedMessage
+
+	// no validation rules for ActorId
+
+	// no validation rules for ActorName
+
+	// no validation rules for ActorEmail
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ActivityLogValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ActivityLogValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ActivityLogValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for UserAgent
+
+	// no validation rules for IpAddress
+
+	// no validation rules for DeviceId
+
+	// no validation rules for CountryCode
+
+	// no validation rules for City
+
+	// no validation rules for ExternalId
+
+	if len(errors) > 0 {
+		return ActivityLogMultiError(errors)
+	}
+
+	return nil
+}
+
+// ActivityLogMultiError is an error wrapping multiple validation errors
+// returned by ActivityLog.ValidateAll() if the designated constraints aren't met.
+type ActivityLogMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ActivityLogMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ActivityLogMultiError) AllErrors() []error { return m }
+
+// ActivityLogValidationError is the validation error returned by
+// ActivityLog.Validate if the designated constraints aren't met.
+type ActivityLogValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ActivityLogValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ActivityLogValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ActivityLogValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ActivityLogValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ActivityLogValidationError) ErrorName() string { return "ActivityLogValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ActivityLogValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sActivityLog.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ActivityLogValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ActivityLogValidationError{}
+
+// Validate checks the field values on CreateActivityLogRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateActivityLogRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateActivityLogRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateActivityLogRequestMultiError, or nil if none found.
+func (m *CreateActivityLogRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateActivityLogRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetActivityName()); l < 1 || l > 128 {
+		err := CreateActivityLogRequestValidationError{
+			field:  "ActivityName",
+			reason: "value length must be between 1 and 128 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetCompanyId()); l < 1 || l > 128 {
+		err := CreateActivityLogRequestValidationError{
+			field:  "CompanyId",
+			reason: "value length must be between 1 and 128 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetObjectName()); l < 1 || l > 128 {
+		err := CreateActivityLogRequestValidationError{
+			field:  "ObjectName",
+			reason: "value length must be between 1 and 128 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetObjectId()); l < 1 || l > 256 {
+		err := CreateActivityLogRequestValidationError{
+			field:  "ObjectId",
+			reason: "value length must be between 1 and 256 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Changes
+
+	if l := utf8.RuneCountInString(m.GetFormattedMessage()); l < 1 || l > 2048 {
+		err := CreateActivityLogRequestValidationError{
+			field:  "FormattedMessage",
+			reason: "value length must be between 1 and 2048 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetActorId()); l < 1 || l > 256 {
+		err := CreateActivityLogRequestValidationError{
+			field:  "ActorId",
+			reason: "value length must be between 1 and 256 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetActorName()); l < 1 || l > 256 {
+		err := CreateActivityLogRequestValidationError{
+			field:  "ActorName",
+			reason: "value length must be between 1 and 256 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if err := m._validateEmail(m.GetActorEmail()); err != nil {
+		err = CreateActivityLogRequestValidationError{
+			field:  "ActorEmail",
+			reason: "value must be a valid email address",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetUserAgent()) > 1024 {
+		err := CreateActivityLogRequestValidationError{
+			field:  "UserAgent",
+			reason: "value length must be at most 1024 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetIpAddress() != "" {
+
+		if ip := net.ParseIP(m.GetIpAddress()); ip == nil {
+			err := CreateActivityLogRequestValidationError{
+				field:  "IpAddress",
+				reason: "value must be a valid IP address",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if utf8.RuneCountInString(m.GetDeviceId()) > 256 {
+		err := CreateActivityLogRequestValidationError{
+			field:  "DeviceId",
+			reason: "value length must be at most 256 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetTags()) > 20 {
+		err := CreateActivityLogRequestValidationError{
+			field:  "Tags",
+			reason: "value must contain no more than 20 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetTags() {
+		_, _ = idx, item
+
+		if l := utf8.RuneCountInString(item); l < 1 || l > 64 {
+			err := CreateActivityLogRequestValidationError{
+				field:  fmt.Sprintf("Tags[%v]", idx),
+				reason: "value length must be between 1 and 64 runes, inclusive",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if utf8.RuneCountInString(m.GetExternalId()) > 256 {
+		err := CreateActivityLogRequestValidationError{
+			field:  "ExternalId",
+			reason: "value length must be at most 256 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := CreateMode_name[int32(m.GetCreateMode())]; !ok {
+		err := CreateActivityLogRequestValidationError{
+			field:  "CreateMode",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return CreateActivityLogRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *CreateActivityLogRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *CreateActivityLogRequest) _validateEmail(addr string) error {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	addr = a.Address
+
+	if len(addr) > 254 {
+		return errors.New("email addresses cannot exceed 254 characters")
+	}
+
+	parts := strings.SplitN(addr, "@", 2)
+
+	if len(parts[0]) > 64 {
+		return errors.New("email address local phrase cannot exceed 64 characters")
+	}
+
+	return m._validateHostname(parts[1])
+}
+
+// CreateActivityLogRequestMultiError is an error wrapping multiple validation
+// errors returned by CreateActivityLogRequest.ValidateAll() if the designated
+// constraints aren't met.
+type CreateActivityLogRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateActivityLogRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateActivityLogRequestMultiError) AllErrors() []error { return m }
+
+// CreateActivityLogRequestValidationError is the validation error returned by
+// CreateActivityLogRequest.Validate if the designated constraints aren't met.
+type CreateActivityLogRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateActivityLogRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateActivityLogRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateActivityLogRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateActivityLogRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateActivityLogRequestValidationError) ErrorName() string {
+	return "CreateActivityLogRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateActivityLogRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateActivityLogRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateActivityLogRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateActivityLogRequestValidationError{}
+
+// Validate checks the field values on CreateActivityLogResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateActivityLogResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateActivityLogResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateActivityLogResponseMultiError, or nil if none found.
+func (m *CreateActivityLogResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateActivityLogResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetActivityLog()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateActivityLogResponseValidationError{
+					field:  "ActivityLog",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateActivityLogResponseValidationError{
+					field:  "ActivityLog",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetActivityLog()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateActivityLogResponseValidationError{
+				field:  "ActivityLog",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Queued
+
+	// no validation rules for SessionToken
+
+	// no validation rules for Outcome
+
+	if len(errors) > 0 {
+		return CreateActivityLogResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateActivityLogResponseMultiError is an error wrapping multiple validation
+// errors returned by CreateActivityLogResponse.ValidateAll() if the
+// designated constraints aren't met.
+type CreateActivityLogResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateActivityLogResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateActivityLogResponseMultiError) AllErrors() []error { return m }
+
+// CreateActivityLogResponseValidationError is the validation error returned by
+// CreateActivityLogResponse.Validate if the designated constraints aren't met.
+type CreateActivityLogResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateActivityLogResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateActivityLogResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateActivityLogResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateActivityLogResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateActivityLogResponseValidationError) ErrorName() string {
+	return "CreateActivityLogResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateActivityLogResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateActivityLogResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateActivityLogResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateActivityLogResponseValidationError{}
+
+// Validate checks the field values on GetActivityLogRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetActivityLogRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetActivityLogRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetActivityLogRequestMultiError, or nil if none found.
+func (m *GetActivityLogRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetActivityLogRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetId()) < 1 {
+		err := GetActivityLogRequestValidationError{
+			field:  "Id",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for SessionToken
+
+	if len(errors) > 0 {
+		return GetActivityLogRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetActivityLogRequestMultiError is an error wrapping multiple validation
+// errors returned by GetActivityLogRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetActivityLogRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetActivityLogRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetActivityLogRequestMultiError) AllErrors() []error { return m }
+
+// GetActivityLogRequestValidationError is the validation error returned by
+// GetActivityLogRequest.Validate if the designated constraints aren't met.
+type GetActivityLogRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetActivityLogRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetActivityLogRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetActivityLogRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetActivityLogRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetActivityLogRequestValidationError) ErrorName() string {
+	return "GetActivityLogRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetActivityLogRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetActivityLogRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetActivityLogRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetActivityLogRequestValidationError{}
+
+// Validate checks the field values on GetActivityLogResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetActivityLogResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetActivityLogResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetActivityLogResponseMultiError, or nil if none found.
+func (m *GetActivityLogResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetActivityLogResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetActivityLog()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetActivityLogResponseValidationError{
+					field:  "ActivityLog",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetActivityLogResponseValidationError{
+					field:  "ActivityLog",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetActivityLog()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetActivityLogResponseValidationError{
+				field:  "ActivityLog",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GetActivityLogResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetActivityLogResponseMultiError is an error wrapping multiple validation
+// errors returned by GetActivityLogResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetActivityLogResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetActivityLogResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetActivityLogResponseMultiError) AllErrors() []error { return m }
+
+// GetActivityLogResponseValidationError is the validation error returned by
+// GetActivityLogResponse.Validate if the designated constraints aren't met.
+type GetActivityLogResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetActivityLogResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetActivityLogResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetActivityLogResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetActivityLogResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetActivityLogResponseValidationError) ErrorName() string {
+	return "GetActivityLogResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetActivityLogResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetActivityLogResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetActivityLogResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetActivityLogResponseValidationError{}
+
+// Validate checks the field values on BatchGetActivityLogsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchGetActivityLogsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchGetActivityLogsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchGetActivityLogsRequestMultiError, or nil if none found.
+func (m *BatchGetActivityLogsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchGetActivityLogsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCompanyId()) < 1 {
+		err := BatchGetActivityLogsRequestValidationError{
+			field:  "CompanyId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := len(m.GetIds()); l < 1 || l > 100 {
+		err := BatchGetActivityLogsRequestValidationError{
+			field:  "Ids",
+			reason: "value must contain between 1 and 100 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetIds() {
+		_, _ = idx, item
+
+		if utf8.RuneCountInString(item) < 1 {
+			err := BatchGetActivityLogsRequestValidationError{
+				field:  fmt.Sprintf("Ids[%v]", idx),
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return BatchGetActivityLogsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchGetActivityLogsRequestMultiError is an error wrapping multiple
+// validation errors returned by BatchGetActivityLogsRequest.ValidateAll() if
+// the designated constraints aren't met.
+type BatchGetActivityLogsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchGetActivityLogsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchGetActivityLogsRequestMultiError) AllErrors() []error { return m }
+
+// BatchGetActivityLogsRequestValidationError is the validation error returned
+// by BatchGetActivityLogsRequest.Validate if the designated constraints
+// aren't met.
+type BatchGetActivityLogsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchGetActivityLogsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchGetActivityLogsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchGetActivityLogsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchGetActivityLogsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchGetActivityLogsRequestValidationError) ErrorName() string {
+	return "BatchGetActivityLogsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchGetActivityLogsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchGetActivityLogsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchGetActivityLogsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchGetActivityLogsRequestValidationError{}
+
+// Validate checks the field values on BatchGetActivityLogsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchGetActivityLogsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchGetActivityLogsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchGetActivityLogsResponseMultiError, or nil if none found.
+func (m *BatchGetActivityLogsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchGetActivityLogsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetActivityLogs() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, BatchGetActivityLogsResponseValidationError{
+						field:  fmt.Sprintf("ActivityLogs[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, BatchGetActivityLogsResponseValidationError{
+						field:  fmt.Sprintf("ActivityLogs[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BatchGetActivityLogsResponseValidationError{
+					field:  fmt.Sprintf("ActivityLogs[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return BatchGetActivityLogsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchGetActivityLogsResponseMultiError is an error wrapping multiple
+// validation errors returned by BatchGetActivityLogsResponse.ValidateAll() if
+// the designated constraints aren't met.
+type BatchGetActivityLogsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchGetActivityLogsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchGetActivityLogsResponseMultiError) AllErrors() []error { return m }
+
+// BatchGetActivityLogsResponseValidationError is the validation error returned
+// by BatchGetActivityLogsResponse.Validate if the designated constraints
+// aren't met.
+type BatchGetActivityLogsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchGetActivityLogsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchGetActivityLogsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchGetActivityLogsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchGetActivityLogsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchGetActivityLogsResponseValidationError) ErrorName() string {
+	return "BatchGetActivityLogsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchGetActivityLogsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchGetActivityLogsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchGetActivityLogsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchGetActivityLogsResponseValidationError{}
+
+// Validate checks the field values on ListActivityLogsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListActivityLogsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListActivityLogsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListActivityLogsRequestMultiError, or nil if none found.
+func (m *ListActivityLogsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListActivityLogsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCompanyId()) < 1 {
+		err := ListActivityLogsRequestValidationError{
+			field:  "CompanyId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Page
+
+	// no validation rules for Limit
+
+	// no validation rules for DeviceId
+
+	// no validation rules for PageToken
+
+	// no validation rules for CountryCode
+
+	// no validation rules for SessionToken
+
+	if len(errors) > 0 {
+		return ListActivityLogsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListActivityLogsRequestMultiError is an error wrapping multiple validation
+// errors returned by ListActivityLogsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListActivityLogsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListActivityLogsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListActivityLogsRequestMultiError) AllErrors() []error { return m }
+
+// ListActivityLogsRequestValidationError is the validation error returned by
+// ListActivityLogsRequest.Validate if the designated constraints aren't met.
+type ListActivityLogsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListActivityLogsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListActivityLogsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListActivityLogsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListActivityLogsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListActivityLogsRequestValidationError) ErrorName() string {
+	return "ListActivityLogsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListActivityLogsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListActivityLogsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListActivityLogsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListActivityLogsRequestValidationError{}
+
+// Validate checks the field values on ListActivityLogsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListActivityLogsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListActivityLogsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListActivityLogsResponseMultiError, or nil if none found.
+func (m *ListActivityLogsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListActivityLogsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetActivityLogs() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListActivityLogsResponseValidationError{
+						field:  fmt.Sprintf("ActivityLogs[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListActivityLogsResponseValidationError{
+						field:  fmt.Sprintf("ActivityLogs[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListActivityLogsResponseValidationError{
+					field:  fmt.Sprintf("ActivityLogs[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Total
+
+	// no validation rules for Page
+
+	// no validation rules for Limit
+
+	// no validation rules for HasMore
+
+	// no validation rules for NextPageToken
+
+	if len(errors) > 0 {
+		return ListActivityLogsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListActivityLogsResponseMultiError is an error wrapping multiple validation
+// errors returned by ListActivityLogsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListActivityLogsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListActivityLogsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListActivityLogsResponseMultiError) AllErrors() []error { return m }
+
+// ListActivityLogsResponseValidationError is the validation error returned by
+// ListActivityLogsResponse.Validate if the designated constraints aren't met.
+type ListActivityLogsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListActivityLogsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListActivityLogsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListActivityLogsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListActivityLogsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListActivityLogsResponseValidationError) ErrorName() string {
+	return "ListActivityLogsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListActivityLogsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListActivityLogsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListActivityLogsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListActivityLogsResponseValidationError{}
+
+// Validate checks the field values on StreamActivityLogsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *StreamActivityLogsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StreamActivityLogsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// StreamActivityLogsRequestMultiError, or nil if none found.
+func (m *StreamActivityLogsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StreamActivityLogsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCompanyId()) < 1 {
+		err := StreamActivityLogsRequestValidationError{
+			field:  "CompanyId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for ObjectId
+
+	// no validation rules for ActivityName
+
+	// no validation rules for ActorId
+
+	// no validation rules for DeviceId
+
+	// no validation rules for CountryCode
+
+	if all {
+		switch v := interface{}(m.GetStartDate()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, StreamActivityLogsRequestValidationError{
+					field:  "StartDate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, StreamActivityLogsRequestValidationError{
+					field:  "StartDate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStartDate()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return StreamActivityLogsRequestValidationError{
+				field:  "StartDate",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetEndDate()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, StreamActivityLogsRequestValidationError{
+					field:  "EndDate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, StreamActivityLogsRequestValidationError{
+					field:  "EndDate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEndDate()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return StreamActivityLogsRequestValidationError{
+				field:  "EndDate",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return StreamActivityLogsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// StreamActivityLogsRequestMultiError is an error wrapping multiple validation
+// errors returned by StreamActivityLogsRequest.ValidateAll() if the
+// designated constraints aren't met.
+type StreamActivityLogsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StreamActivityLogsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StreamActivityLogsRequestMultiError) AllErrors() []error { return m }
+
+// StreamActivityLogsRequestValidationError is the validation error returned by
+// StreamActivityLogsRequest.Validate if the designated constraints aren't met.
+type StreamActivityLogsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StreamActivityLogsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StreamActivityLogsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StreamActivityLogsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StreamActivityLogsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StreamActivityLogsRequestValidationError) ErrorName() string {
+	return "StreamActivityLogsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e StreamActivityLogsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStreamActivityLogsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StreamActivityLogsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StreamActivityLogsRequestValidationError{}
+
+// Validate checks the field values on ExportActivityLogsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ExportActivityLogsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExportActivityLogsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ExportActivityLogsRequestMultiError, or nil if none found.
+func (m *ExportActivityLogsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExportActivityLogsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCompanyId()) < 1 {
+		err := ExportActivityLogsRequestValidationError{
+			field:  "CompanyId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for ObjectId
+
+	// no validation rules for ActivityName
+
+	// no validation rules for ActorId
+
+	// no validation rules for DeviceId
+
+	// no validation rules for CountryCode
+
+	if all {
+		switch v := interface{}(m.GetStartDate()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ExportActivityLogsRequestValidationError{
+					field:  "StartDate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ExportActivityLogsRequestValidationError{
+					field:  "StartDate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStartDate()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ExportActivityLogsRequestValidationError{
+				field:  "StartDate",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetEndDate()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ExportActivityLogsRequestValidationError{
+					field:  "EndDate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ExportActivityLogsRequestValidationError{
+					field:  "EndDate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEndDate()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ExportActivityLogsRequestValidationError{
+				field:  "EndDate",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if val := m.GetChunkSize(); val < 0 || val > 5000 {
+		err := ExportActivityLogsRequestValidationError{
+			field:  "ChunkSize",
+			reason: "value must be inside range [0, 5000]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for ResumeToken
+
+	if len(errors) > 0 {
+		return ExportActivityLogsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExportActivityLogsRequestMultiError is an error wrapping multiple validation
+// errors returned by ExportActivityLogsRequest.ValidateAll() if the
+// designated constraints aren't met.
+type ExportActivityLogsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExportActivityLogsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExportActivityLogsRequestMultiError) AllErrors() []error { return m }
+
+// ExportActivityLogsRequestValidationError is the validation error returned by
+// ExportActivityLogsRequest.Validate if the designated constraints aren't met.
+type ExportActivityLogsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExportActivityLogsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExportActivityLogsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExportActivityLogsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExportActivityLogsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExportActivityLogsRequestValidationError) ErrorName() string {
+	return "ExportActivityLogsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ExportActivityLogsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExportActivityLogsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExportActivityLogsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExportActivityLogsRequestValidationError{}
+
+// Validate checks the field values on ExportChunk with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ExportChunk) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExportChunk with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ExportChunkMultiError, or
+// nil if none found.
+func (m *ExportChunk) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExportChunk) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetActivityLogs() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ExportChunkValidationError{
+						field:  fmt.Sprintf("ActivityLogs[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ExportChunkValidationError{
+						field:  fmt.Sprintf("ActivityLogs[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ExportChunkValidationError{
+					field:  fmt.Sprintf("ActivityLogs[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for ResumeToken
+
+	// no validation rules for Last
+
+	// no validation rules for EncryptedPayload
+
+	// no validation rules for KeyId
+
+	if len(errors) > 0 {
+		return ExportChunkMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExportChunkMultiError is an error wrapping multiple validation errors
+// returned by ExportChunk.ValidateAll() if the designated constraints aren't met.
+type ExportChunkMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExportChunkMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExportChunkMultiError) AllErrors() []error { return m }
+
+// ExportChunkValidationError is the validation error returned by
+// ExportChunk.Validate if the designated constraints aren't met.
+type ExportChunkValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExportChunkValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExportChunkValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExportChunkValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExportChunkValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExportChunkValidationError) ErrorName() string { return "ExportChunkValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ExportChunkValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExportChunk.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExportChunkValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExportChunkValidationError{}
+
+// Validate checks the field values on IngestFailure with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *IngestFailure) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on IngestFailure with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in IngestFailureMultiError, or
+// nil if none found.
+func (m *IngestFailure) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *IngestFailure) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Index
+
+	// no validation rules for Error
+
+	if len(errors) > 0 {
+		return IngestFailureMultiError(errors)
+	}
+
+	return nil
+}
+
+// IngestFailureMultiError is an error wrapping multiple validation errors
+// returned by IngestFailure.ValidateAll() if the designated constraints
+// aren't met.
+type IngestFailureMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m IngestFailureMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m IngestFailureMultiError) AllErrors() []error { return m }
+
+// IngestFailureValidationError is the validation error returned by
+// IngestFailure.Validate if the designated constraints aren't met.
+type IngestFailureValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e IngestFailureValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e IngestFailureValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e IngestFailureValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e IngestFailureValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e IngestFailureValidationError) ErrorName() string { return "IngestFailureValidationError" }
+
+// Error satisfies the builtin error interface
+func (e IngestFailureValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sIngestFailure.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = IngestFailureValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = IngestFailureValidationError{}
+
+// Validate checks the field values on IngestActivityLogsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *IngestActivityLogsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on IngestActivityLogsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// IngestActivityLogsResponseMultiError, or nil if none found.
+func (m *IngestActivityLogsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *IngestActivityLogsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Received
+
+	// no validation rules for Accepted
+
+	// no validation rules for Failed
+
+	for idx, item := range m.GetFailures() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, IngestActivityLogsResponseValidationError{
+						field:  fmt.Sprintf("Failures[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, IngestActivityLogsResponseValidationError{
+						field:  fmt.Sprintf("Failures[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return IngestActivityLogsResponseValidationError{
+					field:  fmt.Sprintf("Failures[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return IngestActivityLogsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// IngestActivityLogsResponseMultiError is an error wrapping multiple
+// validation errors returned by IngestActivityLogsResponse.ValidateAll() if
+// the designated constraints aren't met.
+type IngestActivityLogsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m IngestActivityLogsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m IngestActivityLogsResponseMultiError) AllErrors() []error { return m }
+
+// IngestActivityLogsResponseValidationError is the validation error returned
+// by IngestActivityLogsResponse.Validate if the designated constraints aren't met.
+type IngestActivityLogsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e IngestActivityLogsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e IngestActivityLogsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e IngestActivityLogsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e IngestActivityLogsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e IngestActivityLogsResponseValidationError) ErrorName() string {
+	return "IngestActivityLogsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e IngestActivityLogsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sIngestActivityLogsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = IngestActivityLogsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = IngestActivityLogsResponseValidationError{}
+
+// Validate checks the field values on BatchCreateActivityLogsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchCreateActivityLogsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchCreateActivityLogsRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// BatchCreateActivityLogsRequestMultiError, or nil if none found.
+func (m *BatchCreateActivityLogsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchCreateActivityLogsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := len(m.GetRequests()); l < 1 || l > 500 {
+		err := BatchCreateActivityLogsRequestValidationError{
+			field:  "Requests",
+			reason: "value must contain between 1 and 500 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetRequests() {
+		_, _ = idx, item
+
+		// skipping validation for requests
+
+	}
+
+	// no validation rules for Atomic
+
+	if len(errors) > 0 {
+		return BatchCreateActivityLogsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchCreateActivityLogsRequestMultiError is an error wrapping multiple
+// validation errors returned by BatchCreateActivityLogsRequest.ValidateAll()
+// if the designated constraints aren't met.
+type BatchCreateActivityLogsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchCreateActivityLogsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchCreateActivityLogsRequestMultiError) AllErrors() []error { return m }
+
+// BatchCreateActivityLogsRequestValidationError is the validation error
+// returned by BatchCreateActivityLogsRequest.Validate if the designated
+// constraints aren't met.
+type BatchCreateActivityLogsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchCreateActivityLogsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchCreateActivityLogsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchCreateActivityLogsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchCreateActivityLogsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchCreateActivityLogsRequestValidationError) ErrorName() string {
+	return "BatchCreateActivityLogsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchCreateActivityLogsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchCreateActivityLogsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchCreateActivityLogsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchCreateActivityLogsRequestValidationError{}
+
+// Validate checks the field values on BatchCreateResult with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *BatchCreateResult) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchCreateResult with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchCreateResultMultiError, or nil if none found.
+func (m *BatchCreateResult) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchCreateResult) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Index
+
+	if all {
+		switch v := interface{}(m.GetActivityLog()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BatchCreateResultValidationError{
+					field:  "ActivityLog",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BatchCreateResultValidationError{
+					field:  "ActivityLog",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetActivityLog()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BatchCreateResultValidationError{
+				field:  "ActivityLog",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Error
+
+	// no validation rules for ErrorCode
+
+	// no validation rules for Warning
+
+	if len(errors) > 0 {
+		return BatchCreateResultMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchCreateResultMultiError is an error wrapping multiple validation errors
+// returned by BatchCreateResult.ValidateAll() if the designated constraints
+// aren't met.
+type BatchCreateResultMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchCreateResultMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchCreateResultMultiError) AllErrors() []error { return m }
+
+// BatchCreateResultValidationError is the validation error returned by
+// BatchCreateResult.Validate if the designated constraints aren't met.
+type BatchCreateResultValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchCreateResultValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchCreateResultValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchCreateResultValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchCreateResultValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchCreateResultValidationError) ErrorName() string {
+	return "BatchCreateResultValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchCreateResultValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchCreateResult.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchCreateResultValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchCreateResultValidationError{}
+
+// Validate checks the field values on BatchCreateActivityLogsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchCreateActivityLogsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchCreateActivityLogsResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// BatchCreateActivityLogsResponseMultiError, or nil if none found.
+func (m *BatchCreateActivityLogsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchCreateActivityLogsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Accepted
+
+	// no validation rules for Failed
+
+	for idx, item := range m.GetResults() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, BatchCreateActivityLogsResponseValidationError{
+						field:  fmt.Sprintf("Results[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, BatchCreateActivityLogsResponseValidationError{
+						field:  fmt.Sprintf("Results[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BatchCreateActivityLogsResponseValidationError{
+					field:  fmt.Sprintf("Results[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return BatchCreateActivityLogsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchCreateActivityLogsResponseMultiError is an error wrapping multiple
+// validation errors returned by BatchCreateActivityLogsResponse.ValidateAll()
+// if the designated constraints aren't met.
+type BatchCreateActivityLogsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchCreateActivityLogsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchCreateActivityLogsResponseMultiError) AllErrors() []error { return m }
+
+// BatchCreateActivityLogsResponseValidationError is the validation error
+// returned by BatchCreateActivityLogsResponse.Validate if the designated
+// constraints aren't met.
+type BatchCreateActivityLogsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchCreateActivityLogsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchCreateActivityLogsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchCreateActivityLogsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchCreateActivityLogsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchCreateActivityLogsResponseValidationError) ErrorName() string {
+	return "BatchCreateActivityLogsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchCreateActivityLogsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchCreateActivityLogsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchCreateActivityLogsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchCreateActivityLogsResponseValidationError{}
+
+// Validate checks the field values on GetActivityStatsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetActivityStatsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetActivityStatsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetActivityStatsRequestMultiError, or nil if none found.
+func (m *GetActivityStatsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetActivityStatsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCompanyId()) < 1 {
+		err := GetActivityStatsRequestValidationError{
+			field:  "CompanyId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := _GetActivityStatsRequest_GroupBy_NotInLookup[m.GetGroupBy()]; ok {
+		err := GetActivityStatsRequestValidationError{
+			field:  "GroupBy",
+			reason: "value must not be in list [STATS_GROUP_BY_UNSPECIFIED]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := StatsGroupBy_name[int32(m.GetGroupBy())]; !ok {
+		err := GetActivityStatsRequestValidationError{
+			field:  "GroupBy",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetStartDate() == nil {
+		err := GetActivityStatsRequestValidationError{
+			field:  "StartDate",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetEndDate() == nil {
+		err := GetActivityStatsRequestValidationError{
+			field:  "EndDate",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetLimit(); val < 0 || val > 1000 {
+		err := GetActivityStatsRequestValidationError{
+			field:  "Limit",
+			reason: "value must be inside range [0, 1000]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetActivityStatsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetActivityStatsRequestMultiError is an error wrapping multiple validation
+// errors returned by GetActivityStatsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetActivityStatsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetActivityStatsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetActivityStatsRequestMultiError) AllErrors() []error { return m }
+
+// GetActivityStatsRequestValidationError is the validation error returned by
+// GetActivityStatsRequest.Validate if the designated constraints aren't met.
+type GetActivityStatsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetActivityStatsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetActivityStatsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetActivityStatsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetActivityStatsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetActivityStatsRequestValidationError) ErrorName() string {
+	return "GetActivityStatsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetActivityStatsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetActivityStatsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetActivityStatsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetActivityStatsRequestValidationError{}
+
+var _GetActivityStatsRequest_GroupBy_NotInLookup = map[StatsGroupBy]struct{}{
+	0: {},
+}
+
+// Validate checks the field values on ActivityStat with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ActivityStat) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ActivityStat with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ActivityStatMultiError, or
+// nil if none found.
+func (m *ActivityStat) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ActivityStat) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Key
+
+	// no validation rules for Count
+
+	if len(errors) > 0 {
+		return ActivityStatMultiError(errors)
+	}
+
+	return nil
+}
+
+// ActivityStatMultiError is an error wrapping multiple validation errors
+// returned by ActivityStat.ValidateAll() if the designated constraints aren't met.
+type ActivityStatMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ActivityStatMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ActivityStatMultiError) AllErrors() []error { return m }
+
+// ActivityStatValidationError is the validation error returned by
+// ActivityStat.Validate if the designated constraints aren't met.
+type ActivityStatValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ActivityStatValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ActivityStatValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ActivityStatValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ActivityStatValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ActivityStatValidationError) ErrorName() string { return "ActivityStatValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ActivityStatValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sActivityStat.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ActivityStatValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ActivityStatValidationError{}
+
+// Validate checks the field values on GetActivityStatsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetActivityStatsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetActivityStatsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetActivityStatsResponseMultiError, or nil if none found.
+func (m *GetActivityStatsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetActivityStatsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetStats() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetActivityStatsResponseValidationError{
+						field:  fmt.Sprintf("Stats[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetActivityStatsResponseValidationError{
+						field:  fmt.Sprintf("Stats[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetActivityStatsResponseValidationError{
+					field:  fmt.Sprintf("Stats[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Truncated
+
+	if len(errors) > 0 {
+		return GetActivityStatsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetActivityStatsResponseMultiError is an error wrapping multiple validation
+// errors returned by GetActivityStatsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetActivityStatsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetActivityStatsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetActivityStatsResponseMultiError) AllErrors() []error { return m }
+
+// GetActivityStatsResponseValidationError is the validation error returned by
+// GetActivityStatsResponse.Validate if the designated constraints aren't met.
+type GetActivityStatsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetActivityStatsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetActivityStatsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetActivityStatsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetActivityStatsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetActivityStatsResponseValidationError) ErrorName() string {
+	return "GetActivityStatsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetActivityStatsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetActivityStatsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetActivityStatsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetActivityStatsResponseValidationError{}
+
+// Validate checks the field values on ListActivityNamesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListActivityNamesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListActivityNamesRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListActivityNamesRequestMultiError, or nil if none found.
+func (m *ListActivityNamesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListActivityNamesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCompanyId()) < 1 {
+		err := ListActivityNamesRequestValidationError{
+			field:  "CompanyId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListActivityNamesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListActivityNamesRequestMultiError is an error wrapping multiple validation
+// errors returned by ListActivityNamesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListActivityNamesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListActivityNamesRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListActivityNamesRequestMultiError) AllErrors() []error { return m }
+
+// ListActivityNamesRequestValidationError is the validation error returned by
+// ListActivityNamesRequest.Validate if the designated constraints aren't met.
+type ListActivityNamesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListActivityNamesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListActivityNamesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListActivityNamesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListActivityNamesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListActivityNamesRequestValidationError) ErrorName() string {
+	return "ListActivityNamesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListActivityNamesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListActivityNamesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListActivityNamesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListActivityNamesRequestValidationError{}
+
+// Validate checks the field values on ListActivityNamesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListActivityNamesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListActivityNamesResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListActivityNamesResponseMultiError, or nil if none found.
+func (m *ListActivityNamesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListActivityNamesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ListActivityNamesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListActivityNamesResponseMultiError is an error wrapping multiple validation
+// errors returned by ListActivityNamesResponse.ValidateAll() if the
+// designated constraints aren't met.
+type ListActivityNamesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListActivityNamesResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListActivityNamesResponseMultiError) AllErrors() []error { return m }
+
+// ListActivityNamesResponseValidationError is the validation error returned by
+// ListActivityNamesResponse.Validate if the designated constraints aren't met.
+type ListActivityNamesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListActivityNamesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListActivityNamesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListActivityNamesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListActivityNamesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListActivityNamesResponseValidationError) ErrorName() string {
+	return "ListActivityNamesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListActivityNamesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListActivityNamesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListActivityNamesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListActivityNamesResponseValidationError{}
+
+// Validate checks the field values on ListActorsRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListActorsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListActorsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListActorsRequestMultiError, or nil if none found.
+func (m *ListActorsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListActorsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCompanyId()) < 1 {
+		err := ListActorsRequestValidationError{
+			field:  "CompanyId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListActorsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListActorsRequestMultiError is an error wrapping multiple validation errors
+// returned by ListActorsRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListActorsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListActorsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListActorsRequestMultiError) AllErrors() []error { return m }
+
+// ListActorsRequestValidationError is the validation error returned by
+// ListActorsRequest.Validate if the designated constraints aren't met.
+type ListActorsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListActorsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListActorsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListActorsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListActorsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListActorsRequestValidationError) ErrorName() string {
+	return "ListActorsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListActorsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListActorsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListActorsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListActorsRequestValidationError{}
+
+// Validate checks the field values on Actor with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Actor) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Actor with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in ActorMultiError, or nil if none found.
+func (m *Actor) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Actor) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Name
+
+	// no validation rules for Email
+
+	if all {
+		switch v := interface{}(m.GetLastSeenAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ActorValidationError{
+					field:  "LastSeenAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ActorValidationError{
+					field:  "LastSeenAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLastSeenAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ActorValidationError{
+				field:  "LastSeenAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ActorMultiError(errors)
+	}
+
+	return nil
+}
+
+// ActorMultiError is an error wrapping multiple validation errors returned by
+// Actor.ValidateAll() if the designated constraints aren't met.
+type ActorMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ActorMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ActorMultiError) AllErrors() []error { return m }
+
+// ActorValidationError is the validation error returned by Actor.Validate if
+// the designated constraints aren't met.
+type ActorValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ActorValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ActorValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ActorValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ActorValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ActorValidationError) ErrorName() string { return "ActorValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ActorValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sActor.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ActorValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ActorValidationError{}
+
+// Validate checks the field values on ListActorsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListActorsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListActorsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListActorsResponseMultiError, or nil if none found.
+func (m *ListActorsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListActorsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetActors() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListActorsResponseValidationError{
+						field:  fmt.Sprintf("Actors[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListActorsResponseValidationError{
+						field:  fmt.Sprintf("Actors[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListActorsResponseValidationError{
+					field:  fmt.Sprintf("Actors[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListActorsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListActorsResponseMultiError is an error wrapping multiple validation errors
+// returned by ListActorsResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ListActorsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListActorsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListActorsResponseMultiError) AllErrors() []error { return m }
+
+// ListActorsResponseValidationError is the validation error returned by
+// ListActorsResponse.Validate if the designated constraints aren't met.
+type ListActorsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListActorsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListActorsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListActorsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListActorsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListActorsResponseValidationError) ErrorName() string {
+	return "ListActorsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListActorsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListActorsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListActorsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListActorsResponseValidationError{}
+
+// Validate checks the field values on ListAccessLogRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListAccessLogRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAccessLogRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAccessLogRequestMultiError, or nil if none found.
+func (m *ListAccessLogRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAccessLogRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCompanyId()) < 1 {
+		err := ListAccessLogRequestValidationError{
+			field:  "CompanyId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Page
+
+	if val := m.GetLimit(); val < 0 || val > 100 {
+		err := ListAccessLogRequestValidationError{
+			field:  "Limit",
+			reason: "value must be inside range [0, 100]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListAccessLogRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAccessLogRequestMultiError is an error wrapping multiple validation
+// errors returned by ListAccessLogRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListAccessLogRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAccessLogRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAccessLogRequestMultiError) AllErrors() []error { return m }
+
+// ListAccessLogRequestValidationError is the validation error returned by
+// ListAccessLogRequest.Validate if the designated constraints aren't met.
+type ListAccessLogRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAccessLogRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAccessLogRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAccessLogRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAccessLogRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAccessLogRequestValidationError) ErrorName() string {
+	return "ListAccessLogRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAccessLogRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAccessLogRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAccessLogRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAccessLogRequestValidationError{}
+
+// Validate checks the field values on AccessLogEntry with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AccessLogEntry) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AccessLogEntry with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AccessLogEntryMultiError,
+// or nil if none found.
+func (m *AccessLogEntry) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AccessLogEntry) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Principal
+
+	// no validation rules for Operation
+
+	// no validation rules for Filter
+
+	// no validation rules for ResultCount
+
+	if all {
+		switch v := interface{}(m.GetAccessedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AccessLogEntryValidationError{
+					field:  "AccessedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AccessLogEntryValidationError{
+					field:  "AccessedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAccessedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AccessLogEntryValidationError{
+				field:  "AccessedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return AccessLogEntryMultiError(errors)
+	}
+
+	return nil
+}
+
+// AccessLogEntryMultiError is an error wrapping multiple validation errors
+// returned by AccessLogEntry.ValidateAll() if the designated constraints
+// aren't met.
+type AccessLogEntryMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AccessLogEntryMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AccessLogEntryMultiError) AllErrors() []error { return m }
+
+// AccessLogEntryValidationError is the validation error returned by
+// AccessLogEntry.Validate if the designated constraints aren't met.
+type AccessLogEntryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AccessLogEntryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AccessLogEntryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AccessLogEntryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AccessLogEntryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AccessLogEntryValidationError) ErrorName() string { return "AccessLogEntryValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AccessLogEntryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAccessLogEntry.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AccessLogEntryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AccessLogEntryValidationError{}
+
+// Validate checks the field values on ListAccessLogResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListAccessLogResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAccessLogResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAccessLogResponseMultiError, or nil if none found.
+func (m *ListAccessLogResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAccessLogResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetEntries() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListAccessLogResponseValidationError{
+						field:  fmt.Sprintf("Entries[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListAccessLogResponseValidationError{
+						field:  fmt.Sprintf("Entries[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListAccessLogResponseValidationError{
+					field:  fmt.Sprintf("Entries[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Total
+
+	// no validation rules for Page
+
+	// no validation rules for Limit
+
+	// no validation rules for HasMore
+
+	if len(errors) > 0 {
+		return ListAccessLogResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAccessLogResponseMultiError is an error wrapping multiple validation
+// errors returned by ListAccessLogResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListAccessLogResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAccessLogResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAccessLogResponseMultiError) AllErrors() []error { return m }
+
+// ListAccessLogResponseValidationError is the validation error returned by
+// ListAccessLogResponse.Validate if the designated constraints aren't met.
+type ListAccessLogResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAccessLogResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAccessLogResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAccessLogResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAccessLogResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAccessLogResponseValidationError) ErrorName() string {
+	return "ListAccessLogResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAccessLogResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAccessLogResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAccessLogResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAccessLogResponseValidationError{}
+
+// Validate checks the field values on SetExportKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SetExportKeyRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SetExportKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SetExportKeyRequestMultiError, or nil if none found.
+func (m *SetExportKeyRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SetExportKeyRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCompanyId()) < 1 {
+		err := SetExportKeyRequestValidationError{
+			field:  "CompanyId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetPublicKeyPem()) < 1 {
+		err := SetExportKeyRequestValidationError{
+			field:  "PublicKeyPem",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return SetExportKeyRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SetExportKeyRequestMultiError is an error wrapping multiple validation
+// errors returned by SetExportKeyRequest.ValidateAll() if the designated
+// constraints aren't met.
+type SetExportKeyRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SetExportKeyRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SetExportKeyRequestMultiError) AllErrors() []error { return m }
+
+// SetExportKeyRequestValidationError is the validation error returned by
+// SetExportKeyRequest.Validate if the designated constraints aren't met.
+type SetExportKeyRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SetExportKeyRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SetExportKeyRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SetExportKeyRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SetExportKeyRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SetExportKeyRequestValidationError) ErrorName() string {
+	return "SetExportKeyRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SetExportKeyRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSetExportKeyRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SetExportKeyRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SetExportKeyRequestValidationError{}
+
+// Validate checks the field values on SetExportKeyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SetExportKeyResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SetExportKeyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SetExportKeyResponseMultiError, or nil if none found.
+func (m *SetExportKeyResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SetExportKeyResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for KeyId
+
+	// no validation rules for Algorithm
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SetExportKeyResponseValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SetExportKeyResponseValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SetExportKeyResponseValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SetExportKeyResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// SetExportKeyResponseMultiError is an error wrapping multiple validation
+// errors returned by SetExportKeyResponse.ValidateAll() if the designated
+// constraints aren't met.
+type SetExportKeyResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SetExportKeyResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SetExportKeyResponseMultiError) AllErrors() []error { return m }
+
+// SetExportKeyResponseValidationError is the validation error returned by
+// SetExportKeyResponse.Validate if the designated constraints aren't met.
+type SetExportKeyResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SetExportKeyResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SetExportKeyResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SetExportKeyResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SetExportKeyResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SetExportKeyResponseValidationError) ErrorName() string {
+	return "SetExportKeyResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SetExportKeyResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSetExportKeyResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SetExportKeyResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SetExportKeyResponseValidationError{}
+
+// Validate checks the field values on DeleteExportKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeleteExportKeyRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteExportKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteExportKeyRequestMultiError, or nil if none found.
+func (m *DeleteExportKeyRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteExportKeyRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCompanyId()) < 1 {
+		err := DeleteExportKeyRequestValidationError{
+			field:  "CompanyId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DeleteExportKeyRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteExportKeyRequestMultiError is an error wrapping multiple validation
+// errors returned by DeleteExportKeyRequest.ValidateAll() if the designated
+// constraints aren't met.
+type DeleteExportKeyRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteExportKeyRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteExportKeyRequestMultiError) AllErrors() []error { return m }
+
+// DeleteExportKeyRequestValidationError is the validation error returned by
+// DeleteExportKeyRequest.Validate if the designated constraints aren't met.
+type DeleteExportKeyRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteExportKeyRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteExportKeyRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteExportKeyRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteExportKeyRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteExportKeyRequestValidationError) ErrorName() string {
+	return "DeleteExportKeyRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteExportKeyRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteExportKeyRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteExportKeyRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteExportKeyRequestValidationError{}
+
+// Validate checks the field values on DeleteExportKeyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeleteExportKeyResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteExportKeyResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteExportKeyResponseMultiError, or nil if none found.
+func (m *DeleteExportKeyResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteExportKeyResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return DeleteExportKeyResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteExportKeyResponseMultiError is an error wrapping multiple validation
+// errors returned by DeleteExportKeyResponse.ValidateAll() if the designated
+// constraints aren't met.
+type DeleteExportKeyResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteExportKeyResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteExportKeyResponseMultiError) AllErrors() []error { return m }
+
+// DeleteExportKeyResponseValidationError is the validation error returned by
+// DeleteExportKeyResponse.Validate if the designated constraints aren't met.
+type DeleteExportKeyResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteExportKeyResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteExportKeyResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteExportKeyResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteExportKeyResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteExportKeyResponseValidationError) ErrorName() string {
+	return "DeleteExportKeyResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteExportKeyResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteExportKeyResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteExportKeyResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteExportKeyResponseValidationError{}
+
+// Validate checks the field values on SearchActivityLogsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SearchActivityLogsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SearchActivityLogsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SearchActivityLogsRequestMultiError, or nil if none found.
+func (m *SearchActivityLogsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SearchActivityLogsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCompanyId()) < 1 {
+		err := SearchActivityLogsRequestValidationError{
+			field:  "CompanyId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for ActorId
+
+	// no validation rules for ObjectId
+
+	// no validation rules for ActivityName
+
+	if all {
+		switch v := interface{}(m.GetStartDate()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SearchActivityLogsRequestValidationError{
+					field:  "StartDate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SearchActivityLogsRequestValidationError{
+					field:  "StartDate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStartDate()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SearchActivityLogsRequestValidationError{
+				field:  "StartDate",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetEndDate()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SearchActivityLogsRequestValidationError{
+					field:  "EndDate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SearchActivityLogsRequestValidationError{
+					field:  "EndDate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEndDate()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SearchActivityLogsRequestValidationError{
+				field:  "EndDate",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Text
+
+	if len(m.GetTags()) > 20 {
+		err := SearchActivityLogsRequestValidationError{
+			field:  "Tags",
+			reason: "value must contain no more than 20 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Cursor
+
+	if val := m.GetLimit(); val < 0 || val > 100 {
+		err := SearchActivityLogsRequestValidationError{
+			field:  "Limit",
+			reason: "value must be inside range [0, 100]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for DeviceId
+
+	// no validation rules for IpAddress
+
+	// no validation rules for UserAgent
+
+	if len(errors) > 0 {
+		return SearchActivityLogsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SearchActivityLogsRequestMultiError is an error wrapping multiple validation
+// errors returned by SearchActivityLogsRequest.ValidateAll() if the
+// designated constraints aren't met.
+type SearchActivityLogsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SearchActivityLogsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SearchActivityLogsRequestMultiError) AllErrors() []error { return m }
+
+// SearchActivityLogsRequestValidationError is the validation error returned by
+// SearchActivityLogsRequest.Validate if the designated constraints aren't met.
+type SearchActivityLogsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SearchActivityLogsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SearchActivityLogsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SearchActivityLogsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SearchActivityLogsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SearchActivityLogsRequestValidationError) ErrorName() string {
+	return "SearchActivityLogsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SearchActivityLogsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSearchActivityLogsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SearchActivityLogsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SearchActivityLogsRequestValidationError{}
+
+// Validate checks the field values on SearchActivityLogsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SearchActivityLogsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SearchActivityLogsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SearchActivityLogsResponseMultiError, or nil if none found.
+func (m *SearchActivityLogsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SearchActivityLogsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetActivityLogs() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SearchActivityLogsResponseValidationError{
+						field:  fmt.Sprintf("ActivityLogs[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SearchActivityLogsResponseValidationError{
+						field:  fmt.Sprintf("ActivityLogs[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SearchActivityLogsResponseValidationError{
+					field:  fmt.Sprintf("ActivityLogs[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for HasMore
+
+	// no validation rules for NextCursor
+
+	if len(errors) > 0 {
+		return SearchActivityLogsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// SearchActivityLogsResponseMultiError is an error wrapping multiple
+// validation errors returned by SearchActivityLogsResponse.ValidateAll() if
+// the designated constraints aren't met.
+type SearchActivityLogsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SearchActivityLogsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SearchActivityLogsResponseMultiError) AllErrors() []error { return m }
+
+// SearchActivityLogsResponseValidationError is the validation error returned
+// by SearchActivityLogsResponse.Validate if the designated constraints aren't met.
+type SearchActivityLogsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SearchActivityLogsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SearchActivityLogsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SearchActivityLogsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SearchActivityLogsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SearchActivityLogsResponseValidationError) ErrorName() string {
+	return "SearchActivityLogsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SearchActivityLogsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSearchActivityLogsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SearchActivityLogsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SearchActivityLogsResponseValidationError{}
+
+// Validate checks the field values on FlushCacheRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *FlushCacheRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FlushCacheRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// FlushCacheRequestMultiError, or nil if none found.
+func (m *FlushCacheRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FlushCacheRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for CompanyId
+
+	if len(errors) > 0 {
+		return FlushCacheRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// FlushCacheRequestMultiError is an error wrapping multiple validation errors
+// returned by FlushCacheRequest.ValidateAll() if the designated constraints
+// aren't met.
+type FlushCacheRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FlushCacheRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FlushCacheRequestMultiError) AllErrors() []error { return m }
+
+// FlushCacheRequestValidationError is the validation error returned by
+// FlushCacheRequest.Validate if the designated constraints aren't met.
+type FlushCacheRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FlushCacheRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FlushCacheRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FlushCacheRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FlushCacheRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FlushCacheRequestValidationError) ErrorName() string {
+	return "FlushCacheRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e FlushCacheRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFlushCacheRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FlushCacheRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FlushCacheRequestValidationError{}
+
+// Validate checks the field values on FlushCacheResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *FlushCacheResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FlushCacheResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// FlushCacheResponseMultiError, or nil if none found.
+func (m *FlushCacheResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FlushCacheResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return FlushCacheResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// FlushCacheResponseMultiError is an error wrapping multiple validation errors
+// returned by FlushCacheResponse.ValidateAll() if the designated constraints
+// aren't met.
+type FlushCacheResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FlushCacheResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FlushCacheResponseMultiError) AllErrors() []error { return m }
+
+// FlushCacheResponseValidationError is the validation error returned by
+// FlushCacheResponse.Validate if the designated constraints aren't met.
+type FlushCacheResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FlushCacheResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FlushCacheResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FlushCacheResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FlushCacheResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FlushCacheResponseValidationError) ErrorName() string {
+	return "FlushCacheResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e FlushCacheResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFlushCacheResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FlushCacheResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FlushCacheResponseValidationError{}
+
+// Validate checks the field values on TriggerCronJobRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *TriggerCronJobRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TriggerCronJobRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// TriggerCronJobRequestMultiError, or nil if none found.
+func (m *TriggerCronJobRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TriggerCronJobRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetJob()) < 1 {
+		err := TriggerCronJobRequestValidationError{
+			field:  "Job",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return TriggerCronJobRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// TriggerCronJobRequestMultiError is an error wrapping multiple validation
+// errors returned by TriggerCronJobRequest.ValidateAll() if the designated
+// constraints aren't met.
+type TriggerCronJobRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TriggerCronJobRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TriggerCronJobRequestMultiError) AllErrors() []error { return m }
+
+// TriggerCronJobRequestValidationError is the validation error returned by
+// TriggerCronJobRequest.Validate if the designated constraints aren't met.
+type TriggerCronJobRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TriggerCronJobRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TriggerCronJobRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TriggerCronJobRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TriggerCronJobRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TriggerCronJobRequestValidationError) ErrorName() string {
+	return "TriggerCronJobRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e TriggerCronJobRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTriggerCronJobRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TriggerCronJobRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TriggerCronJobRequestValidationError{}
+
+// Validate checks the field values on TriggerCronJobResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *TriggerCronJobResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TriggerCronJobResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// TriggerCronJobResponseMultiError, or nil if none found.
+func (m *TriggerCronJobResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TriggerCronJobResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return TriggerCronJobResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// TriggerCronJobResponseMultiError is an error wrapping multiple validation
+// errors returned by TriggerCronJobResponse.ValidateAll() if the designated
+// constraints aren't met.
+type TriggerCronJobResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TriggerCronJobResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TriggerCronJobResponseMultiError) AllErrors() []error { return m }
+
+// TriggerCronJobResponseValidationError is the validation error returned by
+// TriggerCronJobResponse.Validate if the designated constraints aren't met.
+type TriggerCronJobResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TriggerCronJobResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TriggerCronJobResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TriggerCronJobResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TriggerCronJobResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TriggerCronJobResponseValidationError) ErrorName() string {
+	return "TriggerCronJobResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e TriggerCronJobResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTriggerCronJobResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TriggerCronJobResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TriggerCronJobResponseValidationError{}
+
+// Validate checks the field values on JobRun with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *JobRun) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on JobRun with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in JobRunMultiError, or nil if none found.
+func (m *JobRun) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *JobRun) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Job
+
+	if all {
+		switch v := interface{}(m.GetStartedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, JobRunValidationError{
+					field:  "StartedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, JobRunValidationError{
+					field:  "StartedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStartedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return JobRunValidationError{
+				field:  "StartedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetFinishedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, JobRunValidationError{
+					field:  "FinishedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, JobRunValidationError{
+					field:  "FinishedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFinishedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return JobRunValidationError{
+				field:  "FinishedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for DurationMs
+
+	// no validation rules for Status
+
+	// no validation rules for Error
+
+	// no validation rules for Items
+
+	if len(errors) > 0 {
+		return JobRunMultiError(errors)
+	}
+
+	return nil
+}
+
+// JobRunMultiError is an error wrapping multiple validation errors returned by
+// JobRun.ValidateAll() if the designated constraints aren't met.
+type JobRunMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m JobRunMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m JobRunMultiError) AllErrors() []error { return m }
+
+// JobRunValidationError is the validation error returned by JobRun.Validate if
+// the designated constraints aren't met.
+type JobRunValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e JobRunValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e JobRunValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e JobRunValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e JobRunValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e JobRunValidationError) ErrorName() string { return "JobRunValidationError" }
+
+// Error satisfies the builtin error interface
+func (e JobRunValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sJobRun.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = JobRunValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = JobRunValidationError{}
+
+// Validate checks the field values on ListJobRunsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListJobRunsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListJobRunsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListJobRunsRequestMultiError, or nil if none found.
+func (m *ListJobRunsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListJobRunsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Job
+
+	if val := m.GetLimit(); val < 0 || val > 1000 {
+		err := ListJobRunsRequestValidationError{
+			field:  "Limit",
+			reason: "value must be inside range [0, 1000]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListJobRunsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListJobRunsRequestMultiError is an error wrapping multiple validation errors
+// returned by ListJobRunsRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListJobRunsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListJobRunsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListJobRunsRequestMultiError) AllErrors() []error { return m }
+
+// ListJobRunsRequestValidationError is the validation error returned by
+// ListJobRunsRequest.Validate if the designated constraints aren't met.
+type ListJobRunsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListJobRunsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListJobRunsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListJobRunsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListJobRunsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListJobRunsRequestValidationError) ErrorName() string {
+	return "ListJobRunsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListJobRunsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListJobRunsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListJobRunsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListJobRunsRequestValidationError{}
+
+// Validate checks the field values on ListJobRunsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListJobRunsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListJobRunsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListJobRunsResponseMultiError, or nil if none found.
+func (m *ListJobRunsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListJobRunsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetRuns() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListJobRunsResponseValidationError{
+						field:  fmt.Sprintf("Runs[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListJobRunsResponseValidationError{
+						field:  fmt.Sprintf("Runs[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListJobRunsResponseValidationError{
+					field:  fmt.Sprintf("Runs[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListJobRunsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListJobRunsResponseMultiError is an error wrapping multiple validation
+// errors returned by ListJobRunsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListJobRunsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListJobRunsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListJobRunsResponseMultiError) AllErrors() []error { return m }
+
+// ListJobRunsResponseValidationError is the validation error returned by
+// ListJobRunsResponse.Validate if the designated constraints aren't met.
+type ListJobRunsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListJobRunsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListJobRunsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListJobRunsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListJobRunsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListJobRunsResponseValidationError) ErrorName() string {
+	return "ListJobRunsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListJobRunsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListJobRunsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListJobRunsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListJobRunsResponseValidationError{}
+
+// Validate checks the field values on ReindexRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ReindexRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReindexRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ReindexRequestMultiError,
+// or nil if none found.
+func (m *ReindexRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReindexRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ReindexRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReindexRequestMultiError is an error wrapping multiple validation errors
+// returned by ReindexRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ReindexRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReindexRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReindexRequestMultiError) AllErrors() []error { return m }
+
+// ReindexRequestValidationError is the validation error returned by
+// ReindexRequest.Validate if the designated constraints aren't met.
+type ReindexRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReindexRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReindexRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReindexRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReindexRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReindexRequestValidationError) ErrorName() string { return "ReindexRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ReindexRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReindexRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReindexRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReindexRequestValidationError{}
+
+// Validate checks the field values on IndexStatus with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *IndexStatus) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on IndexStatus with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in IndexStatusMultiError, or
+// nil if none found.
+func (m *IndexStatus) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *IndexStatus) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	// no validation rules for Action
+
+	if len(errors) > 0 {
+		return IndexStatusMultiError(errors)
+	}
+
+	return nil
+}
+
+// IndexStatusMultiError is an error wrapping multiple validation errors
+// returned by IndexStatus.ValidateAll() if the designated constraints aren't met.
+type IndexStatusMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m IndexStatusMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m IndexStatusMultiError) AllErrors() []error { return m }
+
+// IndexStatusValidationError is the validation error returned by
+// IndexStatus.Validate if the designated constraints aren't met.
+type IndexStatusValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e IndexStatusValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e IndexStatusValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e IndexStatusValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e IndexStatusValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e IndexStatusValidationError) ErrorName() string { return "IndexStatusValidationError" }
+
+// Error satisfies the builtin error interface
+func (e IndexStatusValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sIndexStatus.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = IndexStatusValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = IndexStatusValidationError{}
+
+// Validate checks the field values on ReindexResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ReindexResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReindexResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReindexResponseMultiError, or nil if none found.
+func (m *ReindexResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReindexResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetIndexes() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ReindexResponseValidationError{
+						field:  fmt.Sprintf("Indexes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ReindexResponseValidationError{
+						field:  fmt.Sprintf("Indexes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ReindexResponseValidationError{
+					field:  fmt.Sprintf("Indexes[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ReindexResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReindexResponseMultiError is an error wrapping multiple validation errors
+// returned by ReindexResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ReindexResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReindexResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReindexResponseMultiError) AllErrors() []error { return m }
+
+// ReindexResponseValidationError is the validation error returned by
+// ReindexResponse.Validate if the designated constraints aren't met.
+type ReindexResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReindexResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReindexResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReindexResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReindexResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReindexResponseValidationError) ErrorName() string { return "ReindexResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ReindexResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReindexResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReindexResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReindexResponseValidationError{}
+
+// Validate checks the field values on PurgeCompanyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PurgeCompanyRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PurgeCompanyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PurgeCompanyRequestMultiError, or nil if none found.
+func (m *PurgeCompanyRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PurgeCompanyRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCompanyId()) < 1 {
+		err := PurgeCompanyRequestValidationError{
+			field:  "CompanyId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return PurgeCompanyRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// PurgeCompanyRequestMultiError is an error wrapping multiple validation
+// errors returned by PurgeCompanyRequest.ValidateAll() if the designated
+// constraints aren't met.
+type PurgeCompanyRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PurgeCompanyRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PurgeCompanyRequestMultiError) AllErrors() []error { return m }
+
+// PurgeCompanyRequestValidationError is the validation error returned by
+// PurgeCompanyRequest.Validate if the designated constraints aren't met.
+type PurgeCompanyRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PurgeCompanyRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PurgeCompanyRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PurgeCompanyRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PurgeCompanyRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PurgeCompanyRequestValidationError) ErrorName() string {
+	return "PurgeCompanyRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PurgeCompanyRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPurgeCompanyRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PurgeCompanyRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PurgeCompanyRequestValidationError{}
+
+// Validate checks the field values on PurgeCompanyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PurgeCompanyResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PurgeCompanyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PurgeCompanyResponseMultiError, or nil if none found.
+func (m *PurgeCompanyResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PurgeCompanyResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return PurgeCompanyResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// PurgeCompanyResponseMultiError is an error wrapping multiple validation
+// errors returned by PurgeCompanyResponse.ValidateAll() if the designated
+// constraints aren't met.
+type PurgeCompanyResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PurgeCompanyResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PurgeCompanyResponseMultiError) AllErrors() []error { return m }
+
+// PurgeCompanyResponseValidationError is the validation error returned by
+// PurgeCompanyResponse.Validate if the designated constraints aren't met.
+type PurgeCompanyResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PurgeCompanyResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PurgeCompanyResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PurgeCompanyResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PurgeCompanyResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PurgeCompanyResponseValidationError) ErrorName() string {
+	return "PurgeCompanyResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PurgeCompanyResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPurgeCompanyResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PurgeCompanyResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PurgeCompanyResponseValidationError{}
+
+// Validate checks the field values on ArchiveManifest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ArchiveManifest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ArchiveManifest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ArchiveManifestMultiError, or nil if none found.
+func (m *ArchiveManifest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ArchiveManifest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for CompanyId
+
+	// no validation rules for Object
+
+	if all {
+		switch v := interface{}(m.GetFrom()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ArchiveManifestValidationError{
+					field:  "From",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ArchiveManifestValidationError{
+					field:  "From",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFrom()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ArchiveManifestValidationError{
+				field:  "From",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetTo()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ArchiveManifestValidationError{
+					field:  "To",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ArchiveManifestValidationError{
+					field:  "To",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTo()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ArchiveManifestValidationError{
+				field:  "To",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Count
+
+	// no validation rules for Bytes
+
+	if all {
+		switch v := interface{}(m.GetArchivedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ArchiveManifestValidationError{
+					field:  "ArchivedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ArchiveManifestValidationError{
+					field:  "ArchivedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetArchivedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ArchiveManifestValidationError{
+				field:  "ArchivedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetRestoredAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ArchiveManifestValidationError{
+					field:  "RestoredAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ArchiveManifestValidationError{
+					field:  "RestoredAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRestoredAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ArchiveManifestValidationError{
+				field:  "RestoredAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for OnHold
+
+	// no validation rules for KeyId
+
+	if len(errors) > 0 {
+		return ArchiveManifestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ArchiveManifestMultiError is an error wrapping multiple validation errors
+// returned by ArchiveManifest.ValidateAll() if the designated constraints
+// aren't met.
+type ArchiveManifestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ArchiveManifestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ArchiveManifestMultiError) AllErrors() []error { return m }
+
+// ArchiveManifestValidationError is the validation error returned by
+// ArchiveManifest.Validate if the designated constraints aren't met.
+type ArchiveManifestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ArchiveManifestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ArchiveManifestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ArchiveManifestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ArchiveManifestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ArchiveManifestValidationError) ErrorName() string { return "ArchiveManifestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ArchiveManifestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sArchiveManifest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ArchiveManifestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ArchiveManifestValidationError{}
+
+// Validate checks the field values on ListArchivesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListArchivesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListArchivesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListArchivesRequestMultiError, or nil if none found.
+func (m *ListArchivesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListArchivesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCompanyId()) < 1 {
+		err := ListArchivesRequestValidationError{
+			field:  "CompanyId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListArchivesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListArchivesRequestMultiError is an error wrapping multiple validation
+// errors returned by ListArchivesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListArchivesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListArchivesRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListArchivesRequestMultiError) AllErrors() []error { return m }
+
+// ListArchivesRequestValidationError is the validation error returned by
+// ListArchivesRequest.Validate if the designated constraints aren't met.
+type ListArchivesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListArchivesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListArchivesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListArchivesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListArchivesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListArchivesRequestValidationError) ErrorName() string {
+	return "ListArchivesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListArchivesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListArchivesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListArchivesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListArchivesRequestValidationError{}
+
+// Validate checks the field values on ListArchivesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListArchivesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListArchivesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListArchivesResponseMultiError, or nil if none found.
+func (m *ListArchivesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListArchivesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetArchives() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListArchivesResponseValidationError{
+						field:  fmt.Sprintf("Archives[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListArchivesResponseValidationError{
+						field:  fmt.Sprintf("Archives[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListArchivesResponseValidationError{
+					field:  fmt.Sprintf("Archives[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListArchivesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListArchivesResponseMultiError is an error wrapping multiple validation
+// errors returned by ListArchivesResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListArchivesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListArchivesResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListArchivesResponseMultiError) AllErrors() []error { return m }
+
+// ListArchivesResponseValidationError is the validation error returned by
+// ListArchivesResponse.Validate if the designated constraints aren't met.
+type ListArchivesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListArchivesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListArchivesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListArchivesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListArchivesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListArchivesResponseValidationError) ErrorName() string {
+	return "ListArchivesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListArchivesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListArchivesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListArchivesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListArchivesResponseValidationError{}
+
+// Validate checks the field values on GetArchiveKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetArchiveKeyRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetArchiveKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetArchiveKeyRequestMultiError, or nil if none found.
+func (m *GetArchiveKeyRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetArchiveKeyRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetId()) < 1 {
+		err := GetArchiveKeyRequestValidationError{
+			field:  "Id",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetArchiveKeyRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetArchiveKeyRequestMultiError is an error wrapping multiple validation
+// errors returned by GetArchiveKeyRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetArchiveKeyRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetArchiveKeyRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetArchiveKeyRequestMultiError) AllErrors() []error { return m }
+
+// GetArchiveKeyRequestValidationError is the validation error returned by
+// GetArchiveKeyRequest.Validate if the designated constraints aren't met.
+type GetArchiveKeyRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetArchiveKeyRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetArchiveKeyRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetArchiveKeyRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetArchiveKeyRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetArchiveKeyRequestValidationError) ErrorName() string {
+	return "GetArchiveKeyRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetArchiveKeyRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetArchiveKeyRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetArchiveKeyRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetArchiveKeyRequestValidationError{}
+
+// Validate checks the field values on GetArchiveKeyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetArchiveKeyResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetArchiveKeyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetArchiveKeyResponseMultiError, or nil if none found.
+func (m *GetArchiveKeyResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetArchiveKeyResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetArchive()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetArchiveKeyResponseValidationError{
+					field:  "Archive",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetArchiveKeyResponseValidationError{
+					field:  "Archive",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetArchive()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetArchiveKeyResponseValidationError{
+				field:  "Archive",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for EnvelopeHeader
+
+	if len(errors) > 0 {
+		return GetArchiveKeyResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetArchiveKeyResponseMultiError is an error wrapping multiple validation
+// errors returned by GetArchiveKeyResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetArchiveKeyResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetArchiveKeyResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetArchiveKeyResponseMultiError) AllErrors() []error { return m }
+
+// GetArchiveKeyResponseValidationError is the validation error returned by
+// GetArchiveKeyResponse.Validate if the designated constraints aren't met.
+type GetArchiveKeyResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetArchiveKeyResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetArchiveKeyResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetArchiveKeyResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetArchiveKeyResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetArchiveKeyResponseValidationError) ErrorName() string {
+	return "GetArchiveKeyResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetArchiveKeyResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetArchiveKeyResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetArchiveKeyResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetArchiveKeyResponseValidationError{}
+
+// Validate checks the field values on RestoreArchiveRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RestoreArchiveRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RestoreArchiveRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RestoreArchiveRequestMultiError, or nil if none found.
+func (m *RestoreArchiveRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RestoreArchiveRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetId()) < 1 {
+		err := RestoreArchiveRequestValidationError{
+			field:  "Id",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for DataKey
+
+	if len(errors) > 0 {
+		return RestoreArchiveRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RestoreArchiveRequestMultiError is an error wrapping multiple validation
+// errors returned by RestoreArchiveRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RestoreArchiveRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RestoreArchiveRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RestoreArchiveRequestMultiError) AllErrors() []error { return m }
+
+// RestoreArchiveRequestValidationError is the validation error returned by
+// RestoreArchiveRequest.Validate if the designated constraints aren't met.
+type RestoreArchiveRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RestoreArchiveRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RestoreArchiveRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RestoreArchiveRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RestoreArchiveRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RestoreArchiveRequestValidationError) ErrorName() string {
+	return "RestoreArchiveRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RestoreArchiveRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRestoreArchiveRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RestoreArchiveRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RestoreArchiveRequestValidationError{}
+
+// Validate checks the field values on RestoreArchiveResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RestoreArchiveResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RestoreArchiveResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RestoreArchiveResponseMultiError, or nil if none found.
+func (m *RestoreArchiveResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RestoreArchiveResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetArchive()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RestoreArchiveResponseValidationError{
+					field:  "Archive",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RestoreArchiveResponseValidationError{
+					field:  "Archive",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetArchive()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RestoreArchiveResponseValidationError{
+				field:  "Archive",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return RestoreArchiveResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RestoreArchiveResponseMultiError is an error wrapping multiple validation
+// errors returned by RestoreArchiveResponse.ValidateAll() if the designated
+// constraints aren't met.
+type RestoreArchiveResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RestoreArchiveResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RestoreArchiveResponseMultiError) AllErrors() []error { return m }
+
+// RestoreArchiveResponseValidationError is the validation error returned by
+// RestoreArchiveResponse.Validate if the designated constraints aren't met.
+type RestoreArchiveResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RestoreArchiveResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RestoreArchiveResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RestoreArchiveResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RestoreArchiveResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RestoreArchiveResponseValidationError) ErrorName() string {
+	return "RestoreArchiveResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RestoreArchiveResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRestoreArchiveResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RestoreArchiveResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RestoreArchiveResponseValidationError{}
+
+// Validate checks the field values on ReleaseArchiveRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ReleaseArchiveRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReleaseArchiveRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReleaseArchiveRequestMultiError, or nil if none found.
+func (m *ReleaseArchiveRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReleaseArchiveRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetId()) < 1 {
+		err := ReleaseArchiveRequestValidationError{
+			field:  "Id",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ReleaseArchiveRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReleaseArchiveRequestMultiError is an error wrapping multiple validation
+// errors returned by ReleaseArchiveRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ReleaseArchiveRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReleaseArchiveRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReleaseArchiveRequestMultiError) AllErrors() []error { return m }
+
+// ReleaseArchiveRequestValidationError is the validation error returned by
+// ReleaseArchiveRequest.Validate if the designated constraints aren't met.
+type ReleaseArchiveRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReleaseArchiveRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReleaseArchiveRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReleaseArchiveRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReleaseArchiveRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReleaseArchiveRequestValidationError) ErrorName() string {
+	return "ReleaseArchiveRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReleaseArchiveRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReleaseArchiveRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReleaseArchiveRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReleaseArchiveRequestValidationError{}
+
+// Validate checks the field values on ReleaseArchiveResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ReleaseArchiveResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReleaseArchiveResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReleaseArchiveResponseMultiError, or nil if none found.
+func (m *ReleaseArchiveResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReleaseArchiveResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ReleaseArchiveResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReleaseArchiveResponseMultiError is an error wrapping multiple validation
+// errors returned by ReleaseArchiveResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ReleaseArchiveResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReleaseArchiveResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReleaseArchiveResponseMultiError) AllErrors() []error { return m }
+
+// ReleaseArchiveResponseValidationError is the validation error returned by
+// ReleaseArchiveResponse.Validate if the designated constraints aren't met.
+type ReleaseArchiveResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReleaseArchiveResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReleaseArchiveResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReleaseArchiveResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReleaseArchiveResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReleaseArchiveResponseValidationError) ErrorName() string {
+	return "ReleaseArchiveResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReleaseArchiveResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReleaseArchiveResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReleaseArchiveResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReleaseArchiveResponseValidationError{}
+
+// Validate checks the field values on EraseActivityLogRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *EraseActivityLogRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on EraseActivityLogRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// EraseActivityLogRequestMultiError, or nil if none found.
+func (m *EraseActivityLogRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *EraseActivityLogRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetId()) < 1 {
+		err := EraseActivityLogRequestValidationError{
+			field:  "Id",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetReason()) < 1 {
+		err := EraseActivityLogRequestValidationError{
+			field:  "Reason",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return EraseActivityLogRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// EraseActivityLogRequestMultiError is an error wrapping multiple validation
+// errors returned by EraseActivityLogRequest.ValidateAll() if the designated
+// constraints aren't met.
+type EraseActivityLogRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EraseActivityLogRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EraseActivityLogRequestMultiError) AllErrors() []error { return m }
+
+// EraseActivityLogRequestValidationError is the validation error returned by
+// EraseActivityLogRequest.Validate if the designated constraints aren't met.
+type EraseActivityLogRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EraseActivityLogRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EraseActivityLogRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EraseActivityLogRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EraseActivityLogRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EraseActivityLogRequestValidationError) ErrorName() string {
+	return "EraseActivityLogRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e EraseActivityLogRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEraseActivityLogRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EraseActivityLogRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EraseActivityLogRequestValidationError{}
+
+// Validate checks the field values on EraseActivityLogResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *EraseActivityLogResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on EraseActivityLogResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// EraseActivityLogResponseMultiError, or nil if none found.
+func (m *EraseActivityLogResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *EraseActivityLogResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return EraseActivityLogResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// EraseActivityLogResponseMultiError is an error wrapping multiple validation
+// errors returned by EraseActivityLogResponse.ValidateAll() if the designated
+// constraints aren't met.
+type EraseActivityLogResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EraseActivityLogResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EraseActivityLogResponseMultiError) AllErrors() []error { return m }
+
+// EraseActivityLogResponseValidationError is the validation error returned by
+// EraseActivityLogResponse.Validate if the designated constraints aren't met.
+type EraseActivityLogResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EraseActivityLogResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EraseActivityLogResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EraseActivityLogResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EraseActivityLogResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EraseActivityLogResponseValidationError) ErrorName() string {
+	return "EraseActivityLogResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e EraseActivityLogResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEraseActivityLogResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EraseActivityLogResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EraseActivityLogResponseValidationError{}
+
+// Validate checks the field values on EventEnvelope with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *EventEnvelope) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on EventEnvelope with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in EventEnvelopeMultiError, or
+// nil if none found.
+func (m *EventEnvelope) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *EventEnvelope) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for EventId
+
+	// no validation rules for EventType
+
+	// no validation rules for AggregateId
+
+	if all {
+		switch v := interface{}(m.GetTimestamp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EventEnvelopeValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EventEnvelopeValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EventEnvelopeValidationError{
+				field:  "Timestamp",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Version
+
+	switch v := m.Payload.(type) {
+	case *EventEnvelope_Created:
+		if v == nil {
+			err := EventEnvelopeValidationError{
+				field:  "Payload",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetCreated()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, EventEnvelopeValidationError{
+						field:  "Created",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, EventEnvelopeValidationError{
+						field:  "Created",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetCreated()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return EventEnvelopeValidationError{
+					field:  "Created",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *EventEnvelope_Updated:
+		if v == nil {
+			err := EventEnvelopeValidationError{
+				field:  "Payload",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetUpdated()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, EventEnvelopeValidationError{
+						field:  "Updated",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, EventEnvelopeValidationError{
+						field:  "Updated",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetUpdated()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return EventEnvelopeValidationError{
+					field:  "Updated",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *EventEnvelope_Deleted:
+		if v == nil {
+			err := EventEnvelopeValidationError{
+				field:  "Payload",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetDeleted()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, EventEnvelopeValidationError{
+						field:  "Deleted",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, EventEnvelopeValidationError{
+						field:  "Deleted",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetDeleted()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return EventEnvelopeValidationError{
+					field:  "Deleted",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+
+	if len(errors) > 0 {
+		return EventEnvelopeMultiError(errors)
+	}
+
+	return nil
+}
+
+// EventEnvelopeMultiError is an error wrapping multiple validation errors
+// returned by EventEnvelope.ValidateAll() if the designated constraints
+// aren't met.
+type EventEnvelopeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EventEnvelopeMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EventEnvelopeMultiError) AllErrors() []error { return m }
+
+// EventEnvelopeValidationError is the validation error returned by
+// EventEnvelope.Validate if the designated constraints aren't met.
+type EventEnvelopeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EventEnvelopeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EventEnvelopeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EventEnvelopeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EventEnvelopeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EventEnvelopeValidationError) ErrorName() string { return "EventEnvelopeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e EventEnvelopeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEventEnvelope.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EventEnvelopeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EventEnvelopeValidationError{}
+
+// Validate checks the field values on ActivityLogCreatedEvent with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ActivityLogCreatedEvent) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ActivityLogCreatedEvent with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ActivityLogCreatedEventMultiError, or nil if none found.
+func (m *ActivityLogCreatedEvent) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ActivityLogCreatedEvent) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetActivityLog()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ActivityLogCreatedEventValidationError{
+					field:  "ActivityLog",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ActivityLogCreatedEventValidationError{
+					field:  "ActivityLog",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetActivityLog()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ActivityLogCreatedEventValidationError{
+				field:  "ActivityLog",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Trimmed
+
+	// no validation rules for Queued
+
+	if len(errors) > 0 {
+		return ActivityLogCreatedEventMultiError(errors)
+	}
+
+	return nil
+}
+
+// ActivityLogCreatedEventMultiError is an error wrapping multiple validation
+// errors returned by ActivityLogCreatedEvent.ValidateAll() if the designated
+// constraints aren't met.
+type ActivityLogCreatedEventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ActivityLogCreatedEventMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ActivityLogCreatedEventMultiError) AllErrors() []error { return m }
+
+// ActivityLogCreatedEventValidationError is the validation error returned by
+// ActivityLogCreatedEvent.Validate if the designated constraints aren't met.
+type ActivityLogCreatedEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ActivityLogCreatedEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ActivityLogCreatedEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ActivityLogCreatedEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ActivityLogCreatedEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ActivityLogCreatedEventValidationError) ErrorName() string {
+	return "ActivityLogCreatedEventValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ActivityLogCreatedEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sActivityLogCreatedEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ActivityLogCreatedEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ActivityLogCreatedEventValidationError{}
+
+// Validate checks the field values on ActivityLogUpdatedEvent with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ActivityLogUpdatedEvent) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ActivityLogUpdatedEvent with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ActivityLogUpdatedEventMultiError, or nil if none found.
+func (m *ActivityLogUpdatedEvent) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ActivityLogUpdatedEvent) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetActivityLog()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ActivityLogUpdatedEventValidationError{
+					field:  "ActivityLog",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ActivityLogUpdatedEventValidationError{
+					field:  "ActivityLog",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetActivityLog()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ActivityLogUpdatedEventValidationError{
+				field:  "ActivityLog",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ActivityLogUpdatedEventMultiError(errors)
+	}
+
+	return nil
+}
+
+// ActivityLogUpdatedEventMultiError is an error wrapping multiple validation
+// errors returned by ActivityLogUpdatedEvent.ValidateAll() if the designated
+// constraints aren't met.
+type ActivityLogUpdatedEventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ActivityLogUpdatedEventMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ActivityLogUpdatedEventMultiError) AllErrors() []error { return m }
+
+// ActivityLogUpdatedEventValidationError is the validation error returned by
+// ActivityLogUpdatedEvent.Validate if the designated constraints aren't met.
+type ActivityLogUpdatedEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ActivityLogUpdatedEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ActivityLogUpdatedEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ActivityLogUpdatedEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ActivityLogUpdatedEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ActivityLogUpdatedEventValidationError) ErrorName() string {
+	return "ActivityLogUpdatedEventValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ActivityLogUpdatedEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sActivityLogUpdatedEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ActivityLogUpdatedEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ActivityLogUpdatedEventValidationError{}
+
+// Validate checks the field values on ActivityLogDeletedEvent with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ActivityLogDeletedEvent) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ActivityLogDeletedEvent with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ActivityLogDeletedEventMultiError, or nil if none found.
+func (m *ActivityLogDeletedEvent) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ActivityLogDeletedEvent) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for CompanyId
+
+	if len(errors) > 0 {
+		return ActivityLogDeletedEventMultiError(errors)
+	}
+
+	return nil
+}
+
+// ActivityLogDeletedEventMultiError is an error wrapping multiple validation
+// errors returned by ActivityLogDeletedEvent.ValidateAll() if the designated
+// constraints aren't met.
+type ActivityLogDeletedEventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ActivityLogDeletedEventMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ActivityLogDeletedEventMultiError) AllErrors() []error { return m }
+
+// ActivityLogDeletedEventValidationError is the validation error returned by
+// ActivityLogDeletedEvent.Validate if the designated constraints aren't met.
+type ActivityLogDeletedEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ActivityLogDeletedEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ActivityLogDeletedEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ActivityLogDeletedEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ActivityLogDeletedEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ActivityLogDeletedEventValidationError) ErrorName() string {
+	return "ActivityLogDeletedEventValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ActivityLogDeletedEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sActivityLogDeletedEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ActivityLogDeletedEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ActivityLogDeletedEventValidationError{}
@@ -19,9 +19,18 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ActivityLogService_CreateActivityLog_FullMethodName = "/activity_log.ActivityLogService/CreateActivityLog"
-	ActivityLogService_GetActivityLog_FullMethodName    = "/activity_log.ActivityLogService/GetActivityLog"
-	ActivityLogService_ListActivityLogs_FullMethodName  = "/activity_log.ActivityLogService/ListActivityLogs"
+	ActivityLogService_CreateActivityLog_FullMethodName       = "/activity_log.ActivityLogService/CreateActivityLog"
+	ActivityLogService_GetActivityLog_FullMethodName          = "/activity_log.ActivityLogService/GetActivityLog"
+	ActivityLogService_BatchGetActivityLogs_FullMethodName    = "/activity_log.ActivityLogService/BatchGetActivityLogs"
+	ActivityLogService_ListActivityLogs_FullMethodName        = "/activity_log.ActivityLogService/ListActivityLogs"
+	ActivityLogService_StreamActivityLogs_FullMethodName      = "/activity_log.ActivityLogService/StreamActivityLogs"
+	ActivityLogService_ExportActivityLogs_FullMethodName      = "/activity_log.ActivityLogService/ExportActivityLogs"
+	ActivityLogService_IngestActivityLogs_FullMethodName      = "/activity_log.ActivityLogService/IngestActivityLogs"
+	ActivityLogService_SearchActivityLogs_FullMethodName      = "/activity_log.ActivityLogService/SearchActivityLogs"
+	ActivityLogService_BatchCreateActivityLogs_FullMethodName = "/activity_log.ActivityLogService/BatchCreateActivityLogs"
+	ActivityLogService_GetActivityStats_FullMethodName        = "/activity_log.ActivityLogService/GetActivityStats"
+	ActivityLogService_ListActivityNames_FullMethodName       = "/activity_log.ActivityLogService/ListActivityNames"
+	ActivityLogService_ListActors_FullMethodName              = "/activity_log.ActivityLogService/ListActors"
 )
 
 // ActivityLogServiceClient is the client API for ActivityLogService service.
@@ -32,7 +41,16 @@ const (
 type ActivityLogServiceClient interface {
 	CreateActivityLog(ctx context.Context, in *CreateActivityLogRequest, opts ...grpc.CallOption) (*CreateActivityLogResponse, error)
 	GetActivityLog(ctx context.Context, in *GetActivityLogRequest, opts ...grpc.CallOption) (*GetActivityLogResponse, error)
+	BatchGetActivityLogs(ctx context.Context, in *BatchGetActivityLogsRequest, opts ...grpc.CallOption) (*BatchGetActivityLogsResponse, error)
 	ListActivityLogs(ctx context.Context, in *ListActivityLogsRequest, opts ...grpc.CallOption) (*ListActivityLogsResponse, error)
+	StreamActivityLogs(ctx context.Context, in *StreamActivityLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ActivityLog], error)
+	ExportActivityLogs(ctx context.Context, in *ExportActivityLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error)
+	IngestActivityLogs(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreateActivityLogRequest, IngestActivityLogsResponse], error)
+	SearchActivityLogs(ctx context.Context, in *SearchActivityLogsRequest, opts ...grpc.CallOption) (*SearchActivityLogsResponse, error)
+	BatchCreateActivityLogs(ctx context.Context, in *BatchCreateActivityLogsRequest, opts ...grpc.CallOption) (*BatchCreateActivityLogsResponse, error)
+	GetActivityStats(ctx context.Context, in *GetActivityStatsRequest, opts ...grpc.CallOption) (*GetActivityStatsResponse, error)
+	ListActivityNames(ctx context.Context, in *ListActivityNamesRequest, opts ...grpc.CallOption) (*ListActivityNamesResponse, error)
+	ListActors(ctx context.Context, in *ListActorsRequest, opts ...grpc.CallOption) (*ListActorsResponse, error)
 }
 
 type activityLogServiceClient struct {
@@ -63,6 +81,16 @@ func (c *activityLogServiceClient) GetActivityLog(ctx context.Context, in *GetAc
 	return out, nil
 }
 
+func (c *activityLogServiceClient) BatchGetActivityLogs(ctx context.Context, in *BatchGetActivityLogsRequest, opts ...grpc.CallOption) (*BatchGetActivityLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetActivityLogsResponse)
+	err := c.cc.Invoke(ctx, ActivityLogService_BatchGetActivityLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *activityLogServiceClient) ListActivityLogs(ctx context.Context, in *ListActivityLogsRequest, opts ...grpc.CallOption) (*ListActivityLogsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListActivityLogsResponse)
@@ -73,6 +101,107 @@ func (c *activityLogServiceClient) ListActivityLogs(ctx context.Context, in *Lis
 	return out, nil
 }
 
+func (c *activityLogServiceClient) StreamActivityLogs(ctx context.Context, in *StreamActivityLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ActivityLog], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ActivityLogService_ServiceDesc.Streams[0], ActivityLogService_StreamActivityLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamActivityLogsRequest, ActivityLog]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ActivityLogService_StreamActivityLogsClient = grpc.ServerStreamingClient[ActivityLog]
+
+func (c *activityLogServiceClient) ExportActivityLogs(ctx context.Context, in *ExportActivityLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ActivityLogService_ServiceDesc.Streams[1], ActivityLogService_ExportActivityLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportActivityLogsRequest, ExportChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ActivityLogService_ExportActivityLogsClient = grpc.ServerStreamingClient[ExportChunk]
+
+func (c *activityLogServiceClient) IngestActivityLogs(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreateActivityLogRequest, IngestActivityLogsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ActivityLogService_ServiceDesc.Streams[2], ActivityLogService_IngestActivityLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CreateActivityLogRequest, IngestActivityLogsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ActivityLogService_IngestActivityLogsClient = grpc.ClientStreamingClient[CreateActivityLogRequest, IngestActivityLogsResponse]
+
+func (c *activityLogServiceClient) SearchActivityLogs(ctx context.Context, in *SearchActivityLogsRequest, opts ...grpc.CallOption) (*SearchActivityLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchActivityLogsResponse)
+	err := c.cc.Invoke(ctx, ActivityLogService_SearchActivityLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *activityLogServiceClient) BatchCreateActivityLogs(ctx context.Context, in *BatchCreateActivityLogsRequest, opts ...grpc.CallOption) (*BatchCreateActivityLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchCreateActivityLogsResponse)
+	err := c.cc.Invoke(ctx, ActivityLogService_BatchCreateActivityLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *activityLogServiceClient) GetActivityStats(ctx context.Context, in *GetActivityStatsRequest, opts ...grpc.CallOption) (*GetActivityStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetActivityStatsResponse)
+	err := c.cc.Invoke(ctx, ActivityLogService_GetActivityStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *activityLogServiceClient) ListActivityNames(ctx context.Context, in *ListActivityNamesRequest, opts ...grpc.CallOption) (*ListActivityNamesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListActivityNamesResponse)
+	err := c.cc.Invoke(ctx, ActivityLogService_ListActivityNames_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *activityLogServiceClient) ListActors(ctx context.Context, in *ListActorsRequest, opts ...grpc.CallOption) (*ListActorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListActorsResponse)
+	err := c.cc.Invoke(ctx, ActivityLogService_ListActors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ActivityLogServiceServer is the server API for ActivityLogService service.
 // All implementations must embed UnimplementedActivityLogServiceServer
 // for forward compatibility.
@@ -81,7 +210,16 @@ func (c *activityLogServiceClient) ListActivityLogs(ctx context.Context, in *Lis
 type ActivityLogServiceServer interface {
 	CreateActivityLog(context.Context, *CreateActivityLogRequest) (*CreateActivityLogResponse, error)
 	GetActivityLog(context.Context, *GetActivityLogRequest) (*GetActivityLogResponse, error)
+	BatchGetActivityLogs(context.Context, *BatchGetActivityLogsRequest) (*BatchGetActivityLogsResponse, error)
 	ListActivityLogs(context.Context, *ListActivityLogsRequest) (*ListActivityLogsResponse, error)
+	StreamActivityLogs(*StreamActivityLogsRequest, grpc.ServerStreamingServer[ActivityLog]) error
+	ExportActivityLogs(*ExportActivityLogsRequest, grpc.ServerStreamingServer[ExportChunk]) error
+	IngestActivityLogs(grpc.ClientStreamingServer[CreateActivityLogRequest, IngestActivityLogsResponse]) error
+	SearchActivityLogs(context.Context, *SearchActivityLogsRequest) (*SearchActivityLogsResponse, error)
+	BatchCreateActivityLogs(context.Context, *BatchCreateActivityLogsRequest) (*BatchCreateActivityLogsResponse, error)
+	GetActivityStats(context.Context, *GetActivityStatsRequest) (*GetActivityStatsResponse, error)
+	ListActivityNames(context.Context, *ListActivityNamesRequest) (*ListActivityNamesResponse, error)
+	ListActors(context.Context, *ListActorsRequest) (*ListActorsResponse, error)
 	mustEmbedUnimplementedActivityLogServiceServer()
 }
 
@@ -98,9 +236,36 @@ func (UnimplementedActivityLogServiceServer) CreateActivityLog(context.Context,
 func (UnimplementedActivityLogServiceServer) GetActivityLog(context.Context, *GetActivityLogRequest) (*GetActivityLogResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetActivityLog not implemented")
 }
+func (UnimplementedActivityLogServiceServer) BatchGetActivityLogs(context.Context, *BatchGetActivityLogsRequest) (*BatchGetActivityLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetActivityLogs not implemented")
+}
 func (UnimplementedActivityLogServiceServer) ListActivityLogs(context.Context, *ListActivityLogsRequest) (*ListActivityLogsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListActivityLogs not implemented")
 }
+func (UnimplementedActivityLogServiceServer) StreamActivityLogs(*StreamActivityLogsRequest, grpc.ServerStreamingServer[ActivityLog]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamActivityLogs not implemented")
+}
+func (UnimplementedActivityLogServiceServer) ExportActivityLogs(*ExportActivityLogsRequest, grpc.ServerStreamingServer[ExportChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportActivityLogs not implemented")
+}
+func (UnimplementedActivityLogServiceServer) IngestActivityLogs(grpc.ClientStreamingServer[CreateActivityLogRequest, IngestActivityLogsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method IngestActivityLogs not implemented")
+}
+func (UnimplementedActivityLogServiceServer) SearchActivityLogs(context.Context, *SearchActivityLogsRequest) (*SearchActivityLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchActivityLogs not implemented")
+}
+func (UnimplementedActivityLogServiceServer) BatchCreateActivityLogs(context.Context, *BatchCreateActivityLogsRequest) (*BatchCreateActivityLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateActivityLogs not implemented")
+}
+func (UnimplementedActivityLogServiceServer) GetActivityStats(context.Context, *GetActivityStatsRequest) (*GetActivityStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActivityStats not implemented")
+}
+func (UnimplementedActivityLogServiceServer) ListActivityNames(context.Context, *ListActivityNamesRequest) (*ListActivityNamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListActivityNames not implemented")
+}
+func (UnimplementedActivityLogServiceServer) ListActors(context.Context, *ListActorsRequest) (*ListActorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListActors not implemented")
+}
 func (UnimplementedActivityLogServiceServer) mustEmbedUnimplementedActivityLogServiceServer() {}
 func (UnimplementedActivityLogServiceServer) testEmbeddedByValue()                            {}
 
@@ -158,6 +323,24 @@ func _ActivityLogService_GetActivityLog_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ActivityLogService_BatchGetActivityLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetActivityLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActivityLogServiceServer).BatchGetActivityLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ActivityLogService_BatchGetActivityLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActivityLogServiceServer).BatchGetActivityLogs(ctx, req.(*BatchGetActivityLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ActivityLogService_ListActivityLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListActivityLogsRequest)
 	if err := dec(in); err != nil {
@@ -176,6 +359,125 @@ func _ActivityLogService_ListActivityLogs_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ActivityLogService_StreamActivityLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamActivityLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ActivityLogServiceServer).StreamActivityLogs(m, &grpc.GenericServerStream[StreamActivityLogsRequest, ActivityLog]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ActivityLogService_StreamActivityLogsServer = grpc.ServerStreamingServer[ActivityLog]
+
+func _ActivityLogService_ExportActivityLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportActivityLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ActivityLogServiceServer).ExportActivityLogs(m, &grpc.GenericServerStream[ExportActivityLogsRequest, ExportChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ActivityLogService_ExportActivityLogsServer = grpc.ServerStreamingServer[ExportChunk]
+
+func _ActivityLogService_IngestActivityLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ActivityLogServiceServer).IngestActivityLogs(&grpc.GenericServerStream[CreateActivityLogRequest, IngestActivityLogsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ActivityLogService_IngestActivityLogsServer = grpc.ClientStreamingServer[CreateActivityLogRequest, IngestActivityLogsResponse]
+
+func _ActivityLogService_SearchActivityLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchActivityLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActivityLogServiceServer).SearchActivityLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ActivityLogService_SearchActivityLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActivityLogServiceServer).SearchActivityLogs(ctx, req.(*SearchActivityLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ActivityLogService_BatchCreateActivityLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateActivityLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActivityLogServiceServer).BatchCreateActivityLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ActivityLogService_BatchCreateActivityLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActivityLogServiceServer).BatchCreateActivityLogs(ctx, req.(*BatchCreateActivityLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ActivityLogService_GetActivityStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetActivityStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActivityLogServiceServer).GetActivityStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ActivityLogService_GetActivityStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActivityLogServiceServer).GetActivityStats(ctx, req.(*GetActivityStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ActivityLogService_ListActivityNames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListActivityNamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActivityLogServiceServer).ListActivityNames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ActivityLogService_ListActivityNames_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActivityLogServiceServer).ListActivityNames(ctx, req.(*ListActivityNamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ActivityLogService_ListActors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListActorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActivityLogServiceServer).ListActors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ActivityLogService_ListActors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActivityLogServiceServer).ListActors(ctx, req.(*ListActorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ActivityLogService_ServiceDesc is the grpc.ServiceDesc for ActivityLogService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -191,10 +493,614 @@ var ActivityLogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetActivityLog",
 			Handler:    _ActivityLogService_GetActivityLog_Handler,
 		},
+		{
+			MethodName: "BatchGetActivityLogs",
+			Handler:    _ActivityLogService_BatchGetActivityLogs_Handler,
+		},
 		{
 			MethodName: "ListActivityLogs",
 			Handler:    _ActivityLogService_ListActivityLogs_Handler,
 		},
+		{
+			MethodName: "SearchActivityLogs",
+			Handler:    _ActivityLogService_SearchActivityLogs_Handler,
+		},
+		{
+			MethodName: "BatchCreateActivityLogs",
+			Handler:    _ActivityLogService_BatchCreateActivityLogs_Handler,
+		},
+		{
+			MethodName: "GetActivityStats",
+			Handler:    _ActivityLogService_GetActivityStats_Handler,
+		},
+		{
+			MethodName: "ListActivityNames",
+			Handler:    _ActivityLogService_ListActivityNames_Handler,
+		},
+		{
+			MethodName: "ListActors",
+			Handler:    _ActivityLogService_ListActors_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamActivityLogs",
+			Handler:       _ActivityLogService_StreamActivityLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportActivityLogs",
+			Handler:       _ActivityLogService_ExportActivityLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "IngestActivityLogs",
+			Handler:       _ActivityLogService_IngestActivityLogs_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pkg/proto/activity_log.proto",
+}
+
+const (
+	AdminService_FlushCache_FullMethodName       = "/activity_log.AdminService/FlushCache"
+	AdminService_TriggerCronJob_FullMethodName   = "/activity_log.AdminService/TriggerCronJob"
+	AdminService_ListJobRuns_FullMethodName      = "/activity_log.AdminService/ListJobRuns"
+	AdminService_Reindex_FullMethodName          = "/activity_log.AdminService/Reindex"
+	AdminService_PurgeCompany_FullMethodName     = "/activity_log.AdminService/PurgeCompany"
+	AdminService_ListArchives_FullMethodName     = "/activity_log.AdminService/ListArchives"
+	AdminService_GetArchiveKey_FullMethodName    = "/activity_log.AdminService/GetArchiveKey"
+	AdminService_RestoreArchive_FullMethodName   = "/activity_log.AdminService/RestoreArchive"
+	AdminService_ReleaseArchive_FullMethodName   = "/activity_log.AdminService/ReleaseArchive"
+	AdminService_EraseActivityLog_FullMethodName = "/activity_log.AdminService/EraseActivityLog"
+	AdminService_ListAccessLog_FullMethodName    = "/activity_log.AdminService/ListAccessLog"
+	AdminService_SetExportKey_FullMethodName     = "/activity_log.AdminService/SetExportKey"
+	AdminService_DeleteExportKey_FullMethodName  = "/activity_log.AdminService/DeleteExportKey"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AdminService holds operator actions. It shares the port with
+// ActivityLogService but every call needs the admin token.
+type AdminServiceClient interface {
+	FlushCache(ctx context.Context, in *FlushCacheRequest, opts ...grpc.CallOption) (*FlushCacheResponse, error)
+	TriggerCronJob(ctx context.Context, in *TriggerCronJobRequest, opts ...grpc.CallOption) (*TriggerCronJobResponse, error)
+	ListJobRuns(ctx context.Context, in *ListJobRunsRequest, opts ...grpc.CallOption) (*ListJobRunsResponse, error)
+	Reindex(ctx context.Context, in *ReindexRequest, opts ...grpc.CallOption) (*ReindexResponse, error)
+	PurgeCompany(ctx context.Context, in *PurgeCompanyRequest, opts ...grpc.CallOption) (*PurgeCompanyResponse, error)
+	ListArchives(ctx context.Context, in *ListArchivesRequest, opts ...grpc.CallOption) (*ListArchivesResponse, error)
+	GetArchiveKey(ctx context.Context, in *GetArchiveKeyRequest, opts ...grpc.CallOption) (*GetArchiveKeyResponse, error)
+	RestoreArchive(ctx context.Context, in *RestoreArchiveRequest, opts ...grpc.CallOption) (*RestoreArchiveResponse, error)
+	ReleaseArchive(ctx context.Context, in *ReleaseArchiveRequest, opts ...grpc.CallOption) (*ReleaseArchiveResponse, error)
+	EraseActivityLog(ctx context.Context, in *EraseActivityLogRequest, opts ...grpc.CallOption) (*EraseActivityLogResponse, error)
+	ListAccessLog(ctx context.Context, in *ListAccessLogRequest, opts ...grpc.CallOption) (*ListAccessLogResponse, error)
+	SetExportKey(ctx context.Context, in *SetExportKeyRequest, opts ...grpc.CallOption) (*SetExportKeyResponse, error)
+	DeleteExportKey(ctx context.Context, in *DeleteExportKeyRequest, opts ...grpc.CallOption) (*DeleteExportKeyResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) FlushCache(ctx context.Context, in *FlushCacheRequest, opts ...grpc.CallOption) (*FlushCacheResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FlushCacheResponse)
+	err := c.cc.Invoke(ctx, AdminService_FlushCache_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) TriggerCronJob(ctx context.Context, in *TriggerCronJobRequest, opts ...grpc.CallOption) (*TriggerCronJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TriggerCronJobResponse)
+	err := c.cc.Invoke(ctx, AdminService_TriggerCronJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListJobRuns(ctx context.Context, in *ListJobRunsRequest, opts ...grpc.CallOption) (*ListJobRunsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListJobRunsResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListJobRuns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) Reindex(ctx context.Context, in *ReindexRequest, opts ...grpc.CallOption) (*ReindexResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReindexResponse)
+	err := c.cc.Invoke(ctx, AdminService_Reindex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) PurgeCompany(ctx context.Context, in *PurgeCompanyRequest, opts ...grpc.CallOption) (*PurgeCompanyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeCompanyResponse)
+	err := c.cc.Invoke(ctx, AdminService_PurgeCompany_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListArchives(ctx context.Context, in *ListArchivesRequest, opts ...grpc.CallOption) (*ListArchivesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListArchivesResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListArchives_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetArchiveKey(ctx context.Context, in *GetArchiveKeyRequest, opts ...grpc.CallOption) (*GetArchiveKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetArchiveKeyResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetArchiveKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RestoreArchive(ctx context.Context, in *RestoreArchiveRequest, opts ...grpc.CallOption) (*RestoreArchiveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreArchiveResponse)
+	err := c.cc.Invoke(ctx, AdminService_RestoreArchive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ReleaseArchive(ctx context.Context, in *ReleaseArchiveRequest, opts ...grpc.CallOption) (*ReleaseArchiveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseArchiveResponse)
+	err := c.cc.Invoke(ctx, AdminService_ReleaseArchive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) EraseActivityLog(ctx context.Context, in *EraseActivityLogRequest, opts ...grpc.CallOption) (*EraseActivityLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EraseActivityLogResponse)
+	err := c.cc.Invoke(ctx, AdminService_EraseActivityLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListAccessLog(ctx context.Context, in *ListAccessLogRequest, opts ...grpc.CallOption) (*ListAccessLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccessLogResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListAccessLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetExportKey(ctx context.Context, in *SetExportKeyRequest, opts ...grpc.CallOption) (*SetExportKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetExportKeyResponse)
+	err := c.cc.Invoke(ctx, AdminService_SetExportKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeleteExportKey(ctx context.Context, in *DeleteExportKeyRequest, opts ...grpc.CallOption) (*DeleteExportKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteExportKeyResponse)
+	err := c.cc.Invoke(ctx, AdminService_DeleteExportKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility.
+//
+// AdminService holds operator actions. It shares the port with
+// ActivityLogService but every call needs the admin token.
+type AdminServiceServer interface {
+	FlushCache(context.Context, *FlushCacheRequest) (*FlushCacheResponse, error)
+	TriggerCronJob(context.Context, *TriggerCronJobRequest) (*TriggerCronJobResponse, error)
+	ListJobRuns(context.Context, *ListJobRunsRequest) (*ListJobRunsResponse, error)
+	Reindex(context.Context, *ReindexRequest) (*ReindexResponse, error)
+	PurgeCompany(context.Context, *PurgeCompanyRequest) (*PurgeCompanyResponse, error)
+	ListArchives(context.Context, *ListArchivesRequest) (*ListArchivesResponse, error)
+	GetArchiveKey(context.Context, *GetArchiveKeyRequest) (*GetArchiveKeyResponse, error)
+	RestoreArchive(context.Context, *RestoreArchiveRequest) (*RestoreArchiveResponse, error)
+	ReleaseArchive(context.Context, *ReleaseArchiveRequest) (*ReleaseArchiveResponse, error)
+	EraseActivityLog(context.Context, *EraseActivityLogRequest) (*EraseActivityLogResponse, error)
+	ListAccessLog(context.Context, *ListAccessLogRequest) (*ListAccessLogResponse, error)
+	SetExportKey(context.Context, *SetExportKeyRequest) (*SetExportKeyResponse, error)
+	DeleteExportKey(context.Context, *DeleteExportKeyRequest) (*DeleteExportKeyResponse, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServiceServer struct{}
+
+func (UnimplementedAdminServiceServer) FlushCache(context.Context, *FlushCacheRequest) (*FlushCacheResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FlushCache not implemented")
+}
+func (UnimplementedAdminServiceServer) TriggerCronJob(context.Context, *TriggerCronJobRequest) (*TriggerCronJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerCronJob not implemented")
+}
+func (UnimplementedAdminServiceServer) ListJobRuns(context.Context, *ListJobRunsRequest) (*ListJobRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobRuns not implemented")
+}
+func (UnimplementedAdminServiceServer) Reindex(context.Context, *ReindexRequest) (*ReindexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reindex not implemented")
+}
+func (UnimplementedAdminServiceServer) PurgeCompany(context.Context, *PurgeCompanyRequest) (*PurgeCompanyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeCompany not implemented")
+}
+func (UnimplementedAdminServiceServer) ListArchives(context.Context, *ListArchivesRequest) (*ListArchivesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArchives not implemented")
+}
+func (UnimplementedAdminServiceServer) GetArchiveKey(context.Context, *GetArchiveKeyRequest) (*GetArchiveKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArchiveKey not implemented")
+}
+func (UnimplementedAdminServiceServer) RestoreArchive(context.Context, *RestoreArchiveRequest) (*RestoreArchiveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreArchive not implemented")
+}
+func (UnimplementedAdminServiceServer) ReleaseArchive(context.Context, *ReleaseArchiveRequest) (*ReleaseArchiveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseArchive not implemented")
+}
+func (UnimplementedAdminServiceServer) EraseActivityLog(context.Context, *EraseActivityLogRequest) (*EraseActivityLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseActivityLog not implemented")
+}
+func (UnimplementedAdminServiceServer) ListAccessLog(context.Context, *ListAccessLogRequest) (*ListAccessLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccessLog not implemented")
+}
+func (UnimplementedAdminServiceServer) SetExportKey(context.Context, *SetExportKeyRequest) (*SetExportKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetExportKey not implemented")
+}
+func (UnimplementedAdminServiceServer) DeleteExportKey(context.Context, *DeleteExportKeyRequest) (*DeleteExportKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteExportKey not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_FlushCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).FlushCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_FlushCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).FlushCache(ctx, req.(*FlushCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_TriggerCronJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerCronJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).TriggerCronJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_TriggerCronJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).TriggerCronJob(ctx, req.(*TriggerCronJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListJobRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListJobRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListJobRuns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListJobRuns(ctx, req.(*ListJobRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_Reindex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReindexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).Reindex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_Reindex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).Reindex(ctx, req.(*ReindexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_PurgeCompany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeCompanyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).PurgeCompany(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_PurgeCompany_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).PurgeCompany(ctx, req.(*PurgeCompanyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListArchives_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArchivesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListArchives(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListArchives_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListArchives(ctx, req.(*ListArchivesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetArchiveKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArchiveKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetArchiveKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetArchiveKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetArchiveKey(ctx, req.(*GetArchiveKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RestoreArchive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreArchiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RestoreArchive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_RestoreArchive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RestoreArchive(ctx, req.(*RestoreArchiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ReleaseArchive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseArchiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ReleaseArchive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ReleaseArchive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ReleaseArchive(ctx, req.(*ReleaseArchiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_EraseActivityLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseActivityLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).EraseActivityLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_EraseActivityLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).EraseActivityLog(ctx, req.(*EraseActivityLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListAccessLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccessLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListAccessLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListAccessLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListAccessLog(ctx, req.(*ListAccessLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetExportKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetExportKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetExportKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetExportKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetExportKey(ctx, req.(*SetExportKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeleteExportKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteExportKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteExportKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeleteExportKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteExportKey(ctx, req.(*DeleteExportKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "activity_log.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FlushCache",
+			Handler:    _AdminService_FlushCache_Handler,
+		},
+		{
+			MethodName: "TriggerCronJob",
+			Handler:    _AdminService_TriggerCronJob_Handler,
+		},
+		{
+			MethodName: "ListJobRuns",
+			Handler:    _AdminService_ListJobRuns_Handler,
+		},
+		{
+			MethodName: "Reindex",
+			Handler:    _AdminService_Reindex_Handler,
+		},
+		{
+			MethodName: "PurgeCompany",
+			Handler:    _AdminService_PurgeCompany_Handler,
+		},
+		{
+			MethodName: "ListArchives",
+			Handler:    _AdminService_ListArchives_Handler,
+		},
+		{
+			MethodName: "GetArchiveKey",
+			Handler:    _AdminService_GetArchiveKey_Handler,
+		},
+		{
+			MethodName: "RestoreArchive",
+			Handler:    _AdminService_RestoreArchive_Handler,
+		},
+		{
+			MethodName: "ReleaseArchive",
+			Handler:    _AdminService_ReleaseArchive_Handler,
+		},
+		{
+			MethodName: "EraseActivityLog",
+			Handler:    _AdminService_EraseActivityLog_Handler,
+		},
+		{
+			MethodName: "ListAccessLog",
+			Handler:    _AdminService_ListAccessLog_Handler,
+		},
+		{
+			MethodName: "SetExportKey",
+			Handler:    _AdminService_SetExportKey_Handler,
+		},
+		{
+			MethodName: "DeleteExportKey",
+			Handler:    _AdminService_DeleteExportKey_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/proto/activity_log.proto",